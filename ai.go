@@ -9,9 +9,27 @@ import (
 // AIService defines operations for AI-powered analysis.
 type AIService interface {
 	// AnalyzePhoto analyzes a photo for safety violations.
-	// The photoURL should be a publicly accessible URL.
+	AnalyzePhoto(ctx context.Context, req AnalysisRequest) (*AnalysisResult, error)
+}
+
+// AnalysisRequest describes a photo to analyze.
+type AnalysisRequest struct {
+	// PhotoURL is the storage URL of the photo.
+	PhotoURL string
+
 	// SafetyCodes are the codes to check against.
-	AnalyzePhoto(ctx context.Context, photoURL string, safetyCodes []*SafetyCode) (*AnalysisResult, error)
+	SafetyCodes []*SafetyCode
+
+	// Inspection and Project describe where the photo was taken, so the
+	// analysis can weigh the inspection type and site conditions.
+	// Either may be nil.
+	Inspection *Inspection
+	Project    *Project
+}
+
+// PhotoAnalysisPayload is the payload of a photo analysis job.
+type PhotoAnalysisPayload struct {
+	PhotoID uuid.UUID `json:"photo_id"`
 }
 
 // AnalysisResult contains the results of an AI photo analysis.
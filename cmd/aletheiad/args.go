@@ -0,0 +1,87 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/dukerupert/aletheia"
+)
+
+// Run modes.
+const (
+	ModeServe  = "serve"  // HTTP server, with workers unless disabled
+	ModeWorker = "worker" // Job workers only
+)
+
+// queueNames lists the queues a worker may consume.
+var queueNames = []string{aletheia.QueueCritical, aletheia.QueueDefault, aletheia.QueueLow}
+
+// Options holds the run mode and the command-line flags for it.
+type Options struct {
+	Mode        string
+	Workers     bool     // Run job workers and the scheduler
+	Queues      []string // Queues the workers consume
+	WorkerCount int      // Number of concurrent workers
+}
+
+// parseArgs parses the command line:
+//
+//	aletheiad [serve] [-no-workers] [-queues list] [-concurrency n]
+//	aletheiad worker [-queues list] [-concurrency n]
+//
+// The mode defaults to serve. Queue and worker flags default to cfg.
+func parseArgs(args []string, stderr io.Writer, cfg *Config) (*Options, error) {
+	opts := &Options{Mode: ModeServe}
+	if len(args) > 1 && !strings.HasPrefix(args[1], "-") {
+		opts.Mode = args[1]
+		args = args[1:]
+	}
+	if opts.Mode != ModeServe && opts.Mode != ModeWorker {
+		return nil, fmt.Errorf("unknown mode %q (want %s or %s)", opts.Mode, ModeServe, ModeWorker)
+	}
+
+	fs := flag.NewFlagSet("aletheiad "+opts.Mode, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	queues := fs.String("queues", strings.Join(cfg.QueueNames, ","), "comma-separated queues to consume")
+	fs.IntVar(&opts.WorkerCount, "concurrency", cfg.QueueWorkerCount, "number of concurrent workers")
+	noWorkers := false
+	if opts.Mode == ModeServe {
+		fs.BoolVar(&noWorkers, "no-workers", false, "serve HTTP only; run workers in a separate process")
+	}
+
+	if err := fs.Parse(args[1:]); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	opts.Workers = !noWorkers
+	if !opts.Workers {
+		return opts, nil
+	}
+
+	if opts.WorkerCount < 1 {
+		return nil, fmt.Errorf("-concurrency must be at least 1")
+	}
+	for _, name := range strings.Split(*queues, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		if !slices.Contains(queueNames, name) {
+			return nil, fmt.Errorf("unknown queue %q (want one of %s)", name, strings.Join(queueNames, ", "))
+		}
+		if !slices.Contains(opts.Queues, name) {
+			opts.Queues = append(opts.Queues, name)
+		}
+	}
+	if len(opts.Queues) == 0 {
+		return nil, fmt.Errorf("-queues must name at least one queue")
+	}
+
+	return opts, nil
+}
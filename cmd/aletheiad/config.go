@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -24,6 +25,10 @@ type Config struct {
 	// Auth settings
 	JWTSecret     string
 	JWTExpiration time.Duration
+	AdminEmails   []string
+
+	// Report signing key, a base64 Ed25519 seed (empty disables signing)
+	ReportSigningKey string
 
 	// Session settings
 	SessionCookieName string
@@ -37,6 +42,7 @@ type Config struct {
 	EmailFromAddress     string
 	EmailFromName        string
 	EmailVerifyBaseURL   string
+	EmailWebhookToken    string
 
 	// Storage settings
 	StorageProvider  string
@@ -46,6 +52,17 @@ type Config struct {
 	StorageS3Region  string
 	StorageS3BaseURL string
 
+	// S3-compatible store settings
+	StorageS3Endpoint           string
+	StorageS3UsePathStyle       bool
+	StorageS3AccessKeyID        string
+	StorageS3SecretAccessKey    string
+	StorageS3CACertFile         string
+	StorageS3InsecureSkipVerify bool
+
+	// Storage quota per organization in megabytes (0 means unlimited)
+	StorageOrgQuotaMB int
+
 	// AI settings
 	AIProvider     string
 	AIClaudeAPIKey string
@@ -56,6 +73,7 @@ type Config struct {
 	// Queue settings
 	QueueProvider          string
 	QueueWorkerCount       int
+	QueueNames             []string
 	QueuePollInterval      time.Duration
 	QueueJobTimeout        time.Duration
 	QueueEnableRateLimits  bool
@@ -64,6 +82,15 @@ type Config struct {
 	QueueCleanupRetention  time.Duration
 	QueueMaxJobsPerHour    int
 	QueueMaxConcurrentJobs int
+	QueueRetryBaseDelay    time.Duration
+	QueueRetryMaxDelay     time.Duration
+
+	// Maintenance settings
+	AuditLogRetentionDays int
+
+	// Project summary settings
+	ProjectSummarySchedule string // Cron spec, or "off"
+	ProjectSummaryDays     int
 }
 
 // LoadConfig loads configuration from environment variables.
@@ -85,6 +112,9 @@ func LoadConfig(getenv func(string) string) (*Config, error) {
 		// Auth settings
 		JWTSecret:     envString(getenv, "JWT_SECRET", "your-secret-key-change-in-production"),
 		JWTExpiration: 7 * 24 * time.Hour,
+		AdminEmails:   envList(getenv, "ADMIN_EMAILS"),
+
+		ReportSigningKey: envString(getenv, "REPORT_SIGNING_KEY", ""),
 
 		// Session settings
 		SessionCookieName: "session_token",
@@ -97,6 +127,7 @@ func LoadConfig(getenv func(string) string) (*Config, error) {
 		EmailFromAddress:     envString(getenv, "EMAIL_FROM_ADDRESS", "noreply@example.com"),
 		EmailFromName:        envString(getenv, "EMAIL_FROM_NAME", "Aletheia"),
 		EmailVerifyBaseURL:   envString(getenv, "EMAIL_VERIFY_BASE_URL", "http://localhost:1323"),
+		EmailWebhookToken:    envString(getenv, "EMAIL_WEBHOOK_TOKEN", ""),
 
 		// Storage settings
 		StorageProvider:  envString(getenv, "STORAGE_PROVIDER", "local"),
@@ -106,6 +137,15 @@ func LoadConfig(getenv func(string) string) (*Config, error) {
 		StorageS3Region:  envString(getenv, "STORAGE_S3_REGION", "us-east-1"),
 		StorageS3BaseURL: envString(getenv, "STORAGE_S3_BASE_URL", ""),
 
+		// S3-compatible store settings
+		StorageS3Endpoint:           envString(getenv, "STORAGE_S3_ENDPOINT", ""),
+		StorageS3UsePathStyle:       envBool(getenv, "STORAGE_S3_USE_PATH_STYLE", false),
+		StorageS3AccessKeyID:        envString(getenv, "STORAGE_S3_ACCESS_KEY_ID", ""),
+		StorageS3SecretAccessKey:    envString(getenv, "STORAGE_S3_SECRET_ACCESS_KEY", ""),
+		StorageS3CACertFile:         envString(getenv, "STORAGE_S3_CA_CERT_FILE", ""),
+		StorageS3InsecureSkipVerify: envBool(getenv, "STORAGE_S3_INSECURE_SKIP_VERIFY", false),
+		StorageOrgQuotaMB:           envInt(getenv, "STORAGE_ORG_QUOTA_MB", 0),
+
 		// AI settings
 		AIProvider:     envString(getenv, "AI_PROVIDER", "mock"),
 		AIClaudeAPIKey: envString(getenv, "CLAUDE_API_KEY", ""),
@@ -116,14 +156,29 @@ func LoadConfig(getenv func(string) string) (*Config, error) {
 		// Queue settings
 		QueueProvider:          envString(getenv, "QUEUE_PROVIDER", "postgres"),
 		QueueWorkerCount:       envInt(getenv, "QUEUE_WORKER_COUNT", 3),
+		QueueNames:             envList(getenv, "QUEUE_NAMES"),
 		QueuePollInterval:      envDuration(getenv, "QUEUE_POLL_INTERVAL", time.Second),
 		QueueJobTimeout:        envDuration(getenv, "QUEUE_JOB_TIMEOUT", 60*time.Second),
 		QueueEnableRateLimits:  envBool(getenv, "QUEUE_ENABLE_RATE_LIMITING", true),
 		QueueShutdownTimeout:   10 * time.Second,
-		QueueCleanupInterval:   time.Hour,
-		QueueCleanupRetention:  7 * 24 * time.Hour,
+		QueueCleanupInterval:   envDuration(getenv, "QUEUE_CLEANUP_INTERVAL", time.Hour),
+		QueueCleanupRetention:  envDuration(getenv, "QUEUE_CLEANUP_RETENTION", 7*24*time.Hour),
 		QueueMaxJobsPerHour:    envInt(getenv, "QUEUE_MAX_JOBS_PER_HOUR", 100),
 		QueueMaxConcurrentJobs: envInt(getenv, "QUEUE_MAX_CONCURRENT_JOBS", 10),
+		QueueRetryBaseDelay:    envDuration(getenv, "QUEUE_RETRY_BASE_DELAY", 10*time.Second),
+		QueueRetryMaxDelay:     envDuration(getenv, "QUEUE_RETRY_MAX_DELAY", time.Hour),
+
+		// Maintenance settings
+		AuditLogRetentionDays: envInt(getenv, "AUDIT_LOG_RETENTION_DAYS", 2555),
+
+		// Project summary settings
+		ProjectSummarySchedule: envString(getenv, "PROJECT_SUMMARY_SCHEDULE", "0 6 * * 1"),
+		ProjectSummaryDays:     envInt(getenv, "PROJECT_SUMMARY_DAYS", 7),
+	}
+
+	// Workers consume every queue unless told otherwise
+	if len(cfg.QueueNames) == 0 {
+		cfg.QueueNames = queueNames
 	}
 
 	// Session secure only in production
@@ -149,6 +204,9 @@ func (c *Config) validate() error {
 		if c.JWTSecret == "your-secret-key-change-in-production" {
 			return fmt.Errorf("JWT_SECRET must be set in production environment")
 		}
+		if c.StorageS3InsecureSkipVerify {
+			return fmt.Errorf("STORAGE_S3_INSECURE_SKIP_VERIFY must not be enabled in production environment")
+		}
 	}
 	return nil
 }
@@ -189,6 +247,16 @@ func envBool(getenv func(string) string, key string, defaultValue bool) bool {
 	return defaultValue
 }
 
+func envList(getenv func(string) string, key string) []string {
+	var values []string
+	for _, value := range strings.Split(getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 func envDuration(getenv func(string) string, key string, defaultValue time.Duration) time.Duration {
 	if value := getenv(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
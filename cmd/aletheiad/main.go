@@ -13,6 +13,7 @@ import (
 
 	aletheiahttp "github.com/dukerupert/aletheia/http"
 	"github.com/dukerupert/aletheia/internal/migrations"
+	"github.com/dukerupert/aletheia/internal/queue"
 	"github.com/dukerupert/aletheia/internal/templates"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -42,11 +43,18 @@ func run(
 		return fmt.Errorf("loading config: %w", err)
 	}
 
+	// Parse run mode and flags
+	opts, err := parseArgs(args, stderr, cfg)
+	if err != nil {
+		return err
+	}
+
 	// Configure logger
 	logger := newLogger(stderr, cfg)
 	slog.SetDefault(logger)
 	logger.Debug("logger initialized", slog.String("level", cfg.LogLevel))
 	logger.Debug("application configuration",
+		slog.String("mode", opts.Mode),
 		slog.String("environment", cfg.Environment),
 		slog.String("host", cfg.Host),
 		slog.Int("port", cfg.Port))
@@ -63,56 +71,49 @@ func run(
 		return fmt.Errorf("running migrations: %w", err)
 	}
 
-	// Initialize template renderer
-	renderer, err := templates.NewTemplateRenderer("web/templates")
-	if err != nil {
-		return fmt.Errorf("initializing templates: %w", err)
-	}
-	logger.Info("template renderer initialized")
-
 	// Initialize services
 	services, err := initServices(ctx, pool, cfg, logger)
 	if err != nil {
 		return fmt.Errorf("initializing services: %w", err)
 	}
 
-	// Create HTTP server configuration
-	addr := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
-	serverCfg := aletheiahttp.Config{
-		Addr:                addr,
-		Logger:              logger,
-		Renderer:            renderer,
-		SessionDuration:     cfg.SessionDuration,
-		SessionSecure:       cfg.SessionSecure,
-		UserService:         services.UserService,
-		SessionService:      services.SessionService,
-		OrganizationService: services.OrganizationService,
-		ProjectService:      services.ProjectService,
-		InspectionService:   services.InspectionService,
-		PhotoService:        services.PhotoService,
-		ViolationService:    services.ViolationService,
-		SafetyCodeService:   services.SafetyCodeService,
-		FileStorage:         services.FileStorage,
-		EmailService:        services.EmailService,
-		AIService:           services.AIService,
-		Queue:               services.Queue,
-	}
-
-	// Create HTTP server
-	server := aletheiahttp.NewServer(serverCfg)
-
 	// Create channel for shutdown signals
 	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
 
-	// Start server
-	serverErr := make(chan error, 1)
-	go func() {
-		logger.Info("starting server", slog.String("addr", addr))
-		if err := server.Open(); err != nil && err != http.ErrServerClosed {
-			serverErr <- err
+	// Start workers and the maintenance scheduler
+	var workers *queue.WorkerPool
+	var scheduler *queue.Scheduler
+	if opts.Workers {
+		workers, scheduler, err = initWorkers(pool, services, cfg, opts, logger)
+		if err != nil {
+			return fmt.Errorf("initializing workers: %w", err)
+		}
+		if err := workers.Start(ctx, opts.Queues); err != nil {
+			return fmt.Errorf("starting workers: %w", err)
+		}
+		if err := scheduler.Start(ctx); err != nil {
+			return fmt.Errorf("starting scheduler: %w", err)
+		}
+	}
+
+	// Start server; serverErr stays nil in worker mode
+	var server *aletheiahttp.Server
+	var serverErr chan error
+	if opts.Mode == ModeServe {
+		server, err = newServer(services, cfg, logger)
+		if err != nil {
+			return err
 		}
-	}()
+
+		serverErr = make(chan error, 1)
+		go func() {
+			logger.Info("starting server", slog.String("addr", server.Addr))
+			if err := server.Open(); err != nil && err != http.ErrServerClosed {
+				serverErr <- err
+			}
+		}()
+	}
 
 	// Wait for shutdown signal or server error
 	select {
@@ -123,20 +124,69 @@ func run(
 	}
 
 	// Graceful shutdown
-	logger.Info("shutting down server...")
+	logger.Info("shutting down...", slog.String("mode", opts.Mode))
 	shutdownCtx, shutdownCancel := context.WithTimeout(ctx, 10*time.Second)
 	defer shutdownCancel()
 
+	// Stop scheduling and let running jobs finish
+	if opts.Workers {
+		if err := scheduler.Stop(); err != nil {
+			logger.Error("failed to stop scheduler", slog.String("error", err.Error()))
+		}
+		if err := workers.Stop(); err != nil {
+			logger.Error("failed to stop workers", slog.String("error", err.Error()))
+		}
+	}
+
 	// Shutdown HTTP server
-	if err := server.Close(shutdownCtx); err != nil {
-		logger.Error("server forced to shutdown", slog.String("error", err.Error()))
-		return fmt.Errorf("server shutdown: %w", err)
+	if server != nil {
+		if err := server.Close(shutdownCtx); err != nil {
+			logger.Error("server forced to shutdown", slog.String("error", err.Error()))
+			return fmt.Errorf("server shutdown: %w", err)
+		}
 	}
 
-	logger.Info("server exited gracefully")
+	logger.Info("exited gracefully")
 	return nil
 }
 
+// newServer creates the HTTP server.
+func newServer(services *Services, cfg *Config, logger *slog.Logger) (*aletheiahttp.Server, error) {
+	// Initialize template renderer
+	renderer, err := templates.NewTemplateRenderer("web/templates")
+	if err != nil {
+		return nil, fmt.Errorf("initializing templates: %w", err)
+	}
+	logger.Info("template renderer initialized")
+
+	return aletheiahttp.NewServer(aletheiahttp.Config{
+		Addr:                fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+		Logger:              logger,
+		Renderer:            renderer,
+		SessionDuration:     cfg.SessionDuration,
+		SessionSecure:       cfg.SessionSecure,
+		StorageQuota:        int64(cfg.StorageOrgQuotaMB) * 1024 * 1024,
+		AdminEmails:         cfg.AdminEmails,
+		EmailWebhookToken:   cfg.EmailWebhookToken,
+		UserService:         services.UserService,
+		SessionService:      services.SessionService,
+		OrganizationService: services.OrganizationService,
+		ProjectService:      services.ProjectService,
+		InspectionService:   services.InspectionService,
+		PhotoService:        services.PhotoService,
+		ViolationService:    services.ViolationService,
+		SafetyCodeService:   services.SafetyCodeService,
+		UploadService:       services.UploadService,
+		ReportService:       services.ReportService,
+		ShareLinkService:    services.ShareLinkService,
+		FileStorage:         services.FileStorage,
+		EmailService:        services.EmailService,
+		AIService:           services.AIService,
+		Queue:               services.Queue,
+		Signer:              services.Signer,
+	}), nil
+}
+
 // newLogger creates a configured slog.Logger based on environment.
 func newLogger(w io.Writer, cfg *Config) *slog.Logger {
 	var level slog.Level
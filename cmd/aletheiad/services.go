@@ -2,9 +2,17 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 
 	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/ai"
+	"github.com/dukerupert/aletheia/internal/analysis"
+	"github.com/dukerupert/aletheia/internal/audit"
+	"github.com/dukerupert/aletheia/internal/maintenance"
+	"github.com/dukerupert/aletheia/internal/queue"
+	"github.com/dukerupert/aletheia/internal/report"
+	"github.com/dukerupert/aletheia/internal/signing"
 	"github.com/dukerupert/aletheia/postgres"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -20,10 +28,14 @@ type Services struct {
 	PhotoService        aletheia.PhotoService
 	ViolationService    aletheia.ViolationService
 	SafetyCodeService   aletheia.SafetyCodeService
+	UploadService       aletheia.UploadService
+	ReportService       aletheia.ReportService
+	ShareLinkService    aletheia.ShareLinkService
 	FileStorage         aletheia.FileStorage
 	EmailService        aletheia.EmailService
 	AIService           aletheia.AIService
 	Queue               aletheia.Queue
+	Signer              *signing.Signer // nil if reports are not signed
 }
 
 // initServices initializes all application services.
@@ -39,18 +51,33 @@ func initServices(ctx context.Context, pool *pgxpool.Pool, cfg *Config, logger *
 	}
 	logger.Info("file storage initialized", slog.String("provider", cfg.StorageProvider))
 
+	// Resumable uploads assemble files through storage multipart uploads
+	uploadService := postgres.NewUploadService(db, fileStorage)
+
 	// Initialize email service
 	emailService := initEmailService(cfg, logger)
 	logger.Info("email service initialized", slog.String("provider", cfg.EmailProvider))
 
 	// Initialize AI service
-	aiService := initAIService(cfg, logger)
+	aiService := initAIService(cfg, fileStorage, logger)
 	logger.Info("AI service initialized", slog.String("provider", cfg.AIProvider))
 
 	// Initialize queue
 	queue := initQueue(pool, cfg, logger)
 	logger.Info("queue service initialized", slog.String("provider", cfg.QueueProvider))
 
+	// Initialize report signing
+	var signer *signing.Signer
+	if cfg.ReportSigningKey != "" {
+		signer, err = signing.NewSigner(cfg.ReportSigningKey)
+		if err != nil {
+			return nil, fmt.Errorf("REPORT_SIGNING_KEY: %w", err)
+		}
+		logger.Info("report signing enabled", slog.String("key_id", signer.KeyID()))
+	} else {
+		logger.Warn("REPORT_SIGNING_KEY not set, reports will not be signed")
+	}
+
 	return &Services{
 		UserService:         db.UserService,
 		SessionService:      db.SessionService,
@@ -60,10 +87,14 @@ func initServices(ctx context.Context, pool *pgxpool.Pool, cfg *Config, logger *
 		PhotoService:        db.PhotoService,
 		ViolationService:    db.ViolationService,
 		SafetyCodeService:   db.SafetyCodeService,
+		UploadService:       uploadService,
+		ReportService:       db.ReportService,
+		ShareLinkService:    db.ShareLinkService,
 		FileStorage:         fileStorage,
 		EmailService:        emailService,
 		AIService:           aiService,
 		Queue:               queue,
+		Signer:              signer,
 	}, nil
 }
 
@@ -73,7 +104,8 @@ func initFileStorage(ctx context.Context, cfg *Config, logger *slog.Logger) (ale
 		slog.String("provider", cfg.StorageProvider),
 		slog.String("local_path", cfg.StorageLocalPath),
 		slog.String("s3_bucket", cfg.StorageS3Bucket),
-		slog.String("s3_region", cfg.StorageS3Region))
+		slog.String("s3_region", cfg.StorageS3Region),
+		slog.String("s3_endpoint", cfg.StorageS3Endpoint))
 
 	storageCfg := aletheia.StorageConfig{
 		Provider:  cfg.StorageProvider,
@@ -82,6 +114,13 @@ func initFileStorage(ctx context.Context, cfg *Config, logger *slog.Logger) (ale
 		S3Bucket:  cfg.StorageS3Bucket,
 		S3Region:  cfg.StorageS3Region,
 		S3BaseURL: cfg.StorageS3BaseURL,
+
+		S3Endpoint:           cfg.StorageS3Endpoint,
+		S3UsePathStyle:       cfg.StorageS3UsePathStyle,
+		S3AccessKeyID:        cfg.StorageS3AccessKeyID,
+		S3SecretAccessKey:    cfg.StorageS3SecretAccessKey,
+		S3CACertFile:         cfg.StorageS3CACertFile,
+		S3InsecureSkipVerify: cfg.StorageS3InsecureSkipVerify,
 	}
 
 	return postgres.NewFileStorage(ctx, logger, storageCfg)
@@ -106,10 +145,20 @@ func initEmailService(cfg *Config, logger *slog.Logger) aletheia.EmailService {
 }
 
 // initAIService creates the appropriate AI service implementation.
-func initAIService(cfg *Config, logger *slog.Logger) aletheia.AIService {
+func initAIService(cfg *Config, storage aletheia.FileStorage, logger *slog.Logger) aletheia.AIService {
 	logger.Debug("AI service configuration",
 		slog.String("provider", cfg.AIProvider))
 
+	if cfg.AIProvider == "claude" {
+		return ai.NewService(logger, ai.AIConfig{
+			Provider:     cfg.AIProvider,
+			ClaudeAPIKey: cfg.AIClaudeAPIKey,
+			ClaudeModel:  cfg.AIClaudeModel,
+			MaxTokens:    cfg.AIMaxTokens,
+			Temperature:  cfg.AITemperature,
+		}, storage)
+	}
+
 	aiCfg := aletheia.AIConfig{
 		Provider:     cfg.AIProvider,
 		ClaudeAPIKey: cfg.AIClaudeAPIKey,
@@ -124,12 +173,102 @@ func initQueue(pool *pgxpool.Pool, cfg *Config, logger *slog.Logger) aletheia.Qu
 	logger.Debug("initializing queue service")
 
 	queueCfg := aletheia.QueueConfig{
-		Provider:           cfg.QueueProvider,
-		WorkerCount:        cfg.QueueWorkerCount,
-		PollInterval:       cfg.QueuePollInterval,
-		JobTimeout:         cfg.QueueJobTimeout,
-		EnableRateLimiting: cfg.QueueEnableRateLimits,
+		Provider:                 cfg.QueueProvider,
+		WorkerCount:              cfg.QueueWorkerCount,
+		PollInterval:             cfg.QueuePollInterval,
+		JobTimeout:               cfg.QueueJobTimeout,
+		EnableRateLimiting:       cfg.QueueEnableRateLimits,
+		DefaultMaxJobsPerHour:    cfg.QueueMaxJobsPerHour,
+		DefaultMaxConcurrentJobs: cfg.QueueMaxConcurrentJobs,
+		RetryBaseDelay:           cfg.QueueRetryBaseDelay,
+		RetryMaxDelay:            cfg.QueueRetryMaxDelay,
 	}
 
 	return postgres.NewQueue(pool, logger, queueCfg)
 }
+
+// initWorkers creates a worker pool with a handler for every job type and
+// the scheduler that enqueues maintenance jobs. Every process running
+// workers runs the scheduler too; the queue ensures each scheduled run is
+// enqueued once.
+func initWorkers(pool *pgxpool.Pool, services *Services, cfg *Config, opts *Options, logger *slog.Logger) (*queue.WorkerPool, *queue.Scheduler, error) {
+	maintenanceCfg := maintenance.Config{
+		JobCleanupInterval:    cfg.QueueCleanupInterval,
+		JobRetention:          cfg.QueueCleanupRetention,
+		AuditLogRetentionDays: cfg.AuditLogRetentionDays,
+	}
+
+	workerCfg := queue.DefaultConfig()
+	workerCfg.WorkerCount = opts.WorkerCount
+	workerCfg.PollInterval = cfg.QueuePollInterval
+	workerCfg.JobTimeout = cfg.QueueJobTimeout
+	workerCfg.ShutdownTimeout = cfg.QueueShutdownTimeout
+
+	workers := queue.NewWorkerPool(services.Queue, logger, workerCfg)
+	handlers := &maintenance.Handlers{
+		Sessions:  services.SessionService,
+		Uploads:   services.UploadService,
+		Queue:     services.Queue,
+		AuditLogs: audit.NewAuditLogger(pool, logger),
+		Config:    maintenanceCfg,
+		Logger:    logger,
+	}
+	handlers.Register(workers)
+
+	generator := &report.Generator{
+		Inspections:   services.InspectionService,
+		Projects:      services.ProjectService,
+		Organizations: services.OrganizationService,
+		Users:         services.UserService,
+		Photos:        services.PhotoService,
+		Violations:    services.ViolationService,
+		SafetyCodes:   services.SafetyCodeService,
+		Reports:       services.ReportService,
+		Storage:       services.FileStorage,
+		Queue:         services.Queue,
+		Signer:        services.Signer,
+		Logger:        logger,
+		StorageQuota:  int64(cfg.StorageOrgQuotaMB) * 1024 * 1024,
+	}
+	generator.Register(workers)
+
+	mailer := &report.Mailer{
+		Inspections:   services.InspectionService,
+		Projects:      services.ProjectService,
+		Organizations: services.OrganizationService,
+		Reports:       services.ReportService,
+		Storage:       services.FileStorage,
+		Email:         services.EmailService,
+		Logger:        logger,
+	}
+	mailer.Register(workers)
+
+	analyzer := &analysis.Analyzer{
+		Photos:      services.PhotoService,
+		Inspections: services.InspectionService,
+		Projects:    services.ProjectService,
+		SafetyCodes: services.SafetyCodeService,
+		Violations:  services.ViolationService,
+		AI:          services.AIService,
+		Logger:      logger,
+	}
+	analyzer.Register(workers)
+
+	scheduler := queue.NewScheduler(services.Queue, logger)
+	for _, schedule := range maintenance.Schedules(maintenanceCfg) {
+		if err := scheduler.Register(schedule); err != nil {
+			return nil, nil, err
+		}
+	}
+	if cfg.ProjectSummarySchedule != "off" {
+		schedule, err := report.SummarySchedule(cfg.ProjectSummarySchedule, cfg.ProjectSummaryDays)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := scheduler.Register(schedule); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return workers, scheduler, nil
+}
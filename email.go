@@ -13,8 +13,37 @@ type EmailService interface {
 	// SendWelcomeEmail sends a welcome email to a new user.
 	SendWelcomeEmail(ctx context.Context, to, name string) error
 
-	// SendInspectionReport sends an inspection report to recipients.
-	SendInspectionReport(ctx context.Context, to []string, subject string, reportURL string) error
+	// SendInspectionReport sends an inspection report to one recipient,
+	// attached or linked. It returns the provider's ID for the message, which
+	// bounce notifications refer to.
+	SendInspectionReport(ctx context.Context, email ReportEmail) (messageID string, err error)
+}
+
+// ReportEmail is an inspection report sent to one recipient.
+type ReportEmail struct {
+	To      string
+	Subject string
+
+	// Message is an optional note from the sender.
+	Message string
+
+	// ReportURL links to the report. It is used when there is no attachment.
+	ReportURL string
+
+	// Attachment is the report's PDF, if it is attached.
+	Attachment *Attachment
+
+	// Organization is the name of the organization sending the report, and
+	// Branding is how the email is styled.
+	Organization string
+	Branding     OrganizationBranding
+}
+
+// Attachment is a file attached to an email.
+type Attachment struct {
+	Name        string
+	ContentType string
+	Content     []byte
 }
 
 // EmailConfig holds configuration for email services.
@@ -38,6 +67,10 @@ type EmailConfig struct {
 	PostmarkServerToken string
 }
 
+// MaxAttachmentSize is the largest attachment sent; larger reports are
+// linked instead. Providers limit whole messages to about 10 MB.
+const MaxAttachmentSize = 7 * 1024 * 1024
+
 // Email represents an email message.
 type Email struct {
 	To       []string
@@ -28,6 +28,26 @@ func errorStatusCode(code string) int {
 	}
 }
 
+// statusErrorCode maps an HTTP status code to the closest domain error code.
+func statusErrorCode(status int) string {
+	switch status {
+	case http.StatusNotFound:
+		return aletheia.ENOTFOUND
+	case http.StatusUnauthorized:
+		return aletheia.EUNAUTHORIZED
+	case http.StatusForbidden:
+		return aletheia.EFORBIDDEN
+	case http.StatusConflict:
+		return aletheia.ECONFLICT
+	case http.StatusTooManyRequests:
+		return aletheia.ERATELIMIT
+	}
+	if status >= 500 {
+		return aletheia.EINTERNAL
+	}
+	return aletheia.EINVALID
+}
+
 // ErrorResponse represents the JSON error response format.
 type ErrorResponse struct {
 	Error   string            `json:"error"`
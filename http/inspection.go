@@ -2,14 +2,46 @@ package http
 
 import (
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/report"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
+// AttendeeRequest is a person present at an inspection.
+type AttendeeRequest struct {
+	Name    string `json:"name" validate:"required,max=100"`
+	Company string `json:"company" validate:"max=100"`
+	Role    string `json:"role" validate:"max=100"`
+}
+
 // CreateInspectionRequest is the request payload for creating an inspection.
 type CreateInspectionRequest struct {
-	ProjectID string `json:"project_id" form:"project_id" validate:"required,uuid"`
+	ProjectID      string            `json:"project_id" form:"project_id" validate:"required,uuid"`
+	Title          string            `json:"title" form:"title" validate:"max=255"`
+	InspectionType string            `json:"inspection_type" form:"inspection_type" validate:"max=50"`
+	ScheduledAt    *time.Time        `json:"scheduled_at" form:"scheduled_at"`
+	PerformedAt    *time.Time        `json:"performed_at" form:"performed_at"`
+	Notes          string            `json:"notes" form:"notes" validate:"max=10000"`
+	Weather        string            `json:"weather" form:"weather" validate:"max=100"`
+	TemperatureF   *float64          `json:"temperature_f" form:"temperature_f" validate:"omitempty,min=-100,max=150"`
+	Attendees      []AttendeeRequest `json:"attendees" validate:"max=50,dive"`
+}
+
+// toAttendees converts requested attendees to domain attendees.
+func toAttendees(reqs []AttendeeRequest) []aletheia.Attendee {
+	attendees := make([]aletheia.Attendee, len(reqs))
+	for i, req := range reqs {
+		attendees[i] = aletheia.Attendee{
+			Name:    strings.TrimSpace(req.Name),
+			Company: strings.TrimSpace(req.Company),
+			Role:    strings.TrimSpace(req.Role),
+		}
+	}
+	return attendees
 }
 
 func (s *Server) handleCreateInspection(c echo.Context) error {
@@ -32,9 +64,17 @@ func (s *Server) handleCreateInspection(c echo.Context) error {
 	}
 
 	inspection := &aletheia.Inspection{
-		ProjectID:   projectID,
-		InspectorID: userID,
-		Status:      aletheia.InspectionStatusDraft,
+		ProjectID:      projectID,
+		InspectorID:    userID,
+		Status:         aletheia.InspectionStatusDraft,
+		Title:          strings.TrimSpace(req.Title),
+		InspectionType: strings.TrimSpace(req.InspectionType),
+		ScheduledAt:    req.ScheduledAt,
+		PerformedAt:    req.PerformedAt,
+		Notes:          req.Notes,
+		Weather:        strings.TrimSpace(req.Weather),
+		TemperatureF:   req.TemperatureF,
+		Attendees:      toAttendees(req.Attendees),
 	}
 
 	if err := s.inspectionService.CreateInspection(ctx, inspection); err != nil {
@@ -63,6 +103,12 @@ func (s *Server) handleGetInspection(c echo.Context) error {
 		return err
 	}
 
+	// Flag a report that no longer matches the inspection
+	inspection.Report, err = report.Status(ctx, s.reportService, s.inspectionService, s.photoService, s.violationService, inspectionID)
+	if err != nil {
+		return err
+	}
+
 	return RespondOK(c, inspection)
 }
 
@@ -87,18 +133,167 @@ func (s *Server) handleListInspections(c echo.Context) error {
 		status := aletheia.InspectionStatus(statusStr)
 		filter.Status = &status
 	}
+	if v := c.QueryParam("type"); v != "" {
+		filter.InspectionType = &v
+	}
+	if v := strings.TrimSpace(c.QueryParam("search")); v != "" {
+		filter.Search = &v
+	}
+	filter.ScheduledFrom, filter.ScheduledTo, err = parseDateRange(c, "scheduled_from", "scheduled_to")
+	if err != nil {
+		return err
+	}
+	filter.PerformedFrom, filter.PerformedTo, err = parseDateRange(c, "performed_from", "performed_to")
+	if err != nil {
+		return err
+	}
 
 	inspections, total, err := s.inspectionService.FindInspections(ctx, filter)
 	if err != nil {
 		return err
 	}
 
+	// Stats for the whole page are fetched at once
+	ids := make([]uuid.UUID, len(inspections))
+	for i, inspection := range inspections {
+		ids[i] = inspection.ID
+	}
+	stats, err := s.inspectionService.FindInspectionStats(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, inspection := range inspections {
+		inspection.Stats = stats[inspection.ID]
+	}
+
 	return RespondOK(c, map[string]interface{}{
 		"inspections": inspections,
 		"total":       total,
 	})
 }
 
+// handleGetInspectionStats returns an inspection's photo and violation
+// counts.
+func (s *Server) handleGetInspectionStats(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	inspectionID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	inspection, err := s.inspectionService.FindInspectionByID(ctx, inspectionID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.getProjectWithOrgCheck(c, inspection.ProjectID); err != nil {
+		return err
+	}
+
+	stats, err := s.inspectionService.GetInspectionStats(ctx, inspectionID)
+	if err != nil {
+		return err
+	}
+
+	return RespondOK(c, stats)
+}
+
+// UpdateInspectionRequest is the request payload for updating an
+// inspection. Omitted fields are left unchanged. Optional fields named in
+// Clear are removed, and an empty attendee list clears the attendees.
+type UpdateInspectionRequest struct {
+	Status         *string           `json:"status" validate:"omitempty,oneof=draft in_progress completed"`
+	Title          *string           `json:"title" validate:"omitempty,max=255"`
+	InspectionType *string           `json:"inspection_type" validate:"omitempty,max=50"`
+	ScheduledAt    *time.Time        `json:"scheduled_at"`
+	PerformedAt    *time.Time        `json:"performed_at"`
+	Notes          *string           `json:"notes" validate:"omitempty,max=10000"`
+	Weather        *string           `json:"weather" validate:"omitempty,max=100"`
+	TemperatureF   *float64          `json:"temperature_f" validate:"omitempty,min=-100,max=150"`
+	Attendees      []AttendeeRequest `json:"attendees" validate:"max=50,dive"`
+	Clear          []string          `json:"clear"`
+}
+
+func (s *Server) handleUpdateInspection(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	inspectionID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req UpdateInspectionRequest
+	if err := bind(c, &req); err != nil {
+		return err
+	}
+
+	inspection, err := s.inspectionService.FindInspectionByID(ctx, inspectionID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.getProjectWithOrgCheck(c, inspection.ProjectID); err != nil {
+		return err
+	}
+
+	upd := aletheia.InspectionUpdate{
+		Title:          trimPtr(req.Title),
+		InspectionType: trimPtr(req.InspectionType),
+		ScheduledAt:    req.ScheduledAt,
+		PerformedAt:    req.PerformedAt,
+		Notes:          req.Notes,
+		Weather:        trimPtr(req.Weather),
+		TemperatureF:   req.TemperatureF,
+	}
+	if req.Status != nil {
+		status := aletheia.InspectionStatus(*req.Status)
+		upd.Status = &status
+	}
+	if req.Attendees != nil {
+		attendees := toAttendees(req.Attendees)
+		upd.Attendees = &attendees
+	}
+	for _, field := range req.Clear {
+		switch field {
+		case "inspection_type":
+			upd.ClearInspectionType = true
+		case "scheduled_at":
+			upd.ClearScheduledAt = true
+		case "performed_at":
+			upd.ClearPerformedAt = true
+		case "notes":
+			upd.ClearNotes = true
+		case "weather":
+			upd.ClearWeather = true
+		case "temperature_f":
+			upd.ClearTemperatureF = true
+		default:
+			return aletheia.Invalid("Cannot clear %s", field)
+		}
+	}
+
+	inspection, err = s.inspectionService.UpdateInspection(ctx, inspectionID, upd)
+	if err != nil {
+		return err
+	}
+
+	s.log(c).Info("inspection updated",
+		slog.String("inspection_id", inspectionID.String()),
+	)
+
+	return RespondOK(c, inspection)
+}
+
+// trimPtr trims the space around an optional string.
+func trimPtr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := strings.TrimSpace(*s)
+	return &v
+}
+
 // UpdateInspectionStatusRequest is the request payload for updating inspection status.
 type UpdateInspectionStatusRequest struct {
 	Status string `json:"status" form:"status" validate:"required,oneof=draft in_progress completed"`
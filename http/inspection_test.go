@@ -0,0 +1,62 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/mock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateInspection_Clear(t *testing.T) {
+	inspection := &aletheia.Inspection{ID: uuid.New(), ProjectID: uuid.New()}
+
+	var got *aletheia.InspectionUpdate
+	s, _ := newTestServer(t, Config{
+		InspectionService: &mock.InspectionService{
+			FindInspectionByIDFn: func(ctx context.Context, id uuid.UUID) (*aletheia.Inspection, error) {
+				return inspection, nil
+			},
+			UpdateInspectionFn: func(ctx context.Context, id uuid.UUID, upd aletheia.InspectionUpdate) (*aletheia.Inspection, error) {
+				got = &upd
+				return inspection, nil
+			},
+		},
+		ProjectService: &mock.ProjectService{
+			FindProjectByIDFn: func(ctx context.Context, id uuid.UUID) (*aletheia.Project, error) {
+				return &aletheia.Project{ID: id, OrganizationID: uuid.New()}, nil
+			},
+		},
+		OrganizationService: &mock.OrganizationService{},
+	})
+
+	update := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/api/inspections/"+inspection.ID.String(), strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		return serve(s, req)
+	}
+
+	rec := update(`{"weather": "Sunny", "clear": ["inspection_type", "scheduled_at", "notes", "temperature_f"]}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.NotNil(t, got)
+	assert.True(t, got.ClearInspectionType)
+	assert.True(t, got.ClearScheduledAt)
+	assert.False(t, got.ClearPerformedAt)
+	assert.True(t, got.ClearNotes)
+	assert.False(t, got.ClearWeather)
+	assert.True(t, got.ClearTemperatureF)
+	assert.Equal(t, "Sunny", *got.Weather)
+
+	// Required fields cannot be cleared
+	got = nil
+	rec = update(`{"clear": ["title"]}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Nil(t, got)
+}
@@ -0,0 +1,323 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	defaultJobPageSize = 50
+	maxJobPageSize     = 200
+)
+
+// JobResponse is the admin view of a job. Payload and result are returned
+// as JSON rather than base64.
+type JobResponse struct {
+	ID              string              `json:"id"`
+	QueueName       string              `json:"queue_name"`
+	JobType         string              `json:"job_type"`
+	OrganizationID  string              `json:"organization_id"`
+	InspectionID    string              `json:"inspection_id,omitempty"`
+	Payload         json.RawMessage     `json:"payload,omitempty"`
+	Status          aletheia.JobStatus  `json:"status"`
+	Priority        int                 `json:"priority"`
+	Attempts        int                 `json:"attempts"`
+	Progress        int                 `json:"progress"`
+	ProgressMessage string              `json:"progress_message,omitempty"`
+	MaxAttempts     int                 `json:"max_attempts"`
+	ScheduledAt     time.Time           `json:"scheduled_at"`
+	CreatedAt       time.Time           `json:"created_at"`
+	StartedAt       *time.Time          `json:"started_at,omitempty"`
+	CompletedAt     *time.Time          `json:"completed_at,omitempty"`
+	Result          json.RawMessage     `json:"result,omitempty"`
+	Error           string              `json:"error,omitempty"`
+	Errors          []aletheia.JobError `json:"errors"`
+	WorkerID        string              `json:"worker_id,omitempty"`
+	HeartbeatAt     *time.Time          `json:"heartbeat_at,omitempty"`
+	UniqueKey       string              `json:"unique_key,omitempty"`
+
+	DependsOn        []string                  `json:"depends_on,omitempty"`
+	DependencyPolicy aletheia.DependencyPolicy `json:"dependency_policy,omitempty"`
+}
+
+func newJobResponse(job *aletheia.Job) JobResponse {
+	resp := JobResponse{
+		ID:              job.ID.String(),
+		QueueName:       job.QueueName,
+		JobType:         job.JobType,
+		OrganizationID:  job.OrganizationID.String(),
+		Status:          job.Status,
+		Priority:        job.Priority,
+		Attempts:        job.AttemptCount,
+		Progress:        job.Progress,
+		ProgressMessage: job.ProgressMessage,
+		MaxAttempts:     job.MaxAttempts,
+		ScheduledAt:     job.ScheduledAt,
+		CreatedAt:       job.CreatedAt,
+		StartedAt:       job.StartedAt,
+		CompletedAt:     job.CompletedAt,
+		Error:           job.ErrorMessage,
+		Errors:          job.Errors,
+		WorkerID:        job.WorkerID,
+		HeartbeatAt:     job.HeartbeatAt,
+		UniqueKey:       job.UniqueKey,
+
+		DependencyPolicy: job.DependencyPolicy,
+	}
+	for _, id := range job.DependsOn {
+		resp.DependsOn = append(resp.DependsOn, id.String())
+	}
+	if job.InspectionID != uuid.Nil {
+		resp.InspectionID = job.InspectionID.String()
+	}
+	if json.Valid(job.Payload) {
+		resp.Payload = job.Payload
+	}
+	if json.Valid(job.Result) {
+		resp.Result = job.Result
+	}
+	if resp.Errors == nil {
+		resp.Errors = []aletheia.JobError{}
+	}
+	return resp
+}
+
+// JobFilterRequest selects jobs for the admin API. It is read from the query
+// string when listing and from the body for batch operations.
+type JobFilterRequest struct {
+	Queue          string `json:"queue" query:"queue"`
+	Type           string `json:"type" query:"type"`
+	Status         string `json:"status" query:"status"`
+	OrganizationID string `json:"organization_id" query:"organization_id"`
+}
+
+// toFilter converts the request to a domain filter, validating its fields.
+func (r JobFilterRequest) toFilter() (aletheia.JobFilter, error) {
+	var filter aletheia.JobFilter
+
+	if r.Queue != "" {
+		filter.QueueName = &r.Queue
+	}
+	if r.Type != "" {
+		filter.JobType = &r.Type
+	}
+	if r.Status != "" {
+		status := aletheia.JobStatus(r.Status)
+		if !status.IsValid() {
+			return filter, aletheia.Invalid("Invalid job status: %s", r.Status)
+		}
+		filter.Status = &status
+	}
+	if r.OrganizationID != "" {
+		orgID, err := parseUUID(r.OrganizationID)
+		if err != nil {
+			return filter, aletheia.Invalid("Invalid organization ID format")
+		}
+		filter.OrganizationID = &orgID
+	}
+
+	return filter, nil
+}
+
+func (s *Server) handleListJobs(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	filter, err := JobFilterRequest{
+		Queue:          c.QueryParam("queue"),
+		Type:           c.QueryParam("type"),
+		Status:         c.QueryParam("status"),
+		OrganizationID: c.QueryParam("organization_id"),
+	}.toFilter()
+	if err != nil {
+		return err
+	}
+
+	filter.Limit = defaultJobPageSize
+	if v := c.QueryParam("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 {
+			return aletheia.Invalid("limit must be a positive integer")
+		}
+		filter.Limit = min(limit, maxJobPageSize)
+	}
+	if v := c.QueryParam("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			return aletheia.Invalid("offset must be a non-negative integer")
+		}
+		filter.Offset = offset
+	}
+
+	jobs, total, err := s.queue.ListJobs(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	data := make([]JobResponse, len(jobs))
+	for i, job := range jobs {
+		data[i] = newJobResponse(job)
+	}
+
+	return RespondList(c, data, total, filter.Offset, filter.Limit)
+}
+
+func (s *Server) handleGetJob(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	jobID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	job, err := s.queue.GetJob(ctx, jobID)
+	if err != nil {
+		return err
+	}
+
+	return RespondOK(c, newJobResponse(job))
+}
+
+func (s *Server) handleRetryJob(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	jobID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	if err := s.queue.RetryJob(ctx, jobID); err != nil {
+		return err
+	}
+
+	s.log(c).Info("job retried by admin", slog.String("job_id", jobID.String()))
+
+	job, err := s.queue.GetJob(ctx, jobID)
+	if err != nil {
+		return err
+	}
+
+	return RespondOK(c, newJobResponse(job))
+}
+
+func (s *Server) handleCancelJob(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	jobID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	if err := s.queue.CancelJob(ctx, jobID); err != nil {
+		return err
+	}
+
+	s.log(c).Info("job cancelled by admin", slog.String("job_id", jobID.String()))
+
+	job, err := s.queue.GetJob(ctx, jobID)
+	if err != nil {
+		return err
+	}
+
+	return RespondOK(c, newJobResponse(job))
+}
+
+// handleRetryJobs retries every job matching the filter in the request body.
+// A status is required so a batch cannot sweep up deliberately cancelled
+// jobs along with failed ones.
+func (s *Server) handleRetryJobs(c echo.Context) error {
+	var req JobFilterRequest
+	if err := bind(c, &req); err != nil {
+		return err
+	}
+
+	filter, err := req.toFilter()
+	if err != nil {
+		return err
+	}
+	if filter.Status == nil || !filter.Status.IsRetryable() {
+		return aletheia.Invalid("status must be failed, dead, or cancelled")
+	}
+
+	return s.retryJobs(c, filter)
+}
+
+// handleReplayDeadJobs retries dead-lettered jobs matching the filter in the
+// request body, for recovering after an incident.
+func (s *Server) handleReplayDeadJobs(c echo.Context) error {
+	var req JobFilterRequest
+	if err := bind(c, &req); err != nil {
+		return err
+	}
+	req.Status = string(aletheia.JobStatusDead)
+
+	filter, err := req.toFilter()
+	if err != nil {
+		return err
+	}
+
+	return s.retryJobs(c, filter)
+}
+
+func (s *Server) retryJobs(c echo.Context, filter aletheia.JobFilter) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	retried, err := s.queue.RetryJobs(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	s.log(c).Info("jobs retried by admin",
+		slog.String("status", string(*filter.Status)),
+		slog.Int("count", retried),
+	)
+
+	return RespondOK(c, map[string]interface{}{
+		"retried": retried,
+	})
+}
+
+// handleCancelJobs cancels every pending job matching the filter in the
+// request body. At least one of queue, type, or organization is required so
+// an empty body cannot cancel every pending job.
+func (s *Server) handleCancelJobs(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	var req JobFilterRequest
+	if err := bind(c, &req); err != nil {
+		return err
+	}
+	if req.Status != "" && req.Status != string(aletheia.JobStatusPending) {
+		return aletheia.Invalid("Can only cancel pending jobs")
+	}
+
+	filter, err := req.toFilter()
+	if err != nil {
+		return err
+	}
+	if filter.QueueName == nil && filter.JobType == nil && filter.OrganizationID == nil {
+		return aletheia.Invalid("queue, type, or organization_id is required")
+	}
+
+	cancelled, err := s.queue.CancelJobs(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	s.log(c).Info("jobs cancelled by admin", slog.Int("count", cancelled))
+
+	return RespondOK(c, map[string]interface{}{
+		"cancelled": cancelled,
+	})
+}
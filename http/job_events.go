@@ -0,0 +1,441 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// jobEventInterval is how often a job event stream checks its jobs for
+	// changes when the queue cannot push job events.
+	jobEventInterval = time.Second
+
+	// jobEventFallbackInterval is how often a stream subscribed to job
+	// events reloads its jobs anyway, in case a notification was dropped.
+	jobEventFallbackInterval = 30 * time.Second
+
+	// jobEventKeepAlive is how often a comment is sent on a stream to keep
+	// proxies from closing it.
+	jobEventKeepAlive = 15 * time.Second
+)
+
+// jobEventHub shares one job event subscription between every open stream,
+// so streams do not each hold a database connection.
+type jobEventHub struct {
+	listener aletheia.QueueListener
+	logger   *slog.Logger
+	ctx      context.Context // Ends the subscription when the server closes
+	cancel   context.CancelFunc
+
+	mu       sync.Mutex
+	running  bool
+	watchers map[*jobWatch]struct{}
+}
+
+// newJobEventHub returns a hub for queue, or nil if the queue cannot push
+// job events.
+func newJobEventHub(queue aletheia.Queue, logger *slog.Logger) *jobEventHub {
+	listener, ok := queue.(aletheia.QueueListener)
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &jobEventHub{
+		listener: listener,
+		logger:   logger,
+		ctx:      ctx,
+		cancel:   cancel,
+		watchers: make(map[*jobWatch]struct{}),
+	}
+}
+
+// watch adds w to the streams woken by job events, subscribing to them if
+// no subscription is running. It returns false, leaving the stream to
+// poll, if job events are unavailable.
+func (h *jobEventHub) watch(w *jobWatch) bool {
+	if h == nil {
+		return false
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.running {
+		changes, err := h.listener.ListenJobEvents(h.ctx)
+		if err != nil {
+			h.logger.Warn("job events unavailable, polling instead", slog.String("error", err.Error()))
+			return false
+		}
+		h.running = true
+		go h.run(changes)
+	}
+
+	h.watchers[w] = struct{}{}
+	return true
+}
+
+// unwatch stops waking w.
+func (h *jobEventHub) unwatch(w *jobWatch) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.watchers, w)
+}
+
+// run passes each change to the watching streams until the subscription
+// ends. Those streams fall back to polling, and the next stream to open
+// subscribes again.
+func (h *jobEventHub) run(changes <-chan aletheia.JobChange) {
+	for change := range changes {
+		h.mu.Lock()
+		for w := range h.watchers {
+			w.notify(change)
+		}
+		h.mu.Unlock()
+	}
+
+	h.mu.Lock()
+	h.running = false
+	h.mu.Unlock()
+}
+
+// close ends the subscription.
+func (h *jobEventHub) close() {
+	if h != nil {
+		h.cancel()
+	}
+}
+
+// jobWatch wakes a stream when one of its jobs changes or, for a stream
+// following an inspection, when a job is enqueued for the inspection.
+type jobWatch struct {
+	wake         chan struct{}
+	inspectionID uuid.UUID
+
+	mu   sync.Mutex
+	jobs map[uuid.UUID]bool
+}
+
+func newJobWatch(inspectionID uuid.UUID) *jobWatch {
+	return &jobWatch{
+		wake:         make(chan struct{}, 1),
+		inspectionID: inspectionID,
+	}
+}
+
+// setJobs sets the jobs the stream is following.
+func (w *jobWatch) setJobs(jobs []*aletheia.Job) {
+	ids := make(map[uuid.UUID]bool, len(jobs))
+	for _, job := range jobs {
+		ids[job.ID] = true
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.jobs = ids
+}
+
+// notify wakes the stream if change concerns it. Wakeups while the stream
+// is busy are merged, since it reloads all of its jobs.
+func (w *jobWatch) notify(change aletheia.JobChange) {
+	w.mu.Lock()
+	relevant := change.JobID == uuid.Nil || w.jobs[change.JobID] ||
+		(w.inspectionID != uuid.Nil && change.InspectionID == w.inspectionID)
+	w.mu.Unlock()
+
+	if relevant {
+		select {
+		case w.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// JobEvent is sent on a job event stream when a job's status or progress
+// changes.
+type JobEvent struct {
+	ID              string             `json:"id"`
+	JobType         string             `json:"job_type"`
+	Status          aletheia.JobStatus `json:"status"`
+	Progress        int                `json:"progress"`
+	ProgressMessage string             `json:"progress_message,omitempty"`
+	Error           string             `json:"error,omitempty"`
+	CompletedAt     *time.Time         `json:"completed_at,omitempty"`
+}
+
+func newJobEvent(job *aletheia.Job) JobEvent {
+	return JobEvent{
+		ID:              job.ID.String(),
+		JobType:         job.JobType,
+		Status:          job.Status,
+		Progress:        job.Progress,
+		ProgressMessage: job.ProgressMessage,
+		Error:           job.ErrorMessage,
+		CompletedAt:     job.CompletedAt,
+	}
+}
+
+// JobsDoneEvent is sent when every job on a stream has finished, just
+// before the stream closes.
+type JobsDoneEvent struct {
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
+}
+
+// handleJobEvents streams status and progress changes for a single job.
+func (s *Server) handleJobEvents(c echo.Context) error {
+	jobID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	load := func(ctx context.Context) ([]*aletheia.Job, error) {
+		job, err := s.queue.GetJob(ctx, jobID)
+		if err != nil {
+			return nil, err
+		}
+		return []*aletheia.Job{job}, nil
+	}
+
+	jobs, err := s.loadJobs(c, load)
+	if err != nil {
+		return err
+	}
+	if err := s.requireJobAccess(c, jobs[0]); err != nil {
+		return err
+	}
+
+	return s.streamJobs(c, jobs, load, newJobWatch(uuid.Nil), true)
+}
+
+// handleBatchEvents streams status and progress changes for a batch: the
+// callback job given by ID and the jobs it depends on.
+func (s *Server) handleBatchEvents(c echo.Context) error {
+	jobID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	load := func(ctx context.Context) ([]*aletheia.Job, error) {
+		callback, err := s.queue.GetJob(ctx, jobID)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs := []*aletheia.Job{callback}
+		for _, id := range callback.DependsOn {
+			job, err := s.queue.GetJob(ctx, id)
+			if aletheia.IsErrorCode(err, aletheia.ENOTFOUND) {
+				continue // Finished jobs may have been cleaned up
+			} else if err != nil {
+				return nil, err
+			}
+			jobs = append(jobs, job)
+		}
+		return jobs, nil
+	}
+
+	jobs, err := s.loadJobs(c, load)
+	if err != nil {
+		return err
+	}
+	if err := s.requireJobAccess(c, jobs[0]); err != nil {
+		return err
+	}
+
+	return s.streamJobs(c, jobs, load, newJobWatch(uuid.Nil), true)
+}
+
+// handleInspectionJobEvents streams status and progress changes for every
+// job working on an inspection. The stream stays open as new jobs start,
+// until the client disconnects.
+func (s *Server) handleInspectionJobEvents(c echo.Context) error {
+	inspectionID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	inspection, err := s.inspectionService.FindInspectionByID(ctx, inspectionID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.getProjectWithOrgCheck(c, inspection.ProjectID); err != nil {
+		return err
+	}
+
+	load := func(ctx context.Context) ([]*aletheia.Job, error) {
+		jobs, _, err := s.queue.ListJobs(ctx, aletheia.JobFilter{
+			InspectionID: &inspectionID,
+			Limit:        maxJobPageSize,
+		})
+		return jobs, err
+	}
+
+	jobs, err := s.loadJobs(c, load)
+	if err != nil {
+		return err
+	}
+
+	return s.streamJobs(c, jobs, load, newJobWatch(inspectionID), false)
+}
+
+// loadJobs runs load with the request timeout.
+func (s *Server) loadJobs(c echo.Context, load func(ctx context.Context) ([]*aletheia.Job, error)) ([]*aletheia.Job, error) {
+	if s.queue == nil {
+		return nil, aletheia.Internal("Queue service not available", nil)
+	}
+
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	return load(ctx)
+}
+
+// requireJobAccess returns ENOTFOUND unless the user belongs to the job's
+// organization. Maintenance jobs belong to no organization and are hidden.
+func (s *Server) requireJobAccess(c echo.Context, job *aletheia.Job) error {
+	user, err := requireUser(c)
+	if err != nil {
+		return err
+	}
+
+	if job.OrganizationID == uuid.Nil {
+		return aletheia.NotFound("Job not found")
+	}
+
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	if _, err := s.organizationService.RequireMembership(ctx, job.OrganizationID, user.ID); err != nil {
+		if aletheia.IsErrorCode(err, aletheia.EFORBIDDEN) {
+			return aletheia.NotFound("Job not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// streamJobs sends jobs as Server-Sent Events, then reloads them whenever
+// watch is woken by a job event, or every jobEventInterval if the queue
+// cannot push job events, and sends a "job" event for each job whose status
+// or progress changed. If untilDone is set, it sends a "done" event and
+// closes the stream once every job has finished.
+func (s *Server) streamJobs(c echo.Context, jobs []*aletheia.Job, load func(ctx context.Context) ([]*aletheia.Job, error), watch *jobWatch, untilDone bool) error {
+	w := c.Response()
+	w.Header().Set(echo.HeaderContentType, "text/event-stream")
+	w.Header().Set(echo.HeaderCacheControl, "no-cache")
+	w.Header().Set(echo.HeaderConnection, "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable proxy buffering
+	w.WriteHeader(http.StatusOK)
+
+	ctx := c.Request().Context()
+	sent := map[uuid.UUID]JobEvent{}
+
+	interval := jobEventInterval
+	if s.jobEvents.watch(watch) {
+		defer s.jobEvents.unwatch(watch)
+		interval = jobEventFallbackInterval
+
+		// Changes made since jobs were loaded were missed, so reload once
+		// straight away.
+		watch.notify(aletheia.JobChange{})
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	keepAlive := time.NewTicker(jobEventKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		watch.setJobs(jobs)
+
+		for _, job := range jobs {
+			event := newJobEvent(job)
+			if prev, ok := sent[job.ID]; ok && sameJobEvent(prev, event) {
+				continue
+			}
+			if err := writeEvent(w, "job", event); err != nil {
+				return nil // Client went away
+			}
+			sent[job.ID] = event
+		}
+
+		if untilDone && allFinished(jobs) {
+			done := JobsDoneEvent{Total: len(jobs)}
+			for _, job := range jobs {
+				if job.Status == aletheia.JobStatusCompleted {
+					done.Completed++
+				}
+			}
+			writeEvent(w, "done", done)
+			return nil
+		}
+
+	wait:
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-keepAlive.C:
+				if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+					return nil
+				}
+				w.Flush()
+			case <-ticker.C:
+				break wait
+			case <-watch.wake:
+				break wait
+			}
+		}
+
+		var err error
+		if jobs, err = s.loadJobs(c, load); err != nil {
+			s.log(c).Error("failed to reload streamed jobs", slog.String("error", err.Error()))
+			writeEvent(w, "error", map[string]string{"message": aletheia.ErrorMessage(err)})
+			return nil
+		}
+	}
+}
+
+// writeEvent writes a Server-Sent Event with a JSON payload and flushes it.
+func writeEvent(w *echo.Response, name string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, payload); err != nil {
+		return err
+	}
+	w.Flush()
+	return nil
+}
+
+// sameJobEvent reports whether two events describe the same job state.
+func sameJobEvent(a, b JobEvent) bool {
+	return a.Status == b.Status &&
+		a.Progress == b.Progress &&
+		a.ProgressMessage == b.ProgressMessage &&
+		a.Error == b.Error
+}
+
+// allFinished reports whether every job is in a terminal state.
+func allFinished(jobs []*aletheia.Job) bool {
+	for _, job := range jobs {
+		if !job.Status.IsTerminal() {
+			return false
+		}
+	}
+	return true
+}
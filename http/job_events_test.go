@@ -0,0 +1,93 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobEvents_WakesOnJobEvents(t *testing.T) {
+	queue := mock.NewQueue()
+	s, _ := newTestServer(t, Config{
+		Queue: queue,
+		OrganizationService: &mock.OrganizationService{
+			RequireMembershipFn: func(ctx context.Context, orgID, userID uuid.UUID, allowedRoles ...aletheia.OrganizationRole) (*aletheia.OrganizationMember, error) {
+				return &aletheia.OrganizationMember{OrganizationID: orgID, UserID: userID}, nil
+			},
+		},
+	})
+
+	ctx := context.Background()
+	job := &aletheia.Job{QueueName: "reports", JobType: "generate_report", OrganizationID: uuid.New()}
+	require.NoError(t, queue.Enqueue(ctx, job))
+	running, err := queue.Dequeue(ctx, "reports", "worker-1")
+	require.NoError(t, err)
+	require.NotNil(t, running)
+
+	reqCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "/api/jobs/"+job.ID.String()+"/events", nil).WithContext(reqCtx)
+
+	done := make(chan *httptest.ResponseRecorder)
+	start := time.Now()
+	go func() { done <- serve(s, req) }()
+
+	// Wait for the stream to subscribe before changing the job
+	require.Eventually(t, func() bool {
+		s.jobEvents.mu.Lock()
+		defer s.jobEvents.mu.Unlock()
+		return len(s.jobEvents.watchers) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, queue.UpdateProgress(ctx, job.ID, "worker-1", 50, "Halfway"))
+	require.NoError(t, queue.Complete(ctx, job.ID, "worker-1", nil))
+
+	rec := <-done
+	assert.Less(t, time.Since(start), jobEventInterval, "stream should not wait to poll")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"completed"`)
+	assert.Contains(t, rec.Body.String(), "event: done")
+}
+
+func TestJobWatch_Notify(t *testing.T) {
+	jobID := uuid.New()
+	inspectionID := uuid.New()
+	job := &aletheia.Job{ID: jobID}
+
+	tests := []struct {
+		name         string
+		inspectionID uuid.UUID
+		change       aletheia.JobChange
+		wake         bool
+	}{
+		{"FollowedJob", uuid.Nil, aletheia.JobChange{JobID: jobID}, true},
+		{"OtherJob", uuid.Nil, aletheia.JobChange{JobID: uuid.New()}, false},
+		{"AnyJob", uuid.Nil, aletheia.JobChange{}, true},
+		{"NewInspectionJob", inspectionID, aletheia.JobChange{JobID: uuid.New(), InspectionID: inspectionID}, true},
+		{"OtherInspectionJob", inspectionID, aletheia.JobChange{JobID: uuid.New(), InspectionID: uuid.New()}, false},
+		{"OtherJobWithoutInspection", uuid.Nil, aletheia.JobChange{JobID: uuid.New(), InspectionID: inspectionID}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newJobWatch(tt.inspectionID)
+			w.setJobs([]*aletheia.Job{job})
+			w.notify(tt.change)
+
+			select {
+			case <-w.wake:
+				assert.True(t, tt.wake, "unexpected wakeup")
+			default:
+				assert.False(t, tt.wake, "expected wakeup")
+			}
+		})
+	}
+}
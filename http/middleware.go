@@ -3,6 +3,7 @@ package http
 import (
 	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/dukerupert/aletheia"
@@ -32,9 +33,10 @@ func (s *Server) registerMiddleware() {
 
 	// CORS middleware (configure as needed)
 	s.echo.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: []string{"*"},
-		AllowMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions},
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, "HX-Request", "HX-Target", "HX-Trigger"},
+		AllowOrigins:  []string{"*"},
+		AllowMethods:  []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions},
+		AllowHeaders:  append([]string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, "HX-Request", "HX-Target", "HX-Trigger"}, tusHeaders...),
+		ExposeHeaders: tusExposedHeaders,
 	}))
 
 	// Custom error handler
@@ -93,7 +95,8 @@ func (s *Server) httpErrorHandler(err error, c echo.Context) {
 	if he, ok := err.(*echo.HTTPError); ok {
 		msg := he.Message
 		if m, ok := msg.(string); ok {
-			_ = HandleError(c, s.logger, echo.NewHTTPError(he.Code, m))
+			// Keep the status, such as the 412 of an unsupported tus version
+			_ = c.JSON(he.Code, ErrorResponse{Error: statusErrorCode(he.Code), Message: m})
 		} else {
 			_ = c.JSON(he.Code, map[string]any{"error": msg})
 		}
@@ -207,6 +210,25 @@ func (s *Server) RequireOrgMembership(paramName string, allowedRoles ...aletheia
 	}
 }
 
+// RequireAdmin is a middleware that requires the user to be an administrator.
+// Administrators are configured by email and must have verified it.
+func (s *Server) RequireAdmin() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user := aletheia.UserFromContext(c.Request().Context())
+			if user == nil {
+				return aletheia.Unauthorized("Authentication required")
+			}
+
+			if !user.IsVerified() || !s.adminEmails[strings.ToLower(user.Email)] {
+				return aletheia.Forbidden("Administrator access required")
+			}
+
+			return next(c)
+		}
+	}
+}
+
 // getRequestLogger retrieves the request-scoped logger from context.
 func (s *Server) getRequestLogger(c echo.Context) *slog.Logger {
 	if logger, ok := c.Get("logger").(*slog.Logger); ok {
@@ -227,3 +249,15 @@ func getUserID(c echo.Context) (uuid.UUID, bool) {
 	}
 	return uuid.UUID{}, false
 }
+
+// publicRateLimit limits each client to about one request a second, with
+// bursts of 20, on public routes: to slow down guessing share link
+// passwords, and to keep report verification from being used to hash
+// files at will.
+func publicRateLimit() echo.MiddlewareFunc {
+	return middleware.RateLimiter(middleware.NewRateLimiterMemoryStoreWithConfig(middleware.RateLimiterMemoryStoreConfig{
+		Rate:      1,
+		Burst:     20,
+		ExpiresIn: 10 * time.Minute,
+	}))
+}
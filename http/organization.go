@@ -3,8 +3,11 @@ package http
 import (
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/dukerupert/aletheia"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
@@ -237,3 +240,216 @@ func (s *Server) handleRemoveOrganizationMember(c echo.Context) error {
 
 	return c.NoContent(http.StatusNoContent)
 }
+
+// Organization storage handlers
+
+func (s *Server) handleGetOrganizationStorageUsage(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	orgID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	userID, err := requireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.organizationService.RequireMembership(ctx, orgID, userID); err != nil {
+		return err
+	}
+
+	usage, err := s.organizationService.GetStorageUsage(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	usage.QuotaBytes = s.storageQuota
+
+	return RespondOK(c, usage)
+}
+
+// Organization branding handlers
+
+// maxLogoSize is the largest logo accepted.
+const maxLogoSize = 2 * 1024 * 1024 // 2MB
+
+// UpdateBrandingRequest is the request payload for updating an
+// organization's branding. Omitted fields are left unchanged; empty strings
+// clear them.
+type UpdateBrandingRequest struct {
+	PrimaryColor   *string `json:"primary_color" form:"primary_color" validate:"omitnil,max=7"` // "#rrggbb"
+	Address        *string `json:"address" form:"address" validate:"omitempty,max=500"`
+	ReportFooter   *string `json:"report_footer" form:"report_footer" validate:"omitempty,max=2000"`
+	ReportTemplate *string `json:"report_template" form:"report_template" validate:"omitnil,oneof=full executive_summary violations_only"`
+}
+
+func (s *Server) handleUpdateOrganizationBranding(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	orgID, err := s.requireOrganizationAdmin(c)
+	if err != nil {
+		return err
+	}
+
+	var req UpdateBrandingRequest
+	if err := bind(c, &req); err != nil {
+		return err
+	}
+
+	upd := aletheia.OrganizationUpdate{
+		PrimaryColor: req.PrimaryColor,
+		Address:      req.Address,
+		ReportFooter: req.ReportFooter,
+	}
+	if req.PrimaryColor != nil {
+		color := strings.ToLower(*req.PrimaryColor)
+		if color != "" && !isHexColor(color) {
+			return aletheia.Invalid("primary_color must be a color such as #1d4ed8")
+		}
+		upd.PrimaryColor = &color
+	}
+	if req.ReportTemplate != nil {
+		template := aletheia.ReportTemplate(*req.ReportTemplate)
+		upd.ReportTemplate = &template
+	}
+
+	org, err := s.organizationService.UpdateOrganization(ctx, orgID, upd)
+	if err != nil {
+		return err
+	}
+
+	s.log(c).Info("organization branding updated", slog.String("org_id", org.ID.String()))
+
+	return RespondOK(c, org)
+}
+
+// handleUploadOrganizationLogo stores a new logo for an organization,
+// replacing its current one.
+func (s *Server) handleUploadOrganizationLogo(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	orgID, err := s.requireOrganizationAdmin(c)
+	if err != nil {
+		return err
+	}
+
+	file, err := c.FormFile("logo")
+	if err != nil {
+		return aletheia.Invalid("logo file is required")
+	}
+	// Logos are exempt from the storage quota, so their size is capped here
+	if file.Size > maxLogoSize {
+		return aletheia.Invalid("logo file exceeds maximum size of 2MB")
+	}
+	// Logos are embedded in PDF reports, which can decode JPEG and PNG only
+	contentType := file.Header.Get("Content-Type")
+	if contentType != "image/jpeg" && contentType != "image/png" {
+		return aletheia.Invalid("invalid logo type, must be JPEG or PNG")
+	}
+
+	org, err := s.organizationService.FindOrganizationByID(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return aletheia.Internal("Failed to read uploaded file", err)
+	}
+	defer src.Close()
+
+	// Every logo gets its own key so reports and emails showing the old one
+	// are not changed under them
+	storagePath := "organizations/" + orgID.String() + "/logo/" + uuid.NewString()
+	logoURL, err := s.fileStorage.Upload(ctx, storagePath, src, contentType)
+	if err != nil {
+		s.log(c).Error("failed to upload logo", slog.String("error", err.Error()))
+		return aletheia.Internal("Failed to upload logo", err)
+	}
+
+	org, err = s.setOrganizationLogo(c, org, logoURL)
+	if err != nil {
+		_ = s.fileStorage.Delete(ctx, storagePath)
+		return err
+	}
+
+	s.log(c).Info("organization logo uploaded", slog.String("org_id", org.ID.String()))
+
+	return RespondOK(c, org)
+}
+
+func (s *Server) handleDeleteOrganizationLogo(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	orgID, err := s.requireOrganizationAdmin(c)
+	if err != nil {
+		return err
+	}
+
+	org, err := s.organizationService.FindOrganizationByID(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.setOrganizationLogo(c, org, ""); err != nil {
+		return err
+	}
+
+	s.log(c).Info("organization logo removed", slog.String("org_id", orgID.String()))
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// setOrganizationLogo points an organization at a new logo, or at none if
+// logoURL is empty, and deletes the old one from storage.
+func (s *Server) setOrganizationLogo(c echo.Context, org *aletheia.Organization, logoURL string) (*aletheia.Organization, error) {
+	ctx := c.Request().Context()
+	previous := org.Branding.LogoURL
+
+	org, err := s.organizationService.UpdateOrganization(ctx, org.ID, aletheia.OrganizationUpdate{LogoURL: &logoURL})
+	if err != nil {
+		return nil, err
+	}
+
+	if key, ok := aletheia.StorageKey(s.fileStorage, previous); previous != "" && ok {
+		if err := s.fileStorage.Delete(ctx, key); err != nil {
+			s.log(c).Warn("failed to delete previous logo",
+				slog.String("org_id", org.ID.String()),
+				slog.String("error", err.Error()))
+		}
+	}
+	return org, nil
+}
+
+// isHexColor reports whether s is a "#rrggbb" color.
+func isHexColor(s string) bool {
+	if len(s) != 7 || s[0] != '#' {
+		return false
+	}
+	_, err := strconv.ParseUint(s[1:], 16, 32)
+	return err == nil
+}
+
+// requireOrganizationAdmin returns the organization ID of the request, if
+// the user is one of its owners or admins.
+func (s *Server) requireOrganizationAdmin(c echo.Context) (uuid.UUID, error) {
+	orgID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	userID, err := requireUserID(c)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if _, err := s.organizationService.RequireMembership(c.Request().Context(), orgID, userID, aletheia.RoleOwner, aletheia.RoleAdmin); err != nil {
+		return uuid.Nil, err
+	}
+	return orgID, nil
+}
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
 	"log/slog"
 	"net/http"
@@ -33,7 +34,7 @@ func (s *Server) handleUploadPhoto(c echo.Context) error {
 	}
 
 	// Verify inspection exists
-	_, err = s.inspectionService.FindInspectionByID(ctx, inspectionID)
+	inspection, err := s.inspectionService.FindInspectionByID(ctx, inspectionID)
 	if err != nil {
 		return err
 	}
@@ -55,6 +56,11 @@ func (s *Server) handleUploadPhoto(c echo.Context) error {
 		return aletheia.Invalid("invalid image type, must be JPEG, PNG, or WebP")
 	}
 
+	// Enforce the organization's storage quota
+	if err := s.checkStorageQuota(ctx, inspection, file.Size); err != nil {
+		return err
+	}
+
 	// Open file for reading
 	src, err := file.Open()
 	if err != nil {
@@ -78,6 +84,7 @@ func (s *Server) handleUploadPhoto(c echo.Context) error {
 		ID:           photoID,
 		InspectionID: inspectionID,
 		StorageURL:   storageURL,
+		SizeBytes:    file.Size,
 	}
 
 	if err := s.photoService.CreatePhoto(ctx, photo); err != nil {
@@ -211,25 +218,30 @@ func (s *Server) handleAnalyzePhoto(c echo.Context) error {
 	}
 
 	// Create job with payload
-	payload, _ := json.Marshal(map[string]interface{}{
-		"photo_id": photoID.String(),
-	})
+	payload, _ := json.Marshal(aletheia.PhotoAnalysisPayload{PhotoID: photoID})
 
 	job := &aletheia.Job{
 		ID:             uuid.New(),
 		QueueName:      aletheia.QueueDefault,
 		JobType:        aletheia.JobTypePhotoAnalysis,
 		OrganizationID: project.OrganizationID,
+		InspectionID:   inspection.ID,
 		Payload:        payload,
 		Status:         aletheia.JobStatusPending,
 		MaxAttempts:    3,
 	}
 
-	if err := s.queue.Enqueue(ctx, job); err != nil {
+	// Repeated requests for the same photo share the active analysis job
+	if err := s.queue.Enqueue(ctx, job, aletheia.WithUniqueKey(aletheia.JobTypePhotoAnalysis+":"+photoID.String())); err != nil {
 		s.log(c).Error("failed to enqueue photo analysis", slog.String("error", err.Error()))
 		return aletheia.Internal("Failed to queue analysis", err)
 	}
 
+	status := "queued"
+	if job.Status != aletheia.JobStatusPending {
+		status = string(job.Status)
+	}
+
 	s.log(c).Info("photo analysis queued",
 		slog.String("photo_id", photoID.String()),
 		slog.String("job_id", job.ID.String()),
@@ -238,7 +250,7 @@ func (s *Server) handleAnalyzePhoto(c echo.Context) error {
 	return RespondOK(c, map[string]interface{}{
 		"job_id":   job.ID.String(),
 		"photo_id": photoID.String(),
-		"status":   "queued",
+		"status":   status,
 	})
 }
 
@@ -261,17 +273,47 @@ func (s *Server) handleGetPhotoAnalysisStatus(c echo.Context) error {
 	}
 
 	return RespondOK(c, map[string]interface{}{
-		"job_id":       job.ID.String(),
-		"status":       string(job.Status),
-		"result":       job.Result,
-		"error":        job.ErrorMessage,
-		"created_at":   job.CreatedAt,
-		"completed_at": job.CompletedAt,
+		"job_id":           job.ID.String(),
+		"status":           string(job.Status),
+		"progress":         job.Progress,
+		"progress_message": job.ProgressMessage,
+		"result":           job.Result,
+		"error":            job.ErrorMessage,
+		"attempts":         job.AttemptCount,
+		"max_attempts":     job.MaxAttempts,
+		"errors":           job.Errors,
+		"worker_id":        job.WorkerID,
+		"created_at":       job.CreatedAt,
+		"completed_at":     job.CompletedAt,
 	})
 }
 
 // Helper functions
 
+// checkStorageQuota returns EFORBIDDEN if storing size more bytes for the
+// inspection would exceed its organization's storage quota.
+func (s *Server) checkStorageQuota(ctx context.Context, inspection *aletheia.Inspection, size int64) error {
+	if s.storageQuota <= 0 {
+		return nil
+	}
+
+	project, err := s.projectService.FindProjectByID(ctx, inspection.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	usage, err := s.organizationService.GetStorageUsage(ctx, project.OrganizationID)
+	if err != nil {
+		return err
+	}
+	usage.QuotaBytes = s.storageQuota
+
+	if usage.ExceedsQuota(size) {
+		return aletheia.Forbidden("Organization storage quota exceeded (%d of %d bytes used)", usage.TotalBytes, usage.QuotaBytes)
+	}
+	return nil
+}
+
 func isAllowedImageType(contentType string) bool {
 	switch contentType {
 	case "image/jpeg", "image/png", "image/webp":
@@ -0,0 +1,71 @@
+package http
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckStorageQuota(t *testing.T) {
+	const quota = 10 * 1024 * 1024
+	orgID := uuid.New()
+	inspection := &aletheia.Inspection{ID: uuid.New(), ProjectID: uuid.New()}
+
+	// The organization stores 4MB and has 4MB of uploads in progress
+	usage := func(ctx context.Context, id uuid.UUID) (*aletheia.StorageUsage, error) {
+		assert.Equal(t, orgID, id)
+		return &aletheia.StorageUsage{OrganizationID: id, PhotoBytes: 4 * 1024 * 1024, UploadBytes: 4 * 1024 * 1024, TotalBytes: 8 * 1024 * 1024}, nil
+	}
+
+	var created int
+	s, _ := newTestServer(t, Config{
+		StorageQuota: quota,
+		InspectionService: &mock.InspectionService{
+			FindInspectionByIDFn: func(ctx context.Context, id uuid.UUID) (*aletheia.Inspection, error) {
+				return inspection, nil
+			},
+		},
+		ProjectService: &mock.ProjectService{
+			FindProjectByIDFn: func(ctx context.Context, id uuid.UUID) (*aletheia.Project, error) {
+				return &aletheia.Project{ID: id, OrganizationID: orgID}, nil
+			},
+		},
+		OrganizationService: &mock.OrganizationService{GetStorageUsageFn: usage},
+		UploadService: &mock.UploadService{
+			CreateUploadFn: func(ctx context.Context, upload *aletheia.Upload) error {
+				created++
+				upload.ID = uuid.New()
+				return nil
+			},
+		},
+	})
+
+	create := func(length int) int {
+		req := httptest.NewRequest(http.MethodPost, "/api/uploads", nil)
+		req.Header.Set(headerTusResumable, tusVersion)
+		req.Header.Set(headerUploadLength, strconv.Itoa(length))
+		req.Header.Set(headerUploadMetadata,
+			"inspection_id "+base64.StdEncoding.EncodeToString([]byte(inspection.ID.String()))+
+				",filetype "+base64.StdEncoding.EncodeToString([]byte("image/jpeg")))
+		return serve(s, req).Code
+	}
+
+	t.Run("UnderQuota", func(t *testing.T) {
+		assert.Equal(t, http.StatusCreated, create(2*1024*1024))
+		assert.Equal(t, 1, created)
+	})
+
+	t.Run("OverQuota", func(t *testing.T) {
+		// Fits beside the stored bytes, but not the uploads in progress
+		assert.Equal(t, http.StatusForbidden, create(3*1024*1024))
+		assert.Equal(t, 1, created)
+	})
+}
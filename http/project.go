@@ -78,6 +78,28 @@ func (s *Server) handleGetProject(c echo.Context) error {
 	return RespondOK(c, project)
 }
 
+// handleGetProjectStats returns a project's inspection, photo and
+// violation counts.
+func (s *Server) handleGetProjectStats(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	projectID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+	if _, err := s.getProjectWithOrgCheck(c, projectID); err != nil {
+		return err
+	}
+
+	stats, err := s.projectService.GetProjectStats(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	return RespondOK(c, stats)
+}
+
 func (s *Server) handleListProjects(c echo.Context) error {
 	ctx, cancel := withTimeout(c)
 	defer cancel()
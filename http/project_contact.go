@@ -0,0 +1,117 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/labstack/echo/v4"
+)
+
+// CreateProjectContactRequest is the request payload for adding a contact to
+// a project.
+type CreateProjectContactRequest struct {
+	Name  string `json:"name" form:"name" validate:"required,max=255"`
+	Email string `json:"email" form:"email" validate:"required,email,max=255"`
+	Role  string `json:"role" form:"role" validate:"max=100"`
+}
+
+func (s *Server) handleListProjectContacts(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	projectID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+	if _, err := s.getProjectWithOrgCheck(c, projectID); err != nil {
+		return err
+	}
+
+	contacts, err := s.projectService.FindProjectContacts(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	return RespondOK(c, map[string]interface{}{
+		"contacts": contacts,
+		"total":    len(contacts),
+	})
+}
+
+func (s *Server) handleCreateProjectContact(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	projectID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+	if _, err := s.getProjectWithOrgCheck(c, projectID); err != nil {
+		return err
+	}
+
+	var req CreateProjectContactRequest
+	if err := bind(c, &req); err != nil {
+		return err
+	}
+
+	contact := &aletheia.ProjectContact{
+		ProjectID: projectID,
+		Name:      strings.TrimSpace(req.Name),
+		Email:     strings.ToLower(strings.TrimSpace(req.Email)),
+		Role:      strings.TrimSpace(req.Role),
+	}
+	if err := s.projectService.CreateProjectContact(ctx, contact); err != nil {
+		return err
+	}
+
+	s.log(c).Info("project contact created",
+		slog.String("project_id", projectID.String()),
+		slog.String("contact_id", contact.ID.String()),
+	)
+
+	return RespondCreated(c, contact)
+}
+
+func (s *Server) handleDeleteProjectContact(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	projectID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+	contactID, err := requireUUIDParam(c, "contactId")
+	if err != nil {
+		return err
+	}
+	if _, err := s.getProjectWithOrgCheck(c, projectID); err != nil {
+		return err
+	}
+
+	// Only contacts of this project can be removed through it
+	contacts, err := s.projectService.FindProjectContacts(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, contact := range contacts {
+		found = found || contact.ID == contactID
+	}
+	if !found {
+		return aletheia.NotFound("Project contact not found")
+	}
+
+	if err := s.projectService.DeleteProjectContact(ctx, contactID); err != nil {
+		return err
+	}
+
+	s.log(c).Info("project contact deleted",
+		slog.String("project_id", projectID.String()),
+		slog.String("contact_id", contactID.String()),
+	)
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,110 @@
+package http
+
+import (
+	"log/slog"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/labstack/echo/v4"
+)
+
+func (s *Server) handleListRateLimits(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	orgID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	// Distinguish an unknown organization from one without rate limits.
+	if _, err := s.organizationService.FindOrganizationByID(ctx, orgID); err != nil {
+		return err
+	}
+
+	limits, err := s.queue.ListRateLimits(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	return RespondOK(c, limits)
+}
+
+// SetRateLimitRequest is the request payload for setting an organization's
+// rate limit tier on a queue. The limits default to the tier's and may be
+// overridden individually.
+type SetRateLimitRequest struct {
+	Tier              string `json:"tier" form:"tier" validate:"required"`
+	MaxJobsPerHour    *int   `json:"max_jobs_per_hour" form:"max_jobs_per_hour" validate:"omitempty,min=1"`
+	MaxConcurrentJobs *int   `json:"max_concurrent_jobs" form:"max_concurrent_jobs" validate:"omitempty,min=1"`
+}
+
+func (s *Server) handleSetRateLimit(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	orgID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	queueName, err := requireParam(c, "queue")
+	if err != nil {
+		return err
+	}
+
+	var req SetRateLimitRequest
+	if err := bind(c, &req); err != nil {
+		return err
+	}
+
+	limit, err := aletheia.NewRateLimit(orgID, queueName, req.Tier)
+	if err != nil {
+		return err
+	}
+	if req.MaxJobsPerHour != nil {
+		limit.MaxJobsPerHour = *req.MaxJobsPerHour
+	}
+	if req.MaxConcurrentJobs != nil {
+		limit.MaxConcurrentJobs = *req.MaxConcurrentJobs
+	}
+
+	if err := s.queue.SetRateLimit(ctx, limit); err != nil {
+		return err
+	}
+
+	s.log(c).Info("rate limit changed by admin",
+		slog.String("organization_id", orgID.String()),
+		slog.String("queue", queueName),
+		slog.String("tier", limit.Tier),
+		slog.Int("max_jobs_per_hour", limit.MaxJobsPerHour),
+		slog.Int("max_concurrent_jobs", limit.MaxConcurrentJobs),
+	)
+
+	return RespondOK(c, limit)
+}
+
+func (s *Server) handleDeleteRateLimit(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	orgID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	queueName, err := requireParam(c, "queue")
+	if err != nil {
+		return err
+	}
+
+	if err := s.queue.DeleteRateLimit(ctx, orgID, queueName); err != nil {
+		return err
+	}
+
+	s.log(c).Info("rate limit removed by admin",
+		slog.String("organization_id", orgID.String()),
+		slog.String("queue", queueName),
+	)
+
+	return RespondNoContent(c)
+}
@@ -0,0 +1,364 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/report"
+	"github.com/labstack/echo/v4"
+)
+
+// GenerateReportRequest is the request payload for generating a report.
+type GenerateReportRequest struct {
+	// Template defaults to the organization's report template.
+	Template string `json:"template" form:"template" validate:"omitempty,oneof=full executive_summary violations_only"`
+}
+
+// handleGenerateReport queues a job that renders an inspection's report.
+// Progress can be followed with the job's event stream.
+func (s *Server) handleGenerateReport(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	userID, err := requireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	inspectionID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req GenerateReportRequest
+	if err := bind(c, &req); err != nil {
+		return err
+	}
+
+	inspection, err := s.inspectionService.FindInspectionByID(ctx, inspectionID)
+	if err != nil {
+		return err
+	}
+	project, err := s.getProjectWithOrgCheck(c, inspection.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	// Refuse to generate a report the pre-flight checks would block
+	preflight, err := report.Check(ctx, s.inspectionService, s.photoService, s.violationService, inspection)
+	if err != nil {
+		return err
+	}
+	if err := preflight.Err(); err != nil {
+		return err
+	}
+
+	if s.queue == nil {
+		return aletheia.Internal("Queue service not available", nil)
+	}
+
+	job, err := report.NewJob(inspection, project.OrganizationID, userID, aletheia.ReportTemplate(req.Template))
+	if err != nil {
+		return aletheia.Internal("Failed to create report job", err)
+	}
+	if err := s.queue.Enqueue(ctx, job); err != nil {
+		s.log(c).Error("failed to enqueue report generation", slog.String("error", err.Error()))
+		return aletheia.Internal("Failed to queue report generation", err)
+	}
+
+	status := "queued"
+	if job.Status != aletheia.JobStatusPending {
+		status = string(job.Status)
+	}
+
+	s.log(c).Info("report generation queued",
+		slog.String("inspection_id", inspectionID.String()),
+		slog.String("job_id", job.ID.String()),
+	)
+
+	return Respond(c, http.StatusAccepted, map[string]interface{}{
+		"job_id":        job.ID.String(),
+		"inspection_id": inspectionID.String(),
+		"status":        status,
+	})
+}
+
+// handleReportPreflight summarizes what a report of an inspection would
+// include, with findings to fix or acknowledge before generating it.
+func (s *Server) handleReportPreflight(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	inspectionID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	inspection, err := s.inspectionService.FindInspectionByID(ctx, inspectionID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.getProjectWithOrgCheck(c, inspection.ProjectID); err != nil {
+		return err
+	}
+
+	preflight, err := report.Check(ctx, s.inspectionService, s.photoService, s.violationService, inspection)
+	if err != nil {
+		return err
+	}
+
+	return RespondOK(c, preflight)
+}
+
+// handleListReports lists every version of an inspection's report, newest
+// first, and whether the newest still matches the inspection.
+func (s *Server) handleListReports(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	inspectionID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	inspection, err := s.inspectionService.FindInspectionByID(ctx, inspectionID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.getProjectWithOrgCheck(c, inspection.ProjectID); err != nil {
+		return err
+	}
+
+	reports, err := s.reportService.FindReports(ctx, inspectionID)
+	if err != nil {
+		return err
+	}
+
+	status, err := report.Status(ctx, s.reportService, s.inspectionService, s.photoService, s.violationService, inspectionID)
+	if err != nil {
+		return err
+	}
+
+	return RespondOK(c, map[string]interface{}{
+		"reports": reports,
+		"total":   len(reports),
+		"status":  status,
+	})
+}
+
+// handleDownloadReport streams a report's PDF from storage. Any version can
+// be downloaded.
+func (s *Server) handleDownloadReport(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	reportID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	rpt, err := s.reportService.FindReportByID(ctx, reportID)
+	if err != nil {
+		return err
+	}
+	inspection, err := s.inspectionService.FindInspectionByID(ctx, rpt.InspectionID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.getProjectWithOrgCheck(c, inspection.ProjectID); err != nil {
+		return err
+	}
+
+	key, ok := aletheia.StorageKey(s.fileStorage, rpt.StorageURL)
+	if !ok {
+		return aletheia.Internal("Report is not in storage", nil)
+	}
+	file, err := s.fileStorage.Open(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", report.Filename(rpt)))
+	c.Response().Header().Set(echo.HeaderContentLength, fmt.Sprint(rpt.SizeBytes))
+	c.Response().Header().Set(echo.HeaderContentType, "application/pdf")
+	c.Response().WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(c.Response(), file); err != nil {
+		s.log(c).Error("failed to stream report",
+			slog.String("report_id", reportID.String()),
+			slog.String("error", err.Error()),
+		)
+	}
+	return nil
+}
+
+// SendReportRequest is the request payload for emailing a report.
+type SendReportRequest struct {
+	ContactIDs []string `json:"contact_ids" form:"contact_ids" validate:"max=50,dive,uuid"`
+	Emails     []string `json:"emails" form:"emails" validate:"max=50,dive,email,max=255"`
+	Message    string   `json:"message" form:"message" validate:"max=2000"`
+
+	// Attach sends the PDF as an attachment rather than a link. It defaults
+	// to true; reports too large to attach are always linked.
+	Attach *bool `json:"attach" form:"attach"`
+}
+
+// handleSendReport emails a report to project contacts and ad-hoc
+// addresses. Each recipient gets a delivery, sent by its own job.
+func (s *Server) handleSendReport(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	userID, err := requireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	reportID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req SendReportRequest
+	if err := bind(c, &req); err != nil {
+		return err
+	}
+
+	rpt, err := s.reportService.FindReportByID(ctx, reportID)
+	if err != nil {
+		return err
+	}
+	inspection, err := s.inspectionService.FindInspectionByID(ctx, rpt.InspectionID)
+	if err != nil {
+		return err
+	}
+	project, err := s.getProjectWithOrgCheck(c, inspection.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	recipients, err := s.reportRecipients(c, project, req)
+	if err != nil {
+		return err
+	}
+
+	if s.queue == nil {
+		return aletheia.Internal("Queue service not available", nil)
+	}
+
+	attach := (req.Attach == nil || *req.Attach) && rpt.SizeBytes <= aletheia.MaxAttachmentSize
+
+	deliveries := make([]*aletheia.ReportDelivery, 0, len(recipients))
+	for _, recipient := range recipients {
+		delivery := &aletheia.ReportDelivery{
+			ReportID:  rpt.ID,
+			Recipient: recipient.Email,
+			ContactID: recipient.ID,
+			Attached:  attach,
+			Message:   strings.TrimSpace(req.Message),
+			SentBy:    userID,
+		}
+		if err := s.reportService.CreateReportDelivery(ctx, delivery); err != nil {
+			return err
+		}
+
+		job, err := report.NewDeliveryJob(delivery, inspection, project.OrganizationID)
+		if err != nil {
+			return aletheia.Internal("Failed to create delivery job", err)
+		}
+		if err := s.queue.Enqueue(ctx, job); err != nil {
+			s.log(c).Error("failed to enqueue report delivery", slog.String("error", err.Error()))
+			return aletheia.Internal("Failed to queue report delivery", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	s.log(c).Info("report delivery queued",
+		slog.String("report_id", rpt.ID.String()),
+		slog.Int("recipients", len(deliveries)),
+	)
+
+	return Respond(c, http.StatusAccepted, map[string]interface{}{
+		"deliveries": deliveries,
+		"total":      len(deliveries),
+	})
+}
+
+// reportRecipients resolves the contacts and addresses of a request into
+// one recipient per address. Ad-hoc addresses have no contact ID.
+func (s *Server) reportRecipients(c echo.Context, project *aletheia.Project, req SendReportRequest) ([]*aletheia.ProjectContact, error) {
+	var recipients []*aletheia.ProjectContact
+	seen := map[string]bool{}
+	add := func(contact *aletheia.ProjectContact) {
+		email := strings.ToLower(strings.TrimSpace(contact.Email))
+		if seen[email] {
+			return
+		}
+		seen[email] = true
+		contact.Email = email
+		recipients = append(recipients, contact)
+	}
+
+	if len(req.ContactIDs) > 0 {
+		contacts, err := s.projectService.FindProjectContacts(c.Request().Context(), project.ID)
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[string]*aletheia.ProjectContact, len(contacts))
+		for _, contact := range contacts {
+			byID[contact.ID.String()] = contact
+		}
+		for _, id := range req.ContactIDs {
+			contact, ok := byID[strings.ToLower(id)]
+			if !ok {
+				return nil, aletheia.Invalid("Contact %s is not a contact of this project", id)
+			}
+			add(contact)
+		}
+	}
+	for _, email := range req.Emails {
+		add(&aletheia.ProjectContact{Email: email})
+	}
+
+	if len(recipients) == 0 {
+		return nil, aletheia.Invalid("At least one recipient is required")
+	}
+	return recipients, nil
+}
+
+// handleListReportDeliveries returns a report's delivery log.
+func (s *Server) handleListReportDeliveries(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	reportID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	rpt, err := s.reportService.FindReportByID(ctx, reportID)
+	if err != nil {
+		return err
+	}
+	inspection, err := s.inspectionService.FindInspectionByID(ctx, rpt.InspectionID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.getProjectWithOrgCheck(c, inspection.ProjectID); err != nil {
+		return err
+	}
+
+	deliveries, err := s.reportService.FindReportDeliveries(ctx, reportID)
+	if err != nil {
+		return err
+	}
+
+	return RespondOK(c, map[string]interface{}{
+		"deliveries": deliveries,
+		"total":      len(deliveries),
+	})
+}
@@ -18,6 +18,21 @@ func (s *Server) registerRoutes() {
 	auth.POST("/verify-reset-token", s.handleVerifyResetToken)
 	auth.POST("/reset-password", s.handleResetPassword)
 
+	// Email provider webhooks (authenticated by token)
+	s.echo.POST("/webhooks/email/bounce", s.handleEmailBounce)
+
+	// Shared inspections (authenticated by the link's token and password).
+	// Rate limited per client to slow down password guessing.
+	share := s.echo.Group("/api/share", publicRateLimit())
+	share.GET("/:token", s.handleViewShareLink)
+	share.GET("/:token/report", s.handleDownloadSharedReport)
+
+	// Report verification (public, rate limited per client)
+	verify := s.echo.Group("/api/verify", publicRateLimit())
+	verify.POST("/reports", s.handleVerifyReportFile)
+	verify.GET("/reports/:sha256", s.handleVerifyReportHash)
+	verify.GET("/signing-key", s.handleGetSigningKey)
+
 	// Protected routes (require authentication)
 	protected := s.echo.Group("/api")
 	protected.Use(s.RequireAuth())
@@ -40,17 +55,43 @@ func (s *Server) registerRoutes() {
 	protected.PUT("/organizations/:id/members/:memberId", s.handleUpdateOrganizationMember)
 	protected.DELETE("/organizations/:id/members/:memberId", s.handleRemoveOrganizationMember)
 
+	// Organization storage
+	protected.GET("/organizations/:id/storage", s.handleGetOrganizationStorageUsage)
+
+	// Organization branding
+	protected.PUT("/organizations/:id/branding", s.handleUpdateOrganizationBranding)
+	protected.POST("/organizations/:id/logo", s.handleUploadOrganizationLogo)
+	protected.DELETE("/organizations/:id/logo", s.handleDeleteOrganizationLogo)
+
+	// Organization exports
+	protected.GET("/organizations/:id/violations/export", s.handleExportViolations)
+
 	// Projects
 	protected.POST("/projects", s.handleCreateProject)
 	protected.GET("/projects/:id", s.handleGetProject)
+	protected.GET("/projects/:id/stats", s.handleGetProjectStats)
 	protected.GET("/organizations/:orgId/projects", s.handleListProjects)
 	protected.PUT("/projects/:id", s.handleUpdateProject)
 	protected.DELETE("/projects/:id", s.handleDeleteProject)
 
+	// Project summaries
+	protected.GET("/projects/:id/summary", s.handleGetProjectSummary)
+	protected.POST("/projects/:id/summary-reports", s.handleGenerateSummaryReport)
+	protected.GET("/projects/:id/summary-reports", s.handleListSummaryReports)
+	protected.GET("/summary-reports/:id", s.handleGetSummaryReport)
+	protected.GET("/summary-reports/:id/download", s.handleDownloadSummaryReport)
+
+	// Project contacts
+	protected.GET("/projects/:id/contacts", s.handleListProjectContacts)
+	protected.POST("/projects/:id/contacts", s.handleCreateProjectContact)
+	protected.DELETE("/projects/:id/contacts/:contactId", s.handleDeleteProjectContact)
+
 	// Inspections
 	protected.POST("/inspections", s.handleCreateInspection)
 	protected.GET("/inspections/:id", s.handleGetInspection)
+	protected.GET("/inspections/:id/stats", s.handleGetInspectionStats)
 	protected.GET("/projects/:projectId/inspections", s.handleListInspections)
+	protected.PUT("/inspections/:id", s.handleUpdateInspection)
 	protected.PUT("/inspections/:id/status", s.handleUpdateInspectionStatus)
 
 	// Photos
@@ -61,6 +102,38 @@ func (s *Server) registerRoutes() {
 	protected.POST("/photos/analyze", s.handleAnalyzePhoto)
 	protected.GET("/photos/analyze/:jobId", s.handleGetPhotoAnalysisStatus)
 
+	// Job progress (Server-Sent Events)
+	protected.GET("/jobs/:id/events", s.handleJobEvents)
+	protected.GET("/jobs/:id/batch/events", s.handleBatchEvents)
+	protected.GET("/inspections/:id/jobs/events", s.handleInspectionJobEvents)
+
+	// Resumable uploads (tus protocol)
+	protected.OPTIONS("/uploads", s.handleUploadOptions)
+	protected.POST("/uploads", s.handleCreateUpload)
+	protected.HEAD("/uploads/:id", s.handleHeadUpload)
+	protected.PATCH("/uploads/:id", s.handlePatchUpload)
+	protected.GET("/uploads/:id", s.handleGetUpload)
+	protected.DELETE("/uploads/:id", s.handleDeleteUpload)
+
+	// Reports
+	protected.GET("/inspections/:id/reports/preflight", s.handleReportPreflight)
+	protected.POST("/inspections/:id/reports", s.handleGenerateReport)
+	protected.GET("/inspections/:id/reports", s.handleListReports)
+	protected.GET("/reports/:id/download", s.handleDownloadReport)
+	protected.POST("/reports/:id/deliveries", s.handleSendReport)
+	protected.GET("/reports/:id/deliveries", s.handleListReportDeliveries)
+
+	// Sign-offs
+	protected.GET("/inspections/:id/signoffs", s.handleListSignoffs)
+	protected.POST("/inspections/:id/signoffs", s.handleCreateSignoff)
+	protected.DELETE("/inspections/:id/signoffs/:role", s.handleDeleteSignoff)
+
+	// Share links
+	protected.POST("/inspections/:id/share-links", s.handleCreateShareLink)
+	protected.GET("/inspections/:id/share-links", s.handleListShareLinks)
+	protected.DELETE("/share-links/:id", s.handleRevokeShareLink)
+	protected.GET("/share-links/:id/views", s.handleListShareLinkViews)
+
 	// Safety codes
 	protected.POST("/safety-codes", s.handleCreateSafetyCode)
 	protected.GET("/safety-codes", s.handleListSafetyCodes)
@@ -75,4 +148,17 @@ func (s *Server) registerRoutes() {
 	protected.POST("/violations/:id/dismiss", s.handleDismissViolation)
 	protected.POST("/violations/:id/pending", s.handleSetViolationPending)
 	protected.PATCH("/violations/:id", s.handleUpdateViolation)
+
+	// Admin: job console and rate limits (require administrator)
+	admin := protected.Group("/admin", s.RequireAdmin())
+	admin.GET("/jobs", s.handleListJobs)
+	admin.GET("/jobs/:id", s.handleGetJob)
+	admin.POST("/jobs/:id/retry", s.handleRetryJob)
+	admin.POST("/jobs/:id/cancel", s.handleCancelJob)
+	admin.POST("/jobs/retry", s.handleRetryJobs)
+	admin.POST("/jobs/cancel", s.handleCancelJobs)
+	admin.POST("/jobs/dead/replay", s.handleReplayDeadJobs)
+	admin.GET("/organizations/:id/rate-limits", s.handleListRateLimits)
+	admin.PUT("/organizations/:id/rate-limits/:queue", s.handleSetRateLimit)
+	admin.DELETE("/organizations/:id/rate-limits/:queue", s.handleDeleteRateLimit)
 }
@@ -4,9 +4,11 @@ import (
 	"context"
 	"log/slog"
 	"net"
+	"strings"
 	"time"
 
 	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/signing"
 	"github.com/labstack/echo/v4"
 )
 
@@ -24,6 +26,15 @@ type Server struct {
 	SessionDuration time.Duration
 	SessionSecure   bool
 
+	// Storage quota per organization in bytes (0 means unlimited)
+	storageQuota int64
+
+	// Lowercased emails of users allowed to use the admin API
+	adminEmails map[string]bool
+
+	// Password the email provider's webhooks authenticate with
+	emailWebhookToken string
+
 	// Domain services
 	userService         aletheia.UserService
 	organizationService aletheia.OrganizationService
@@ -33,12 +44,22 @@ type Server struct {
 	violationService    aletheia.ViolationService
 	safetyCodeService   aletheia.SafetyCodeService
 	sessionService      aletheia.SessionService
+	uploadService       aletheia.UploadService
+	reportService       aletheia.ReportService
+	shareLinkService    aletheia.ShareLinkService
 
 	// External services
 	fileStorage  aletheia.FileStorage
 	emailService aletheia.EmailService
 	aiService    aletheia.AIService
 	queue        aletheia.Queue
+
+	// Shared job event subscription for job event streams (nil if the
+	// queue cannot push job events)
+	jobEvents *jobEventHub
+
+	// Report signer (nil if reports are not signed)
+	signer *signing.Signer
 }
 
 // Config holds the configuration for creating a new Server.
@@ -54,6 +75,16 @@ type Config struct {
 	// Template renderer
 	Renderer echo.Renderer
 
+	// Storage quota per organization in bytes (0 means unlimited)
+	StorageQuota int64
+
+	// Emails of users allowed to use the admin API
+	AdminEmails []string
+
+	// Password the email provider's webhooks authenticate with (empty
+	// disables them)
+	EmailWebhookToken string
+
 	// Domain services
 	UserService         aletheia.UserService
 	OrganizationService aletheia.OrganizationService
@@ -63,12 +94,18 @@ type Config struct {
 	ViolationService    aletheia.ViolationService
 	SafetyCodeService   aletheia.SafetyCodeService
 	SessionService      aletheia.SessionService
+	UploadService       aletheia.UploadService
+	ReportService       aletheia.ReportService
+	ShareLinkService    aletheia.ShareLinkService
 
 	// External services
 	FileStorage  aletheia.FileStorage
 	EmailService aletheia.EmailService
 	AIService    aletheia.AIService
 	Queue        aletheia.Queue
+
+	// Report signer (nil if reports are not signed)
+	Signer *signing.Signer
 }
 
 // NewServer creates a new HTTP server with the given configuration.
@@ -79,6 +116,9 @@ func NewServer(cfg Config) *Server {
 		logger:              cfg.Logger,
 		SessionDuration:     cfg.SessionDuration,
 		SessionSecure:       cfg.SessionSecure,
+		storageQuota:        cfg.StorageQuota,
+		adminEmails:         make(map[string]bool),
+		emailWebhookToken:   cfg.EmailWebhookToken,
 		userService:         cfg.UserService,
 		organizationService: cfg.OrganizationService,
 		projectService:      cfg.ProjectService,
@@ -87,10 +127,19 @@ func NewServer(cfg Config) *Server {
 		violationService:    cfg.ViolationService,
 		safetyCodeService:   cfg.SafetyCodeService,
 		sessionService:      cfg.SessionService,
+		uploadService:       cfg.UploadService,
+		reportService:       cfg.ReportService,
+		shareLinkService:    cfg.ShareLinkService,
 		fileStorage:         cfg.FileStorage,
 		emailService:        cfg.EmailService,
 		aiService:           cfg.AIService,
 		queue:               cfg.Queue,
+		jobEvents:           newJobEventHub(cfg.Queue, cfg.Logger),
+		signer:              cfg.Signer,
+	}
+
+	for _, email := range cfg.AdminEmails {
+		s.adminEmails[strings.ToLower(strings.TrimSpace(email))] = true
 	}
 
 	// Set default session duration if not specified
@@ -140,6 +189,8 @@ func (s *Server) Open() error {
 
 // Close gracefully shuts down the HTTP server.
 func (s *Server) Close(ctx context.Context) error {
+	defer s.jobEvents.close()
+
 	if err := s.echo.Shutdown(ctx); err != nil {
 		return err
 	}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/validation"
+	"github.com/dukerupert/aletheia/mock"
+	"github.com/google/uuid"
+)
+
+// testSessionToken is the session cookie that authenticates the user of a
+// test server.
+const testSessionToken = "test-session"
+
+// newTestServer creates a server from cfg whose test session authenticates
+// the returned user. Services left nil in cfg are empty mocks.
+func newTestServer(t *testing.T, cfg Config) (*Server, *aletheia.User) {
+	t.Helper()
+
+	user := &aletheia.User{ID: uuid.New(), Email: "inspector@example.com"}
+	cfg.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg.SessionService = &mock.SessionService{
+		FindSessionByTokenWithUserFn: func(ctx context.Context, token string) (*aletheia.Session, error) {
+			if token != testSessionToken {
+				return nil, aletheia.Unauthorized("Invalid session")
+			}
+			return &aletheia.Session{UserID: user.ID, Token: token, User: user}, nil
+		},
+	}
+	if cfg.InspectionService == nil {
+		cfg.InspectionService = &mock.InspectionService{}
+	}
+	if cfg.ProjectService == nil {
+		cfg.ProjectService = &mock.ProjectService{}
+	}
+	if cfg.OrganizationService == nil {
+		cfg.OrganizationService = &mock.OrganizationService{}
+	}
+	if cfg.PhotoService == nil {
+		cfg.PhotoService = &mock.PhotoService{}
+	}
+	if cfg.UploadService == nil {
+		cfg.UploadService = &mock.UploadService{}
+	}
+
+	s := NewServer(cfg)
+	s.Echo().Validator = validation.NewValidator()
+	return s, user
+}
+
+// serve sends an authenticated request to s.
+func serve(s *Server, req *http.Request) *httptest.ResponseRecorder {
+	req.AddCookie(&http.Cookie{Name: SessionCookieName, Value: testSessionToken})
+	rec := httptest.NewRecorder()
+	s.Echo().ServeHTTP(rec, req)
+	return rec
+}
@@ -0,0 +1,491 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/report"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// sharePasswordHeader is the request header viewers give a share link's
+// password in.
+const sharePasswordHeader = "X-Share-Password"
+
+// CreateShareLinkRequest is the request payload for sharing an inspection.
+type CreateShareLinkRequest struct {
+	Kind string `json:"kind" form:"kind" validate:"required,oneof=report inspection"`
+
+	// ReportID is the report version a report link shares. It defaults to
+	// the latest version.
+	ReportID string `json:"report_id" form:"report_id" validate:"omitempty,uuid"`
+
+	// ExpiresInDays defaults to 14 days.
+	ExpiresInDays int    `json:"expires_in_days" form:"expires_in_days" validate:"omitempty,min=1,max=90"`
+	Password      string `json:"password" form:"password" validate:"omitempty,min=6,max=72"`
+}
+
+// handleCreateShareLink creates a link to an inspection's report or summary
+// for people without an account. The token is only returned here.
+func (s *Server) handleCreateShareLink(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	userID, err := requireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	inspectionID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req CreateShareLinkRequest
+	if err := bind(c, &req); err != nil {
+		return err
+	}
+
+	inspection, err := s.inspectionService.FindInspectionByID(ctx, inspectionID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.getProjectWithOrgCheck(c, inspection.ProjectID); err != nil {
+		return err
+	}
+
+	duration := aletheia.DefaultShareLinkDuration
+	if req.ExpiresInDays > 0 {
+		duration = time.Duration(req.ExpiresInDays) * 24 * time.Hour
+	}
+
+	link := &aletheia.ShareLink{
+		InspectionID: inspectionID,
+		Kind:         aletheia.ShareLinkKind(req.Kind),
+		ExpiresAt:    time.Now().Add(duration),
+		CreatedBy:    userID,
+	}
+
+	if link.Kind == aletheia.ShareLinkReport {
+		rpt, err := s.sharedReport(c, inspectionID, req.ReportID)
+		if err != nil {
+			return err
+		}
+		link.ReportID = rpt.ID
+	} else if req.ReportID != "" {
+		return aletheia.Invalid("Only report links can name a report")
+	}
+
+	if err := s.shareLinkService.CreateShareLink(ctx, link, req.Password); err != nil {
+		return err
+	}
+
+	s.log(c).Info("share link created",
+		slog.String("share_link_id", link.ID.String()),
+		slog.String("inspection_id", inspectionID.String()),
+		slog.String("kind", string(link.Kind)),
+	)
+
+	return RespondCreated(c, map[string]interface{}{
+		"share_link": link,
+		"url":        shareURL(c, link.Token),
+	})
+}
+
+// sharedReport returns the report version a report link shares: the one
+// named, or else the latest.
+func (s *Server) sharedReport(c echo.Context, inspectionID uuid.UUID, reportID string) (*aletheia.Report, error) {
+	ctx := c.Request().Context()
+
+	if reportID == "" {
+		reports, err := s.reportService.FindReports(ctx, inspectionID)
+		if err != nil {
+			return nil, err
+		}
+		if len(reports) == 0 {
+			return nil, aletheia.Invalid("Inspection has no report to share")
+		}
+		return reports[0], nil
+	}
+
+	id, err := parseUUID(reportID)
+	if err != nil {
+		return nil, err
+	}
+	rpt, err := s.reportService.FindReportByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rpt.InspectionID != inspectionID {
+		return nil, aletheia.NotFound("Report not found")
+	}
+	return rpt, nil
+}
+
+// shareURL returns the public URL of a share link.
+func shareURL(c echo.Context, token string) string {
+	return fmt.Sprintf("%s://%s/api/share/%s", c.Scheme(), c.Request().Host, token)
+}
+
+// handleListShareLinks lists an inspection's share links, newest first.
+func (s *Server) handleListShareLinks(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	inspectionID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	inspection, err := s.inspectionService.FindInspectionByID(ctx, inspectionID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.getProjectWithOrgCheck(c, inspection.ProjectID); err != nil {
+		return err
+	}
+
+	links, err := s.shareLinkService.FindShareLinks(ctx, inspectionID)
+	if err != nil {
+		return err
+	}
+
+	return RespondOK(c, map[string]interface{}{
+		"share_links": links,
+		"total":       len(links),
+	})
+}
+
+// handleRevokeShareLink stops a share link from being viewed.
+func (s *Server) handleRevokeShareLink(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	link, err := s.getShareLinkWithOrgCheck(c)
+	if err != nil {
+		return err
+	}
+
+	link, err = s.shareLinkService.RevokeShareLink(ctx, link.ID)
+	if err != nil {
+		return err
+	}
+
+	s.log(c).Info("share link revoked", slog.String("share_link_id", link.ID.String()))
+
+	return RespondOK(c, link)
+}
+
+// maxShareLinkViews is the most views listed for a share link.
+const maxShareLinkViews = 100
+
+// handleListShareLinkViews lists the most recent views of a share link.
+func (s *Server) handleListShareLinkViews(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	link, err := s.getShareLinkWithOrgCheck(c)
+	if err != nil {
+		return err
+	}
+
+	views, err := s.shareLinkService.FindShareLinkViews(ctx, link.ID, maxShareLinkViews)
+	if err != nil {
+		return err
+	}
+
+	return RespondOK(c, map[string]interface{}{
+		"views": views,
+		"total": link.ViewCount,
+	})
+}
+
+// getShareLinkWithOrgCheck returns the share link named by the "id" route
+// parameter if the user is a member of its organization.
+func (s *Server) getShareLinkWithOrgCheck(c echo.Context) (*aletheia.ShareLink, error) {
+	ctx := c.Request().Context()
+
+	linkID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := s.shareLinkService.FindShareLinkByID(ctx, linkID)
+	if err != nil {
+		return nil, err
+	}
+	inspection, err := s.inspectionService.FindInspectionByID(ctx, link.InspectionID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.getProjectWithOrgCheck(c, inspection.ProjectID); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// Public share handlers
+
+// SharedInspection is what a share link shows. Inspection links list the
+// confirmed violations; report links describe the shared report.
+type SharedInspection struct {
+	Kind      aletheia.ShareLinkKind `json:"kind"`
+	ExpiresAt time.Time              `json:"expiresAt"`
+
+	Organization string `json:"organization"`
+	LogoURL      string `json:"logoUrl,omitempty"`
+	PrimaryColor string `json:"primaryColor,omitempty"`
+
+	Project        string                    `json:"project"`
+	ProjectAddress string                    `json:"projectAddress,omitempty"`
+	Inspection     string                    `json:"inspection,omitempty"`
+	InspectionDate time.Time                 `json:"inspectionDate"`
+	Status         aletheia.InspectionStatus `json:"status"`
+
+	Violations []*SharedViolation        `json:"violations,omitempty"`
+	BySeverity map[aletheia.Severity]int `json:"bySeverity,omitempty"`
+	Report     *SharedReport             `json:"report,omitempty"`
+}
+
+// SharedViolation is a confirmed violation shown through a share link.
+type SharedViolation struct {
+	Description           string            `json:"description"`
+	Severity              aletheia.Severity `json:"severity"`
+	Location              string            `json:"location,omitempty"`
+	SafetyCode            string            `json:"safetyCode,omitempty"`
+	SafetyCodeDescription string            `json:"safetyCodeDescription,omitempty"`
+	PhotoURL              string            `json:"photoUrl,omitempty"`
+	ThumbnailURL          string            `json:"thumbnailUrl,omitempty"`
+}
+
+// SharedReport is a report shown through a share link.
+type SharedReport struct {
+	Version     int                     `json:"version"`
+	Template    aletheia.ReportTemplate `json:"template"`
+	CreatedAt   time.Time               `json:"createdAt"`
+	SizeBytes   int64                   `json:"sizeBytes"`
+	SHA256      string                  `json:"sha256,omitempty"` // For verifying the downloaded file
+	DownloadURL string                  `json:"downloadUrl"`
+}
+
+// handleViewShareLink shows what a share link shares. Nothing beyond the
+// shared inspection and its confirmed violations is exposed.
+func (s *Server) handleViewShareLink(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	link, err := s.openShareLink(c)
+	if err != nil {
+		return err
+	}
+
+	inspection, err := s.inspectionService.FindInspectionByID(ctx, link.InspectionID)
+	if err != nil {
+		return err
+	}
+	project, err := s.projectService.FindProjectByID(ctx, inspection.ProjectID)
+	if err != nil {
+		return err
+	}
+	organization, err := s.organizationService.FindOrganizationByID(ctx, project.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	shared := &SharedInspection{
+		Kind:           link.Kind,
+		ExpiresAt:      link.ExpiresAt,
+		Organization:   organization.Name,
+		LogoURL:        organization.Branding.LogoURL,
+		PrimaryColor:   organization.Branding.PrimaryColor,
+		Project:        project.Name,
+		ProjectAddress: projectAddress(project),
+		Inspection:     inspection.Title,
+		InspectionDate: inspection.Date(),
+		Status:         inspection.Status,
+	}
+
+	switch link.Kind {
+	case aletheia.ShareLinkReport:
+		rpt, err := s.reportService.FindReportByID(ctx, link.ReportID)
+		if err != nil {
+			return err
+		}
+		shared.Report = &SharedReport{
+			Version:     rpt.Version,
+			Template:    rpt.Template,
+			CreatedAt:   rpt.CreatedAt,
+			SizeBytes:   rpt.SizeBytes,
+			SHA256:      rpt.SHA256,
+			DownloadURL: shareURL(c, c.Param("token")) + "/report",
+		}
+	case aletheia.ShareLinkInspection:
+		if shared.Violations, err = s.sharedViolations(c, inspection.ID); err != nil {
+			return err
+		}
+		shared.BySeverity = map[aletheia.Severity]int{}
+		for _, v := range shared.Violations {
+			shared.BySeverity[v.Severity]++
+		}
+	}
+
+	s.recordShareLinkView(c, link)
+
+	return RespondOK(c, shared)
+}
+
+// sharedViolations returns an inspection's confirmed violations, most
+// severe first, with their photos and safety codes.
+func (s *Server) sharedViolations(c echo.Context, inspectionID uuid.UUID) ([]*SharedViolation, error) {
+	ctx := c.Request().Context()
+
+	confirmed := aletheia.ViolationStatusConfirmed
+	violations, _, err := s.violationService.FindViolations(ctx, aletheia.ViolationFilter{
+		InspectionID: &inspectionID,
+		Status:       &confirmed,
+	})
+	if err != nil {
+		return nil, err
+	}
+	report.SortViolations(violations)
+
+	photos := map[uuid.UUID]*aletheia.Photo{}
+	codes := map[uuid.UUID]*aletheia.SafetyCode{}
+	shared := make([]*SharedViolation, len(violations))
+	for i, v := range violations {
+		sv := &SharedViolation{
+			Description: v.Description,
+			Severity:    v.Severity,
+			Location:    v.Location,
+		}
+
+		photo, ok := photos[v.PhotoID]
+		if !ok {
+			photo, err = s.photoService.FindPhotoByID(ctx, v.PhotoID)
+			if err != nil && !aletheia.IsErrorCode(err, aletheia.ENOTFOUND) {
+				return nil, err
+			}
+			photos[v.PhotoID] = photo
+		}
+		if photo != nil {
+			sv.PhotoURL, sv.ThumbnailURL = photo.StorageURL, photo.ThumbnailURL
+		}
+
+		if v.SafetyCodeID != uuid.Nil {
+			code, ok := codes[v.SafetyCodeID]
+			if !ok {
+				code, err = s.safetyCodeService.FindSafetyCodeByID(ctx, v.SafetyCodeID)
+				if err != nil && !aletheia.IsErrorCode(err, aletheia.ENOTFOUND) {
+					return nil, err
+				}
+				codes[v.SafetyCodeID] = code
+			}
+			if code != nil {
+				sv.SafetyCode, sv.SafetyCodeDescription = code.Code, code.Description
+			}
+		}
+
+		shared[i] = sv
+	}
+	return shared, nil
+}
+
+// handleDownloadSharedReport streams the PDF of the report a report link
+// shares.
+func (s *Server) handleDownloadSharedReport(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	link, err := s.openShareLink(c)
+	if err != nil {
+		return err
+	}
+	if link.Kind != aletheia.ShareLinkReport {
+		return aletheia.NotFound("This link does not share a report")
+	}
+
+	rpt, err := s.reportService.FindReportByID(ctx, link.ReportID)
+	if err != nil {
+		return err
+	}
+
+	key, ok := aletheia.StorageKey(s.fileStorage, rpt.StorageURL)
+	if !ok {
+		return aletheia.Internal("Report is not in storage", nil)
+	}
+	file, err := s.fileStorage.Open(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	s.recordShareLinkView(c, link)
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", report.Filename(rpt)))
+	c.Response().Header().Set(echo.HeaderContentLength, fmt.Sprint(rpt.SizeBytes))
+	c.Response().Header().Set(echo.HeaderContentType, "application/pdf")
+	c.Response().WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(c.Response(), file); err != nil {
+		s.log(c).Error("failed to stream shared report",
+			slog.String("share_link_id", link.ID.String()),
+			slog.String("error", err.Error()),
+		)
+	}
+	return nil
+}
+
+// openShareLink returns the share link named by the "token" route parameter
+// if it is active and the request gives its password, if any.
+func (s *Server) openShareLink(c echo.Context) (*aletheia.ShareLink, error) {
+	ctx := c.Request().Context()
+
+	token, err := requireParam(c, "token")
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := s.shareLinkService.FindShareLinkByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := link.Active(); err != nil {
+		return nil, err
+	}
+	if err := s.shareLinkService.VerifyShareLinkPassword(ctx, link, c.Request().Header.Get(sharePasswordHeader)); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// recordShareLinkView logs a view of a share link. Failing to log a view
+// does not stop it.
+func (s *Server) recordShareLinkView(c echo.Context, link *aletheia.ShareLink) {
+	view := &aletheia.ShareLinkView{
+		ShareLinkID: link.ID,
+		IPAddress:   c.RealIP(),
+		UserAgent:   c.Request().UserAgent(),
+	}
+	if err := s.shareLinkService.RecordShareLinkView(c.Request().Context(), view); err != nil {
+		s.log(c).Error("failed to record share link view",
+			slog.String("share_link_id", link.ID.String()),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// projectAddress formats a project's address on one line.
+func projectAddress(p *aletheia.Project) string {
+	var parts []string
+	for _, part := range []string{p.Address, p.City, p.State, p.ZipCode} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,290 @@
+package http
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image/png"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/report"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// maxSignatureSize is the largest drawn signature accepted.
+const maxSignatureSize = 256 * 1024 // 256KB
+
+// signatureDataURLPrefix starts a drawn signature in a sign-off request.
+const signatureDataURLPrefix = "data:image/png;base64,"
+
+// CreateSignoffRequest is the request payload for signing off on an inspection.
+type CreateSignoffRequest struct {
+	Role string `json:"role" form:"role" validate:"required,oneof=inspector reviewer"`
+
+	// SignerName defaults to the user's full name.
+	SignerName    string `json:"signer_name" form:"signer_name" validate:"omitempty,max=200"`
+	SignatureKind string `json:"signature_kind" form:"signature_kind" validate:"required,oneof=drawn typed"`
+
+	// Signature is the typed name, or the drawn signature as a PNG data URL.
+	Signature string `json:"signature" form:"signature" validate:"required"`
+}
+
+// SignoffResponse is a sign-off and whether it still approves the
+// inspection as it is now. Reports leave out sign-offs that do not.
+type SignoffResponse struct {
+	*aletheia.Signoff
+	Current bool `json:"current"`
+}
+
+// handleListSignoffs lists an inspection's sign-offs.
+func (s *Server) handleListSignoffs(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	inspectionID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	inspection, err := s.inspectionService.FindInspectionByID(ctx, inspectionID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.getProjectWithOrgCheck(c, inspection.ProjectID); err != nil {
+		return err
+	}
+
+	signoffs, err := s.inspectionService.FindSignoffs(ctx, inspectionID)
+	if err != nil {
+		return err
+	}
+	content, err := report.Content(ctx, s.photoService, s.violationService, inspectionID)
+	if err != nil {
+		return err
+	}
+
+	result := make([]SignoffResponse, len(signoffs))
+	for i, signoff := range signoffs {
+		result[i] = SignoffResponse{Signoff: signoff, Current: signoff.Current(content)}
+	}
+
+	return RespondOK(c, map[string]interface{}{
+		"signoffs": result,
+		"total":    len(result),
+	})
+}
+
+// handleCreateSignoff signs off on a completed inspection's photos and
+// confirmed violations as they are now. The inspector signs as inspector;
+// an owner or admin other than the inspector can then sign as reviewer.
+func (s *Server) handleCreateSignoff(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	user, err := requireUser(c)
+	if err != nil {
+		return err
+	}
+
+	inspectionID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req CreateSignoffRequest
+	if err := bind(c, &req); err != nil {
+		return err
+	}
+
+	inspection, err := s.inspectionService.FindInspectionByID(ctx, inspectionID)
+	if err != nil {
+		return err
+	}
+	project, err := s.getProjectWithOrgCheck(c, inspection.ProjectID)
+	if err != nil {
+		return err
+	}
+	if inspection.Status != aletheia.InspectionStatusCompleted {
+		return aletheia.Invalid("Only completed inspections can be signed off")
+	}
+
+	// Signers approve what the report would show, so it must be ready
+	preflight, err := report.Check(ctx, s.inspectionService, s.photoService, s.violationService, inspection)
+	if err != nil {
+		return err
+	}
+	if err := preflight.Err(); err != nil {
+		return err
+	}
+
+	content, err := report.Content(ctx, s.photoService, s.violationService, inspectionID)
+	if err != nil {
+		return err
+	}
+
+	role := aletheia.SignoffRole(req.Role)
+	switch role {
+	case aletheia.SignoffInspector:
+		if user.ID != inspection.InspectorID {
+			return aletheia.Forbidden("Only the inspection's inspector can sign off as inspector")
+		}
+	case aletheia.SignoffReviewer:
+		if user.ID == inspection.InspectorID {
+			return aletheia.Forbidden("Inspectors cannot review their own inspections")
+		}
+		if _, err := s.organizationService.RequireMembership(ctx, project.OrganizationID, user.ID, aletheia.RoleOwner, aletheia.RoleAdmin); err != nil {
+			return err
+		}
+		if err := s.requireInspectorSignoff(c, inspectionID, content); err != nil {
+			return err
+		}
+	}
+
+	signoff := &aletheia.Signoff{
+		InspectionID:  inspectionID,
+		Role:          role,
+		SignerID:      user.ID,
+		SignerName:    strings.TrimSpace(req.SignerName),
+		SignatureKind: aletheia.SignatureKind(req.SignatureKind),
+		ContentHash:   content.ContentHash(),
+	}
+	if signoff.SignerName == "" {
+		signoff.SignerName = user.FullName()
+	}
+
+	var storagePath string
+	switch signoff.SignatureKind {
+	case aletheia.SignatureTyped:
+		signoff.Signature = strings.TrimSpace(req.Signature)
+		if signoff.Signature == "" || len(signoff.Signature) > 200 {
+			return aletheia.Invalid("Typed signature must be 1 to 200 characters")
+		}
+	case aletheia.SignatureDrawn:
+		image, err := decodeSignature(req.Signature)
+		if err != nil {
+			return err
+		}
+		// Every signature gets its own key so reports showing it stay unchanged
+		storagePath = "inspections/" + inspectionID.String() + "/signatures/" + uuid.NewString() + ".png"
+		signoff.Signature, err = s.fileStorage.Upload(ctx, storagePath, bytes.NewReader(image), "image/png")
+		if err != nil {
+			s.log(c).Error("failed to upload signature", slog.String("error", err.Error()))
+			return aletheia.Internal("Failed to upload signature", err)
+		}
+	}
+
+	if err := s.inspectionService.CreateSignoff(ctx, signoff); err != nil {
+		if storagePath != "" {
+			_ = s.fileStorage.Delete(ctx, storagePath)
+		}
+		return err
+	}
+
+	s.log(c).Info("inspection signed off",
+		slog.String("inspection_id", inspectionID.String()),
+		slog.String("signoff_id", signoff.ID.String()),
+		slog.String("role", string(signoff.Role)),
+	)
+
+	return RespondCreated(c, SignoffResponse{Signoff: signoff, Current: true})
+}
+
+// requireInspectorSignoff returns an error unless the inspector has signed
+// off on the inspection's content as it is now.
+func (s *Server) requireInspectorSignoff(c echo.Context, inspectionID uuid.UUID, content aletheia.ReportSnapshot) error {
+	signoffs, err := s.inspectionService.FindSignoffs(c.Request().Context(), inspectionID)
+	if err != nil {
+		return err
+	}
+	for _, signoff := range signoffs {
+		if signoff.Role == aletheia.SignoffInspector && signoff.Current(content) {
+			return nil
+		}
+	}
+	return aletheia.Invalid("The inspector must sign off before a reviewer")
+}
+
+// decodeSignature returns the PNG image in a drawn signature's data URL.
+func decodeSignature(dataURL string) ([]byte, error) {
+	encoded, ok := strings.CutPrefix(dataURL, signatureDataURLPrefix)
+	if !ok {
+		return nil, aletheia.Invalid("Drawn signature must be a PNG data URL")
+	}
+	if base64.StdEncoding.DecodedLen(len(encoded)) > maxSignatureSize {
+		return nil, aletheia.Invalid("Drawn signature exceeds maximum size of 256KB")
+	}
+	image, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, aletheia.Invalid("Drawn signature is not valid base64")
+	}
+	if _, err := png.DecodeConfig(bytes.NewReader(image)); err != nil {
+		return nil, aletheia.Invalid("Drawn signature is not a valid PNG image")
+	}
+	return image, nil
+}
+
+// handleDeleteSignoff withdraws an inspection's sign-off in a role so it
+// can be given again. Signers can withdraw their own sign-offs, and owners
+// and admins anyone's. Reports already generated keep showing it.
+func (s *Server) handleDeleteSignoff(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	user, err := requireUser(c)
+	if err != nil {
+		return err
+	}
+
+	inspectionID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+	role := aletheia.SignoffRole(c.Param("role"))
+	if !role.IsValid() {
+		return aletheia.Invalid("Invalid sign-off role: %s", role)
+	}
+
+	inspection, err := s.inspectionService.FindInspectionByID(ctx, inspectionID)
+	if err != nil {
+		return err
+	}
+	project, err := s.getProjectWithOrgCheck(c, inspection.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	signoffs, err := s.inspectionService.FindSignoffs(ctx, inspectionID)
+	if err != nil {
+		return err
+	}
+	var signoff *aletheia.Signoff
+	for _, so := range signoffs {
+		if so.Role == role {
+			signoff = so
+		}
+	}
+	if signoff == nil {
+		return aletheia.NotFound("Sign-off not found")
+	}
+
+	if signoff.SignerID != user.ID {
+		if _, err := s.organizationService.RequireMembership(ctx, project.OrganizationID, user.ID, aletheia.RoleOwner, aletheia.RoleAdmin); err != nil {
+			return err
+		}
+	}
+
+	if err := s.inspectionService.DeleteSignoff(ctx, signoff.ID); err != nil {
+		return err
+	}
+
+	s.log(c).Info("inspection sign-off withdrawn",
+		slog.String("inspection_id", inspectionID.String()),
+		slog.String("signoff_id", signoff.ID.String()),
+		slog.String("role", string(role)),
+	)
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,234 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/report"
+	"github.com/labstack/echo/v4"
+)
+
+// defaultSummaryDays is the number of days a summary covers when no period
+// is given.
+const defaultSummaryDays = 30
+
+// SummaryReportRequest is the request payload for generating a project
+// summary report. Dates are inclusive, in UTC, and default to the last 30
+// days.
+type SummaryReportRequest struct {
+	From string `json:"from" form:"from"`
+	To   string `json:"to" form:"to"`
+}
+
+// parseSummaryPeriod returns the period covered by inclusive from and to
+// dates, either of which may be empty. The period ends at midnight after
+// the last day.
+func parseSummaryPeriod(fromDate, toDate string) (from, to time.Time, err error) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	to = today.AddDate(0, 0, 1)
+	if toDate != "" {
+		if to, err = time.Parse(dateLayout, toDate); err != nil {
+			return from, to, aletheia.Invalid("to must be a date like 2025-01-31")
+		}
+		// The whole of the last day is included
+		to = to.AddDate(0, 0, 1)
+	}
+	from = to.AddDate(0, 0, -defaultSummaryDays)
+	if fromDate != "" {
+		if from, err = time.Parse(dateLayout, fromDate); err != nil {
+			return from, to, aletheia.Invalid("from must be a date like 2025-01-31")
+		}
+	}
+
+	if !from.Before(to) {
+		return from, to, aletheia.Invalid("from must not be after to")
+	}
+	if to.Sub(from) > aletheia.MaxSummaryPeriod {
+		return from, to, aletheia.Invalid("A summary can cover at most %d days", int(aletheia.MaxSummaryPeriod/(24*time.Hour)))
+	}
+	return from, to, nil
+}
+
+// handleGetProjectSummary summarizes a project's inspections and violations
+// over a period. Query parameters "from" and "to" are inclusive dates in
+// UTC, and default to the last 30 days.
+func (s *Server) handleGetProjectSummary(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	projectID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+	project, err := s.getProjectWithOrgCheck(c, projectID)
+	if err != nil {
+		return err
+	}
+
+	from, to, err := parseSummaryPeriod(c.QueryParam("from"), c.QueryParam("to"))
+	if err != nil {
+		return err
+	}
+
+	summary, err := report.Summarize(ctx, s.inspectionService, s.violationService, project, from, to)
+	if err != nil {
+		return err
+	}
+
+	return RespondOK(c, summary)
+}
+
+// handleGenerateSummaryReport queues a job that renders a project summary
+// to PDF and stores it. Progress can be followed with the job's event
+// stream.
+func (s *Server) handleGenerateSummaryReport(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	userID, err := requireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	projectID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req SummaryReportRequest
+	if err := bind(c, &req); err != nil {
+		return err
+	}
+	from, to, err := parseSummaryPeriod(req.From, req.To)
+	if err != nil {
+		return err
+	}
+
+	project, err := s.getProjectWithOrgCheck(c, projectID)
+	if err != nil {
+		return err
+	}
+
+	if s.queue == nil {
+		return aletheia.Internal("Queue service not available", nil)
+	}
+
+	job, err := report.NewSummaryJob(project, from, to, userID)
+	if err != nil {
+		return aletheia.Internal("Failed to create summary job", err)
+	}
+	if err := s.queue.Enqueue(ctx, job); err != nil {
+		s.log(c).Error("failed to enqueue project summary", slog.String("error", err.Error()))
+		return aletheia.Internal("Failed to queue project summary", err)
+	}
+
+	status := "queued"
+	if job.Status != aletheia.JobStatusPending {
+		status = string(job.Status)
+	}
+
+	s.log(c).Info("project summary queued",
+		slog.String("project_id", projectID.String()),
+		slog.String("job_id", job.ID.String()),
+	)
+
+	return Respond(c, http.StatusAccepted, map[string]interface{}{
+		"job_id":     job.ID.String(),
+		"project_id": projectID.String(),
+		"status":     status,
+	})
+}
+
+// handleListSummaryReports lists a project's stored summary reports, newest
+// first.
+func (s *Server) handleListSummaryReports(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	projectID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+	if _, err := s.getProjectWithOrgCheck(c, projectID); err != nil {
+		return err
+	}
+
+	reports, err := s.reportService.FindSummaryReports(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	return RespondOK(c, map[string]interface{}{
+		"reports": reports,
+		"total":   len(reports),
+	})
+}
+
+// handleGetSummaryReport returns a stored summary report with the summary
+// it was rendered from.
+func (s *Server) handleGetSummaryReport(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	rpt, err := s.findSummaryReport(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	return RespondOK(c, rpt)
+}
+
+// handleDownloadSummaryReport streams a summary report's PDF from storage.
+func (s *Server) handleDownloadSummaryReport(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	rpt, err := s.findSummaryReport(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	key, ok := aletheia.StorageKey(s.fileStorage, rpt.StorageURL)
+	if !ok {
+		return aletheia.Internal("Report is not in storage", nil)
+	}
+	file, err := s.fileStorage.Open(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", report.SummaryFilename(rpt)))
+	c.Response().Header().Set(echo.HeaderContentLength, fmt.Sprint(rpt.SizeBytes))
+	c.Response().Header().Set(echo.HeaderContentType, "application/pdf")
+	c.Response().WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(c.Response(), file); err != nil {
+		s.log(c).Error("failed to stream summary report",
+			slog.String("summary_report_id", rpt.ID.String()),
+			slog.String("error", err.Error()),
+		)
+	}
+	return nil
+}
+
+// findSummaryReport returns the summary report in the "id" parameter if the
+// user can access its project.
+func (s *Server) findSummaryReport(ctx context.Context, c echo.Context) (*aletheia.SummaryReport, error) {
+	reportID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return nil, err
+	}
+	rpt, err := s.reportService.FindSummaryReportByID(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.getProjectWithOrgCheck(c, rpt.ProjectID); err != nil {
+		return nil, err
+	}
+	return rpt, nil
+}
@@ -0,0 +1,343 @@
+package http
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Resumable uploads implement the tus 1.0.0 protocol (https://tus.io) with the
+// creation, termination and expiration extensions. A client creates an upload
+// session with POST, queries the current offset with HEAD after a dropped
+// connection, and resumes by PATCHing the remaining bytes.
+
+const (
+	tusVersion    = "1.0.0"
+	tusExtensions = "creation,termination,expiration"
+
+	// tusContentType is the required Content-Type of PATCH requests.
+	tusContentType = "application/offset+octet-stream"
+
+	// uploadChunkTimeout bounds persisting a received chunk to storage.
+	uploadChunkTimeout = 60 * time.Second
+)
+
+// Tus protocol headers.
+const (
+	headerTusResumable   = "Tus-Resumable"
+	headerTusVersion     = "Tus-Version"
+	headerTusExtension   = "Tus-Extension"
+	headerTusMaxSize     = "Tus-Max-Size"
+	headerUploadLength   = "Upload-Length"
+	headerUploadOffset   = "Upload-Offset"
+	headerUploadMetadata = "Upload-Metadata"
+	headerUploadExpires  = "Upload-Expires"
+)
+
+// tusHeaders lists the tus request headers for CORS.
+var tusHeaders = []string{headerTusResumable, headerUploadLength, headerUploadOffset, headerUploadMetadata}
+
+// tusExposedHeaders lists the tus response headers browsers may read.
+var tusExposedHeaders = []string{echo.HeaderLocation, headerTusResumable, headerTusVersion, headerTusExtension, headerTusMaxSize, headerUploadLength, headerUploadOffset, headerUploadExpires}
+
+func (s *Server) handleUploadOptions(c echo.Context) error {
+	h := c.Response().Header()
+	h.Set(headerTusResumable, tusVersion)
+	h.Set(headerTusVersion, tusVersion)
+	h.Set(headerTusExtension, tusExtensions)
+	h.Set(headerTusMaxSize, strconv.Itoa(aletheia.MaxResumableUploadSize))
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (s *Server) handleCreateUpload(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	if err := requireTusResumable(c); err != nil {
+		return err
+	}
+
+	userID, err := requireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	length, err := strconv.ParseInt(c.Request().Header.Get(headerUploadLength), 10, 64)
+	if err != nil || length <= 0 {
+		return aletheia.Invalid("Upload-Length header must be a positive integer")
+	}
+	if length > aletheia.MaxResumableUploadSize {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "Upload exceeds maximum size of 25MB")
+	}
+
+	metadata, err := parseUploadMetadata(c.Request().Header.Get(headerUploadMetadata))
+	if err != nil {
+		return err
+	}
+
+	inspectionIDStr := metadata["inspection_id"]
+	if inspectionIDStr == "" {
+		return aletheia.Invalid("inspection_id metadata is required")
+	}
+	inspectionID, err := parseUUID(inspectionIDStr)
+	if err != nil {
+		return err
+	}
+
+	contentType := metadata["filetype"]
+	if contentType == "" {
+		contentType = metadata["content_type"]
+	}
+	if !isAllowedImageType(contentType) {
+		return aletheia.Invalid("invalid image type, must be JPEG, PNG, or WebP")
+	}
+
+	// Verify inspection exists
+	inspection, err := s.inspectionService.FindInspectionByID(ctx, inspectionID)
+	if err != nil {
+		return err
+	}
+
+	// Enforce the organization's storage quota
+	if err := s.checkStorageQuota(ctx, inspection, length); err != nil {
+		return err
+	}
+
+	upload := &aletheia.Upload{
+		InspectionID: inspectionID,
+		UserID:       userID,
+		Filename:     metadata["filename"],
+		ContentType:  contentType,
+		Length:       length,
+	}
+	if err := s.uploadService.CreateUpload(ctx, upload); err != nil {
+		return err
+	}
+
+	s.log(c).Info("upload created",
+		slog.String("upload_id", upload.ID.String()),
+		slog.String("inspection_id", inspectionID.String()),
+		slog.Int64("length", length),
+	)
+
+	h := c.Response().Header()
+	h.Set(headerTusResumable, tusVersion)
+	h.Set(echo.HeaderLocation, "/api/uploads/"+upload.ID.String())
+	h.Set(headerUploadOffset, "0")
+	h.Set(headerUploadExpires, upload.ExpiresAt.UTC().Format(http.TimeFormat))
+
+	return RespondCreated(c, upload)
+}
+
+func (s *Server) handleHeadUpload(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	if err := requireTusResumable(c); err != nil {
+		return err
+	}
+
+	upload, err := s.findOwnUpload(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	setUploadHeaders(c, upload)
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return c.NoContent(http.StatusOK)
+}
+
+func (s *Server) handlePatchUpload(c echo.Context) error {
+	if err := requireTusResumable(c); err != nil {
+		return err
+	}
+
+	if c.Request().Header.Get(echo.HeaderContentType) != tusContentType {
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, "Content-Type must be "+tusContentType)
+	}
+
+	offset, err := strconv.ParseInt(c.Request().Header.Get(headerUploadOffset), 10, 64)
+	if err != nil || offset < 0 {
+		return aletheia.Invalid("Upload-Offset header must be a non-negative integer")
+	}
+
+	ctx, cancel := withTimeout(c)
+	upload, err := s.findOwnUpload(ctx, c)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	// Read the chunk before touching storage. If the connection drops midway
+	// the bytes received so far are still kept so the client can resume.
+	data, readErr := io.ReadAll(io.LimitReader(c.Request().Body, aletheia.MaxUploadChunkSize+1))
+	if len(data) > aletheia.MaxUploadChunkSize {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "Chunk exceeds maximum size of 8MB")
+	}
+	if readErr != nil && len(data) == 0 {
+		return aletheia.Invalid("Failed to read upload chunk")
+	}
+
+	// Persist independently of the request so a client disconnect does not
+	// discard a chunk that was fully received.
+	ctx, cancel = context.WithTimeout(context.WithoutCancel(c.Request().Context()), uploadChunkTimeout)
+	defer cancel()
+
+	upload, err = s.uploadService.WriteUploadChunk(ctx, upload.ID, offset, data)
+	if err != nil {
+		return err
+	}
+
+	if readErr != nil {
+		s.log(c).Warn("upload chunk interrupted",
+			slog.String("upload_id", upload.ID.String()),
+			slog.Int64("offset", upload.Offset),
+			slog.String("error", readErr.Error()),
+		)
+		return aletheia.Invalid("Failed to read upload chunk")
+	}
+
+	if upload.Status == aletheia.UploadStatusCompleted && upload.PhotoID == nil {
+		if upload, err = s.createUploadPhoto(ctx, upload); err != nil {
+			return err
+		}
+	}
+
+	setUploadHeaders(c, upload)
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (s *Server) handleGetUpload(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	upload, err := s.findOwnUpload(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	return RespondOK(c, upload)
+}
+
+func (s *Server) handleDeleteUpload(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	if err := requireTusResumable(c); err != nil {
+		return err
+	}
+
+	upload, err := s.findOwnUpload(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	if err := s.uploadService.AbortUpload(ctx, upload.ID); err != nil {
+		return err
+	}
+
+	s.log(c).Info("upload aborted", slog.String("upload_id", upload.ID.String()))
+
+	c.Response().Header().Set(headerTusResumable, tusVersion)
+	return c.NoContent(http.StatusNoContent)
+}
+
+// createUploadPhoto creates the photo record for a completed upload.
+func (s *Server) createUploadPhoto(ctx context.Context, upload *aletheia.Upload) (*aletheia.Upload, error) {
+	photo := &aletheia.Photo{
+		ID:           uuid.New(),
+		InspectionID: upload.InspectionID,
+		StorageURL:   upload.StorageURL,
+		SizeBytes:    upload.Length,
+	}
+	if err := s.photoService.CreatePhoto(ctx, photo); err != nil {
+		return nil, err
+	}
+
+	upload, err := s.uploadService.UpdateUpload(ctx, upload.ID, aletheia.UploadUpdate{PhotoID: &photo.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("photo uploaded",
+		slog.String("photo_id", photo.ID.String()),
+		slog.String("upload_id", upload.ID.String()),
+		slog.String("inspection_id", upload.InspectionID.String()),
+	)
+
+	return upload, nil
+}
+
+// findOwnUpload loads the upload named by the id route parameter and checks
+// that it belongs to the authenticated user.
+func (s *Server) findOwnUpload(ctx context.Context, c echo.Context) (*aletheia.Upload, error) {
+	uploadID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := requireUserID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	upload, err := s.uploadService.FindUploadByID(ctx, uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	if upload.UserID != userID {
+		return nil, aletheia.Forbidden("You do not have access to this upload")
+	}
+
+	return upload, nil
+}
+
+// requireTusResumable rejects requests for an unsupported tus protocol version.
+func requireTusResumable(c echo.Context) error {
+	if c.Request().Header.Get(headerTusResumable) != tusVersion {
+		c.Response().Header().Set(headerTusVersion, tusVersion)
+		return echo.NewHTTPError(http.StatusPreconditionFailed, "Unsupported tus version")
+	}
+	return nil
+}
+
+// setUploadHeaders sets the tus headers describing an upload's progress.
+func setUploadHeaders(c echo.Context, upload *aletheia.Upload) {
+	h := c.Response().Header()
+	h.Set(headerTusResumable, tusVersion)
+	h.Set(headerUploadOffset, strconv.FormatInt(upload.Offset, 10))
+	h.Set(headerUploadLength, strconv.FormatInt(upload.Length, 10))
+	h.Set(headerUploadExpires, upload.ExpiresAt.UTC().Format(http.TimeFormat))
+}
+
+// parseUploadMetadata decodes a tus Upload-Metadata header: comma-separated
+// pairs of a key and an optional base64-encoded value.
+func parseUploadMetadata(header string) (map[string]string, error) {
+	metadata := make(map[string]string)
+	if header == "" {
+		return metadata, nil
+	}
+
+	for _, pair := range strings.Split(header, ",") {
+		key, encoded, _ := strings.Cut(strings.TrimSpace(pair), " ")
+		if key == "" {
+			continue
+		}
+		value, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, aletheia.Invalid("Invalid Upload-Metadata value for %s", key)
+		}
+		metadata[key] = string(value)
+	}
+	return metadata, nil
+}
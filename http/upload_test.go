@@ -0,0 +1,161 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/mock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPatchRequest creates a tus PATCH request writing body at offset.
+func newPatchRequest(id uuid.UUID, offset, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPatch, "/api/uploads/"+id.String(), strings.NewReader(body))
+	req.Header.Set(headerTusResumable, tusVersion)
+	req.Header.Set(headerUploadOffset, offset)
+	req.Header.Set(echo.HeaderContentType, tusContentType)
+	return req
+}
+
+func TestPatchUpload_OffsetMismatch(t *testing.T) {
+	uploads := &mock.UploadService{}
+	s, user := newTestServer(t, Config{UploadService: uploads})
+
+	upload := &aletheia.Upload{ID: uuid.New(), UserID: user.ID, Length: 10, Offset: 5, ExpiresAt: time.Now().Add(time.Hour)}
+	uploads.FindUploadByIDFn = func(ctx context.Context, id uuid.UUID) (*aletheia.Upload, error) {
+		return upload, nil
+	}
+	uploads.WriteUploadChunkFn = func(ctx context.Context, id uuid.UUID, offset int64, data []byte) (*aletheia.Upload, error) {
+		if offset != upload.Offset {
+			return nil, aletheia.Conflict("Upload offset does not match")
+		}
+		upload.Offset += int64(len(data))
+		return upload, nil
+	}
+
+	rec := serve(s, newPatchRequest(upload.ID, "0", "hello"))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, int64(5), upload.Offset)
+
+	// HEAD reports the offset to resume from
+	req := httptest.NewRequest(http.MethodHead, "/api/uploads/"+upload.ID.String(), nil)
+	req.Header.Set(headerTusResumable, tusVersion)
+	rec = serve(s, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "5", rec.Header().Get(headerUploadOffset))
+
+	rec = serve(s, newPatchRequest(upload.ID, "5", "world"))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "10", rec.Header().Get(headerUploadOffset))
+}
+
+func TestPatchUpload_RequiresTusVersion(t *testing.T) {
+	s, _ := newTestServer(t, Config{})
+
+	req := newPatchRequest(uuid.New(), "0", "hello")
+	req.Header.Del(headerTusResumable)
+	rec := serve(s, req)
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	assert.Equal(t, tusVersion, rec.Header().Get(headerTusVersion))
+}
+
+func TestCreateUpload_TooLarge(t *testing.T) {
+	s, _ := newTestServer(t, Config{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/uploads", nil)
+	req.Header.Set(headerTusResumable, tusVersion)
+	req.Header.Set(headerUploadLength, strconv.Itoa(aletheia.MaxResumableUploadSize+1))
+	rec := serve(s, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func TestPatchUpload_CompletionCreatesPhoto(t *testing.T) {
+	uploads := &mock.UploadService{}
+	var photo *aletheia.Photo
+	photos := &mock.PhotoService{
+		CreatePhotoFn: func(ctx context.Context, p *aletheia.Photo) error {
+			photo = p
+			return nil
+		},
+	}
+	s, user := newTestServer(t, Config{UploadService: uploads, PhotoService: photos})
+
+	upload := &aletheia.Upload{
+		ID:           uuid.New(),
+		InspectionID: uuid.New(),
+		UserID:       user.ID,
+		Length:       5,
+		Status:       aletheia.UploadStatusInProgress,
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}
+	uploads.FindUploadByIDFn = func(ctx context.Context, id uuid.UUID) (*aletheia.Upload, error) {
+		return upload, nil
+	}
+	uploads.WriteUploadChunkFn = func(ctx context.Context, id uuid.UUID, offset int64, data []byte) (*aletheia.Upload, error) {
+		upload.Offset += int64(len(data))
+		upload.Status = aletheia.UploadStatusCompleted
+		upload.StorageURL = "https://example.com/photos/" + id.String()
+		return upload, nil
+	}
+	uploads.UpdateUploadFn = func(ctx context.Context, id uuid.UUID, upd aletheia.UploadUpdate) (*aletheia.Upload, error) {
+		upload.PhotoID = upd.PhotoID
+		return upload, nil
+	}
+
+	rec := serve(s, newPatchRequest(upload.ID, "0", "hello"))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "5", rec.Header().Get(headerUploadOffset))
+
+	require.NotNil(t, photo)
+	assert.Equal(t, upload.InspectionID, photo.InspectionID)
+	assert.Equal(t, upload.StorageURL, photo.StorageURL)
+	assert.Equal(t, int64(5), photo.SizeBytes)
+	require.NotNil(t, upload.PhotoID)
+	assert.Equal(t, photo.ID, *upload.PhotoID)
+}
+
+func TestDeleteUpload(t *testing.T) {
+	uploads := &mock.UploadService{}
+	s, user := newTestServer(t, Config{UploadService: uploads})
+
+	own := &aletheia.Upload{ID: uuid.New(), UserID: user.ID}
+	other := &aletheia.Upload{ID: uuid.New(), UserID: uuid.New()}
+	uploads.FindUploadByIDFn = func(ctx context.Context, id uuid.UUID) (*aletheia.Upload, error) {
+		if id == own.ID {
+			return own, nil
+		}
+		return other, nil
+	}
+	var aborted []uuid.UUID
+	uploads.AbortUploadFn = func(ctx context.Context, id uuid.UUID) error {
+		aborted = append(aborted, id)
+		return nil
+	}
+
+	del := func(id uuid.UUID) int {
+		req := httptest.NewRequest(http.MethodDelete, "/api/uploads/"+id.String(), nil)
+		req.Header.Set(headerTusResumable, tusVersion)
+		return serve(s, req).Code
+	}
+	assert.Equal(t, http.StatusNoContent, del(own.ID))
+	assert.Equal(t, http.StatusForbidden, del(other.ID))
+	assert.Equal(t, []uuid.UUID{own.ID}, aborted)
+}
+
+func TestParseUploadMetadata(t *testing.T) {
+	metadata, err := parseUploadMetadata("filename cGhvdG8uanBn, filetype aW1hZ2UvanBlZw==,empty")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"filename": "photo.jpg", "filetype": "image/jpeg", "empty": ""}, metadata)
+
+	_, err = parseUploadMetadata("filename !!!")
+	assert.True(t, aletheia.IsErrorCode(err, aletheia.EINVALID))
+}
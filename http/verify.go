@@ -0,0 +1,186 @@
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/signing"
+	"github.com/labstack/echo/v4"
+)
+
+// maxVerifiedReportSize is the largest report file accepted for verification.
+const maxVerifiedReportSize = 100 * 1024 * 1024 // 100MB
+
+// Signature states of a verified report.
+const (
+	SignatureValid      = "valid"
+	SignatureInvalid    = "invalid"
+	SignatureUnsigned   = "unsigned"
+	SignatureUnknownKey = "unknown_key"
+)
+
+// ReportVerification is the result of checking a report file against the
+// reports this server issued.
+type ReportVerification struct {
+	// Verified is true if the file is a report issued by this server,
+	// unaltered, and its signature, if any, is not invalid.
+	Verified bool   `json:"verified"`
+	Reason   string `json:"reason"`
+	SHA256   string `json:"sha256"`
+
+	Report *VerifiedReport `json:"report,omitempty"`
+}
+
+// VerifiedReport describes an issued report matching a verified file.
+type VerifiedReport struct {
+	InspectionID string                  `json:"inspectionId"`
+	Organization string                  `json:"organization,omitempty"`
+	Project      string                  `json:"project,omitempty"`
+	Version      int                     `json:"version"`
+	Template     aletheia.ReportTemplate `json:"template"`
+	CreatedAt    time.Time               `json:"createdAt"`
+	Signature    string                  `json:"signature"` // One of the Signature states
+	SigningKeyID string                  `json:"signingKeyId,omitempty"`
+	Signoffs     []VerifiedSignoff       `json:"signoffs"`
+}
+
+// VerifiedSignoff is a sign-off shown in a verified report.
+type VerifiedSignoff struct {
+	Role       aletheia.SignoffRole `json:"role"`
+	SignerName string               `json:"signerName"`
+	SignedAt   time.Time            `json:"signedAt"`
+}
+
+// handleVerifyReportFile checks an uploaded report file. The file is only
+// hashed, never stored.
+func (s *Server) handleVerifyReportFile(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return aletheia.Invalid("file is required")
+	}
+	if file.Size > maxVerifiedReportSize {
+		return aletheia.Invalid("file exceeds maximum size of 100MB")
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return aletheia.Internal("Failed to read uploaded file", err)
+	}
+	defer src.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, src); err != nil {
+		return aletheia.Internal("Failed to read uploaded file", err)
+	}
+
+	return s.respondReportVerification(ctx, c, hex.EncodeToString(h.Sum(nil)))
+}
+
+// handleVerifyReportHash checks a report by the hex SHA-256 of its file,
+// for those who hashed it themselves.
+func (s *Server) handleVerifyReportHash(c echo.Context) error {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	hash := c.Param("sha256")
+	if b, err := hex.DecodeString(hash); err != nil || len(b) != sha256.Size {
+		return aletheia.Invalid("sha256 must be 64 hexadecimal characters")
+	}
+
+	return s.respondReportVerification(ctx, c, strings.ToLower(hash))
+}
+
+// respondReportVerification looks up the report issued with a hash and
+// checks its signature.
+func (s *Server) respondReportVerification(ctx context.Context, c echo.Context, hash string) error {
+	result := &ReportVerification{SHA256: hash}
+
+	rpt, err := s.reportService.FindReportBySHA256(ctx, hash)
+	if aletheia.IsErrorCode(err, aletheia.ENOTFOUND) {
+		result.Reason = "No report with this fingerprint was issued. The file may have been altered."
+		return RespondOK(c, result)
+	}
+	if err != nil {
+		return err
+	}
+
+	verified := &VerifiedReport{
+		InspectionID: rpt.InspectionID.String(),
+		Version:      rpt.Version,
+		Template:     rpt.Template,
+		CreatedAt:    rpt.CreatedAt,
+		SigningKeyID: rpt.SigningKeyID,
+		Signoffs:     make([]VerifiedSignoff, len(rpt.Signoffs)),
+	}
+	for i, signoff := range rpt.Signoffs {
+		verified.Signoffs[i] = VerifiedSignoff{Role: signoff.Role, SignerName: signoff.SignerName, SignedAt: signoff.SignedAt}
+	}
+	s.describeVerifiedReport(ctx, verified, rpt)
+	result.Report = verified
+
+	issued := fmt.Sprintf("Matches version %d of the report, issued %s.", rpt.Version, rpt.CreatedAt.UTC().Format("January 2, 2006 15:04 MST"))
+	switch {
+	case rpt.Signature == "":
+		verified.Signature = SignatureUnsigned
+		result.Verified = true
+		result.Reason = issued + " The report was not signed."
+	case s.signer == nil || s.signer.KeyID() != rpt.SigningKeyID:
+		verified.Signature = SignatureUnknownKey
+		result.Verified = true
+		result.Reason = issued + " It was signed with a key no longer in use, so its signature cannot be checked."
+	case s.signer.Verify(rpt.InspectionID, rpt.SHA256, rpt.Signature):
+		verified.Signature = SignatureValid
+		result.Verified = true
+		result.Reason = issued + " Its signature is valid."
+	default:
+		// The file matches, but the record of it does not
+		verified.Signature = SignatureInvalid
+		result.Reason = issued + " Its recorded signature is invalid."
+		s.log(c).Warn("report signature invalid",
+			slog.String("report_id", rpt.ID.String()),
+			slog.String("sha256", hash))
+	}
+
+	return RespondOK(c, result)
+}
+
+// describeVerifiedReport adds the organization and project of a report,
+// which its file already shows, to help people recognize it.
+func (s *Server) describeVerifiedReport(ctx context.Context, verified *VerifiedReport, rpt *aletheia.Report) {
+	inspection, err := s.inspectionService.FindInspectionByID(ctx, rpt.InspectionID)
+	if err != nil {
+		return
+	}
+	project, err := s.projectService.FindProjectByID(ctx, inspection.ProjectID)
+	if err != nil {
+		return
+	}
+	verified.Project = project.Name
+	if org, err := s.organizationService.FindOrganizationByID(ctx, project.OrganizationID); err == nil {
+		verified.Organization = org.Name
+	}
+}
+
+// handleGetSigningKey returns the public key reports are signed with, so
+// signatures can be checked independently.
+func (s *Server) handleGetSigningKey(c echo.Context) error {
+	if s.signer == nil {
+		return aletheia.NotFound("Reports are not signed")
+	}
+	return RespondOK(c, map[string]interface{}{
+		"algorithm":  "Ed25519",
+		"key_id":     s.signer.KeyID(),
+		"public_key": s.signer.PublicKey(),
+		"message":    signing.MessageFormat,
+	})
+}
@@ -1,9 +1,12 @@
 package http
 
 import (
+	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/export"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
@@ -210,3 +213,127 @@ func (s *Server) handleUpdateViolation(c echo.Context) error {
 
 	return RespondOK(c, violation)
 }
+
+// dateLayout is the format of dates in query parameters.
+const dateLayout = "2006-01-02"
+
+// handleExportViolations streams an organization's violations as a CSV or
+// XLSX file. Query parameters filter by project, status and detection date;
+// "from" and "to" are inclusive dates in UTC.
+func (s *Server) handleExportViolations(c echo.Context) error {
+	orgID, err := requireUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	filter, format, err := s.parseViolationExport(c, orgID)
+	if err != nil {
+		return err
+	}
+
+	filename := fmt.Sprintf("violations-%s.%s", time.Now().UTC().Format(dateLayout), format)
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	c.Response().Header().Set(echo.HeaderContentType, format.ContentType())
+
+	// The export can run well past the request timeout, so it is bounded
+	// only by the client staying connected
+	n, err := export.Violations(c.Request().Context(), s.violationService, filter, format, c.Response())
+	if err != nil {
+		// Nothing has been sent yet, so the error can still be reported
+		if !c.Response().Committed {
+			return err
+		}
+		s.log(c).Error("failed to stream violation export",
+			slog.String("organization_id", orgID.String()),
+			slog.Int("rows", n),
+			slog.String("error", err.Error()),
+		)
+		return nil
+	}
+
+	s.log(c).Info("violations exported",
+		slog.String("organization_id", orgID.String()),
+		slog.String("format", string(format)),
+		slog.Int("rows", n),
+	)
+	return nil
+}
+
+// parseViolationExport checks the user can export the organization's
+// violations and parses the export's query parameters.
+func (s *Server) parseViolationExport(c echo.Context, orgID uuid.UUID) (aletheia.ViolationExportFilter, export.Format, error) {
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	filter := aletheia.ViolationExportFilter{OrganizationID: orgID}
+
+	userID, err := requireUserID(c)
+	if err != nil {
+		return filter, "", err
+	}
+	if _, err := s.organizationService.RequireMembership(ctx, orgID, userID); err != nil {
+		return filter, "", err
+	}
+
+	format := export.FormatCSV
+	if v := c.QueryParam("format"); v != "" {
+		format = export.Format(v)
+		if !format.IsValid() {
+			return filter, "", aletheia.Invalid("Format must be csv or xlsx")
+		}
+	}
+
+	if v := c.QueryParam("project_id"); v != "" {
+		projectID, err := parseUUID(v)
+		if err != nil {
+			return filter, "", err
+		}
+		project, err := s.projectService.FindProjectByID(ctx, projectID)
+		if err != nil {
+			return filter, "", err
+		}
+		if project.OrganizationID != orgID {
+			return filter, "", aletheia.NotFound("Project not found")
+		}
+		filter.ProjectID = &projectID
+	}
+
+	if v := c.QueryParam("status"); v != "" {
+		status := aletheia.ViolationStatus(v)
+		if !status.IsValid() {
+			return filter, "", aletheia.Invalid("Invalid violation status: %s", v)
+		}
+		filter.Status = &status
+	}
+
+	filter.CreatedFrom, filter.CreatedTo, err = parseDateRange(c, "from", "to")
+	if err != nil {
+		return filter, "", err
+	}
+
+	return filter, format, nil
+}
+
+// parseDateRange parses a pair of optional date query parameters. The
+// returned end is exclusive, so the whole of the last day is included.
+func parseDateRange(c echo.Context, fromParam, toParam string) (from, to *time.Time, err error) {
+	if v := c.QueryParam(fromParam); v != "" {
+		t, err := time.Parse(dateLayout, v)
+		if err != nil {
+			return nil, nil, aletheia.Invalid("%s must be a date like 2025-01-31", fromParam)
+		}
+		from = &t
+	}
+	if v := c.QueryParam(toParam); v != "" {
+		t, err := time.Parse(dateLayout, v)
+		if err != nil {
+			return nil, nil, aletheia.Invalid("%s must be a date like 2025-01-31", toParam)
+		}
+		t = t.AddDate(0, 0, 1)
+		to = &t
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, nil, aletheia.Invalid("%s must not be after %s", fromParam, toParam)
+	}
+	return from, to, nil
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"crypto/subtle"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/labstack/echo/v4"
+)
+
+// BounceWebhookRequest is the part of a Postmark bounce webhook used to
+// track report deliveries.
+type BounceWebhookRequest struct {
+	RecordType  string    `json:"RecordType"`
+	MessageID   string    `json:"MessageID"`
+	Name        string    `json:"Name"` // Bounce type, such as "Hard bounce"
+	Description string    `json:"Description"`
+	BouncedAt   time.Time `json:"BouncedAt"`
+}
+
+// handleEmailBounce records that a report email bounced. The email provider
+// authenticates with HTTP basic auth, using the webhook token as password.
+// The endpoint is disabled if no token is configured.
+func (s *Server) handleEmailBounce(c echo.Context) error {
+	if s.emailWebhookToken == "" {
+		return aletheia.NotFound("Not found")
+	}
+	_, password, ok := c.Request().BasicAuth()
+	if !ok || subtle.ConstantTimeCompare([]byte(password), []byte(s.emailWebhookToken)) != 1 {
+		return aletheia.Unauthorized("Invalid webhook credentials")
+	}
+
+	ctx, cancel := withTimeout(c)
+	defer cancel()
+
+	var req BounceWebhookRequest
+	if err := c.Bind(&req); err != nil {
+		return aletheia.Invalid("Invalid request body")
+	}
+	if req.RecordType != "Bounce" || req.MessageID == "" {
+		return c.NoContent(http.StatusNoContent)
+	}
+	if req.BouncedAt.IsZero() {
+		req.BouncedAt = time.Now()
+	}
+
+	reason := req.Name
+	if req.Description != "" {
+		reason += ": " + req.Description
+	}
+
+	// Other emails bounce too; only report deliveries are tracked
+	err := s.reportService.MarkReportDeliveryBounced(ctx, req.MessageID, reason, req.BouncedAt)
+	if err != nil && !aletheia.IsErrorCode(err, aletheia.ENOTFOUND) {
+		return err
+	}
+	if err == nil {
+		s.log(c).Info("report delivery bounced",
+			slog.String("message_id", req.MessageID),
+			slog.String("reason", reason),
+		)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
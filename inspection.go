@@ -2,6 +2,8 @@ package aletheia
 
 import (
 	"context"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,16 +11,73 @@ import (
 
 // Inspection represents a specific inspection event at a project.
 type Inspection struct {
-	ID          uuid.UUID        `json:"id"`
-	ProjectID   uuid.UUID        `json:"projectId"`
-	InspectorID uuid.UUID        `json:"inspectorId"`
-	Status      InspectionStatus `json:"status"`
-	CreatedAt   time.Time        `json:"createdAt"`
-	UpdatedAt   time.Time        `json:"updatedAt"`
+	ID             uuid.UUID        `json:"id"`
+	ProjectID      uuid.UUID        `json:"projectId"`
+	InspectorID    uuid.UUID        `json:"inspectorId"`
+	Status         InspectionStatus `json:"status"`
+	Title          string           `json:"title"`
+	InspectionType string           `json:"inspectionType,omitempty"` // Free-form, such as "framing" or "final"
+	ScheduledAt    *time.Time       `json:"scheduledAt,omitempty"`
+	PerformedAt    *time.Time       `json:"performedAt,omitempty"`
+	Notes          string           `json:"notes,omitempty"`
+	CreatedAt      time.Time        `json:"createdAt"`
+	UpdatedAt      time.Time        `json:"updatedAt"`
+
+	// Site conditions
+	Weather      string   `json:"weather,omitempty"`
+	TemperatureF *float64 `json:"temperatureF,omitempty"` // Degrees Fahrenheit
+
+	Attendees []Attendee `json:"attendees"`
 
 	// Joined fields (populated by some queries)
-	Project   *Project `json:"project,omitempty"`
-	Inspector *User    `json:"inspector,omitempty"`
+	Project   *Project         `json:"project,omitempty"`
+	Inspector *User            `json:"inspector,omitempty"`
+	Report    *ReportStatus    `json:"report,omitempty"`
+	Stats     *InspectionStats `json:"stats,omitempty"`
+}
+
+// Date returns when the inspection was performed, or when it was created if
+// that was not recorded.
+func (i *Inspection) Date() time.Time {
+	if i.PerformedAt != nil {
+		return *i.PerformedAt
+	}
+	return i.CreatedAt
+}
+
+// Conditions describes the site conditions, such as "Overcast, 58°F", or
+// returns an empty string if none were recorded.
+func (i *Inspection) Conditions() string {
+	var parts []string
+	if i.Weather != "" {
+		parts = append(parts, i.Weather)
+	}
+	if i.TemperatureF != nil {
+		parts = append(parts, strconv.FormatFloat(*i.TemperatureF, 'f', -1, 64)+"°F")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Attendee is someone present at an inspection.
+type Attendee struct {
+	Name    string `json:"name"`
+	Company string `json:"company,omitempty"`
+	Role    string `json:"role,omitempty"`
+}
+
+// String returns the attendee's name followed by their role and company,
+// such as "Sam Lee (Superintendent, Acme Construction)".
+func (a Attendee) String() string {
+	var details []string
+	for _, s := range []string{a.Role, a.Company} {
+		if s != "" {
+			details = append(details, s)
+		}
+	}
+	if len(details) == 0 {
+		return a.Name
+	}
+	return a.Name + " (" + strings.Join(details, ", ") + ")"
 }
 
 // InspectionStatus represents the status of an inspection.
@@ -78,15 +137,39 @@ type InspectionService interface {
 	DeleteInspection(ctx context.Context, id uuid.UUID) error
 
 	// GetInspectionStats retrieves statistics for an inspection.
+	// Returns ENOTFOUND if the inspection does not exist.
 	GetInspectionStats(ctx context.Context, id uuid.UUID) (*InspectionStats, error)
+
+	// FindInspectionStats retrieves statistics for several inspections at
+	// once, keyed by inspection ID. Inspections that do not exist are left
+	// out.
+	FindInspectionStats(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*InspectionStats, error)
+
+	// FindSignoffs retrieves the sign-offs of an inspection, oldest first.
+	FindSignoffs(ctx context.Context, inspectionID uuid.UUID) ([]*Signoff, error)
+
+	// CreateSignoff records a sign-off on an inspection.
+	// Returns ECONFLICT if the inspection already has a sign-off in the role.
+	// Returns ENOTFOUND if the inspection does not exist.
+	CreateSignoff(ctx context.Context, signoff *Signoff) error
+
+	// DeleteSignoff deletes a sign-off so that it can be given again.
+	DeleteSignoff(ctx context.Context, id uuid.UUID) error
 }
 
-// InspectionFilter defines criteria for filtering inspections.
+// InspectionFilter defines criteria for filtering inspections. Time ranges
+// include their start and exclude their end.
 type InspectionFilter struct {
-	ID          *uuid.UUID
-	ProjectID   *uuid.UUID
-	InspectorID *uuid.UUID
-	Status      *InspectionStatus
+	ID             *uuid.UUID
+	ProjectID      *uuid.UUID
+	InspectorID    *uuid.UUID
+	Status         *InspectionStatus
+	InspectionType *string
+	ScheduledFrom  *time.Time
+	ScheduledTo    *time.Time
+	PerformedFrom  *time.Time
+	PerformedTo    *time.Time
+	Search         *string // Search in title and notes
 
 	// Pagination
 	Offset int
@@ -94,8 +177,25 @@ type InspectionFilter struct {
 }
 
 // InspectionUpdate defines fields that can be updated on an inspection.
+// Nil fields are left unchanged. The Clear fields remove an optional value
+// and take precedence over the field they clear.
 type InspectionUpdate struct {
-	Status *InspectionStatus
+	Status         *InspectionStatus
+	Title          *string
+	InspectionType *string
+	ScheduledAt    *time.Time
+	PerformedAt    *time.Time
+	Notes          *string
+	Weather        *string
+	TemperatureF   *float64
+	Attendees      *[]Attendee
+
+	ClearInspectionType bool
+	ClearScheduledAt    bool
+	ClearPerformedAt    bool
+	ClearNotes          bool
+	ClearWeather        bool
+	ClearTemperatureF   bool
 }
 
 // InspectionStats contains aggregated statistics for an inspection.
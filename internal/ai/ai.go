@@ -3,6 +3,9 @@ package ai
 import (
 	"context"
 	"log/slog"
+	"time"
+
+	"github.com/dukerupert/aletheia"
 )
 
 // AIService defines the interface for AI vision processing services
@@ -21,7 +24,38 @@ type AnalysisRequest struct {
 	// SafetyCodes is the list of safety codes to check against
 	SafetyCodes []SafetyCodeContext
 	// InspectionContext provides additional context about the inspection
-	InspectionContext string
+	InspectionContext *InspectionContext
+}
+
+// InspectionContext describes the inspection a photo was taken during, so
+// the AI can weigh what it sees against the site's circumstances
+type InspectionContext struct {
+	Title       string
+	Type        string // e.g., "framing", "pre-pour"
+	ProjectType string
+	Date        time.Time
+	Conditions  string // e.g., "Overcast, 58°F"
+	Attendees   []string
+	Notes       string
+}
+
+// NewInspectionContext builds the analysis context for an inspection of a
+// project
+func NewInspectionContext(inspection *aletheia.Inspection, project *aletheia.Project) *InspectionContext {
+	ic := &InspectionContext{
+		Title:      inspection.Title,
+		Type:       inspection.InspectionType,
+		Date:       inspection.Date(),
+		Conditions: inspection.Conditions(),
+		Notes:      inspection.Notes,
+	}
+	if project != nil {
+		ic.ProjectType = project.ProjectType
+	}
+	for _, attendee := range inspection.Attendees {
+		ic.Attendees = append(ic.Attendees, attendee.String())
+	}
+	return ic
 }
 
 // SafetyCodeContext provides context about a safety code for the AI
@@ -147,17 +147,45 @@ func (s *claudeService) buildSystemPrompt(safetyCodes []SafetyCodeContext) strin
 	return sb.String()
 }
 
+// maxPromptNotes caps how much of the inspector's notes goes into a prompt
+const maxPromptNotes = 2000
+
 // buildUserPrompt creates the user prompt with inspection context
-func (s *claudeService) buildUserPrompt(inspectionContext string) string {
-	prompt := "Please analyze this construction site photo for safety violations."
+func (s *claudeService) buildUserPrompt(inspectionContext *InspectionContext) string {
+	var sb strings.Builder
+	sb.WriteString("Please analyze this construction site photo for safety violations.")
 
-	if inspectionContext != "" {
-		prompt += fmt.Sprintf("\n\nInspection Context: %s", inspectionContext)
+	if ic := inspectionContext; ic != nil {
+		var details []string
+		add := func(label, value string) {
+			if value = strings.TrimSpace(value); value != "" {
+				details = append(details, fmt.Sprintf("- %s: %s", label, value))
+			}
+		}
+		add("Inspection", ic.Title)
+		add("Inspection type", ic.Type)
+		add("Project type", ic.ProjectType)
+		if !ic.Date.IsZero() {
+			add("Date", ic.Date.Format("January 2, 2006"))
+		}
+		add("Site conditions", ic.Conditions)
+		add("Attendees", strings.Join(ic.Attendees, "; "))
+		notes := []rune(strings.TrimSpace(ic.Notes))
+		if len(notes) > maxPromptNotes {
+			notes = append(notes[:maxPromptNotes], '…')
+		}
+		add("Inspector notes", string(notes))
+
+		if len(details) > 0 {
+			sb.WriteString("\n\nInspection Context:\n")
+			sb.WriteString(strings.Join(details, "\n"))
+			sb.WriteString("\n\nTake the site conditions into account, for example wet or icy surfaces in bad weather, and pay particular attention to hazards relevant to this type of inspection.")
+		}
 	}
 
-	prompt += "\n\nRespond with a JSON array of violations as specified in the system instructions."
+	sb.WriteString("\n\nRespond with a JSON array of violations as specified in the system instructions.")
 
-	return prompt
+	return sb.String()
 }
 
 // parseClaudeResponse parses Claude's response into DetectedViolation structs
@@ -0,0 +1,60 @@
+package ai
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildUserPrompt_InspectionContext(t *testing.T) {
+	performed := time.Date(2026, time.March, 4, 9, 30, 0, 0, time.UTC)
+	temperature := 28.5
+	inspection := &aletheia.Inspection{
+		Title:          "Level 2 framing",
+		InspectionType: "framing",
+		PerformedAt:    &performed,
+		Notes:          "Deck edge still open on the north side",
+		Weather:        "Light snow",
+		TemperatureF:   &temperature,
+		Attendees: []aletheia.Attendee{
+			{Name: "Dana Ortiz", Company: "Ortiz Framing", Role: "Foreman"},
+			{Name: "Sam Lee"},
+		},
+	}
+	project := &aletheia.Project{ProjectType: "Commercial"}
+
+	s := &claudeService{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	prompt := s.buildUserPrompt(NewInspectionContext(inspection, project))
+
+	for _, want := range []string{
+		"Inspection: Level 2 framing",
+		"Inspection type: framing",
+		"Project type: Commercial",
+		"Date: March 4, 2026",
+		"Site conditions: Light snow, 28.5°F",
+		"Attendees: Dana Ortiz (Foreman, Ortiz Framing); Sam Lee",
+		"Inspector notes: Deck edge still open on the north side",
+	} {
+		assert.Contains(t, prompt, want)
+	}
+}
+
+func TestBuildUserPrompt_NoContext(t *testing.T) {
+	s := &claudeService{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	assert.NotContains(t, s.buildUserPrompt(nil), "Inspection Context")
+	assert.NotContains(t, s.buildUserPrompt(&InspectionContext{}), "Inspection Context")
+}
+
+func TestBuildUserPrompt_LongNotes(t *testing.T) {
+	s := &claudeService{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	prompt := s.buildUserPrompt(&InspectionContext{Notes: strings.Repeat("é", maxPromptNotes+10)})
+
+	assert.Contains(t, prompt, strings.Repeat("é", maxPromptNotes)+"…")
+	assert.NotContains(t, prompt, strings.Repeat("é", maxPromptNotes+1))
+}
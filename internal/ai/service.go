@@ -0,0 +1,95 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+)
+
+// Compile-time interface check
+var _ aletheia.AIService = (*Service)(nil)
+
+// Service analyzes photos for the application, reading them from storage and
+// describing the inspection they were taken during to the AI
+type Service struct {
+	ai      AIService
+	storage aletheia.FileStorage
+}
+
+// NewService creates a Service backed by the configured provider
+func NewService(logger *slog.Logger, config AIConfig, storage aletheia.FileStorage) *Service {
+	return &Service{ai: NewAIService(logger, config), storage: storage}
+}
+
+// AnalyzePhoto analyzes a stored photo for safety violations
+func (s *Service) AnalyzePhoto(ctx context.Context, req aletheia.AnalysisRequest) (*aletheia.AnalysisResult, error) {
+	image, err := s.readPhoto(ctx, req.PhotoURL)
+	if err != nil {
+		return nil, err
+	}
+
+	request := AnalysisRequest{ImageData: image}
+	for _, code := range req.SafetyCodes {
+		request.SafetyCodes = append(request.SafetyCodes, SafetyCodeContext{
+			Code:        code.Code,
+			Description: code.Description,
+			Country:     code.Country,
+		})
+	}
+	if req.Inspection != nil {
+		request.InspectionContext = NewInspectionContext(req.Inspection, req.Project)
+	}
+
+	start := time.Now()
+	response, err := s.ai.AnalyzePhoto(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &aletheia.AnalysisResult{
+		Violations:     make([]aletheia.DetectedViolation, 0, len(response.Violations)),
+		Summary:        response.AnalysisDetails,
+		AnalysisTimeMs: time.Since(start).Milliseconds(),
+	}
+	for _, v := range response.Violations {
+		detected := aletheia.DetectedViolation{
+			SafetyCode:  v.SafetyCode,
+			Description: v.Description,
+			Severity:    aletheia.Severity(v.Severity),
+			Confidence:  v.Confidence,
+			Location:    v.Location,
+		}
+		// The AI matches cited regulations to the codes it was given by code
+		for _, code := range req.SafetyCodes {
+			if v.SafetyCodeID != "" && code.Code == v.SafetyCodeID {
+				detected.SafetyCodeID = code.ID
+				break
+			}
+		}
+		result.Violations = append(result.Violations, detected)
+	}
+	return result, nil
+}
+
+// readPhoto reads a photo from storage
+func (s *Service) readPhoto(ctx context.Context, url string) ([]byte, error) {
+	key, ok := aletheia.StorageKey(s.storage, url)
+	if !ok {
+		return nil, aletheia.Invalid("Photo is not in storage")
+	}
+	r, err := s.storage.Open(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading photo: %w", err)
+	}
+	return data, nil
+}
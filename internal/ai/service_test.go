@@ -0,0 +1,72 @@
+package ai
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingAIService records the request it was given.
+type recordingAIService struct {
+	request  AnalysisRequest
+	response *AnalysisResponse
+}
+
+func (s *recordingAIService) AnalyzePhoto(ctx context.Context, request AnalysisRequest) (*AnalysisResponse, error) {
+	s.request = request
+	return s.response, nil
+}
+
+func TestService_AnalyzePhoto(t *testing.T) {
+	storage := &mock.FileStorage{
+		OpenFn: func(ctx context.Context, key string) (io.ReadCloser, error) {
+			require.Equal(t, "photos/a.jpg", key)
+			return io.NopCloser(strings.NewReader("jpeg bytes")), nil
+		},
+	}
+	inner := &recordingAIService{response: &AnalysisResponse{
+		Violations: []DetectedViolation{
+			{SafetyCodeID: "OSHA 1926.501", SafetyCode: "OSHA 1926.501(b)(1)", Description: "Open deck edge", Severity: SeverityCritical, Confidence: 0.9},
+			{SafetyCode: "OSHA 1926.451", Description: "Missing scaffold plank", Severity: SeverityMedium, Confidence: 0.7},
+		},
+		AnalysisDetails: "2 potential violations",
+	}}
+	s := &Service{ai: inner, storage: storage}
+
+	code := &aletheia.SafetyCode{ID: uuid.New(), Code: "OSHA 1926.501", Description: "Fall protection", Country: "US"}
+	inspection := &aletheia.Inspection{Title: "Level 2 framing", InspectionType: "framing", Weather: "Light snow"}
+	project := &aletheia.Project{ProjectType: "Commercial"}
+
+	result, err := s.AnalyzePhoto(context.Background(), aletheia.AnalysisRequest{
+		PhotoURL:    storage.GetURL("photos/a.jpg"),
+		SafetyCodes: []*aletheia.SafetyCode{code},
+		Inspection:  inspection,
+		Project:     project,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte("jpeg bytes"), inner.request.ImageData)
+	assert.Equal(t, []SafetyCodeContext{{Code: "OSHA 1926.501", Description: "Fall protection", Country: "US"}}, inner.request.SafetyCodes)
+	assert.Equal(t, NewInspectionContext(inspection, project), inner.request.InspectionContext)
+
+	assert.Equal(t, "2 potential violations", result.Summary)
+	require.Len(t, result.Violations, 2)
+	assert.Equal(t, code.ID, result.Violations[0].SafetyCodeID)
+	assert.Equal(t, aletheia.SeverityCritical, result.Violations[0].Severity)
+	assert.Equal(t, uuid.Nil, result.Violations[1].SafetyCodeID)
+	assert.Equal(t, "OSHA 1926.451", result.Violations[1].SafetyCode)
+}
+
+func TestService_AnalyzePhoto_NotInStorage(t *testing.T) {
+	s := &Service{ai: &recordingAIService{}, storage: &mock.FileStorage{}}
+
+	_, err := s.AnalyzePhoto(context.Background(), aletheia.AnalysisRequest{PhotoURL: "https://elsewhere.example.com/a.jpg"})
+	assert.True(t, aletheia.IsErrorCode(err, aletheia.EINVALID))
+}
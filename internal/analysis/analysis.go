@@ -0,0 +1,124 @@
+// Package analysis analyzes inspection photos for safety violations in the
+// background.
+package analysis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/queue"
+	"github.com/google/uuid"
+)
+
+// Analyzer runs photos through the AI service and records the violations it
+// detects as pending, for an inspector to confirm or dismiss.
+type Analyzer struct {
+	Photos      aletheia.PhotoService
+	Inspections aletheia.InspectionService
+	Projects    aletheia.ProjectService
+	SafetyCodes aletheia.SafetyCodeService
+	Violations  aletheia.ViolationService
+	AI          aletheia.AIService
+	Logger      *slog.Logger
+}
+
+// Register registers the photo analysis handler.
+func (a *Analyzer) Register(pool *queue.WorkerPool) {
+	pool.RegisterHandler(aletheia.JobTypePhotoAnalysis, aletheia.JobHandlerFunc(a.handle))
+}
+
+func (a *Analyzer) handle(ctx context.Context, job *aletheia.Job) error {
+	var payload aletheia.PhotoAnalysisPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return aletheia.Permanent(fmt.Errorf("decoding payload: %w", err))
+	}
+
+	violations, err := a.Analyze(ctx, payload.PhotoID)
+	if err != nil {
+		// The photo was deleted after the job was queued
+		if aletheia.IsErrorCode(err, aletheia.ENOTFOUND) {
+			return aletheia.Permanent(err)
+		}
+		return err
+	}
+
+	a.Logger.Info("photo analyzed",
+		slog.String("photo_id", payload.PhotoID.String()),
+		slog.Int("violations", len(violations)))
+	return nil
+}
+
+// Analyze analyzes a photo against every safety code and records the
+// detected violations as pending. Progress is reported to the job running
+// it, if any.
+func (a *Analyzer) Analyze(ctx context.Context, photoID uuid.UUID) ([]*aletheia.Violation, error) {
+	aletheia.ReportProgress(ctx, 5, "Loading photo")
+	photo, err := a.Photos.FindPhotoByID(ctx, photoID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The inspection and its project give the analysis its context
+	inspection, err := a.Inspections.FindInspectionByID(ctx, photo.InspectionID)
+	if err != nil {
+		return nil, err
+	}
+	project, err := a.Projects.FindProjectByID(ctx, inspection.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := a.SafetyCodes.GetAllSafetyCodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	aletheia.ReportProgress(ctx, 20, "Analyzing photo")
+	result, err := a.AI.AnalyzePhoto(ctx, aletheia.AnalysisRequest{
+		PhotoURL:    photo.StorageURL,
+		SafetyCodes: codes,
+		Inspection:  inspection,
+		Project:     project,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("analyzing photo: %w", err)
+	}
+
+	aletheia.ReportProgress(ctx, 90, "Recording violations")
+	violations := make([]*aletheia.Violation, 0, len(result.Violations))
+	for _, detected := range result.Violations {
+		violations = append(violations, &aletheia.Violation{
+			PhotoID:         photo.ID,
+			SafetyCodeID:    safetyCodeID(detected, codes),
+			Description:     detected.Description,
+			Severity:        detected.Severity,
+			Status:          aletheia.ViolationStatusPending,
+			ConfidenceScore: detected.Confidence,
+			Location:        detected.Location,
+		})
+	}
+	if len(violations) > 0 {
+		if err := a.Violations.CreateViolations(ctx, violations); err != nil {
+			return nil, err
+		}
+	}
+
+	return violations, nil
+}
+
+// safetyCodeID returns the ID of the safety code a violation was detected
+// against, matching it by code if the AI service only cited it.
+func safetyCodeID(detected aletheia.DetectedViolation, codes []*aletheia.SafetyCode) uuid.UUID {
+	if detected.SafetyCodeID != uuid.Nil {
+		return detected.SafetyCodeID
+	}
+	for _, code := range codes {
+		if code.Code == detected.SafetyCode {
+			return code.ID
+		}
+	}
+	return uuid.Nil
+}
@@ -0,0 +1,119 @@
+package analysis
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testAnalyzer returns an analyzer for a single photo whose AI service
+// detects violations, and the violations it records.
+func testAnalyzer(t *testing.T, photo *aletheia.Photo, detected []aletheia.DetectedViolation) (*Analyzer, *[]*aletheia.Violation) {
+	t.Helper()
+
+	codes := []*aletheia.SafetyCode{
+		{ID: uuid.New(), Code: "OSHA 1926.501", Description: "Fall protection"},
+		{ID: uuid.New(), Code: "OSHA 1926.100", Description: "Head protection"},
+	}
+
+	inspection := &aletheia.Inspection{ID: photo.InspectionID, ProjectID: uuid.New(), Title: "Level 2 framing"}
+	project := &aletheia.Project{ID: inspection.ProjectID, ProjectType: "Commercial"}
+
+	var recorded []*aletheia.Violation
+	a := &Analyzer{
+		Photos: &mock.PhotoService{
+			FindPhotoByIDFn: func(ctx context.Context, id uuid.UUID) (*aletheia.Photo, error) {
+				if id != photo.ID {
+					return nil, aletheia.NotFound("Photo not found")
+				}
+				return photo, nil
+			},
+		},
+		Inspections: &mock.InspectionService{
+			FindInspectionByIDFn: func(ctx context.Context, id uuid.UUID) (*aletheia.Inspection, error) {
+				require.Equal(t, inspection.ID, id)
+				return inspection, nil
+			},
+		},
+		Projects: &mock.ProjectService{
+			FindProjectByIDFn: func(ctx context.Context, id uuid.UUID) (*aletheia.Project, error) {
+				require.Equal(t, project.ID, id)
+				return project, nil
+			},
+		},
+		SafetyCodes: &mock.SafetyCodeService{
+			GetAllSafetyCodesFn: func(ctx context.Context) ([]*aletheia.SafetyCode, error) {
+				return codes, nil
+			},
+		},
+		Violations: &mock.ViolationService{
+			CreateViolationsFn: func(ctx context.Context, violations []*aletheia.Violation) error {
+				recorded = append(recorded, violations...)
+				return nil
+			},
+		},
+		AI: &mock.AIService{
+			AnalyzePhotoFn: func(ctx context.Context, req aletheia.AnalysisRequest) (*aletheia.AnalysisResult, error) {
+				assert.Equal(t, photo.StorageURL, req.PhotoURL)
+				assert.Equal(t, codes, req.SafetyCodes)
+				assert.Same(t, inspection, req.Inspection)
+				assert.Same(t, project, req.Project)
+				return &aletheia.AnalysisResult{Violations: detected}, nil
+			},
+		},
+		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	return a, &recorded
+}
+
+func TestAnalyzer_Handle(t *testing.T) {
+	photo := &aletheia.Photo{ID: uuid.New(), InspectionID: uuid.New(), StorageURL: "https://storage.example.com/photos/a.jpg"}
+	a, recorded := testAnalyzer(t, photo, []aletheia.DetectedViolation{
+		{SafetyCode: "OSHA 1926.501", Description: "No guardrail", Severity: aletheia.SeverityCritical, Confidence: 0.9, Location: "Upper left"},
+		{SafetyCode: "Unknown", Description: "Loose cable", Severity: aletheia.SeverityLow, Confidence: 0.6},
+	})
+	codes, err := a.SafetyCodes.GetAllSafetyCodes(context.Background())
+	require.NoError(t, err)
+
+	payload, err := json.Marshal(aletheia.PhotoAnalysisPayload{PhotoID: photo.ID})
+	require.NoError(t, err)
+	require.NoError(t, a.handle(context.Background(), &aletheia.Job{Payload: payload}))
+
+	require.Len(t, *recorded, 2)
+	first := (*recorded)[0]
+	assert.Equal(t, photo.ID, first.PhotoID)
+	assert.Equal(t, codes[0].ID, first.SafetyCodeID, "cited codes are matched")
+	assert.Equal(t, aletheia.ViolationStatusPending, first.Status)
+	assert.Equal(t, aletheia.SeverityCritical, first.Severity)
+	assert.Equal(t, 0.9, first.ConfidenceScore)
+	assert.Equal(t, "Upper left", first.Location)
+	assert.Equal(t, uuid.Nil, (*recorded)[1].SafetyCodeID)
+}
+
+func TestAnalyzer_Handle_NoViolations(t *testing.T) {
+	photo := &aletheia.Photo{ID: uuid.New(), InspectionID: uuid.New()}
+	a, recorded := testAnalyzer(t, photo, nil)
+
+	payload, err := json.Marshal(aletheia.PhotoAnalysisPayload{PhotoID: photo.ID})
+	require.NoError(t, err)
+	require.NoError(t, a.handle(context.Background(), &aletheia.Job{Payload: payload}))
+	assert.Empty(t, *recorded)
+}
+
+func TestAnalyzer_Handle_PhotoDeleted(t *testing.T) {
+	a, _ := testAnalyzer(t, &aletheia.Photo{ID: uuid.New(), InspectionID: uuid.New()}, nil)
+
+	payload, err := json.Marshal(aletheia.PhotoAnalysisPayload{PhotoID: uuid.New()})
+	require.NoError(t, err)
+	err = a.handle(context.Background(), &aletheia.Job{Payload: payload})
+
+	assert.True(t, aletheia.IsPermanent(err), "a deleted photo is not retried")
+}
@@ -36,7 +36,7 @@ INSERT INTO detected_violations (
 ) VALUES (
   $1, $2, $3, $4, $5, $6, $7
 )
-RETURNING id, photo_id, description, confidence_score, status, created_at, safety_code_id, severity, location
+RETURNING id, photo_id, description, confidence_score, status, created_at, safety_code_id, severity, location, resolved_at
 `
 
 type CreateDetectedViolationParams struct {
@@ -70,6 +70,7 @@ func (q *Queries) CreateDetectedViolation(ctx context.Context, arg CreateDetecte
 		&i.SafetyCodeID,
 		&i.Severity,
 		&i.Location,
+		&i.ResolvedAt,
 	)
 	return i, err
 }
@@ -105,7 +106,7 @@ func (q *Queries) DeletePendingViolationsByPhoto(ctx context.Context, photoID pg
 }
 
 const getDetectedViolation = `-- name: GetDetectedViolation :one
-SELECT id, photo_id, description, confidence_score, status, created_at, safety_code_id, severity, location FROM detected_violations
+SELECT id, photo_id, description, confidence_score, status, created_at, safety_code_id, severity, location, resolved_at FROM detected_violations
 WHERE id = $1 LIMIT 1
 `
 
@@ -122,6 +123,7 @@ func (q *Queries) GetDetectedViolation(ctx context.Context, id pgtype.UUID) (Det
 		&i.SafetyCodeID,
 		&i.Severity,
 		&i.Location,
+		&i.ResolvedAt,
 	)
 	return i, err
 }
@@ -195,7 +197,7 @@ func (q *Queries) GetViolationCountBySeverityAndOrganization(ctx context.Context
 }
 
 const listDetectedViolations = `-- name: ListDetectedViolations :many
-SELECT id, photo_id, description, confidence_score, status, created_at, safety_code_id, severity, location FROM detected_violations
+SELECT id, photo_id, description, confidence_score, status, created_at, safety_code_id, severity, location, resolved_at FROM detected_violations
 WHERE photo_id = $1
 ORDER BY created_at DESC
 `
@@ -219,6 +221,7 @@ func (q *Queries) ListDetectedViolations(ctx context.Context, photoID pgtype.UUI
 			&i.SafetyCodeID,
 			&i.Severity,
 			&i.Location,
+			&i.ResolvedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -231,7 +234,7 @@ func (q *Queries) ListDetectedViolations(ctx context.Context, photoID pgtype.UUI
 }
 
 const listDetectedViolationsByInspection = `-- name: ListDetectedViolationsByInspection :many
-SELECT dv.id, dv.photo_id, dv.description, dv.confidence_score, dv.status, dv.created_at, dv.safety_code_id, dv.severity, dv.location FROM detected_violations dv
+SELECT dv.id, dv.photo_id, dv.description, dv.confidence_score, dv.status, dv.created_at, dv.safety_code_id, dv.severity, dv.location, dv.resolved_at FROM detected_violations dv
 JOIN photos p ON dv.photo_id = p.id
 WHERE p.inspection_id = $1
 ORDER BY dv.created_at DESC
@@ -256,6 +259,7 @@ func (q *Queries) ListDetectedViolationsByInspection(ctx context.Context, inspec
 			&i.SafetyCodeID,
 			&i.Severity,
 			&i.Location,
+			&i.ResolvedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -268,7 +272,7 @@ func (q *Queries) ListDetectedViolationsByInspection(ctx context.Context, inspec
 }
 
 const listDetectedViolationsByInspectionAndStatus = `-- name: ListDetectedViolationsByInspectionAndStatus :many
-SELECT dv.id, dv.photo_id, dv.description, dv.confidence_score, dv.status, dv.created_at, dv.safety_code_id, dv.severity, dv.location FROM detected_violations dv
+SELECT dv.id, dv.photo_id, dv.description, dv.confidence_score, dv.status, dv.created_at, dv.safety_code_id, dv.severity, dv.location, dv.resolved_at FROM detected_violations dv
 JOIN photos p ON dv.photo_id = p.id
 WHERE p.inspection_id = $1 AND dv.status = $2
 ORDER BY dv.created_at DESC
@@ -298,6 +302,7 @@ func (q *Queries) ListDetectedViolationsByInspectionAndStatus(ctx context.Contex
 			&i.SafetyCodeID,
 			&i.Severity,
 			&i.Location,
+			&i.ResolvedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -310,7 +315,7 @@ func (q *Queries) ListDetectedViolationsByInspectionAndStatus(ctx context.Contex
 }
 
 const listDetectedViolationsByStatus = `-- name: ListDetectedViolationsByStatus :many
-SELECT id, photo_id, description, confidence_score, status, created_at, safety_code_id, severity, location FROM detected_violations
+SELECT id, photo_id, description, confidence_score, status, created_at, safety_code_id, severity, location, resolved_at FROM detected_violations
 WHERE photo_id = $1 AND status = $2
 ORDER BY created_at DESC
 `
@@ -339,6 +344,116 @@ func (q *Queries) ListDetectedViolationsByStatus(ctx context.Context, arg ListDe
 			&i.SafetyCodeID,
 			&i.Severity,
 			&i.Location,
+			&i.ResolvedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listViolationsForExport = `-- name: ListViolationsForExport :many
+SELECT
+  dv.id,
+  dv.description,
+  dv.severity,
+  dv.status,
+  dv.location,
+  dv.created_at,
+  dv.resolved_at,
+  ph.id AS photo_id,
+  ph.storage_url AS photo_url,
+  i.id AS inspection_id,
+  i.status AS inspection_status,
+  COALESCE(i.performed_at, i.created_at)::timestamptz AS inspection_date,
+  p.id AS project_id,
+  p.name AS project_name,
+  sc.code AS safety_code,
+  sc.description AS safety_code_description
+FROM detected_violations dv
+JOIN photos ph ON ph.id = dv.photo_id
+JOIN inspections i ON i.id = ph.inspection_id
+JOIN projects p ON p.id = i.project_id
+LEFT JOIN safety_codes sc ON sc.id = dv.safety_code_id
+WHERE p.organization_id = $1
+  AND ($2::uuid IS NULL OR p.id = $2::uuid)
+  AND ($3::violation_status IS NULL OR dv.status = $3::violation_status)
+  AND ($4::timestamptz IS NULL OR dv.created_at >= $4::timestamptz)
+  AND ($5::timestamptz IS NULL OR dv.created_at < $5::timestamptz)
+  AND (dv.created_at, dv.id) > ($6::timestamptz, $7::uuid)
+ORDER BY dv.created_at, dv.id
+LIMIT $8
+`
+
+type ListViolationsForExportParams struct {
+	OrganizationID pgtype.UUID         `json:"organization_id"`
+	ProjectID      pgtype.UUID         `json:"project_id"`
+	Status         NullViolationStatus `json:"status"`
+	CreatedFrom    pgtype.Timestamptz  `json:"created_from"`
+	CreatedTo      pgtype.Timestamptz  `json:"created_to"`
+	AfterCreatedAt pgtype.Timestamptz  `json:"after_created_at"`
+	AfterID        pgtype.UUID         `json:"after_id"`
+	BatchSize      int32               `json:"batch_size"`
+}
+
+type ListViolationsForExportRow struct {
+	ID                    pgtype.UUID        `json:"id"`
+	Description           string             `json:"description"`
+	Severity              ViolationSeverity  `json:"severity"`
+	Status                ViolationStatus    `json:"status"`
+	Location              pgtype.Text        `json:"location"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	ResolvedAt            pgtype.Timestamptz `json:"resolved_at"`
+	PhotoID               pgtype.UUID        `json:"photo_id"`
+	PhotoUrl              string             `json:"photo_url"`
+	InspectionID          pgtype.UUID        `json:"inspection_id"`
+	InspectionStatus      InspectionStatus   `json:"inspection_status"`
+	InspectionDate        pgtype.Timestamptz `json:"inspection_date"`
+	ProjectID             pgtype.UUID        `json:"project_id"`
+	ProjectName           string             `json:"project_name"`
+	SafetyCode            pgtype.Text        `json:"safety_code"`
+	SafetyCodeDescription pgtype.Text        `json:"safety_code_description"`
+}
+
+func (q *Queries) ListViolationsForExport(ctx context.Context, arg ListViolationsForExportParams) ([]ListViolationsForExportRow, error) {
+	rows, err := q.db.Query(ctx, listViolationsForExport,
+		arg.OrganizationID,
+		arg.ProjectID,
+		arg.Status,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.BatchSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListViolationsForExportRow{}
+	for rows.Next() {
+		var i ListViolationsForExportRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Description,
+			&i.Severity,
+			&i.Status,
+			&i.Location,
+			&i.CreatedAt,
+			&i.ResolvedAt,
+			&i.PhotoID,
+			&i.PhotoUrl,
+			&i.InspectionID,
+			&i.InspectionStatus,
+			&i.InspectionDate,
+			&i.ProjectID,
+			&i.ProjectName,
+			&i.SafetyCode,
+			&i.SafetyCodeDescription,
 		); err != nil {
 			return nil, err
 		}
@@ -354,9 +469,14 @@ const updateDetectedViolationNotes = `-- name: UpdateDetectedViolationNotes :one
 UPDATE detected_violations
 SET
   status = COALESCE($2, status),
+  resolved_at = CASE
+    WHEN $2 IS NULL THEN resolved_at
+    WHEN $2 = 'pending' THEN NULL
+    ELSE COALESCE(resolved_at, CURRENT_TIMESTAMP)
+  END,
   description = COALESCE($3, description)
 WHERE id = $1
-RETURNING id, photo_id, description, confidence_score, status, created_at, safety_code_id, severity, location
+RETURNING id, photo_id, description, confidence_score, status, created_at, safety_code_id, severity, location, resolved_at
 `
 
 type UpdateDetectedViolationNotesParams struct {
@@ -378,6 +498,7 @@ func (q *Queries) UpdateDetectedViolationNotes(ctx context.Context, arg UpdateDe
 		&i.SafetyCodeID,
 		&i.Severity,
 		&i.Location,
+		&i.ResolvedAt,
 	)
 	return i, err
 }
@@ -386,7 +507,7 @@ const updateDetectedViolationSafetyCode = `-- name: UpdateDetectedViolationSafet
 UPDATE detected_violations
 SET safety_code_id = $2
 WHERE id = $1
-RETURNING id, photo_id, description, confidence_score, status, created_at, safety_code_id, severity, location
+RETURNING id, photo_id, description, confidence_score, status, created_at, safety_code_id, severity, location, resolved_at
 `
 
 type UpdateDetectedViolationSafetyCodeParams struct {
@@ -407,15 +528,21 @@ func (q *Queries) UpdateDetectedViolationSafetyCode(ctx context.Context, arg Upd
 		&i.SafetyCodeID,
 		&i.Severity,
 		&i.Location,
+		&i.ResolvedAt,
 	)
 	return i, err
 }
 
 const updateDetectedViolationStatus = `-- name: UpdateDetectedViolationStatus :one
 UPDATE detected_violations
-SET status = $2
+SET
+  status = $2,
+  resolved_at = CASE
+    WHEN $2 = 'pending' THEN NULL
+    ELSE COALESCE(resolved_at, CURRENT_TIMESTAMP)
+  END
 WHERE id = $1
-RETURNING id, photo_id, description, confidence_score, status, created_at, safety_code_id, severity, location
+RETURNING id, photo_id, description, confidence_score, status, created_at, safety_code_id, severity, location, resolved_at
 `
 
 type UpdateDetectedViolationStatusParams struct {
@@ -436,6 +563,7 @@ func (q *Queries) UpdateDetectedViolationStatus(ctx context.Context, arg UpdateD
 		&i.SafetyCodeID,
 		&i.Severity,
 		&i.Location,
+		&i.ResolvedAt,
 	)
 	return i, err
 }
@@ -15,21 +15,49 @@ const createInspection = `-- name: CreateInspection :one
 INSERT INTO inspections (
   project_id,
   inspector_id,
-  status
+  status,
+  title,
+  inspection_type,
+  scheduled_at,
+  performed_at,
+  notes,
+  weather,
+  temperature_f,
+  attendees
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
 )
-RETURNING id, project_id, inspector_id, status, created_at, updated_at
+RETURNING id, project_id, inspector_id, status, created_at, updated_at, title, inspection_type, scheduled_at, performed_at, notes, weather, temperature_f, attendees
 `
 
 type CreateInspectionParams struct {
-	ProjectID   pgtype.UUID      `json:"project_id"`
-	InspectorID pgtype.UUID      `json:"inspector_id"`
-	Status      InspectionStatus `json:"status"`
+	ProjectID      pgtype.UUID        `json:"project_id"`
+	InspectorID    pgtype.UUID        `json:"inspector_id"`
+	Status         InspectionStatus   `json:"status"`
+	Title          string             `json:"title"`
+	InspectionType pgtype.Text        `json:"inspection_type"`
+	ScheduledAt    pgtype.Timestamptz `json:"scheduled_at"`
+	PerformedAt    pgtype.Timestamptz `json:"performed_at"`
+	Notes          pgtype.Text        `json:"notes"`
+	Weather        pgtype.Text        `json:"weather"`
+	TemperatureF   pgtype.Float8      `json:"temperature_f"`
+	Attendees      []byte             `json:"attendees"`
 }
 
 func (q *Queries) CreateInspection(ctx context.Context, arg CreateInspectionParams) (Inspection, error) {
-	row := q.db.QueryRow(ctx, createInspection, arg.ProjectID, arg.InspectorID, arg.Status)
+	row := q.db.QueryRow(ctx, createInspection,
+		arg.ProjectID,
+		arg.InspectorID,
+		arg.Status,
+		arg.Title,
+		arg.InspectionType,
+		arg.ScheduledAt,
+		arg.PerformedAt,
+		arg.Notes,
+		arg.Weather,
+		arg.TemperatureF,
+		arg.Attendees,
+	)
 	var i Inspection
 	err := row.Scan(
 		&i.ID,
@@ -38,6 +66,14 @@ func (q *Queries) CreateInspection(ctx context.Context, arg CreateInspectionPara
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Title,
+		&i.InspectionType,
+		&i.ScheduledAt,
+		&i.PerformedAt,
+		&i.Notes,
+		&i.Weather,
+		&i.TemperatureF,
+		&i.Attendees,
 	)
 	return i, err
 }
@@ -53,7 +89,7 @@ func (q *Queries) DeleteInspection(ctx context.Context, id pgtype.UUID) error {
 }
 
 const getInspection = `-- name: GetInspection :one
-SELECT id, project_id, inspector_id, status, created_at, updated_at FROM inspections
+SELECT id, project_id, inspector_id, status, created_at, updated_at, title, inspection_type, scheduled_at, performed_at, notes, weather, temperature_f, attendees FROM inspections
 WHERE id = $1 LIMIT 1
 `
 
@@ -67,6 +103,14 @@ func (q *Queries) GetInspection(ctx context.Context, id pgtype.UUID) (Inspection
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Title,
+		&i.InspectionType,
+		&i.ScheduledAt,
+		&i.PerformedAt,
+		&i.Notes,
+		&i.Weather,
+		&i.TemperatureF,
+		&i.Attendees,
 	)
 	return i, err
 }
@@ -93,8 +137,36 @@ func (q *Queries) GetInspectionCountByOrganizationAndDateRange(ctx context.Conte
 	return count, err
 }
 
+const getInspectionForUpdate = `-- name: GetInspectionForUpdate :one
+SELECT id, project_id, inspector_id, status, created_at, updated_at, title, inspection_type, scheduled_at, performed_at, notes, weather, temperature_f, attendees FROM inspections
+WHERE id = $1 LIMIT 1
+FOR UPDATE
+`
+
+func (q *Queries) GetInspectionForUpdate(ctx context.Context, id pgtype.UUID) (Inspection, error) {
+	row := q.db.QueryRow(ctx, getInspectionForUpdate, id)
+	var i Inspection
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.InspectorID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Title,
+		&i.InspectionType,
+		&i.ScheduledAt,
+		&i.PerformedAt,
+		&i.Notes,
+		&i.Weather,
+		&i.TemperatureF,
+		&i.Attendees,
+	)
+	return i, err
+}
+
 const getRecentInspectionsByOrganization = `-- name: GetRecentInspectionsByOrganization :many
-SELECT i.id, i.project_id, i.inspector_id, i.status, i.created_at, i.updated_at, p.name as project_name, p.organization_id
+SELECT i.id, i.project_id, i.inspector_id, i.status, i.created_at, i.updated_at, i.title, i.inspection_type, i.scheduled_at, i.performed_at, i.notes, i.weather, i.temperature_f, i.attendees, p.name as project_name, p.organization_id
 FROM inspections i
 JOIN projects p ON p.id = i.project_id
 WHERE p.organization_id = $1
@@ -114,6 +186,14 @@ type GetRecentInspectionsByOrganizationRow struct {
 	Status         InspectionStatus   `json:"status"`
 	CreatedAt      pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	Title          string             `json:"title"`
+	InspectionType pgtype.Text        `json:"inspection_type"`
+	ScheduledAt    pgtype.Timestamptz `json:"scheduled_at"`
+	PerformedAt    pgtype.Timestamptz `json:"performed_at"`
+	Notes          pgtype.Text        `json:"notes"`
+	Weather        pgtype.Text        `json:"weather"`
+	TemperatureF   pgtype.Float8      `json:"temperature_f"`
+	Attendees      []byte             `json:"attendees"`
 	ProjectName    string             `json:"project_name"`
 	OrganizationID pgtype.UUID        `json:"organization_id"`
 }
@@ -134,6 +214,14 @@ func (q *Queries) GetRecentInspectionsByOrganization(ctx context.Context, arg Ge
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Title,
+			&i.InspectionType,
+			&i.ScheduledAt,
+			&i.PerformedAt,
+			&i.Notes,
+			&i.Weather,
+			&i.TemperatureF,
+			&i.Attendees,
 			&i.ProjectName,
 			&i.OrganizationID,
 		); err != nil {
@@ -147,28 +235,58 @@ func (q *Queries) GetRecentInspectionsByOrganization(ctx context.Context, arg Ge
 	return items, nil
 }
 
-const listInspections = `-- name: ListInspections :many
-SELECT id, project_id, inspector_id, status, created_at, updated_at FROM inspections
-WHERE project_id = $1
-ORDER BY created_at DESC
+const listInspectionStats = `-- name: ListInspectionStats :many
+SELECT
+  i.id AS inspection_id,
+  COUNT(DISTINCT p.id)::int AS photo_count,
+  COUNT(dv.id)::int AS violation_count,
+  COUNT(dv.id) FILTER (WHERE dv.status = 'pending')::int AS pending_count,
+  COUNT(dv.id) FILTER (WHERE dv.status = 'confirmed')::int AS confirmed_count,
+  COUNT(dv.id) FILTER (WHERE dv.status = 'dismissed')::int AS dismissed_count,
+  COUNT(dv.id) FILTER (WHERE dv.severity = 'critical')::int AS critical_count,
+  COUNT(dv.id) FILTER (WHERE dv.severity = 'high')::int AS high_count,
+  COUNT(dv.id) FILTER (WHERE dv.severity = 'medium')::int AS medium_count,
+  COUNT(dv.id) FILTER (WHERE dv.severity = 'low')::int AS low_count
+FROM inspections i
+LEFT JOIN photos p ON p.inspection_id = i.id
+LEFT JOIN detected_violations dv ON dv.photo_id = p.id
+WHERE i.id = ANY($1::uuid[])
+GROUP BY i.id
 `
 
-func (q *Queries) ListInspections(ctx context.Context, projectID pgtype.UUID) ([]Inspection, error) {
-	rows, err := q.db.Query(ctx, listInspections, projectID)
+type ListInspectionStatsRow struct {
+	InspectionID   pgtype.UUID `json:"inspection_id"`
+	PhotoCount     int32       `json:"photo_count"`
+	ViolationCount int32       `json:"violation_count"`
+	PendingCount   int32       `json:"pending_count"`
+	ConfirmedCount int32       `json:"confirmed_count"`
+	DismissedCount int32       `json:"dismissed_count"`
+	CriticalCount  int32       `json:"critical_count"`
+	HighCount      int32       `json:"high_count"`
+	MediumCount    int32       `json:"medium_count"`
+	LowCount       int32       `json:"low_count"`
+}
+
+func (q *Queries) ListInspectionStats(ctx context.Context, ids []pgtype.UUID) ([]ListInspectionStatsRow, error) {
+	rows, err := q.db.Query(ctx, listInspectionStats, ids)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Inspection{}
+	items := []ListInspectionStatsRow{}
 	for rows.Next() {
-		var i Inspection
+		var i ListInspectionStatsRow
 		if err := rows.Scan(
-			&i.ID,
-			&i.ProjectID,
-			&i.InspectorID,
-			&i.Status,
-			&i.CreatedAt,
-			&i.UpdatedAt,
+			&i.InspectionID,
+			&i.PhotoCount,
+			&i.ViolationCount,
+			&i.PendingCount,
+			&i.ConfirmedCount,
+			&i.DismissedCount,
+			&i.CriticalCount,
+			&i.HighCount,
+			&i.MediumCount,
+			&i.LowCount,
 		); err != nil {
 			return nil, err
 		}
@@ -180,14 +298,46 @@ func (q *Queries) ListInspections(ctx context.Context, projectID pgtype.UUID) ([
 	return items, nil
 }
 
-const listInspectionsByInspector = `-- name: ListInspectionsByInspector :many
-SELECT id, project_id, inspector_id, status, created_at, updated_at FROM inspections
-WHERE inspector_id = $1
+const searchInspections = `-- name: SearchInspections :many
+SELECT id, project_id, inspector_id, status, created_at, updated_at, title, inspection_type, scheduled_at, performed_at, notes, weather, temperature_f, attendees FROM inspections
+WHERE ($1::uuid IS NULL OR project_id = $1::uuid)
+  AND ($2::uuid IS NULL OR inspector_id = $2::uuid)
+  AND ($3::inspection_status IS NULL OR status = $3::inspection_status)
+  AND ($4::text IS NULL OR inspection_type = $4::text)
+  AND ($5::timestamptz IS NULL OR scheduled_at >= $5::timestamptz)
+  AND ($6::timestamptz IS NULL OR scheduled_at < $6::timestamptz)
+  AND ($7::timestamptz IS NULL OR performed_at >= $7::timestamptz)
+  AND ($8::timestamptz IS NULL OR performed_at < $8::timestamptz)
+  AND ($9::text IS NULL
+    OR title ILIKE '%' || $9::text || '%'
+    OR notes ILIKE '%' || $9::text || '%')
 ORDER BY created_at DESC
 `
 
-func (q *Queries) ListInspectionsByInspector(ctx context.Context, inspectorID pgtype.UUID) ([]Inspection, error) {
-	rows, err := q.db.Query(ctx, listInspectionsByInspector, inspectorID)
+type SearchInspectionsParams struct {
+	ProjectID      pgtype.UUID          `json:"project_id"`
+	InspectorID    pgtype.UUID          `json:"inspector_id"`
+	Status         NullInspectionStatus `json:"status"`
+	InspectionType pgtype.Text          `json:"inspection_type"`
+	ScheduledFrom  pgtype.Timestamptz   `json:"scheduled_from"`
+	ScheduledTo    pgtype.Timestamptz   `json:"scheduled_to"`
+	PerformedFrom  pgtype.Timestamptz   `json:"performed_from"`
+	PerformedTo    pgtype.Timestamptz   `json:"performed_to"`
+	Search         pgtype.Text          `json:"search"`
+}
+
+func (q *Queries) SearchInspections(ctx context.Context, arg SearchInspectionsParams) ([]Inspection, error) {
+	rows, err := q.db.Query(ctx, searchInspections,
+		arg.ProjectID,
+		arg.InspectorID,
+		arg.Status,
+		arg.InspectionType,
+		arg.ScheduledFrom,
+		arg.ScheduledTo,
+		arg.PerformedFrom,
+		arg.PerformedTo,
+		arg.Search,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -202,6 +352,14 @@ func (q *Queries) ListInspectionsByInspector(ctx context.Context, inspectorID pg
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Title,
+			&i.InspectionType,
+			&i.ScheduledAt,
+			&i.PerformedAt,
+			&i.Notes,
+			&i.Weather,
+			&i.TemperatureF,
+			&i.Attendees,
 		); err != nil {
 			return nil, err
 		}
@@ -213,42 +371,82 @@ func (q *Queries) ListInspectionsByInspector(ctx context.Context, inspectorID pg
 	return items, nil
 }
 
-const listInspectionsByStatus = `-- name: ListInspectionsByStatus :many
-SELECT id, project_id, inspector_id, status, created_at, updated_at FROM inspections
-WHERE project_id = $1 AND status = $2
-ORDER BY created_at DESC
+const updateInspection = `-- name: UpdateInspection :one
+UPDATE inspections
+SET
+  title = COALESCE($1, title),
+  inspection_type = CASE WHEN $2::boolean THEN NULL
+    ELSE COALESCE($3::text, inspection_type) END,
+  scheduled_at = CASE WHEN $4::boolean THEN NULL
+    ELSE COALESCE($5::timestamptz, scheduled_at) END,
+  performed_at = CASE WHEN $6::boolean THEN NULL
+    ELSE COALESCE($7::timestamptz, performed_at) END,
+  notes = CASE WHEN $8::boolean THEN NULL
+    ELSE COALESCE($9::text, notes) END,
+  weather = CASE WHEN $10::boolean THEN NULL
+    ELSE COALESCE($11::text, weather) END,
+  temperature_f = CASE WHEN $12::boolean THEN NULL
+    ELSE COALESCE($13::double precision, temperature_f) END,
+  attendees = COALESCE($14, attendees),
+  updated_at = CURRENT_TIMESTAMP
+WHERE id = $15
+RETURNING id, project_id, inspector_id, status, created_at, updated_at, title, inspection_type, scheduled_at, performed_at, notes, weather, temperature_f, attendees
 `
 
-type ListInspectionsByStatusParams struct {
-	ProjectID pgtype.UUID      `json:"project_id"`
-	Status    InspectionStatus `json:"status"`
+type UpdateInspectionParams struct {
+	Title               pgtype.Text        `json:"title"`
+	ClearInspectionType bool               `json:"clear_inspection_type"`
+	InspectionType      pgtype.Text        `json:"inspection_type"`
+	ClearScheduledAt    bool               `json:"clear_scheduled_at"`
+	ScheduledAt         pgtype.Timestamptz `json:"scheduled_at"`
+	ClearPerformedAt    bool               `json:"clear_performed_at"`
+	PerformedAt         pgtype.Timestamptz `json:"performed_at"`
+	ClearNotes          bool               `json:"clear_notes"`
+	Notes               pgtype.Text        `json:"notes"`
+	ClearWeather        bool               `json:"clear_weather"`
+	Weather             pgtype.Text        `json:"weather"`
+	ClearTemperatureF   bool               `json:"clear_temperature_f"`
+	TemperatureF        pgtype.Float8      `json:"temperature_f"`
+	Attendees           []byte             `json:"attendees"`
+	ID                  pgtype.UUID        `json:"id"`
 }
 
-func (q *Queries) ListInspectionsByStatus(ctx context.Context, arg ListInspectionsByStatusParams) ([]Inspection, error) {
-	rows, err := q.db.Query(ctx, listInspectionsByStatus, arg.ProjectID, arg.Status)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	items := []Inspection{}
-	for rows.Next() {
-		var i Inspection
-		if err := rows.Scan(
-			&i.ID,
-			&i.ProjectID,
-			&i.InspectorID,
-			&i.Status,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+func (q *Queries) UpdateInspection(ctx context.Context, arg UpdateInspectionParams) (Inspection, error) {
+	row := q.db.QueryRow(ctx, updateInspection,
+		arg.Title,
+		arg.ClearInspectionType,
+		arg.InspectionType,
+		arg.ClearScheduledAt,
+		arg.ScheduledAt,
+		arg.ClearPerformedAt,
+		arg.PerformedAt,
+		arg.ClearNotes,
+		arg.Notes,
+		arg.ClearWeather,
+		arg.Weather,
+		arg.ClearTemperatureF,
+		arg.TemperatureF,
+		arg.Attendees,
+		arg.ID,
+	)
+	var i Inspection
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.InspectorID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Title,
+		&i.InspectionType,
+		&i.ScheduledAt,
+		&i.PerformedAt,
+		&i.Notes,
+		&i.Weather,
+		&i.TemperatureF,
+		&i.Attendees,
+	)
+	return i, err
 }
 
 const updateInspectionStatus = `-- name: UpdateInspectionStatus :one
@@ -257,7 +455,7 @@ SET
   status = $2,
   updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, project_id, inspector_id, status, created_at, updated_at
+RETURNING id, project_id, inspector_id, status, created_at, updated_at, title, inspection_type, scheduled_at, performed_at, notes, weather, temperature_f, attendees
 `
 
 type UpdateInspectionStatusParams struct {
@@ -275,6 +473,14 @@ func (q *Queries) UpdateInspectionStatus(ctx context.Context, arg UpdateInspecti
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Title,
+		&i.InspectionType,
+		&i.ScheduledAt,
+		&i.PerformedAt,
+		&i.Notes,
+		&i.Weather,
+		&i.TemperatureF,
+		&i.Attendees,
 	)
 	return i, err
 }
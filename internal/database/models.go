@@ -12,6 +12,50 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending DeliveryStatus = "pending"
+	DeliveryStatusSent    DeliveryStatus = "sent"
+	DeliveryStatusFailed  DeliveryStatus = "failed"
+	DeliveryStatusBounced DeliveryStatus = "bounced"
+)
+
+func (e *DeliveryStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DeliveryStatus(s)
+	case string:
+		*e = DeliveryStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DeliveryStatus: %T", src)
+	}
+	return nil
+}
+
+type NullDeliveryStatus struct {
+	DeliveryStatus DeliveryStatus `json:"delivery_status"`
+	Valid          bool           `json:"valid"` // Valid is true if DeliveryStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDeliveryStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.DeliveryStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DeliveryStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDeliveryStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DeliveryStatus), nil
+}
+
 type InspectionStatus string
 
 const (
@@ -98,6 +142,217 @@ func (ns NullOrganizationRole) Value() (driver.Value, error) {
 	return string(ns.OrganizationRole), nil
 }
 
+type ReportTemplate string
+
+const (
+	ReportTemplateFull             ReportTemplate = "full"
+	ReportTemplateExecutiveSummary ReportTemplate = "executive_summary"
+	ReportTemplateViolationsOnly   ReportTemplate = "violations_only"
+)
+
+func (e *ReportTemplate) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ReportTemplate(s)
+	case string:
+		*e = ReportTemplate(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ReportTemplate: %T", src)
+	}
+	return nil
+}
+
+type NullReportTemplate struct {
+	ReportTemplate ReportTemplate `json:"report_template"`
+	Valid          bool           `json:"valid"` // Valid is true if ReportTemplate is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullReportTemplate) Scan(value interface{}) error {
+	if value == nil {
+		ns.ReportTemplate, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ReportTemplate.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullReportTemplate) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ReportTemplate), nil
+}
+
+type ShareLinkKind string
+
+const (
+	ShareLinkKindReport     ShareLinkKind = "report"
+	ShareLinkKindInspection ShareLinkKind = "inspection"
+)
+
+func (e *ShareLinkKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ShareLinkKind(s)
+	case string:
+		*e = ShareLinkKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ShareLinkKind: %T", src)
+	}
+	return nil
+}
+
+type NullShareLinkKind struct {
+	ShareLinkKind ShareLinkKind `json:"share_link_kind"`
+	Valid         bool          `json:"valid"` // Valid is true if ShareLinkKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullShareLinkKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.ShareLinkKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ShareLinkKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullShareLinkKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ShareLinkKind), nil
+}
+
+type SignatureKind string
+
+const (
+	SignatureKindDrawn SignatureKind = "drawn"
+	SignatureKindTyped SignatureKind = "typed"
+)
+
+func (e *SignatureKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SignatureKind(s)
+	case string:
+		*e = SignatureKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SignatureKind: %T", src)
+	}
+	return nil
+}
+
+type NullSignatureKind struct {
+	SignatureKind SignatureKind `json:"signature_kind"`
+	Valid         bool          `json:"valid"` // Valid is true if SignatureKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSignatureKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.SignatureKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SignatureKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSignatureKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SignatureKind), nil
+}
+
+type SignoffRole string
+
+const (
+	SignoffRoleInspector SignoffRole = "inspector"
+	SignoffRoleReviewer  SignoffRole = "reviewer"
+)
+
+func (e *SignoffRole) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SignoffRole(s)
+	case string:
+		*e = SignoffRole(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SignoffRole: %T", src)
+	}
+	return nil
+}
+
+type NullSignoffRole struct {
+	SignoffRole SignoffRole `json:"signoff_role"`
+	Valid       bool        `json:"valid"` // Valid is true if SignoffRole is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSignoffRole) Scan(value interface{}) error {
+	if value == nil {
+		ns.SignoffRole, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SignoffRole.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSignoffRole) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SignoffRole), nil
+}
+
+type UploadStatus string
+
+const (
+	UploadStatusInProgress UploadStatus = "in_progress"
+	UploadStatusCompleted  UploadStatus = "completed"
+)
+
+func (e *UploadStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = UploadStatus(s)
+	case string:
+		*e = UploadStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for UploadStatus: %T", src)
+	}
+	return nil
+}
+
+type NullUploadStatus struct {
+	UploadStatus UploadStatus `json:"upload_status"`
+	Valid        bool         `json:"valid"` // Valid is true if UploadStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullUploadStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.UploadStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.UploadStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullUploadStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.UploadStatus), nil
+}
+
 type UserStatus string
 
 const (
@@ -253,41 +508,79 @@ type DetectedViolation struct {
 	SafetyCodeID    pgtype.UUID        `json:"safety_code_id"`
 	Severity        ViolationSeverity  `json:"severity"`
 	Location        pgtype.Text        `json:"location"`
+	ResolvedAt      pgtype.Timestamptz `json:"resolved_at"`
 }
 
 type Inspection struct {
-	ID          pgtype.UUID        `json:"id"`
-	ProjectID   pgtype.UUID        `json:"project_id"`
-	InspectorID pgtype.UUID        `json:"inspector_id"`
-	Status      InspectionStatus   `json:"status"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	ID             pgtype.UUID        `json:"id"`
+	ProjectID      pgtype.UUID        `json:"project_id"`
+	InspectorID    pgtype.UUID        `json:"inspector_id"`
+	Status         InspectionStatus   `json:"status"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	Title          string             `json:"title"`
+	InspectionType pgtype.Text        `json:"inspection_type"`
+	ScheduledAt    pgtype.Timestamptz `json:"scheduled_at"`
+	PerformedAt    pgtype.Timestamptz `json:"performed_at"`
+	Notes          pgtype.Text        `json:"notes"`
+	Weather        pgtype.Text        `json:"weather"`
+	TemperatureF   pgtype.Float8      `json:"temperature_f"`
+	Attendees      []byte             `json:"attendees"`
+}
+
+type InspectionSignoff struct {
+	ID            pgtype.UUID        `json:"id"`
+	InspectionID  pgtype.UUID        `json:"inspection_id"`
+	Role          SignoffRole        `json:"role"`
+	SignerID      pgtype.UUID        `json:"signer_id"`
+	SignerName    string             `json:"signer_name"`
+	SignatureKind SignatureKind      `json:"signature_kind"`
+	Signature     string             `json:"signature"`
+	ContentHash   string             `json:"content_hash"`
+	SignedAt      pgtype.Timestamptz `json:"signed_at"`
 }
 
 type Job struct {
-	ID             pgtype.UUID        `json:"id"`
-	QueueName      string             `json:"queue_name"`
-	JobType        string             `json:"job_type"`
-	OrganizationID pgtype.UUID        `json:"organization_id"`
-	Payload        []byte             `json:"payload"`
-	Status         string             `json:"status"`
-	Priority       int32              `json:"priority"`
-	MaxAttempts    int32              `json:"max_attempts"`
-	AttemptCount   int32              `json:"attempt_count"`
-	ScheduledAt    pgtype.Timestamptz `json:"scheduled_at"`
-	CreatedAt      pgtype.Timestamptz `json:"created_at"`
-	StartedAt      pgtype.Timestamptz `json:"started_at"`
-	CompletedAt    pgtype.Timestamptz `json:"completed_at"`
-	Result         []byte             `json:"result"`
-	ErrorMessage   pgtype.Text        `json:"error_message"`
-	WorkerID       pgtype.Text        `json:"worker_id"`
+	ID               pgtype.UUID        `json:"id"`
+	QueueName        string             `json:"queue_name"`
+	JobType          string             `json:"job_type"`
+	OrganizationID   pgtype.UUID        `json:"organization_id"`
+	Payload          []byte             `json:"payload"`
+	Status           string             `json:"status"`
+	Priority         int32              `json:"priority"`
+	MaxAttempts      int32              `json:"max_attempts"`
+	AttemptCount     int32              `json:"attempt_count"`
+	ScheduledAt      pgtype.Timestamptz `json:"scheduled_at"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	StartedAt        pgtype.Timestamptz `json:"started_at"`
+	CompletedAt      pgtype.Timestamptz `json:"completed_at"`
+	Result           []byte             `json:"result"`
+	ErrorMessage     pgtype.Text        `json:"error_message"`
+	WorkerID         pgtype.Text        `json:"worker_id"`
+	Errors           []byte             `json:"errors"`
+	HeartbeatAt      pgtype.Timestamptz `json:"heartbeat_at"`
+	DependencyPolicy string             `json:"dependency_policy"`
+	InspectionID     pgtype.UUID        `json:"inspection_id"`
+	Progress         int32              `json:"progress"`
+	ProgressMessage  pgtype.Text        `json:"progress_message"`
+	UniqueKey        pgtype.Text        `json:"unique_key"`
+}
+
+type JobDependency struct {
+	JobID       pgtype.UUID `json:"job_id"`
+	DependsOnID pgtype.UUID `json:"depends_on_id"`
 }
 
 type Organization struct {
-	ID        pgtype.UUID        `json:"id"`
-	Name      string             `json:"name"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	ID             pgtype.UUID        `json:"id"`
+	Name           string             `json:"name"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	LogoUrl        string             `json:"logo_url"`
+	PrimaryColor   string             `json:"primary_color"`
+	Address        string             `json:"address"`
+	ReportFooter   string             `json:"report_footer"`
+	ReportTemplate ReportTemplate     `json:"report_template"`
 }
 
 type OrganizationMember struct {
@@ -316,6 +609,7 @@ type Photo struct {
 	StorageUrl   string             `json:"storage_url"`
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 	ThumbnailUrl pgtype.Text        `json:"thumbnail_url"`
+	SizeBytes    int64              `json:"size_bytes"`
 }
 
 type Project struct {
@@ -334,11 +628,46 @@ type Project struct {
 	Country        pgtype.Text        `json:"country"`
 }
 
+type ProjectContact struct {
+	ID        pgtype.UUID        `json:"id"`
+	ProjectID pgtype.UUID        `json:"project_id"`
+	Name      string             `json:"name"`
+	Email     string             `json:"email"`
+	Role      pgtype.Text        `json:"role"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
 type Report struct {
 	ID           pgtype.UUID        `json:"id"`
 	InspectionID pgtype.UUID        `json:"inspection_id"`
 	StorageUrl   string             `json:"storage_url"`
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	SizeBytes    int64              `json:"size_bytes"`
+	Version      int32              `json:"version"`
+	SnapshotHash string             `json:"snapshot_hash"`
+	Snapshot     []byte             `json:"snapshot"`
+	GeneratedBy  pgtype.UUID        `json:"generated_by"`
+	Template     ReportTemplate     `json:"template"`
+	Sha256       string             `json:"sha256"`
+	Signature    string             `json:"signature"`
+	SigningKeyID string             `json:"signing_key_id"`
+	Signoffs     []byte             `json:"signoffs"`
+}
+
+type ReportDelivery struct {
+	ID                pgtype.UUID        `json:"id"`
+	ReportID          pgtype.UUID        `json:"report_id"`
+	Recipient         string             `json:"recipient"`
+	ContactID         pgtype.UUID        `json:"contact_id"`
+	Attached          bool               `json:"attached"`
+	Message           pgtype.Text        `json:"message"`
+	Status            DeliveryStatus     `json:"status"`
+	ProviderMessageID pgtype.Text        `json:"provider_message_id"`
+	Error             pgtype.Text        `json:"error"`
+	SentBy            pgtype.UUID        `json:"sent_by"`
+	SentAt            pgtype.Timestamptz `json:"sent_at"`
+	BouncedAt         pgtype.Timestamptz `json:"bounced_at"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
 }
 
 type SafetyCode struct {
@@ -351,6 +680,13 @@ type SafetyCode struct {
 	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
 }
 
+type ScheduledJobRun struct {
+	ScheduleName string             `json:"schedule_name"`
+	RunAt        pgtype.Timestamptz `json:"run_at"`
+	JobID        pgtype.UUID        `json:"job_id"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
 type Session struct {
 	ID        int32              `json:"id"`
 	UserID    pgtype.UUID        `json:"user_id"`
@@ -359,6 +695,68 @@ type Session struct {
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
+type ShareLink struct {
+	ID           pgtype.UUID        `json:"id"`
+	InspectionID pgtype.UUID        `json:"inspection_id"`
+	ReportID     pgtype.UUID        `json:"report_id"`
+	Kind         ShareLinkKind      `json:"kind"`
+	TokenHash    string             `json:"token_hash"`
+	PasswordHash pgtype.Text        `json:"password_hash"`
+	ExpiresAt    pgtype.Timestamptz `json:"expires_at"`
+	RevokedAt    pgtype.Timestamptz `json:"revoked_at"`
+	ViewCount    int32              `json:"view_count"`
+	LastViewedAt pgtype.Timestamptz `json:"last_viewed_at"`
+	CreatedBy    pgtype.UUID        `json:"created_by"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+type ShareLinkView struct {
+	ID          pgtype.UUID        `json:"id"`
+	ShareLinkID pgtype.UUID        `json:"share_link_id"`
+	IpAddress   pgtype.Text        `json:"ip_address"`
+	UserAgent   pgtype.Text        `json:"user_agent"`
+	ViewedAt    pgtype.Timestamptz `json:"viewed_at"`
+}
+
+type SummaryReport struct {
+	ID          pgtype.UUID        `json:"id"`
+	ProjectID   pgtype.UUID        `json:"project_id"`
+	PeriodStart pgtype.Timestamptz `json:"period_start"`
+	PeriodEnd   pgtype.Timestamptz `json:"period_end"`
+	StorageUrl  string             `json:"storage_url"`
+	SizeBytes   int64              `json:"size_bytes"`
+	Summary     []byte             `json:"summary"`
+	GeneratedBy pgtype.UUID        `json:"generated_by"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type Upload struct {
+	ID                pgtype.UUID        `json:"id"`
+	InspectionID      pgtype.UUID        `json:"inspection_id"`
+	UserID            pgtype.UUID        `json:"user_id"`
+	Filename          pgtype.Text        `json:"filename"`
+	ContentType       string             `json:"content_type"`
+	UploadLength      int64              `json:"upload_length"`
+	UploadOffset      int64              `json:"upload_offset"`
+	StorageKey        string             `json:"storage_key"`
+	MultipartUploadID string             `json:"multipart_upload_id"`
+	PendingData       []byte             `json:"pending_data"`
+	Status            UploadStatus       `json:"status"`
+	StorageUrl        pgtype.Text        `json:"storage_url"`
+	PhotoID           pgtype.UUID        `json:"photo_id"`
+	ExpiresAt         pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+}
+
+type UploadPart struct {
+	UploadID   pgtype.UUID        `json:"upload_id"`
+	PartNumber int32              `json:"part_number"`
+	Etag       string             `json:"etag"`
+	Size       int64              `json:"size"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
 type User struct {
 	ID                  pgtype.UUID        `json:"id"`
 	Email               string             `json:"email"`
@@ -152,6 +152,11 @@ SELECT
   o.name,
   o.created_at,
   o.updated_at,
+  o.logo_url,
+  o.primary_color,
+  o.address,
+  o.report_footer,
+  o.report_template,
   om.role
 FROM organizations o
 INNER JOIN organization_members om ON o.id = om.organization_id
@@ -160,11 +165,16 @@ ORDER BY o.created_at DESC
 `
 
 type ListUserOrganizationsWithDetailsRow struct {
-	ID        pgtype.UUID        `json:"id"`
-	Name      string             `json:"name"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
-	Role      OrganizationRole   `json:"role"`
+	ID             pgtype.UUID        `json:"id"`
+	Name           string             `json:"name"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	LogoUrl        string             `json:"logo_url"`
+	PrimaryColor   string             `json:"primary_color"`
+	Address        string             `json:"address"`
+	ReportFooter   string             `json:"report_footer"`
+	ReportTemplate ReportTemplate     `json:"report_template"`
+	Role           OrganizationRole   `json:"role"`
 }
 
 func (q *Queries) ListUserOrganizationsWithDetails(ctx context.Context, userID pgtype.UUID) ([]ListUserOrganizationsWithDetailsRow, error) {
@@ -181,6 +191,11 @@ func (q *Queries) ListUserOrganizationsWithDetails(ctx context.Context, userID p
 			&i.Name,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.LogoUrl,
+			&i.PrimaryColor,
+			&i.Address,
+			&i.ReportFooter,
+			&i.ReportTemplate,
 			&i.Role,
 		); err != nil {
 			return nil, err
@@ -17,7 +17,7 @@ INSERT INTO organizations (
 ) VALUES (
   $1
 )
-RETURNING id, name, created_at, updated_at
+RETURNING id, name, created_at, updated_at, logo_url, primary_color, address, report_footer, report_template
 `
 
 func (q *Queries) CreateOrganization(ctx context.Context, name string) (Organization, error) {
@@ -28,6 +28,11 @@ func (q *Queries) CreateOrganization(ctx context.Context, name string) (Organiza
 		&i.Name,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LogoUrl,
+		&i.PrimaryColor,
+		&i.Address,
+		&i.ReportFooter,
+		&i.ReportTemplate,
 	)
 	return i, err
 }
@@ -43,7 +48,7 @@ func (q *Queries) DeleteOrganization(ctx context.Context, id pgtype.UUID) error
 }
 
 const getOrganization = `-- name: GetOrganization :one
-SELECT id, name, created_at, updated_at FROM organizations
+SELECT id, name, created_at, updated_at, logo_url, primary_color, address, report_footer, report_template FROM organizations
 WHERE id = $1 LIMIT 1
 `
 
@@ -55,12 +60,17 @@ func (q *Queries) GetOrganization(ctx context.Context, id pgtype.UUID) (Organiza
 		&i.Name,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LogoUrl,
+		&i.PrimaryColor,
+		&i.Address,
+		&i.ReportFooter,
+		&i.ReportTemplate,
 	)
 	return i, err
 }
 
 const listOrganizations = `-- name: ListOrganizations :many
-SELECT id, name, created_at, updated_at FROM organizations
+SELECT id, name, created_at, updated_at, logo_url, primary_color, address, report_footer, report_template FROM organizations
 ORDER BY created_at DESC
 `
 
@@ -78,6 +88,11 @@ func (q *Queries) ListOrganizations(ctx context.Context) ([]Organization, error)
 			&i.Name,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.LogoUrl,
+			&i.PrimaryColor,
+			&i.Address,
+			&i.ReportFooter,
+			&i.ReportTemplate,
 		); err != nil {
 			return nil, err
 		}
@@ -90,7 +105,7 @@ func (q *Queries) ListOrganizations(ctx context.Context) ([]Organization, error)
 }
 
 const searchOrganizationsByName = `-- name: SearchOrganizationsByName :many
-SELECT id, name, created_at, updated_at FROM organizations
+SELECT id, name, created_at, updated_at, logo_url, primary_color, address, report_footer, report_template FROM organizations
 WHERE name ILIKE '%' || $1 || '%'
 ORDER BY name
 `
@@ -109,6 +124,11 @@ func (q *Queries) SearchOrganizationsByName(ctx context.Context, dollar_1 pgtype
 			&i.Name,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.LogoUrl,
+			&i.PrimaryColor,
+			&i.Address,
+			&i.ReportFooter,
+			&i.ReportTemplate,
 		); err != nil {
 			return nil, err
 		}
@@ -123,25 +143,48 @@ func (q *Queries) SearchOrganizationsByName(ctx context.Context, dollar_1 pgtype
 const updateOrganization = `-- name: UpdateOrganization :one
 UPDATE organizations
 SET
-  name = COALESCE($2, name),
+  name = COALESCE($1, name),
+  logo_url = COALESCE($2, logo_url),
+  primary_color = COALESCE($3, primary_color),
+  address = COALESCE($4, address),
+  report_footer = COALESCE($5, report_footer),
+  report_template = COALESCE($6, report_template),
   updated_at = CURRENT_TIMESTAMP
-WHERE id = $1
-RETURNING id, name, created_at, updated_at
+WHERE id = $7
+RETURNING id, name, created_at, updated_at, logo_url, primary_color, address, report_footer, report_template
 `
 
 type UpdateOrganizationParams struct {
-	ID   pgtype.UUID `json:"id"`
-	Name string      `json:"name"`
+	Name           pgtype.Text        `json:"name"`
+	LogoUrl        pgtype.Text        `json:"logo_url"`
+	PrimaryColor   pgtype.Text        `json:"primary_color"`
+	Address        pgtype.Text        `json:"address"`
+	ReportFooter   pgtype.Text        `json:"report_footer"`
+	ReportTemplate NullReportTemplate `json:"report_template"`
+	ID             pgtype.UUID        `json:"id"`
 }
 
 func (q *Queries) UpdateOrganization(ctx context.Context, arg UpdateOrganizationParams) (Organization, error) {
-	row := q.db.QueryRow(ctx, updateOrganization, arg.ID, arg.Name)
+	row := q.db.QueryRow(ctx, updateOrganization,
+		arg.Name,
+		arg.LogoUrl,
+		arg.PrimaryColor,
+		arg.Address,
+		arg.ReportFooter,
+		arg.ReportTemplate,
+		arg.ID,
+	)
 	var i Organization
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LogoUrl,
+		&i.PrimaryColor,
+		&i.Address,
+		&i.ReportFooter,
+		&i.ReportTemplate,
 	)
 	return i, err
 }
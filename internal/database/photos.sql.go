@@ -15,21 +15,28 @@ const createPhoto = `-- name: CreatePhoto :one
 INSERT INTO photos (
   inspection_id,
   storage_url,
-  thumbnail_url
+  thumbnail_url,
+  size_bytes
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4
 )
-RETURNING id, inspection_id, storage_url, created_at, thumbnail_url
+RETURNING id, inspection_id, storage_url, created_at, thumbnail_url, size_bytes
 `
 
 type CreatePhotoParams struct {
 	InspectionID pgtype.UUID `json:"inspection_id"`
 	StorageUrl   string      `json:"storage_url"`
 	ThumbnailUrl pgtype.Text `json:"thumbnail_url"`
+	SizeBytes    int64       `json:"size_bytes"`
 }
 
 func (q *Queries) CreatePhoto(ctx context.Context, arg CreatePhotoParams) (Photo, error) {
-	row := q.db.QueryRow(ctx, createPhoto, arg.InspectionID, arg.StorageUrl, arg.ThumbnailUrl)
+	row := q.db.QueryRow(ctx, createPhoto,
+		arg.InspectionID,
+		arg.StorageUrl,
+		arg.ThumbnailUrl,
+		arg.SizeBytes,
+	)
 	var i Photo
 	err := row.Scan(
 		&i.ID,
@@ -37,6 +44,7 @@ func (q *Queries) CreatePhoto(ctx context.Context, arg CreatePhotoParams) (Photo
 		&i.StorageUrl,
 		&i.CreatedAt,
 		&i.ThumbnailUrl,
+		&i.SizeBytes,
 	)
 	return i, err
 }
@@ -52,7 +60,7 @@ func (q *Queries) DeletePhoto(ctx context.Context, id pgtype.UUID) error {
 }
 
 const getPhoto = `-- name: GetPhoto :one
-SELECT id, inspection_id, storage_url, created_at, thumbnail_url FROM photos
+SELECT id, inspection_id, storage_url, created_at, thumbnail_url, size_bytes FROM photos
 WHERE id = $1 LIMIT 1
 `
 
@@ -65,6 +73,7 @@ func (q *Queries) GetPhoto(ctx context.Context, id pgtype.UUID) (Photo, error) {
 		&i.StorageUrl,
 		&i.CreatedAt,
 		&i.ThumbnailUrl,
+		&i.SizeBytes,
 	)
 	return i, err
 }
@@ -93,7 +102,7 @@ func (q *Queries) GetPhotoCountByOrganizationAndDateRange(ctx context.Context, a
 }
 
 const listPhotos = `-- name: ListPhotos :many
-SELECT id, inspection_id, storage_url, created_at, thumbnail_url FROM photos
+SELECT id, inspection_id, storage_url, created_at, thumbnail_url, size_bytes FROM photos
 WHERE inspection_id = $1
 ORDER BY created_at DESC
 `
@@ -113,6 +122,7 @@ func (q *Queries) ListPhotos(ctx context.Context, inspectionID pgtype.UUID) ([]P
 			&i.StorageUrl,
 			&i.CreatedAt,
 			&i.ThumbnailUrl,
+			&i.SizeBytes,
 		); err != nil {
 			return nil, err
 		}
@@ -123,3 +133,30 @@ func (q *Queries) ListPhotos(ctx context.Context, inspectionID pgtype.UUID) ([]P
 	}
 	return items, nil
 }
+
+const updatePhotoThumbnail = `-- name: UpdatePhotoThumbnail :one
+UPDATE photos
+SET
+  thumbnail_url = $2
+WHERE id = $1
+RETURNING id, inspection_id, storage_url, created_at, thumbnail_url, size_bytes
+`
+
+type UpdatePhotoThumbnailParams struct {
+	ID           pgtype.UUID `json:"id"`
+	ThumbnailUrl pgtype.Text `json:"thumbnail_url"`
+}
+
+func (q *Queries) UpdatePhotoThumbnail(ctx context.Context, arg UpdatePhotoThumbnailParams) (Photo, error) {
+	row := q.db.QueryRow(ctx, updatePhotoThumbnail, arg.ID, arg.ThumbnailUrl)
+	var i Photo
+	err := row.Scan(
+		&i.ID,
+		&i.InspectionID,
+		&i.StorageUrl,
+		&i.CreatedAt,
+		&i.ThumbnailUrl,
+		&i.SizeBytes,
+	)
+	return i, err
+}
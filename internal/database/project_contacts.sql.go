@@ -0,0 +1,112 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: project_contacts.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createProjectContact = `-- name: CreateProjectContact :one
+INSERT INTO project_contacts (
+  project_id,
+  name,
+  email,
+  role
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING id, project_id, name, email, role, created_at
+`
+
+type CreateProjectContactParams struct {
+	ProjectID pgtype.UUID `json:"project_id"`
+	Name      string      `json:"name"`
+	Email     string      `json:"email"`
+	Role      pgtype.Text `json:"role"`
+}
+
+func (q *Queries) CreateProjectContact(ctx context.Context, arg CreateProjectContactParams) (ProjectContact, error) {
+	row := q.db.QueryRow(ctx, createProjectContact,
+		arg.ProjectID,
+		arg.Name,
+		arg.Email,
+		arg.Role,
+	)
+	var i ProjectContact
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.Name,
+		&i.Email,
+		&i.Role,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteProjectContact = `-- name: DeleteProjectContact :exec
+DELETE FROM project_contacts
+WHERE id = $1
+`
+
+func (q *Queries) DeleteProjectContact(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteProjectContact, id)
+	return err
+}
+
+const getProjectContact = `-- name: GetProjectContact :one
+SELECT id, project_id, name, email, role, created_at FROM project_contacts
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetProjectContact(ctx context.Context, id pgtype.UUID) (ProjectContact, error) {
+	row := q.db.QueryRow(ctx, getProjectContact, id)
+	var i ProjectContact
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.Name,
+		&i.Email,
+		&i.Role,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listProjectContacts = `-- name: ListProjectContacts :many
+SELECT id, project_id, name, email, role, created_at FROM project_contacts
+WHERE project_id = $1
+ORDER BY name
+`
+
+func (q *Queries) ListProjectContacts(ctx context.Context, projectID pgtype.UUID) ([]ProjectContact, error) {
+	rows, err := q.db.Query(ctx, listProjectContacts, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProjectContact{}
+	for rows.Next() {
+		var i ProjectContact
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProjectID,
+			&i.Name,
+			&i.Email,
+			&i.Role,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
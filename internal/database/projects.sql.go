@@ -107,6 +107,51 @@ func (q *Queries) GetProject(ctx context.Context, id pgtype.UUID) (Project, erro
 	return i, err
 }
 
+const getProjectStats = `-- name: GetProjectStats :one
+SELECT
+  pr.id AS project_id,
+  COUNT(DISTINCT i.id)::int AS inspection_count,
+  COUNT(DISTINCT p.id)::int AS photo_count,
+  COUNT(dv.id)::int AS violation_count,
+  COUNT(dv.id) FILTER (WHERE dv.status = 'pending')::int AS open_violations,
+  COUNT(dv.id) FILTER (WHERE dv.status IN ('confirmed', 'dismissed'))::int AS closed_violations,
+  COUNT(dv.id) FILTER (WHERE dv.status = 'confirmed')::int AS confirmed_violations,
+  COUNT(dv.id) FILTER (WHERE dv.status = 'dismissed')::int AS dismissed_violations
+FROM projects pr
+LEFT JOIN inspections i ON i.project_id = pr.id
+LEFT JOIN photos p ON p.inspection_id = i.id
+LEFT JOIN detected_violations dv ON dv.photo_id = p.id
+WHERE pr.id = $1
+GROUP BY pr.id
+`
+
+type GetProjectStatsRow struct {
+	ProjectID           pgtype.UUID `json:"project_id"`
+	InspectionCount     int32       `json:"inspection_count"`
+	PhotoCount          int32       `json:"photo_count"`
+	ViolationCount      int32       `json:"violation_count"`
+	OpenViolations      int32       `json:"open_violations"`
+	ClosedViolations    int32       `json:"closed_violations"`
+	ConfirmedViolations int32       `json:"confirmed_violations"`
+	DismissedViolations int32       `json:"dismissed_violations"`
+}
+
+func (q *Queries) GetProjectStats(ctx context.Context, id pgtype.UUID) (GetProjectStatsRow, error) {
+	row := q.db.QueryRow(ctx, getProjectStats, id)
+	var i GetProjectStatsRow
+	err := row.Scan(
+		&i.ProjectID,
+		&i.InspectionCount,
+		&i.PhotoCount,
+		&i.ViolationCount,
+		&i.OpenViolations,
+		&i.ClosedViolations,
+		&i.ConfirmedViolations,
+		&i.DismissedViolations,
+	)
+	return i, err
+}
+
 const listProjects = `-- name: ListProjects :many
 SELECT id, organization_id, name, created_at, updated_at, description, project_type, status, address, city, state, zip_code, country FROM projects
 WHERE organization_id = $1
@@ -147,6 +192,46 @@ func (q *Queries) ListProjects(ctx context.Context, organizationID pgtype.UUID)
 	return items, nil
 }
 
+const listProjectsByStatus = `-- name: ListProjectsByStatus :many
+SELECT id, organization_id, name, created_at, updated_at, description, project_type, status, address, city, state, zip_code, country FROM projects
+WHERE status = $1
+ORDER BY created_at
+`
+
+func (q *Queries) ListProjectsByStatus(ctx context.Context, status pgtype.Text) ([]Project, error) {
+	rows, err := q.db.Query(ctx, listProjectsByStatus, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Project{}
+	for rows.Next() {
+		var i Project
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganizationID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Description,
+			&i.ProjectType,
+			&i.Status,
+			&i.Address,
+			&i.City,
+			&i.State,
+			&i.ZipCode,
+			&i.Country,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateProject = `-- name: UpdateProject :one
 UPDATE projects
 SET
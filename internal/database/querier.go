@@ -12,44 +12,66 @@ import (
 
 type Querier interface {
 	AddOrganizationMember(ctx context.Context, arg AddOrganizationMemberParams) (OrganizationMember, error)
+	CompleteUpload(ctx context.Context, arg CompleteUploadParams) (Upload, error)
 	CountDetectedViolationsByInspection(ctx context.Context, inspectionID pgtype.UUID) (int64, error)
 	CreateDetectedViolation(ctx context.Context, arg CreateDetectedViolationParams) (DetectedViolation, error)
 	CreateInspection(ctx context.Context, arg CreateInspectionParams) (Inspection, error)
+	CreateInspectionSignoff(ctx context.Context, arg CreateInspectionSignoffParams) (InspectionSignoff, error)
 	CreateOrganization(ctx context.Context, name string) (Organization, error)
 	CreatePhoto(ctx context.Context, arg CreatePhotoParams) (Photo, error)
 	CreateProject(ctx context.Context, arg CreateProjectParams) (Project, error)
+	CreateProjectContact(ctx context.Context, arg CreateProjectContactParams) (ProjectContact, error)
 	CreateReport(ctx context.Context, arg CreateReportParams) (Report, error)
+	CreateReportDelivery(ctx context.Context, arg CreateReportDeliveryParams) (ReportDelivery, error)
 	CreateSafetyCode(ctx context.Context, arg CreateSafetyCodeParams) (SafetyCode, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateShareLink(ctx context.Context, arg CreateShareLinkParams) (ShareLink, error)
+	CreateShareLinkView(ctx context.Context, arg CreateShareLinkViewParams) (ShareLinkView, error)
+	CreateSummaryReport(ctx context.Context, arg CreateSummaryReportParams) (SummaryReport, error)
+	CreateUpload(ctx context.Context, arg CreateUploadParams) (Upload, error)
+	CreateUploadPart(ctx context.Context, arg CreateUploadPartParams) error
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	DeleteDetectedViolation(ctx context.Context, id pgtype.UUID) error
 	DeleteExpiredSessions(ctx context.Context) error
 	DeleteInspection(ctx context.Context, id pgtype.UUID) error
+	DeleteInspectionSignoff(ctx context.Context, id pgtype.UUID) error
 	DeleteOrganization(ctx context.Context, id pgtype.UUID) error
 	DeletePendingAndDismissedViolationsByPhoto(ctx context.Context, photoID pgtype.UUID) error
 	DeletePendingViolationsByPhoto(ctx context.Context, photoID pgtype.UUID) error
 	DeletePhoto(ctx context.Context, id pgtype.UUID) error
 	DeleteProject(ctx context.Context, id pgtype.UUID) error
+	DeleteProjectContact(ctx context.Context, id pgtype.UUID) error
 	DeleteReport(ctx context.Context, id pgtype.UUID) error
 	DeleteSafetyCode(ctx context.Context, id pgtype.UUID) error
 	DeleteSession(ctx context.Context, token string) error
+	DeleteUpload(ctx context.Context, id pgtype.UUID) error
 	DeleteUser(ctx context.Context, id pgtype.UUID) error
 	DeleteUserSessions(ctx context.Context, userID pgtype.UUID) error
 	GetDetectedViolation(ctx context.Context, id pgtype.UUID) (DetectedViolation, error)
 	GetInspection(ctx context.Context, id pgtype.UUID) (Inspection, error)
 	GetInspectionCountByOrganizationAndDateRange(ctx context.Context, arg GetInspectionCountByOrganizationAndDateRangeParams) (int64, error)
+	GetInspectionForUpdate(ctx context.Context, id pgtype.UUID) (Inspection, error)
 	GetOrganization(ctx context.Context, id pgtype.UUID) (Organization, error)
 	GetOrganizationMember(ctx context.Context, id pgtype.UUID) (OrganizationMember, error)
 	GetOrganizationMemberByUserAndOrg(ctx context.Context, arg GetOrganizationMemberByUserAndOrgParams) (OrganizationMember, error)
 	GetPhoto(ctx context.Context, id pgtype.UUID) (Photo, error)
 	GetPhotoCountByOrganizationAndDateRange(ctx context.Context, arg GetPhotoCountByOrganizationAndDateRangeParams) (int64, error)
 	GetProject(ctx context.Context, id pgtype.UUID) (Project, error)
+	GetProjectContact(ctx context.Context, id pgtype.UUID) (ProjectContact, error)
+	GetProjectStats(ctx context.Context, id pgtype.UUID) (GetProjectStatsRow, error)
 	GetRecentInspectionsByOrganization(ctx context.Context, arg GetRecentInspectionsByOrganizationParams) ([]GetRecentInspectionsByOrganizationRow, error)
 	GetReport(ctx context.Context, id pgtype.UUID) (Report, error)
+	GetReportBySHA256(ctx context.Context, sha256 string) (Report, error)
 	GetReportCountByOrganizationAndDateRange(ctx context.Context, arg GetReportCountByOrganizationAndDateRangeParams) (int64, error)
+	GetReportDelivery(ctx context.Context, id pgtype.UUID) (ReportDelivery, error)
 	GetSafetyCode(ctx context.Context, id pgtype.UUID) (SafetyCode, error)
 	GetSafetyCodeByCode(ctx context.Context, code string) (SafetyCode, error)
 	GetSessionByToken(ctx context.Context, token string) (Session, error)
+	GetShareLink(ctx context.Context, id pgtype.UUID) (ShareLink, error)
+	GetShareLinkByTokenHash(ctx context.Context, tokenHash string) (ShareLink, error)
+	GetSummaryReport(ctx context.Context, id pgtype.UUID) (SummaryReport, error)
+	GetUpload(ctx context.Context, id pgtype.UUID) (Upload, error)
+	GetUploadForUpdate(ctx context.Context, id pgtype.UUID) (Upload, error)
 	GetUser(ctx context.Context, id pgtype.UUID) (User, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByResetToken(ctx context.Context, resetToken pgtype.Text) (User, error)
@@ -57,38 +79,57 @@ type Querier interface {
 	GetUserByVerificationToken(ctx context.Context, verificationToken pgtype.Text) (User, error)
 	GetViolationCountByOrganizationAndDateRange(ctx context.Context, arg GetViolationCountByOrganizationAndDateRangeParams) (int64, error)
 	GetViolationCountBySeverityAndOrganization(ctx context.Context, arg GetViolationCountBySeverityAndOrganizationParams) ([]GetViolationCountBySeverityAndOrganizationRow, error)
+	IncrementShareLinkViews(ctx context.Context, id pgtype.UUID) error
 	ListDetectedViolations(ctx context.Context, photoID pgtype.UUID) ([]DetectedViolation, error)
 	ListDetectedViolationsByInspection(ctx context.Context, inspectionID pgtype.UUID) ([]DetectedViolation, error)
 	ListDetectedViolationsByInspectionAndStatus(ctx context.Context, arg ListDetectedViolationsByInspectionAndStatusParams) ([]DetectedViolation, error)
 	ListDetectedViolationsByStatus(ctx context.Context, arg ListDetectedViolationsByStatusParams) ([]DetectedViolation, error)
-	ListInspections(ctx context.Context, projectID pgtype.UUID) ([]Inspection, error)
-	ListInspectionsByInspector(ctx context.Context, inspectorID pgtype.UUID) ([]Inspection, error)
-	ListInspectionsByStatus(ctx context.Context, arg ListInspectionsByStatusParams) ([]Inspection, error)
+	ListExpiredUploads(ctx context.Context) ([]Upload, error)
+	ListInspectionSignoffs(ctx context.Context, inspectionID pgtype.UUID) ([]InspectionSignoff, error)
+	ListInspectionStats(ctx context.Context, ids []pgtype.UUID) ([]ListInspectionStatsRow, error)
 	ListOrganizationMembers(ctx context.Context, organizationID pgtype.UUID) ([]OrganizationMember, error)
 	ListOrganizations(ctx context.Context) ([]Organization, error)
 	ListPhotos(ctx context.Context, inspectionID pgtype.UUID) ([]Photo, error)
+	ListProjectContacts(ctx context.Context, projectID pgtype.UUID) ([]ProjectContact, error)
+	ListProjectStorageUsage(ctx context.Context, organizationID pgtype.UUID) ([]ListProjectStorageUsageRow, error)
 	ListProjects(ctx context.Context, organizationID pgtype.UUID) ([]Project, error)
+	ListProjectsByStatus(ctx context.Context, status pgtype.Text) ([]Project, error)
+	ListReportDeliveries(ctx context.Context, reportID pgtype.UUID) ([]ReportDelivery, error)
 	ListReports(ctx context.Context, inspectionID pgtype.UUID) ([]Report, error)
 	ListSafetyCodes(ctx context.Context) ([]SafetyCode, error)
 	ListSafetyCodesByCountry(ctx context.Context, country pgtype.Text) ([]SafetyCode, error)
 	ListSafetyCodesByLocation(ctx context.Context, arg ListSafetyCodesByLocationParams) ([]SafetyCode, error)
 	ListSafetyCodesByStateProvince(ctx context.Context, stateProvince pgtype.Text) ([]SafetyCode, error)
+	ListShareLinkViews(ctx context.Context, arg ListShareLinkViewsParams) ([]ShareLinkView, error)
+	ListShareLinks(ctx context.Context, inspectionID pgtype.UUID) ([]ShareLink, error)
+	ListSummaryReports(ctx context.Context, projectID pgtype.UUID) ([]SummaryReport, error)
+	ListUploadParts(ctx context.Context, uploadID pgtype.UUID) ([]UploadPart, error)
 	ListUserOrganizations(ctx context.Context, userID pgtype.UUID) ([]OrganizationMember, error)
 	ListUserOrganizationsWithDetails(ctx context.Context, userID pgtype.UUID) ([]ListUserOrganizationsWithDetailsRow, error)
 	ListUsers(ctx context.Context, status UserStatus) ([]User, error)
+	ListViolationsForExport(ctx context.Context, arg ListViolationsForExportParams) ([]ListViolationsForExportRow, error)
+	MarkReportDeliveryBounced(ctx context.Context, arg MarkReportDeliveryBouncedParams) (ReportDelivery, error)
+	MarkReportDeliveryFailed(ctx context.Context, arg MarkReportDeliveryFailedParams) (ReportDelivery, error)
+	MarkReportDeliverySent(ctx context.Context, arg MarkReportDeliverySentParams) (ReportDelivery, error)
 	RemoveOrganizationMember(ctx context.Context, id pgtype.UUID) error
 	ResetUserPassword(ctx context.Context, arg ResetUserPasswordParams) (User, error)
+	RevokeShareLink(ctx context.Context, id pgtype.UUID) (ShareLink, error)
+	SearchInspections(ctx context.Context, arg SearchInspectionsParams) ([]Inspection, error)
 	SearchOrganizationsByName(ctx context.Context, dollar_1 pgtype.Text) ([]Organization, error)
 	SetPasswordResetToken(ctx context.Context, arg SetPasswordResetTokenParams) error
+	SetUploadPhoto(ctx context.Context, arg SetUploadPhotoParams) (Upload, error)
 	SetVerificationToken(ctx context.Context, arg SetVerificationTokenParams) error
 	UpdateDetectedViolationNotes(ctx context.Context, arg UpdateDetectedViolationNotesParams) (DetectedViolation, error)
 	UpdateDetectedViolationSafetyCode(ctx context.Context, arg UpdateDetectedViolationSafetyCodeParams) (DetectedViolation, error)
 	UpdateDetectedViolationStatus(ctx context.Context, arg UpdateDetectedViolationStatusParams) (DetectedViolation, error)
+	UpdateInspection(ctx context.Context, arg UpdateInspectionParams) (Inspection, error)
 	UpdateInspectionStatus(ctx context.Context, arg UpdateInspectionStatusParams) (Inspection, error)
 	UpdateOrganization(ctx context.Context, arg UpdateOrganizationParams) (Organization, error)
 	UpdateOrganizationMemberRole(ctx context.Context, arg UpdateOrganizationMemberRoleParams) (OrganizationMember, error)
+	UpdatePhotoThumbnail(ctx context.Context, arg UpdatePhotoThumbnailParams) (Photo, error)
 	UpdateProject(ctx context.Context, arg UpdateProjectParams) (Project, error)
 	UpdateSafetyCode(ctx context.Context, arg UpdateSafetyCodeParams) (SafetyCode, error)
+	UpdateUploadProgress(ctx context.Context, arg UpdateUploadProgressParams) (Upload, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
 	UpdateUserLastLogin(ctx context.Context, id pgtype.UUID) error
 	UpdateUserStatus(ctx context.Context, arg UpdateUserStatusParams) (User, error)
@@ -28,7 +28,12 @@ RETURNING *;
 
 -- name: UpdateDetectedViolationStatus :one
 UPDATE detected_violations
-SET status = $2
+SET
+  status = $2,
+  resolved_at = CASE
+    WHEN $2 = 'pending' THEN NULL
+    ELSE COALESCE(resolved_at, CURRENT_TIMESTAMP)
+  END
 WHERE id = $1
 RETURNING *;
 
@@ -58,6 +63,11 @@ ORDER BY dv.created_at DESC;
 UPDATE detected_violations
 SET
   status = COALESCE(sqlc.narg(status), status),
+  resolved_at = CASE
+    WHEN sqlc.narg(status) IS NULL THEN resolved_at
+    WHEN sqlc.narg(status) = 'pending' THEN NULL
+    ELSE COALESCE(resolved_at, CURRENT_TIMESTAMP)
+  END,
   description = COALESCE(sqlc.narg(description), description)
 WHERE id = $1
 RETURNING *;
@@ -96,3 +106,35 @@ WHERE p.organization_id = $1
   AND dv.created_at < $3
   AND dv.status = 'confirmed'
 GROUP BY dv.severity;
+
+-- name: ListViolationsForExport :many
+SELECT
+  dv.id,
+  dv.description,
+  dv.severity,
+  dv.status,
+  dv.location,
+  dv.created_at,
+  dv.resolved_at,
+  ph.id AS photo_id,
+  ph.storage_url AS photo_url,
+  i.id AS inspection_id,
+  i.status AS inspection_status,
+  COALESCE(i.performed_at, i.created_at)::timestamptz AS inspection_date,
+  p.id AS project_id,
+  p.name AS project_name,
+  sc.code AS safety_code,
+  sc.description AS safety_code_description
+FROM detected_violations dv
+JOIN photos ph ON ph.id = dv.photo_id
+JOIN inspections i ON i.id = ph.inspection_id
+JOIN projects p ON p.id = i.project_id
+LEFT JOIN safety_codes sc ON sc.id = dv.safety_code_id
+WHERE p.organization_id = sqlc.arg('organization_id')
+  AND (sqlc.narg('project_id')::uuid IS NULL OR p.id = sqlc.narg('project_id')::uuid)
+  AND (sqlc.narg('status')::violation_status IS NULL OR dv.status = sqlc.narg('status')::violation_status)
+  AND (sqlc.narg('created_from')::timestamptz IS NULL OR dv.created_at >= sqlc.narg('created_from')::timestamptz)
+  AND (sqlc.narg('created_to')::timestamptz IS NULL OR dv.created_at < sqlc.narg('created_to')::timestamptz)
+  AND (dv.created_at, dv.id) > (sqlc.arg('after_created_at')::timestamptz, sqlc.arg('after_id')::uuid)
+ORDER BY dv.created_at, dv.id
+LIMIT sqlc.arg('batch_size');
@@ -0,0 +1,22 @@
+-- name: ListInspectionSignoffs :many
+SELECT * FROM inspection_signoffs
+WHERE inspection_id = $1
+ORDER BY signed_at;
+
+-- name: CreateInspectionSignoff :one
+INSERT INTO inspection_signoffs (
+  inspection_id,
+  role,
+  signer_id,
+  signer_name,
+  signature_kind,
+  signature,
+  content_hash
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: DeleteInspectionSignoff :exec
+DELETE FROM inspection_signoffs
+WHERE id = $1;
@@ -2,31 +2,83 @@
 SELECT * FROM inspections
 WHERE id = $1 LIMIT 1;
 
--- name: ListInspections :many
+-- name: GetInspectionForUpdate :one
 SELECT * FROM inspections
-WHERE project_id = $1
-ORDER BY created_at DESC;
+WHERE id = $1 LIMIT 1
+FOR UPDATE;
 
--- name: ListInspectionsByInspector :many
+-- name: SearchInspections :many
 SELECT * FROM inspections
-WHERE inspector_id = $1
+WHERE (sqlc.narg('project_id')::uuid IS NULL OR project_id = sqlc.narg('project_id')::uuid)
+  AND (sqlc.narg('inspector_id')::uuid IS NULL OR inspector_id = sqlc.narg('inspector_id')::uuid)
+  AND (sqlc.narg('status')::inspection_status IS NULL OR status = sqlc.narg('status')::inspection_status)
+  AND (sqlc.narg('inspection_type')::text IS NULL OR inspection_type = sqlc.narg('inspection_type')::text)
+  AND (sqlc.narg('scheduled_from')::timestamptz IS NULL OR scheduled_at >= sqlc.narg('scheduled_from')::timestamptz)
+  AND (sqlc.narg('scheduled_to')::timestamptz IS NULL OR scheduled_at < sqlc.narg('scheduled_to')::timestamptz)
+  AND (sqlc.narg('performed_from')::timestamptz IS NULL OR performed_at >= sqlc.narg('performed_from')::timestamptz)
+  AND (sqlc.narg('performed_to')::timestamptz IS NULL OR performed_at < sqlc.narg('performed_to')::timestamptz)
+  AND (sqlc.narg('search')::text IS NULL
+    OR title ILIKE '%' || sqlc.narg('search')::text || '%'
+    OR notes ILIKE '%' || sqlc.narg('search')::text || '%')
 ORDER BY created_at DESC;
 
--- name: ListInspectionsByStatus :many
-SELECT * FROM inspections
-WHERE project_id = $1 AND status = $2
-ORDER BY created_at DESC;
+-- name: ListInspectionStats :many
+SELECT
+  i.id AS inspection_id,
+  COUNT(DISTINCT p.id)::int AS photo_count,
+  COUNT(dv.id)::int AS violation_count,
+  COUNT(dv.id) FILTER (WHERE dv.status = 'pending')::int AS pending_count,
+  COUNT(dv.id) FILTER (WHERE dv.status = 'confirmed')::int AS confirmed_count,
+  COUNT(dv.id) FILTER (WHERE dv.status = 'dismissed')::int AS dismissed_count,
+  COUNT(dv.id) FILTER (WHERE dv.severity = 'critical')::int AS critical_count,
+  COUNT(dv.id) FILTER (WHERE dv.severity = 'high')::int AS high_count,
+  COUNT(dv.id) FILTER (WHERE dv.severity = 'medium')::int AS medium_count,
+  COUNT(dv.id) FILTER (WHERE dv.severity = 'low')::int AS low_count
+FROM inspections i
+LEFT JOIN photos p ON p.inspection_id = i.id
+LEFT JOIN detected_violations dv ON dv.photo_id = p.id
+WHERE i.id = ANY(sqlc.arg('ids')::uuid[])
+GROUP BY i.id;
 
 -- name: CreateInspection :one
 INSERT INTO inspections (
   project_id,
   inspector_id,
-  status
+  status,
+  title,
+  inspection_type,
+  scheduled_at,
+  performed_at,
+  notes,
+  weather,
+  temperature_f,
+  attendees
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
 )
 RETURNING *;
 
+-- name: UpdateInspection :one
+UPDATE inspections
+SET
+  title = COALESCE(sqlc.narg('title'), title),
+  inspection_type = CASE WHEN sqlc.arg('clear_inspection_type')::boolean THEN NULL
+    ELSE COALESCE(sqlc.narg('inspection_type')::text, inspection_type) END,
+  scheduled_at = CASE WHEN sqlc.arg('clear_scheduled_at')::boolean THEN NULL
+    ELSE COALESCE(sqlc.narg('scheduled_at')::timestamptz, scheduled_at) END,
+  performed_at = CASE WHEN sqlc.arg('clear_performed_at')::boolean THEN NULL
+    ELSE COALESCE(sqlc.narg('performed_at')::timestamptz, performed_at) END,
+  notes = CASE WHEN sqlc.arg('clear_notes')::boolean THEN NULL
+    ELSE COALESCE(sqlc.narg('notes')::text, notes) END,
+  weather = CASE WHEN sqlc.arg('clear_weather')::boolean THEN NULL
+    ELSE COALESCE(sqlc.narg('weather')::text, weather) END,
+  temperature_f = CASE WHEN sqlc.arg('clear_temperature_f')::boolean THEN NULL
+    ELSE COALESCE(sqlc.narg('temperature_f')::double precision, temperature_f) END,
+  attendees = COALESCE(sqlc.narg('attendees'), attendees),
+  updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
 -- name: UpdateInspectionStatus :one
 UPDATE inspections
 SET
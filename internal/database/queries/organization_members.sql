@@ -22,6 +22,11 @@ SELECT
   o.name,
   o.created_at,
   o.updated_at,
+  o.logo_url,
+  o.primary_color,
+  o.address,
+  o.report_footer,
+  o.report_template,
   om.role
 FROM organizations o
 INNER JOIN organization_members om ON o.id = om.organization_id
@@ -17,9 +17,14 @@ RETURNING *;
 -- name: UpdateOrganization :one
 UPDATE organizations
 SET
-  name = COALESCE($2, name),
+  name = COALESCE(sqlc.narg('name'), name),
+  logo_url = COALESCE(sqlc.narg('logo_url'), logo_url),
+  primary_color = COALESCE(sqlc.narg('primary_color'), primary_color),
+  address = COALESCE(sqlc.narg('address'), address),
+  report_footer = COALESCE(sqlc.narg('report_footer'), report_footer),
+  report_template = COALESCE(sqlc.narg('report_template'), report_template),
   updated_at = CURRENT_TIMESTAMP
-WHERE id = $1
+WHERE id = sqlc.arg('id')
 RETURNING *;
 
 -- name: DeleteOrganization :exec
@@ -11,12 +11,20 @@ ORDER BY created_at DESC;
 INSERT INTO photos (
   inspection_id,
   storage_url,
-  thumbnail_url
+  thumbnail_url,
+  size_bytes
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4
 )
 RETURNING *;
 
+-- name: UpdatePhotoThumbnail :one
+UPDATE photos
+SET
+  thumbnail_url = $2
+WHERE id = $1
+RETURNING *;
+
 -- name: DeletePhoto :exec
 DELETE FROM photos
 WHERE id = $1;
@@ -0,0 +1,23 @@
+-- name: GetProjectContact :one
+SELECT * FROM project_contacts
+WHERE id = $1 LIMIT 1;
+
+-- name: ListProjectContacts :many
+SELECT * FROM project_contacts
+WHERE project_id = $1
+ORDER BY name;
+
+-- name: CreateProjectContact :one
+INSERT INTO project_contacts (
+  project_id,
+  name,
+  email,
+  role
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: DeleteProjectContact :exec
+DELETE FROM project_contacts
+WHERE id = $1;
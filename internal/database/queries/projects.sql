@@ -2,11 +2,33 @@
 SELECT * FROM projects
 WHERE id = $1 LIMIT 1;
 
+-- name: GetProjectStats :one
+SELECT
+  pr.id AS project_id,
+  COUNT(DISTINCT i.id)::int AS inspection_count,
+  COUNT(DISTINCT p.id)::int AS photo_count,
+  COUNT(dv.id)::int AS violation_count,
+  COUNT(dv.id) FILTER (WHERE dv.status = 'pending')::int AS open_violations,
+  COUNT(dv.id) FILTER (WHERE dv.status IN ('confirmed', 'dismissed'))::int AS closed_violations,
+  COUNT(dv.id) FILTER (WHERE dv.status = 'confirmed')::int AS confirmed_violations,
+  COUNT(dv.id) FILTER (WHERE dv.status = 'dismissed')::int AS dismissed_violations
+FROM projects pr
+LEFT JOIN inspections i ON i.project_id = pr.id
+LEFT JOIN photos p ON p.inspection_id = i.id
+LEFT JOIN detected_violations dv ON dv.photo_id = p.id
+WHERE pr.id = $1
+GROUP BY pr.id;
+
 -- name: ListProjects :many
 SELECT * FROM projects
 WHERE organization_id = $1
 ORDER BY created_at DESC;
 
+-- name: ListProjectsByStatus :many
+SELECT * FROM projects
+WHERE status = $1
+ORDER BY created_at;
+
 -- name: CreateProject :one
 INSERT INTO projects (
   organization_id,
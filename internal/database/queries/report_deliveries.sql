@@ -0,0 +1,48 @@
+-- name: GetReportDelivery :one
+SELECT * FROM report_deliveries
+WHERE id = $1 LIMIT 1;
+
+-- name: ListReportDeliveries :many
+SELECT * FROM report_deliveries
+WHERE report_id = $1
+ORDER BY created_at DESC;
+
+-- name: CreateReportDelivery :one
+INSERT INTO report_deliveries (
+  report_id,
+  recipient,
+  contact_id,
+  attached,
+  message,
+  sent_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: MarkReportDeliverySent :one
+UPDATE report_deliveries
+SET
+  status = 'sent',
+  provider_message_id = $2,
+  error = NULL,
+  sent_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: MarkReportDeliveryFailed :one
+UPDATE report_deliveries
+SET
+  status = 'failed',
+  error = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: MarkReportDeliveryBounced :one
+UPDATE report_deliveries
+SET
+  status = 'bounced',
+  error = $2,
+  bounced_at = $3
+WHERE provider_message_id = $1
+RETURNING *;
@@ -5,17 +5,35 @@ WHERE id = $1 LIMIT 1;
 -- name: ListReports :many
 SELECT * FROM reports
 WHERE inspection_id = $1
-ORDER BY created_at DESC;
+ORDER BY version DESC;
 
 -- name: CreateReport :one
 INSERT INTO reports (
   inspection_id,
-  storage_url
+  version,
+  storage_url,
+  size_bytes,
+  snapshot_hash,
+  snapshot,
+  generated_by,
+  template,
+  sha256,
+  signature,
+  signing_key_id,
+  signoffs
 ) VALUES (
-  $1, $2
+  $1,
+  (SELECT COALESCE(MAX(r.version), 0) + 1 FROM reports r WHERE r.inspection_id = $1),
+  $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
 )
 RETURNING *;
 
+-- name: GetReportBySHA256 :one
+SELECT * FROM reports
+WHERE sha256 = $1 AND sha256 <> ''
+ORDER BY created_at DESC
+LIMIT 1;
+
 -- name: DeleteReport :exec
 DELETE FROM reports
 WHERE id = $1;
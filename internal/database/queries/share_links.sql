@@ -0,0 +1,55 @@
+-- name: GetShareLink :one
+SELECT * FROM share_links
+WHERE id = $1 LIMIT 1;
+
+-- name: GetShareLinkByTokenHash :one
+SELECT * FROM share_links
+WHERE token_hash = $1 LIMIT 1;
+
+-- name: ListShareLinks :many
+SELECT * FROM share_links
+WHERE inspection_id = $1
+ORDER BY created_at DESC;
+
+-- name: CreateShareLink :one
+INSERT INTO share_links (
+  inspection_id,
+  report_id,
+  kind,
+  token_hash,
+  password_hash,
+  expires_at,
+  created_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: RevokeShareLink :one
+UPDATE share_links
+SET revoked_at = COALESCE(revoked_at, CURRENT_TIMESTAMP)
+WHERE id = $1
+RETURNING *;
+
+-- name: IncrementShareLinkViews :exec
+UPDATE share_links
+SET
+  view_count = view_count + 1,
+  last_viewed_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: CreateShareLinkView :one
+INSERT INTO share_link_views (
+  share_link_id,
+  ip_address,
+  user_agent
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: ListShareLinkViews :many
+SELECT * FROM share_link_views
+WHERE share_link_id = $1
+ORDER BY viewed_at DESC
+LIMIT $2;
@@ -0,0 +1,49 @@
+-- name: ListProjectStorageUsage :many
+SELECT
+  p.id AS project_id,
+  p.name AS project_name,
+  COALESCE(ph.photo_count, 0)::bigint AS photo_count,
+  COALESCE(ph.photo_bytes, 0)::bigint AS photo_bytes,
+  COALESCE(r.report_count, 0)::bigint AS report_count,
+  COALESCE(r.report_bytes, 0)::bigint AS report_bytes,
+  COALESCE(sr.summary_report_bytes, 0)::bigint AS summary_report_bytes,
+  COALESCE(u.upload_bytes, 0)::bigint AS upload_bytes
+FROM projects p
+LEFT JOIN (
+  SELECT
+    i.project_id,
+    COUNT(*) AS photo_count,
+    SUM(photos.size_bytes) AS photo_bytes
+  FROM photos
+  JOIN inspections i ON i.id = photos.inspection_id
+  GROUP BY i.project_id
+) ph ON ph.project_id = p.id
+LEFT JOIN (
+  SELECT
+    i.project_id,
+    COUNT(*) AS report_count,
+    SUM(reports.size_bytes) AS report_bytes
+  FROM reports
+  JOIN inspections i ON i.id = reports.inspection_id
+  GROUP BY i.project_id
+) r ON r.project_id = p.id
+LEFT JOIN (
+  SELECT
+    project_id,
+    SUM(size_bytes) AS summary_report_bytes
+  FROM summary_reports
+  GROUP BY project_id
+) sr ON sr.project_id = p.id
+LEFT JOIN (
+  -- Uploads in progress reserve their declared length
+  SELECT
+    i.project_id,
+    SUM(uploads.upload_length) AS upload_bytes
+  FROM uploads
+  JOIN inspections i ON i.id = uploads.inspection_id
+  WHERE uploads.status = 'in_progress'
+    AND uploads.expires_at > CURRENT_TIMESTAMP
+  GROUP BY i.project_id
+) u ON u.project_id = p.id
+WHERE p.organization_id = $1
+ORDER BY p.name;
@@ -0,0 +1,22 @@
+-- name: GetSummaryReport :one
+SELECT * FROM summary_reports
+WHERE id = $1 LIMIT 1;
+
+-- name: ListSummaryReports :many
+SELECT * FROM summary_reports
+WHERE project_id = $1
+ORDER BY created_at DESC;
+
+-- name: CreateSummaryReport :one
+INSERT INTO summary_reports (
+  project_id,
+  period_start,
+  period_end,
+  storage_url,
+  size_bytes,
+  summary,
+  generated_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
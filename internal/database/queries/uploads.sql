@@ -0,0 +1,75 @@
+-- name: GetUpload :one
+SELECT * FROM uploads
+WHERE id = $1 LIMIT 1;
+
+-- name: GetUploadForUpdate :one
+SELECT * FROM uploads
+WHERE id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: CreateUpload :one
+INSERT INTO uploads (
+  inspection_id,
+  user_id,
+  filename,
+  content_type,
+  upload_length,
+  storage_key,
+  multipart_upload_id,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: UpdateUploadProgress :one
+UPDATE uploads
+SET
+  upload_offset = $2,
+  pending_data = $3,
+  updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: CompleteUpload :one
+UPDATE uploads
+SET
+  status = 'completed',
+  storage_url = $2,
+  pending_data = '',
+  updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: SetUploadPhoto :one
+UPDATE uploads
+SET
+  photo_id = $2,
+  updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteUpload :exec
+DELETE FROM uploads
+WHERE id = $1;
+
+-- name: ListExpiredUploads :many
+SELECT * FROM uploads
+WHERE status = 'in_progress'
+  AND expires_at <= CURRENT_TIMESTAMP
+ORDER BY expires_at ASC;
+
+-- name: CreateUploadPart :exec
+INSERT INTO upload_parts (
+  upload_id,
+  part_number,
+  etag,
+  size
+) VALUES (
+  $1, $2, $3, $4
+);
+
+-- name: ListUploadParts :many
+SELECT * FROM upload_parts
+WHERE upload_id = $1
+ORDER BY part_number ASC;
@@ -0,0 +1,238 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: report_deliveries.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createReportDelivery = `-- name: CreateReportDelivery :one
+INSERT INTO report_deliveries (
+  report_id,
+  recipient,
+  contact_id,
+  attached,
+  message,
+  sent_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+RETURNING id, report_id, recipient, contact_id, attached, message, status, provider_message_id, error, sent_by, sent_at, bounced_at, created_at
+`
+
+type CreateReportDeliveryParams struct {
+	ReportID  pgtype.UUID `json:"report_id"`
+	Recipient string      `json:"recipient"`
+	ContactID pgtype.UUID `json:"contact_id"`
+	Attached  bool        `json:"attached"`
+	Message   pgtype.Text `json:"message"`
+	SentBy    pgtype.UUID `json:"sent_by"`
+}
+
+func (q *Queries) CreateReportDelivery(ctx context.Context, arg CreateReportDeliveryParams) (ReportDelivery, error) {
+	row := q.db.QueryRow(ctx, createReportDelivery,
+		arg.ReportID,
+		arg.Recipient,
+		arg.ContactID,
+		arg.Attached,
+		arg.Message,
+		arg.SentBy,
+	)
+	var i ReportDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.ReportID,
+		&i.Recipient,
+		&i.ContactID,
+		&i.Attached,
+		&i.Message,
+		&i.Status,
+		&i.ProviderMessageID,
+		&i.Error,
+		&i.SentBy,
+		&i.SentAt,
+		&i.BouncedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getReportDelivery = `-- name: GetReportDelivery :one
+SELECT id, report_id, recipient, contact_id, attached, message, status, provider_message_id, error, sent_by, sent_at, bounced_at, created_at FROM report_deliveries
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetReportDelivery(ctx context.Context, id pgtype.UUID) (ReportDelivery, error) {
+	row := q.db.QueryRow(ctx, getReportDelivery, id)
+	var i ReportDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.ReportID,
+		&i.Recipient,
+		&i.ContactID,
+		&i.Attached,
+		&i.Message,
+		&i.Status,
+		&i.ProviderMessageID,
+		&i.Error,
+		&i.SentBy,
+		&i.SentAt,
+		&i.BouncedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listReportDeliveries = `-- name: ListReportDeliveries :many
+SELECT id, report_id, recipient, contact_id, attached, message, status, provider_message_id, error, sent_by, sent_at, bounced_at, created_at FROM report_deliveries
+WHERE report_id = $1
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListReportDeliveries(ctx context.Context, reportID pgtype.UUID) ([]ReportDelivery, error) {
+	rows, err := q.db.Query(ctx, listReportDeliveries, reportID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ReportDelivery{}
+	for rows.Next() {
+		var i ReportDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.ReportID,
+			&i.Recipient,
+			&i.ContactID,
+			&i.Attached,
+			&i.Message,
+			&i.Status,
+			&i.ProviderMessageID,
+			&i.Error,
+			&i.SentBy,
+			&i.SentAt,
+			&i.BouncedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markReportDeliveryBounced = `-- name: MarkReportDeliveryBounced :one
+UPDATE report_deliveries
+SET
+  status = 'bounced',
+  error = $2,
+  bounced_at = $3
+WHERE provider_message_id = $1
+RETURNING id, report_id, recipient, contact_id, attached, message, status, provider_message_id, error, sent_by, sent_at, bounced_at, created_at
+`
+
+type MarkReportDeliveryBouncedParams struct {
+	ProviderMessageID pgtype.Text        `json:"provider_message_id"`
+	Error             pgtype.Text        `json:"error"`
+	BouncedAt         pgtype.Timestamptz `json:"bounced_at"`
+}
+
+func (q *Queries) MarkReportDeliveryBounced(ctx context.Context, arg MarkReportDeliveryBouncedParams) (ReportDelivery, error) {
+	row := q.db.QueryRow(ctx, markReportDeliveryBounced, arg.ProviderMessageID, arg.Error, arg.BouncedAt)
+	var i ReportDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.ReportID,
+		&i.Recipient,
+		&i.ContactID,
+		&i.Attached,
+		&i.Message,
+		&i.Status,
+		&i.ProviderMessageID,
+		&i.Error,
+		&i.SentBy,
+		&i.SentAt,
+		&i.BouncedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const markReportDeliveryFailed = `-- name: MarkReportDeliveryFailed :one
+UPDATE report_deliveries
+SET
+  status = 'failed',
+  error = $2
+WHERE id = $1
+RETURNING id, report_id, recipient, contact_id, attached, message, status, provider_message_id, error, sent_by, sent_at, bounced_at, created_at
+`
+
+type MarkReportDeliveryFailedParams struct {
+	ID    pgtype.UUID `json:"id"`
+	Error pgtype.Text `json:"error"`
+}
+
+func (q *Queries) MarkReportDeliveryFailed(ctx context.Context, arg MarkReportDeliveryFailedParams) (ReportDelivery, error) {
+	row := q.db.QueryRow(ctx, markReportDeliveryFailed, arg.ID, arg.Error)
+	var i ReportDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.ReportID,
+		&i.Recipient,
+		&i.ContactID,
+		&i.Attached,
+		&i.Message,
+		&i.Status,
+		&i.ProviderMessageID,
+		&i.Error,
+		&i.SentBy,
+		&i.SentAt,
+		&i.BouncedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const markReportDeliverySent = `-- name: MarkReportDeliverySent :one
+UPDATE report_deliveries
+SET
+  status = 'sent',
+  provider_message_id = $2,
+  error = NULL,
+  sent_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, report_id, recipient, contact_id, attached, message, status, provider_message_id, error, sent_by, sent_at, bounced_at, created_at
+`
+
+type MarkReportDeliverySentParams struct {
+	ID                pgtype.UUID `json:"id"`
+	ProviderMessageID pgtype.Text `json:"provider_message_id"`
+}
+
+func (q *Queries) MarkReportDeliverySent(ctx context.Context, arg MarkReportDeliverySentParams) (ReportDelivery, error) {
+	row := q.db.QueryRow(ctx, markReportDeliverySent, arg.ID, arg.ProviderMessageID)
+	var i ReportDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.ReportID,
+		&i.Recipient,
+		&i.ContactID,
+		&i.Attached,
+		&i.Message,
+		&i.Status,
+		&i.ProviderMessageID,
+		&i.Error,
+		&i.SentBy,
+		&i.SentAt,
+		&i.BouncedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -14,26 +14,69 @@ import (
 const createReport = `-- name: CreateReport :one
 INSERT INTO reports (
   inspection_id,
-  storage_url
+  version,
+  storage_url,
+  size_bytes,
+  snapshot_hash,
+  snapshot,
+  generated_by,
+  template,
+  sha256,
+  signature,
+  signing_key_id,
+  signoffs
 ) VALUES (
-  $1, $2
+  $1,
+  (SELECT COALESCE(MAX(r.version), 0) + 1 FROM reports r WHERE r.inspection_id = $1),
+  $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
 )
-RETURNING id, inspection_id, storage_url, created_at
+RETURNING id, inspection_id, storage_url, created_at, size_bytes, version, snapshot_hash, snapshot, generated_by, template, sha256, signature, signing_key_id, signoffs
 `
 
 type CreateReportParams struct {
-	InspectionID pgtype.UUID `json:"inspection_id"`
-	StorageUrl   string      `json:"storage_url"`
+	InspectionID pgtype.UUID    `json:"inspection_id"`
+	StorageUrl   string         `json:"storage_url"`
+	SizeBytes    int64          `json:"size_bytes"`
+	SnapshotHash string         `json:"snapshot_hash"`
+	Snapshot     []byte         `json:"snapshot"`
+	GeneratedBy  pgtype.UUID    `json:"generated_by"`
+	Template     ReportTemplate `json:"template"`
+	Sha256       string         `json:"sha256"`
+	Signature    string         `json:"signature"`
+	SigningKeyID string         `json:"signing_key_id"`
+	Signoffs     []byte         `json:"signoffs"`
 }
 
 func (q *Queries) CreateReport(ctx context.Context, arg CreateReportParams) (Report, error) {
-	row := q.db.QueryRow(ctx, createReport, arg.InspectionID, arg.StorageUrl)
+	row := q.db.QueryRow(ctx, createReport,
+		arg.InspectionID,
+		arg.StorageUrl,
+		arg.SizeBytes,
+		arg.SnapshotHash,
+		arg.Snapshot,
+		arg.GeneratedBy,
+		arg.Template,
+		arg.Sha256,
+		arg.Signature,
+		arg.SigningKeyID,
+		arg.Signoffs,
+	)
 	var i Report
 	err := row.Scan(
 		&i.ID,
 		&i.InspectionID,
 		&i.StorageUrl,
 		&i.CreatedAt,
+		&i.SizeBytes,
+		&i.Version,
+		&i.SnapshotHash,
+		&i.Snapshot,
+		&i.GeneratedBy,
+		&i.Template,
+		&i.Sha256,
+		&i.Signature,
+		&i.SigningKeyID,
+		&i.Signoffs,
 	)
 	return i, err
 }
@@ -49,7 +92,7 @@ func (q *Queries) DeleteReport(ctx context.Context, id pgtype.UUID) error {
 }
 
 const getReport = `-- name: GetReport :one
-SELECT id, inspection_id, storage_url, created_at FROM reports
+SELECT id, inspection_id, storage_url, created_at, size_bytes, version, snapshot_hash, snapshot, generated_by, template, sha256, signature, signing_key_id, signoffs FROM reports
 WHERE id = $1 LIMIT 1
 `
 
@@ -61,6 +104,45 @@ func (q *Queries) GetReport(ctx context.Context, id pgtype.UUID) (Report, error)
 		&i.InspectionID,
 		&i.StorageUrl,
 		&i.CreatedAt,
+		&i.SizeBytes,
+		&i.Version,
+		&i.SnapshotHash,
+		&i.Snapshot,
+		&i.GeneratedBy,
+		&i.Template,
+		&i.Sha256,
+		&i.Signature,
+		&i.SigningKeyID,
+		&i.Signoffs,
+	)
+	return i, err
+}
+
+const getReportBySHA256 = `-- name: GetReportBySHA256 :one
+SELECT id, inspection_id, storage_url, created_at, size_bytes, version, snapshot_hash, snapshot, generated_by, template, sha256, signature, signing_key_id, signoffs FROM reports
+WHERE sha256 = $1 AND sha256 <> ''
+ORDER BY created_at DESC
+LIMIT 1
+`
+
+func (q *Queries) GetReportBySHA256(ctx context.Context, sha256 string) (Report, error) {
+	row := q.db.QueryRow(ctx, getReportBySHA256, sha256)
+	var i Report
+	err := row.Scan(
+		&i.ID,
+		&i.InspectionID,
+		&i.StorageUrl,
+		&i.CreatedAt,
+		&i.SizeBytes,
+		&i.Version,
+		&i.SnapshotHash,
+		&i.Snapshot,
+		&i.GeneratedBy,
+		&i.Template,
+		&i.Sha256,
+		&i.Signature,
+		&i.SigningKeyID,
+		&i.Signoffs,
 	)
 	return i, err
 }
@@ -89,9 +171,9 @@ func (q *Queries) GetReportCountByOrganizationAndDateRange(ctx context.Context,
 }
 
 const listReports = `-- name: ListReports :many
-SELECT id, inspection_id, storage_url, created_at FROM reports
+SELECT id, inspection_id, storage_url, created_at, size_bytes, version, snapshot_hash, snapshot, generated_by, template, sha256, signature, signing_key_id, signoffs FROM reports
 WHERE inspection_id = $1
-ORDER BY created_at DESC
+ORDER BY version DESC
 `
 
 func (q *Queries) ListReports(ctx context.Context, inspectionID pgtype.UUID) ([]Report, error) {
@@ -108,6 +190,16 @@ func (q *Queries) ListReports(ctx context.Context, inspectionID pgtype.UUID) ([]
 			&i.InspectionID,
 			&i.StorageUrl,
 			&i.CreatedAt,
+			&i.SizeBytes,
+			&i.Version,
+			&i.SnapshotHash,
+			&i.Snapshot,
+			&i.GeneratedBy,
+			&i.Template,
+			&i.Sha256,
+			&i.Signature,
+			&i.SigningKeyID,
+			&i.Signoffs,
 		); err != nil {
 			return nil, err
 		}
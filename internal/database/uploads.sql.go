@@ -0,0 +1,363 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: uploads.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const completeUpload = `-- name: CompleteUpload :one
+UPDATE uploads
+SET
+  status = 'completed',
+  storage_url = $2,
+  pending_data = '',
+  updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, inspection_id, user_id, filename, content_type, upload_length, upload_offset, storage_key, multipart_upload_id, pending_data, status, storage_url, photo_id, expires_at, created_at, updated_at
+`
+
+type CompleteUploadParams struct {
+	ID         pgtype.UUID `json:"id"`
+	StorageUrl pgtype.Text `json:"storage_url"`
+}
+
+func (q *Queries) CompleteUpload(ctx context.Context, arg CompleteUploadParams) (Upload, error) {
+	row := q.db.QueryRow(ctx, completeUpload, arg.ID, arg.StorageUrl)
+	var i Upload
+	err := row.Scan(
+		&i.ID,
+		&i.InspectionID,
+		&i.UserID,
+		&i.Filename,
+		&i.ContentType,
+		&i.UploadLength,
+		&i.UploadOffset,
+		&i.StorageKey,
+		&i.MultipartUploadID,
+		&i.PendingData,
+		&i.Status,
+		&i.StorageUrl,
+		&i.PhotoID,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createUpload = `-- name: CreateUpload :one
+INSERT INTO uploads (
+  inspection_id,
+  user_id,
+  filename,
+  content_type,
+  upload_length,
+  storage_key,
+  multipart_upload_id,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING id, inspection_id, user_id, filename, content_type, upload_length, upload_offset, storage_key, multipart_upload_id, pending_data, status, storage_url, photo_id, expires_at, created_at, updated_at
+`
+
+type CreateUploadParams struct {
+	InspectionID      pgtype.UUID        `json:"inspection_id"`
+	UserID            pgtype.UUID        `json:"user_id"`
+	Filename          pgtype.Text        `json:"filename"`
+	ContentType       string             `json:"content_type"`
+	UploadLength      int64              `json:"upload_length"`
+	StorageKey        string             `json:"storage_key"`
+	MultipartUploadID string             `json:"multipart_upload_id"`
+	ExpiresAt         pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) CreateUpload(ctx context.Context, arg CreateUploadParams) (Upload, error) {
+	row := q.db.QueryRow(ctx, createUpload,
+		arg.InspectionID,
+		arg.UserID,
+		arg.Filename,
+		arg.ContentType,
+		arg.UploadLength,
+		arg.StorageKey,
+		arg.MultipartUploadID,
+		arg.ExpiresAt,
+	)
+	var i Upload
+	err := row.Scan(
+		&i.ID,
+		&i.InspectionID,
+		&i.UserID,
+		&i.Filename,
+		&i.ContentType,
+		&i.UploadLength,
+		&i.UploadOffset,
+		&i.StorageKey,
+		&i.MultipartUploadID,
+		&i.PendingData,
+		&i.Status,
+		&i.StorageUrl,
+		&i.PhotoID,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createUploadPart = `-- name: CreateUploadPart :exec
+INSERT INTO upload_parts (
+  upload_id,
+  part_number,
+  etag,
+  size
+) VALUES (
+  $1, $2, $3, $4
+)
+`
+
+type CreateUploadPartParams struct {
+	UploadID   pgtype.UUID `json:"upload_id"`
+	PartNumber int32       `json:"part_number"`
+	Etag       string      `json:"etag"`
+	Size       int64       `json:"size"`
+}
+
+func (q *Queries) CreateUploadPart(ctx context.Context, arg CreateUploadPartParams) error {
+	_, err := q.db.Exec(ctx, createUploadPart,
+		arg.UploadID,
+		arg.PartNumber,
+		arg.Etag,
+		arg.Size,
+	)
+	return err
+}
+
+const deleteUpload = `-- name: DeleteUpload :exec
+DELETE FROM uploads
+WHERE id = $1
+`
+
+func (q *Queries) DeleteUpload(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteUpload, id)
+	return err
+}
+
+const getUpload = `-- name: GetUpload :one
+SELECT id, inspection_id, user_id, filename, content_type, upload_length, upload_offset, storage_key, multipart_upload_id, pending_data, status, storage_url, photo_id, expires_at, created_at, updated_at FROM uploads
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetUpload(ctx context.Context, id pgtype.UUID) (Upload, error) {
+	row := q.db.QueryRow(ctx, getUpload, id)
+	var i Upload
+	err := row.Scan(
+		&i.ID,
+		&i.InspectionID,
+		&i.UserID,
+		&i.Filename,
+		&i.ContentType,
+		&i.UploadLength,
+		&i.UploadOffset,
+		&i.StorageKey,
+		&i.MultipartUploadID,
+		&i.PendingData,
+		&i.Status,
+		&i.StorageUrl,
+		&i.PhotoID,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getUploadForUpdate = `-- name: GetUploadForUpdate :one
+SELECT id, inspection_id, user_id, filename, content_type, upload_length, upload_offset, storage_key, multipart_upload_id, pending_data, status, storage_url, photo_id, expires_at, created_at, updated_at FROM uploads
+WHERE id = $1 LIMIT 1
+FOR UPDATE
+`
+
+func (q *Queries) GetUploadForUpdate(ctx context.Context, id pgtype.UUID) (Upload, error) {
+	row := q.db.QueryRow(ctx, getUploadForUpdate, id)
+	var i Upload
+	err := row.Scan(
+		&i.ID,
+		&i.InspectionID,
+		&i.UserID,
+		&i.Filename,
+		&i.ContentType,
+		&i.UploadLength,
+		&i.UploadOffset,
+		&i.StorageKey,
+		&i.MultipartUploadID,
+		&i.PendingData,
+		&i.Status,
+		&i.StorageUrl,
+		&i.PhotoID,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listExpiredUploads = `-- name: ListExpiredUploads :many
+SELECT id, inspection_id, user_id, filename, content_type, upload_length, upload_offset, storage_key, multipart_upload_id, pending_data, status, storage_url, photo_id, expires_at, created_at, updated_at FROM uploads
+WHERE status = 'in_progress'
+  AND expires_at <= CURRENT_TIMESTAMP
+ORDER BY expires_at ASC
+`
+
+func (q *Queries) ListExpiredUploads(ctx context.Context) ([]Upload, error) {
+	rows, err := q.db.Query(ctx, listExpiredUploads)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Upload{}
+	for rows.Next() {
+		var i Upload
+		if err := rows.Scan(
+			&i.ID,
+			&i.InspectionID,
+			&i.UserID,
+			&i.Filename,
+			&i.ContentType,
+			&i.UploadLength,
+			&i.UploadOffset,
+			&i.StorageKey,
+			&i.MultipartUploadID,
+			&i.PendingData,
+			&i.Status,
+			&i.StorageUrl,
+			&i.PhotoID,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUploadParts = `-- name: ListUploadParts :many
+SELECT upload_id, part_number, etag, size, created_at FROM upload_parts
+WHERE upload_id = $1
+ORDER BY part_number ASC
+`
+
+func (q *Queries) ListUploadParts(ctx context.Context, uploadID pgtype.UUID) ([]UploadPart, error) {
+	rows, err := q.db.Query(ctx, listUploadParts, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UploadPart{}
+	for rows.Next() {
+		var i UploadPart
+		if err := rows.Scan(
+			&i.UploadID,
+			&i.PartNumber,
+			&i.Etag,
+			&i.Size,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setUploadPhoto = `-- name: SetUploadPhoto :one
+UPDATE uploads
+SET
+  photo_id = $2,
+  updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, inspection_id, user_id, filename, content_type, upload_length, upload_offset, storage_key, multipart_upload_id, pending_data, status, storage_url, photo_id, expires_at, created_at, updated_at
+`
+
+type SetUploadPhotoParams struct {
+	ID      pgtype.UUID `json:"id"`
+	PhotoID pgtype.UUID `json:"photo_id"`
+}
+
+func (q *Queries) SetUploadPhoto(ctx context.Context, arg SetUploadPhotoParams) (Upload, error) {
+	row := q.db.QueryRow(ctx, setUploadPhoto, arg.ID, arg.PhotoID)
+	var i Upload
+	err := row.Scan(
+		&i.ID,
+		&i.InspectionID,
+		&i.UserID,
+		&i.Filename,
+		&i.ContentType,
+		&i.UploadLength,
+		&i.UploadOffset,
+		&i.StorageKey,
+		&i.MultipartUploadID,
+		&i.PendingData,
+		&i.Status,
+		&i.StorageUrl,
+		&i.PhotoID,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateUploadProgress = `-- name: UpdateUploadProgress :one
+UPDATE uploads
+SET
+  upload_offset = $2,
+  pending_data = $3,
+  updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, inspection_id, user_id, filename, content_type, upload_length, upload_offset, storage_key, multipart_upload_id, pending_data, status, storage_url, photo_id, expires_at, created_at, updated_at
+`
+
+type UpdateUploadProgressParams struct {
+	ID           pgtype.UUID `json:"id"`
+	UploadOffset int64       `json:"upload_offset"`
+	PendingData  []byte      `json:"pending_data"`
+}
+
+func (q *Queries) UpdateUploadProgress(ctx context.Context, arg UpdateUploadProgressParams) (Upload, error) {
+	row := q.db.QueryRow(ctx, updateUploadProgress, arg.ID, arg.UploadOffset, arg.PendingData)
+	var i Upload
+	err := row.Scan(
+		&i.ID,
+		&i.InspectionID,
+		&i.UserID,
+		&i.Filename,
+		&i.ContentType,
+		&i.UploadLength,
+		&i.UploadOffset,
+		&i.StorageKey,
+		&i.MultipartUploadID,
+		&i.PendingData,
+		&i.Status,
+		&i.StorageUrl,
+		&i.PhotoID,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
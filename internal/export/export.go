@@ -0,0 +1,186 @@
+// Package export writes violations as CSV or XLSX spreadsheets for safety
+// managers to work with outside the application.
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/xlsx"
+)
+
+// Format is a spreadsheet file format.
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+// IsValid returns true if f is a known format.
+func (f Format) IsValid() bool {
+	return f == FormatCSV || f == FormatXLSX
+}
+
+// ContentType returns the MIME type of files in the format.
+func (f Format) ContentType() string {
+	if f == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// violationColumns are the columns of a violation export.
+var violationColumns = []string{
+	"Violation ID",
+	"Project",
+	"Project ID",
+	"Inspection ID",
+	"Inspection Date",
+	"Inspection Status",
+	"Photo ID",
+	"Photo URL",
+	"Safety Code",
+	"Safety Code Description",
+	"Description",
+	"Severity",
+	"Status",
+	"Location",
+	"Detected At",
+}
+
+// Violations writes the violations matching filter to w in a format, one
+// row per violation, and returns how many were written. Rows are written as
+// they are read, so nothing is written before an error reading the first
+// batch. Times are in UTC.
+func Violations(ctx context.Context, violations aletheia.ViolationService, filter aletheia.ViolationExportFilter, format Format, w io.Writer) (int, error) {
+	var t table
+	started := false
+	start := func() error {
+		var err error
+		if format == FormatXLSX {
+			t, err = newXLSXTable(w, "Violations")
+		} else {
+			t = newCSVTable(w)
+		}
+		if err != nil {
+			return err
+		}
+		started = true
+		return t.header(violationColumns)
+	}
+
+	n := 0
+	err := violations.ExportViolations(ctx, filter, func(v *aletheia.ViolationExportRow) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		n++
+		return t.row([]any{
+			v.ID.String(),
+			v.ProjectName,
+			v.ProjectID.String(),
+			v.InspectionID.String(),
+			v.InspectionDate,
+			string(v.InspectionStatus),
+			v.PhotoID.String(),
+			v.PhotoURL,
+			v.SafetyCode,
+			v.SafetyCodeDescription,
+			v.Description,
+			string(v.Severity),
+			string(v.Status),
+			v.Location,
+			v.CreatedAt,
+		})
+	})
+	if err != nil {
+		return n, err
+	}
+
+	// An export with no violations still has its header
+	if !started {
+		if err := start(); err != nil {
+			return 0, err
+		}
+	}
+	return n, t.close()
+}
+
+// table writes the rows of an export in one format.
+type table interface {
+	header(titles []string) error
+	row(values []any) error
+	close() error
+}
+
+type csvTable struct {
+	w *csv.Writer
+}
+
+func newCSVTable(w io.Writer) *csvTable {
+	return &csvTable{w: csv.NewWriter(w)}
+}
+
+func (t *csvTable) header(titles []string) error {
+	return t.w.Write(titles)
+}
+
+func (t *csvTable) row(values []any) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		switch v := v.(type) {
+		case string:
+			record[i] = neutralize(v)
+		case time.Time:
+			if !v.IsZero() {
+				record[i] = v.UTC().Format(time.RFC3339)
+			}
+		}
+	}
+	return t.w.Write(record)
+}
+
+func (t *csvTable) close() error {
+	t.w.Flush()
+	return t.w.Error()
+}
+
+// neutralize keeps spreadsheets from evaluating text that looks like a
+// formula, such as a violation description starting with "=".
+func neutralize(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+type xlsxTable struct {
+	w *xlsx.Writer
+}
+
+func newXLSXTable(w io.Writer, sheet string) (*xlsxTable, error) {
+	xw, err := xlsx.NewWriter(w, sheet)
+	if err != nil {
+		return nil, err
+	}
+	return &xlsxTable{w: xw}, nil
+}
+
+func (t *xlsxTable) header(titles []string) error {
+	return t.w.WriteHeader(titles...)
+}
+
+func (t *xlsxTable) row(values []any) error {
+	return t.w.WriteRow(values...)
+}
+
+func (t *xlsxTable) close() error {
+	return t.w.Close()
+}
@@ -0,0 +1,119 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testViolations returns a violation service exporting rows.
+func testViolations(rows ...*aletheia.ViolationExportRow) *mock.ViolationService {
+	return &mock.ViolationService{
+		ExportViolationsFn: func(ctx context.Context, filter aletheia.ViolationExportFilter, fn func(*aletheia.ViolationExportRow) error) error {
+			for _, row := range rows {
+				if err := fn(row); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func TestViolations_CSV(t *testing.T) {
+	row := &aletheia.ViolationExportRow{
+		ID:               uuid.New(),
+		ProjectID:        uuid.New(),
+		ProjectName:      "Riverside Tower",
+		InspectionID:     uuid.New(),
+		InspectionStatus: aletheia.InspectionStatusCompleted,
+		InspectionDate:   time.Date(2025, 12, 1, 9, 0, 0, 0, time.FixedZone("PST", -8*3600)),
+		PhotoID:          uuid.New(),
+		PhotoURL:         "https://example.com/photo.jpg",
+		SafetyCode:       "1926.501",
+		Description:      "=HYPERLINK(\"x\")",
+		Severity:         aletheia.SeverityHigh,
+		Status:           aletheia.ViolationStatusConfirmed,
+		Location:         "Level 3, east stair",
+		CreatedAt:        time.Date(2025, 12, 1, 17, 30, 0, 0, time.UTC),
+	}
+
+	var buf bytes.Buffer
+	n, err := Violations(context.Background(), testViolations(row), aletheia.ViolationExportFilter{}, FormatCSV, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, violationColumns, records[0])
+
+	record := records[1]
+	require.Len(t, record, len(violationColumns))
+	assert.Equal(t, row.ID.String(), record[0])
+	assert.Equal(t, "Riverside Tower", record[1])
+	assert.Equal(t, "2025-12-01T17:00:00Z", record[4])
+	assert.Equal(t, "completed", record[5])
+	assert.Equal(t, "", record[9])
+	assert.Equal(t, `'=HYPERLINK("x")`, record[10])
+	assert.Equal(t, "high", record[11])
+	assert.Equal(t, "confirmed", record[12])
+	assert.Equal(t, "2025-12-01T17:30:00Z", record[14])
+}
+
+func TestViolations_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := Violations(context.Background(), testViolations(), aletheia.ViolationExportFilter{}, FormatCSV, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{violationColumns}, records)
+}
+
+func TestViolations_XLSX(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := Violations(context.Background(), testViolations(&aletheia.ViolationExportRow{ID: uuid.New()}, &aletheia.ViolationExportRow{ID: uuid.New()}), aletheia.ViolationExportFilter{}, FormatXLSX, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	// Spreadsheets are zip files
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("PK")))
+}
+
+func TestViolations_Error(t *testing.T) {
+	violations := &mock.ViolationService{
+		ExportViolationsFn: func(ctx context.Context, filter aletheia.ViolationExportFilter, fn func(*aletheia.ViolationExportRow) error) error {
+			return errors.New("connection reset")
+		},
+	}
+
+	var buf bytes.Buffer
+	_, err := Violations(context.Background(), violations, aletheia.ViolationExportFilter{}, FormatCSV, &buf)
+	assert.Error(t, err)
+	// Nothing is written, so the error can still be reported to the client
+	assert.Zero(t, buf.Len())
+}
+
+func TestNeutralize(t *testing.T) {
+	for in, want := range map[string]string{
+		"":          "",
+		"Guardrail": "Guardrail",
+		"=1+1":      "'=1+1",
+		"+1":        "'+1",
+		"-1":        "'-1",
+		"@SUM(A1)":  "'@SUM(A1)",
+		"a=b":       "a=b",
+	} {
+		assert.Equal(t, want, neutralize(in), in)
+	}
+}
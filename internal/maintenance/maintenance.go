@@ -0,0 +1,118 @@
+// Package maintenance defines the recurring cleanup jobs run by aletheiad.
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/queue"
+)
+
+// Job types for maintenance jobs.
+const (
+	JobTypeCleanupSessions  = "cleanup_sessions"
+	JobTypeCleanupUploads   = "cleanup_uploads"
+	JobTypeCleanupJobs      = "cleanup_jobs"
+	JobTypeCleanupAuditLogs = "cleanup_audit_logs"
+)
+
+// Queue is the queue maintenance jobs run on, so they never compete with
+// user-facing work.
+const Queue = aletheia.QueueLow
+
+// AuditLogCleaner deletes audit logs older than a retention period.
+type AuditLogCleaner interface {
+	CleanupOldAuditLogs(ctx context.Context, retentionDays int) (int64, error)
+}
+
+// Config controls how often maintenance runs and what it keeps.
+type Config struct {
+	JobCleanupInterval    time.Duration // How often to delete finished jobs
+	JobRetention          time.Duration // How long finished jobs are kept
+	AuditLogRetentionDays int           // How long audit logs are kept
+}
+
+// Handlers runs maintenance jobs.
+type Handlers struct {
+	Sessions  aletheia.SessionService
+	Uploads   aletheia.UploadService
+	Queue     aletheia.Queue
+	AuditLogs AuditLogCleaner
+	Config    Config
+	Logger    *slog.Logger
+}
+
+// Register registers a handler for each maintenance job type.
+func (h *Handlers) Register(pool *queue.WorkerPool) {
+	pool.RegisterHandler(JobTypeCleanupSessions, aletheia.JobHandlerFunc(h.cleanupSessions))
+	pool.RegisterHandler(JobTypeCleanupUploads, aletheia.JobHandlerFunc(h.cleanupUploads))
+	pool.RegisterHandler(JobTypeCleanupJobs, aletheia.JobHandlerFunc(h.cleanupJobs))
+	pool.RegisterHandler(JobTypeCleanupAuditLogs, aletheia.JobHandlerFunc(h.cleanupAuditLogs))
+}
+
+// Schedules returns the recurring schedules for maintenance jobs.
+func Schedules(cfg Config) []queue.Schedule {
+	return []queue.Schedule{
+		schedule(JobTypeCleanupSessions, "@hourly"),
+		schedule(JobTypeCleanupUploads, "15 * * * *"),
+		schedule(JobTypeCleanupJobs, fmt.Sprintf("@every %s", cfg.JobCleanupInterval)),
+		schedule(JobTypeCleanupAuditLogs, "30 3 * * *"),
+	}
+}
+
+// schedule declares a maintenance job named after its type. Maintenance jobs
+// belong to no organization and are not retried within a run; the next run
+// picks up where a failed one left off. A run is skipped while the previous
+// one is still pending or running.
+func schedule(jobType, spec string) queue.Schedule {
+	return queue.Schedule{
+		Name: jobType,
+		Spec: spec,
+		Job: aletheia.Job{
+			QueueName:   Queue,
+			JobType:     jobType,
+			Payload:     []byte(`{}`),
+			MaxAttempts: 1,
+			UniqueKey:   jobType,
+		},
+	}
+}
+
+func (h *Handlers) cleanupSessions(ctx context.Context, job *aletheia.Job) error {
+	count, err := h.Sessions.CleanupExpiredSessions(ctx)
+	if err != nil {
+		return fmt.Errorf("cleaning up sessions: %w", err)
+	}
+	h.Logger.Info("expired sessions cleaned up", slog.Int("count", count))
+	return nil
+}
+
+func (h *Handlers) cleanupUploads(ctx context.Context, job *aletheia.Job) error {
+	count, err := h.Uploads.CleanupExpiredUploads(ctx)
+	if err != nil {
+		return fmt.Errorf("cleaning up uploads: %w", err)
+	}
+	h.Logger.Info("expired uploads cleaned up", slog.Int("count", count))
+	return nil
+}
+
+func (h *Handlers) cleanupJobs(ctx context.Context, job *aletheia.Job) error {
+	count, err := h.Queue.CleanupJobs(ctx, h.Config.JobRetention)
+	if err != nil {
+		return fmt.Errorf("cleaning up jobs: %w", err)
+	}
+	h.Logger.Info("finished jobs cleaned up", slog.Int("count", count))
+	return nil
+}
+
+func (h *Handlers) cleanupAuditLogs(ctx context.Context, job *aletheia.Job) error {
+	count, err := h.AuditLogs.CleanupOldAuditLogs(ctx, h.Config.AuditLogRetentionDays)
+	if err != nil {
+		return fmt.Errorf("cleaning up audit logs: %w", err)
+	}
+	h.Logger.Info("old audit logs cleaned up", slog.Int64("count", count))
+	return nil
+}
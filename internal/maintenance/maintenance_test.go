@@ -0,0 +1,77 @@
+package maintenance
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/queue"
+	"github.com/dukerupert/aletheia/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type auditLogCleaner func(ctx context.Context, retentionDays int) (int64, error)
+
+func (f auditLogCleaner) CleanupOldAuditLogs(ctx context.Context, retentionDays int) (int64, error) {
+	return f(ctx, retentionDays)
+}
+
+func TestSchedules_Register(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	scheduler := queue.NewScheduler(mock.NewQueue(), logger)
+
+	schedules := Schedules(Config{JobCleanupInterval: time.Hour})
+	require.Len(t, schedules, 4)
+
+	for _, schedule := range schedules {
+		assert.NoError(t, scheduler.Register(schedule), schedule.Name)
+		assert.Equal(t, Queue, schedule.Job.QueueName)
+	}
+}
+
+func TestHandlers(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	mockQueue := mock.NewQueue()
+
+	var called []string
+	var retentionDays int
+	handlers := &Handlers{
+		Sessions: &mock.SessionService{
+			CleanupExpiredSessionsFn: func(ctx context.Context) (int, error) {
+				called = append(called, JobTypeCleanupSessions)
+				return 2, nil
+			},
+		},
+		Uploads: &mock.UploadService{
+			CleanupExpiredUploadsFn: func(ctx context.Context) (int, error) {
+				called = append(called, JobTypeCleanupUploads)
+				return 1, nil
+			},
+		},
+		Queue: mockQueue,
+		AuditLogs: auditLogCleaner(func(ctx context.Context, days int) (int64, error) {
+			called = append(called, JobTypeCleanupAuditLogs)
+			retentionDays = days
+			return 3, nil
+		}),
+		Config: Config{JobRetention: time.Hour, AuditLogRetentionDays: 90},
+		Logger: logger,
+	}
+
+	pool := queue.NewWorkerPool(mockQueue, logger, queue.DefaultConfig())
+	handlers.Register(pool)
+
+	ctx := context.Background()
+	for _, jobType := range []string{JobTypeCleanupSessions, JobTypeCleanupUploads, JobTypeCleanupJobs, JobTypeCleanupAuditLogs} {
+		handler, ok := pool.GetHandler(jobType)
+		require.True(t, ok, jobType)
+		require.NoError(t, handler.Handle(ctx, &aletheia.Job{JobType: jobType}), jobType)
+	}
+
+	assert.Equal(t, []string{JobTypeCleanupSessions, JobTypeCleanupUploads, JobTypeCleanupAuditLogs}, called)
+	assert.Equal(t, 90, retentionDays)
+}
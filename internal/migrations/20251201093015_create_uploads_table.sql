@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE upload_status AS ENUM ('in_progress', 'completed');
+
+CREATE TABLE IF NOT EXISTS uploads (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    inspection_id UUID NOT NULL REFERENCES inspections(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    filename TEXT,
+    content_type VARCHAR(100) NOT NULL,
+    upload_length BIGINT NOT NULL,
+    upload_offset BIGINT NOT NULL DEFAULT 0,
+    storage_key TEXT NOT NULL,
+    multipart_upload_id TEXT NOT NULL,
+    -- Bytes received but not yet flushed as a storage part (less than one part)
+    pending_data BYTEA NOT NULL DEFAULT '',
+    status upload_status DEFAULT 'in_progress' NOT NULL,
+    storage_url TEXT,
+    photo_id UUID REFERENCES photos(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_uploads_inspection_id ON uploads(inspection_id);
+CREATE INDEX idx_uploads_expires_at ON uploads(expires_at) WHERE status = 'in_progress';
+
+CREATE TABLE IF NOT EXISTS upload_parts (
+    upload_id UUID NOT NULL REFERENCES uploads(id) ON DELETE CASCADE,
+    part_number INTEGER NOT NULL,
+    etag TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (upload_id, part_number)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS upload_parts;
+DROP TABLE IF EXISTS uploads;
+DROP TYPE IF EXISTS upload_status;
+-- +goose StatementEnd
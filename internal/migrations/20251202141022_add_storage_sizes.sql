@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE photos ADD COLUMN size_bytes BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE reports ADD COLUMN size_bytes BIGINT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE reports DROP COLUMN size_bytes;
+ALTER TABLE photos DROP COLUMN size_bytes;
+-- +goose StatementEnd
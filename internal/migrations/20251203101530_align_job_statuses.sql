@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Align job statuses with aletheia.JobStatus: 'processing' becomes 'running'
+-- and cancelled jobs are allowed.
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_status_check;
+UPDATE jobs SET status = 'running' WHERE status = 'processing';
+ALTER TABLE jobs ADD CONSTRAINT jobs_status_check
+    CHECK (status IN ('pending', 'running', 'completed', 'failed', 'cancelled'));
+
+DROP INDEX IF EXISTS idx_jobs_processing;
+CREATE INDEX idx_jobs_running ON jobs(organization_id, queue_name, status)
+    WHERE status = 'running';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_jobs_running;
+CREATE INDEX idx_jobs_processing ON jobs(organization_id, queue_name, status)
+    WHERE status = 'processing';
+
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_status_check;
+UPDATE jobs SET status = 'processing' WHERE status = 'running';
+UPDATE jobs SET status = 'failed' WHERE status = 'cancelled';
+ALTER TABLE jobs ADD CONSTRAINT jobs_status_check
+    CHECK (status IN ('pending', 'processing', 'completed', 'failed'));
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Per-attempt error history, appended each time a job attempt fails.
+ALTER TABLE jobs ADD COLUMN errors JSONB NOT NULL DEFAULT '[]'::jsonb;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE jobs DROP COLUMN errors;
+-- +goose StatementEnd
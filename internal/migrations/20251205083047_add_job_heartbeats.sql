@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Workers refresh heartbeat_at while they hold a job so crashed workers'
+-- jobs can be detected and reclaimed.
+ALTER TABLE jobs ADD COLUMN heartbeat_at TIMESTAMPTZ;
+
+CREATE INDEX idx_jobs_heartbeat ON jobs(heartbeat_at)
+    WHERE status = 'running';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_jobs_heartbeat;
+ALTER TABLE jobs DROP COLUMN heartbeat_at;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Jobs that exhaust their attempts move to 'dead' so they can be replayed
+-- after an incident. Earlier exhausted jobs cannot be told apart from
+-- permanent failures, so they stay 'failed'.
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_status_check;
+ALTER TABLE jobs ADD CONSTRAINT jobs_status_check
+    CHECK (status IN ('pending', 'running', 'completed', 'failed', 'cancelled', 'dead'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_status_check;
+UPDATE jobs SET status = 'failed' WHERE status = 'dead';
+ALTER TABLE jobs ADD CONSTRAINT jobs_status_check
+    CHECK (status IN ('pending', 'running', 'completed', 'failed', 'cancelled'));
+-- +goose StatementEnd
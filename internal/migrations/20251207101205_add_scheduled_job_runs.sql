@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Maintenance jobs enqueued by the scheduler belong to no organization.
+ALTER TABLE jobs ALTER COLUMN organization_id DROP NOT NULL;
+
+-- Each run of a recurring schedule is claimed here in the same transaction
+-- that enqueues its job, so only one aletheiad instance enqueues each run.
+CREATE TABLE scheduled_job_runs (
+    schedule_name VARCHAR(100) NOT NULL,
+    run_at TIMESTAMPTZ NOT NULL,
+    job_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (schedule_name, run_at)
+);
+
+CREATE INDEX idx_scheduled_job_runs_created ON scheduled_job_runs(created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS scheduled_job_runs;
+
+DELETE FROM jobs WHERE organization_id IS NULL;
+ALTER TABLE jobs ALTER COLUMN organization_id SET NOT NULL;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- What happens to a job when one of its prerequisites does not complete.
+ALTER TABLE jobs ADD COLUMN dependency_policy VARCHAR(20) NOT NULL DEFAULT 'fail'
+    CHECK (dependency_policy IN ('fail', 'skip', 'continue'));
+
+-- A job is not dequeued until every job it depends on has finished.
+-- Deleting a finished prerequisite removes the dependency.
+CREATE TABLE job_dependencies (
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    depends_on_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+
+    PRIMARY KEY (job_id, depends_on_id)
+);
+
+CREATE INDEX idx_job_dependencies_depends_on ON job_dependencies(depends_on_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS job_dependencies;
+ALTER TABLE jobs DROP COLUMN IF EXISTS dependency_policy;
+-- +goose StatementEnd
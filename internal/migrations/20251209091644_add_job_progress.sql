@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Jobs report progress while running, and jobs working on an inspection
+-- record it so the inspection page can follow them.
+ALTER TABLE jobs
+    ADD COLUMN inspection_id UUID REFERENCES inspections(id) ON DELETE SET NULL,
+    ADD COLUMN progress INTEGER NOT NULL DEFAULT 0 CHECK (progress BETWEEN 0 AND 100),
+    ADD COLUMN progress_message TEXT;
+
+CREATE INDEX idx_jobs_inspection ON jobs(inspection_id, created_at DESC)
+    WHERE inspection_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_jobs_inspection;
+ALTER TABLE jobs
+    DROP COLUMN IF EXISTS progress_message,
+    DROP COLUMN IF EXISTS progress,
+    DROP COLUMN IF EXISTS inspection_id;
+-- +goose StatementEnd
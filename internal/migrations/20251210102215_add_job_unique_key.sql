@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Jobs with a unique key are not enqueued twice while a duplicate is active.
+ALTER TABLE jobs ADD COLUMN unique_key TEXT;
+
+CREATE INDEX idx_jobs_unique_key ON jobs(unique_key, organization_id)
+    WHERE unique_key IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_jobs_unique_key;
+ALTER TABLE jobs DROP COLUMN IF EXISTS unique_key;
+-- +goose StatementEnd
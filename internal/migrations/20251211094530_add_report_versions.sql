@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Every generated report is kept as a numbered version of the inspection's
+-- report, with a snapshot of the content it was built from.
+ALTER TABLE reports
+    ADD COLUMN version INTEGER,
+    ADD COLUMN snapshot_hash TEXT NOT NULL DEFAULT '',
+    ADD COLUMN snapshot JSONB NOT NULL DEFAULT '{}',
+    ADD COLUMN generated_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+UPDATE reports r SET version = v.version
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY inspection_id ORDER BY created_at, id) AS version
+    FROM reports
+) v
+WHERE r.id = v.id;
+
+ALTER TABLE reports ALTER COLUMN version SET NOT NULL;
+
+CREATE UNIQUE INDEX idx_reports_inspection_version ON reports(inspection_id, version);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_reports_inspection_version;
+ALTER TABLE reports
+    DROP COLUMN IF EXISTS generated_by,
+    DROP COLUMN IF EXISTS snapshot,
+    DROP COLUMN IF EXISTS snapshot_hash,
+    DROP COLUMN IF EXISTS version;
+-- +goose StatementEnd
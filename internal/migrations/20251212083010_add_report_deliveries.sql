@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+-- People outside the organization who receive a project's reports.
+CREATE TABLE IF NOT EXISTS project_contacts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    email TEXT NOT NULL,
+    role TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (project_id, email)
+);
+
+-- Each row is a report emailed to one recipient.
+CREATE TYPE delivery_status AS ENUM ('pending', 'sent', 'failed', 'bounced');
+
+CREATE TABLE IF NOT EXISTS report_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    report_id UUID NOT NULL REFERENCES reports(id) ON DELETE CASCADE,
+    recipient TEXT NOT NULL,
+    contact_id UUID REFERENCES project_contacts(id) ON DELETE SET NULL,
+    attached BOOLEAN NOT NULL DEFAULT TRUE,
+    message TEXT,
+    status delivery_status NOT NULL DEFAULT 'pending',
+    provider_message_id TEXT,
+    error TEXT,
+    sent_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    bounced_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_report_deliveries_report_id ON report_deliveries(report_id);
+CREATE INDEX idx_report_deliveries_provider_message_id ON report_deliveries(provider_message_id)
+    WHERE provider_message_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS report_deliveries;
+DROP TYPE IF EXISTS delivery_status;
+DROP TABLE IF EXISTS project_contacts;
+-- +goose StatementEnd
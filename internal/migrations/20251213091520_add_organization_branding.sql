@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Organizations brand their reports and report emails, and choose the
+-- template reports are generated with unless another is requested.
+CREATE TYPE report_template AS ENUM ('full', 'executive_summary', 'violations_only');
+
+ALTER TABLE organizations
+    ADD COLUMN logo_url TEXT NOT NULL DEFAULT '',
+    ADD COLUMN primary_color TEXT NOT NULL DEFAULT '',
+    ADD COLUMN address TEXT NOT NULL DEFAULT '',
+    ADD COLUMN report_footer TEXT NOT NULL DEFAULT '',
+    ADD COLUMN report_template report_template NOT NULL DEFAULT 'full';
+
+ALTER TABLE reports
+    ADD COLUMN template report_template NOT NULL DEFAULT 'full';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE reports
+    DROP COLUMN IF EXISTS template;
+
+ALTER TABLE organizations
+    DROP COLUMN IF EXISTS report_template,
+    DROP COLUMN IF EXISTS report_footer,
+    DROP COLUMN IF EXISTS address,
+    DROP COLUMN IF EXISTS primary_color,
+    DROP COLUMN IF EXISTS logo_url;
+
+DROP TYPE IF EXISTS report_template;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Violation exports page through violations in (created_at, id) order.
+CREATE INDEX idx_detected_violations_created_at_id ON detected_violations(created_at, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_detected_violations_created_at_id;
+-- +goose StatementEnd
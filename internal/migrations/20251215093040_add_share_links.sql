@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Links that let people without an account view an inspection's report or
+-- summary. Only a hash of each token is stored.
+CREATE TYPE share_link_kind AS ENUM ('report', 'inspection');
+
+CREATE TABLE IF NOT EXISTS share_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    inspection_id UUID NOT NULL REFERENCES inspections(id) ON DELETE CASCADE,
+    report_id UUID REFERENCES reports(id) ON DELETE CASCADE,
+    kind share_link_kind NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    password_hash TEXT,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    view_count INTEGER NOT NULL DEFAULT 0,
+    last_viewed_at TIMESTAMP WITH TIME ZONE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    -- Report links share one version of the report
+    CHECK ((kind = 'report') = (report_id IS NOT NULL))
+);
+
+CREATE INDEX idx_share_links_inspection_id ON share_links(inspection_id);
+
+-- Each row is one view of a shared link.
+CREATE TABLE IF NOT EXISTS share_link_views (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    share_link_id UUID NOT NULL REFERENCES share_links(id) ON DELETE CASCADE,
+    ip_address TEXT,
+    user_agent TEXT,
+    viewed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_share_link_views_share_link_id ON share_link_views(share_link_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS share_link_views;
+DROP TABLE IF EXISTS share_links;
+DROP TYPE IF EXISTS share_link_kind;
+-- +goose StatementEnd
@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Inspectors, and optionally reviewers, sign off on an inspection's
+-- content. content_hash fingerprints the photos and confirmed violations
+-- signed, so later changes can be detected.
+CREATE TYPE signoff_role AS ENUM ('inspector', 'reviewer');
+CREATE TYPE signature_kind AS ENUM ('drawn', 'typed');
+
+CREATE TABLE IF NOT EXISTS inspection_signoffs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    inspection_id UUID NOT NULL REFERENCES inspections(id) ON DELETE CASCADE,
+    role signoff_role NOT NULL,
+    signer_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    signer_name TEXT NOT NULL,
+    signature_kind signature_kind NOT NULL,
+    -- The typed name, or the storage URL of the drawn image
+    signature TEXT NOT NULL,
+    content_hash TEXT NOT NULL,
+    signed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (inspection_id, role)
+);
+
+-- Reports record the SHA-256 of their PDF, the server's signature of it,
+-- and the sign-offs they show, so downloaded copies can be verified.
+ALTER TABLE reports
+    ADD COLUMN sha256 TEXT NOT NULL DEFAULT '',
+    ADD COLUMN signature TEXT NOT NULL DEFAULT '',
+    ADD COLUMN signing_key_id TEXT NOT NULL DEFAULT '',
+    ADD COLUMN signoffs JSONB NOT NULL DEFAULT '[]';
+
+CREATE INDEX idx_reports_sha256 ON reports(sha256) WHERE sha256 <> '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_reports_sha256;
+ALTER TABLE reports
+    DROP COLUMN IF EXISTS signoffs,
+    DROP COLUMN IF EXISTS signing_key_id,
+    DROP COLUMN IF EXISTS signature,
+    DROP COLUMN IF EXISTS sha256;
+DROP TABLE IF EXISTS inspection_signoffs;
+DROP TYPE IF EXISTS signature_kind;
+DROP TYPE IF EXISTS signoff_role;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Violations record when they were confirmed or dismissed, so summaries can
+-- report how long resolving them took. Violations resolved before this
+-- migration have no resolved_at.
+ALTER TABLE detected_violations
+    ADD COLUMN resolved_at TIMESTAMP WITH TIME ZONE;
+
+-- Project summary reports cover a project's inspections and violations over
+-- a period. summary is the data the PDF was rendered from.
+CREATE TABLE IF NOT EXISTS summary_reports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    period_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    period_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    storage_url TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    summary JSONB NOT NULL DEFAULT '{}',
+    generated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (period_start < period_end)
+);
+
+CREATE INDEX idx_summary_reports_project_id ON summary_reports(project_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS summary_reports;
+ALTER TABLE detected_violations
+    DROP COLUMN IF EXISTS resolved_at;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Inspections describe what was inspected, when, and under what conditions.
+-- attendees is a JSON array of {name, company, role} objects.
+ALTER TABLE inspections
+    ADD COLUMN title VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN inspection_type VARCHAR(50),
+    ADD COLUMN scheduled_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN performed_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN notes TEXT,
+    ADD COLUMN weather VARCHAR(100),
+    ADD COLUMN temperature_f DOUBLE PRECISION,
+    ADD COLUMN attendees JSONB NOT NULL DEFAULT '[]';
+
+CREATE INDEX idx_inspections_scheduled_at ON inspections(project_id, scheduled_at);
+CREATE INDEX idx_inspections_performed_at ON inspections(project_id, performed_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_inspections_performed_at;
+DROP INDEX IF EXISTS idx_inspections_scheduled_at;
+ALTER TABLE inspections
+    DROP COLUMN IF EXISTS attendees,
+    DROP COLUMN IF EXISTS temperature_f,
+    DROP COLUMN IF EXISTS weather,
+    DROP COLUMN IF EXISTS notes,
+    DROP COLUMN IF EXISTS performed_at,
+    DROP COLUMN IF EXISTS scheduled_at,
+    DROP COLUMN IF EXISTS inspection_type,
+    DROP COLUMN IF EXISTS title;
+-- +goose StatementEnd
@@ -0,0 +1,287 @@
+// Package pdf writes simple PDF documents: text in the standard Helvetica
+// fonts, filled rectangles, lines, and JPEG images. It needs no external
+// fonts or tools, so reports can be rendered anywhere the server runs.
+//
+// Coordinates are in points (1/72 inch) from the top-left corner of the
+// page; the package converts them to PDF's bottom-left origin.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg" // Register JPEG for image.DecodeConfig
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Page sizes in points.
+const (
+	LetterWidth  = 612.0
+	LetterHeight = 792.0
+)
+
+// Font is one of the standard PDF fonts, which viewers provide.
+type Font int
+
+const (
+	Helvetica Font = iota
+	HelveticaBold
+	HelveticaOblique
+)
+
+// resource returns the font's resource name in page content streams.
+func (f Font) resource() string {
+	return fmt.Sprintf("F%d", int(f)+1)
+}
+
+// baseFont returns the font's PostScript name.
+func (f Font) baseFont() string {
+	switch f {
+	case HelveticaBold:
+		return "Helvetica-Bold"
+	case HelveticaOblique:
+		return "Helvetica-Oblique"
+	}
+	return "Helvetica"
+}
+
+// Document is a PDF document under construction.
+type Document struct {
+	Title   string
+	Author  string
+	Subject string
+
+	width, height float64
+	pages         []*Page
+	images        []*Image
+	created       time.Time
+}
+
+// New creates an empty document with pages of the given size.
+func New(width, height float64) *Document {
+	return &Document{width: width, height: height, created: time.Now()}
+}
+
+// Width returns the page width.
+func (d *Document) Width() float64 { return d.width }
+
+// Height returns the page height.
+func (d *Document) Height() float64 { return d.height }
+
+// PageCount returns the number of pages added so far.
+func (d *Document) PageCount() int { return len(d.pages) }
+
+// Pages returns the pages added so far, in order.
+func (d *Document) Pages() []*Page { return slices.Clone(d.pages) }
+
+// AddPage appends a blank page and returns it.
+func (d *Document) AddPage() *Page {
+	p := &Page{doc: d}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// Image is a JPEG image added to a document. It may be drawn any number of
+// times on any page.
+type Image struct {
+	Width, Height int // Pixel dimensions
+
+	name       string
+	colorSpace string
+	data       []byte
+}
+
+// AddJPEG adds a JPEG image to the document. The data is embedded as is.
+func (d *Document) AddJPEG(data []byte) (*Image, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("reading image: %w", err)
+	}
+	if format != "jpeg" {
+		return nil, fmt.Errorf("unsupported image format: %s", format)
+	}
+
+	colorSpace := "DeviceRGB"
+	switch cfg.ColorModel {
+	case color.GrayModel:
+		colorSpace = "DeviceGray"
+	case color.CMYKModel:
+		colorSpace = "DeviceCMYK"
+	}
+
+	img := &Image{
+		Width:      cfg.Width,
+		Height:     cfg.Height,
+		name:       fmt.Sprintf("Im%d", len(d.images)+1),
+		colorSpace: colorSpace,
+		data:       data,
+	}
+	d.images = append(d.images, img)
+	return img, nil
+}
+
+// Color is an RGB color with components from 0 to 1.
+type Color struct {
+	R, G, B float64
+}
+
+// RGB returns the color with 8-bit components r, g, and b.
+func RGB(r, g, b uint8) Color {
+	return Color{float64(r) / 255, float64(g) / 255, float64(b) / 255}
+}
+
+// Black is the default text color.
+var Black = Color{}
+
+func (c Color) operands() string {
+	return fmt.Sprintf("%s %s %s", num(c.R), num(c.G), num(c.B))
+}
+
+// Page is a page of a document. Drawing operations are recorded in order.
+type Page struct {
+	doc     *Document
+	content bytes.Buffer
+}
+
+// y converts a top-left y coordinate to PDF's bottom-left origin.
+func (p *Page) y(y float64) float64 {
+	return p.doc.height - y
+}
+
+// Text draws s with its baseline at y, starting at x.
+func (p *Page) Text(x, y float64, font Font, size float64, c Color, s string) {
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s rg %s %s Td (%s) Tj ET\n",
+		font.resource(), num(size), c.operands(), num(x), num(p.y(y)), escape(encode(s)))
+}
+
+// Rect fills a rectangle whose top-left corner is at x, y.
+func (p *Page) Rect(x, y, w, h float64, fill Color) {
+	fmt.Fprintf(&p.content, "%s rg %s %s %s %s re f\n",
+		fill.operands(), num(x), num(p.y(y+h)), num(w), num(h))
+}
+
+// StrokeRect outlines a rectangle whose top-left corner is at x, y.
+func (p *Page) StrokeRect(x, y, w, h, lineWidth float64, stroke Color) {
+	fmt.Fprintf(&p.content, "%s w %s RG %s %s %s %s re S\n",
+		num(lineWidth), stroke.operands(), num(x), num(p.y(y+h)), num(w), num(h))
+}
+
+// Line draws a straight line.
+func (p *Page) Line(x1, y1, x2, y2, lineWidth float64, stroke Color) {
+	fmt.Fprintf(&p.content, "%s w %s RG %s %s m %s %s l S\n",
+		num(lineWidth), stroke.operands(), num(x1), num(p.y(y1)), num(x2), num(p.y(y2)))
+}
+
+// Image draws img scaled to w by h, with its top-left corner at x, y.
+func (p *Page) Image(img *Image, x, y, w, h float64) {
+	fmt.Fprintf(&p.content, "q %s 0 0 %s %s %s cm /%s Do Q\n",
+		num(w), num(h), num(x), num(p.y(y+h)), img.name)
+}
+
+// WriteTo writes the document as a PDF file.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	pw := &writer{}
+	pw.printf("%%PDF-1.4\n%%\xe2\xe3\xcf\xd3\n")
+
+	// Object numbers: catalog, page tree, info, fonts, images, then a page
+	// and its content stream for each page.
+	const catalog, pages, info = 1, 2, 3
+	fonts := []Font{Helvetica, HelveticaBold, HelveticaOblique}
+	fontObj := info + 1
+	imageObj := fontObj + len(fonts)
+	pageObj := imageObj + len(d.images)
+
+	pw.object(catalog, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pages))
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", pageObj+2*i)
+	}
+	pw.object(pages, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d /MediaBox [0 0 %s %s] >>",
+		strings.Join(kids, " "), len(d.pages), num(d.width), num(d.height)))
+
+	pw.object(info, fmt.Sprintf("<< /Title (%s) /Author (%s) /Subject (%s) /Producer (Aletheia) /CreationDate (D:%s) >>",
+		escape(encode(d.Title)), escape(encode(d.Author)), escape(encode(d.Subject)), d.created.UTC().Format("20060102150405Z")))
+
+	var fontRes, imageRes strings.Builder
+	for i, f := range fonts {
+		pw.object(fontObj+i, fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", f.baseFont()))
+		fmt.Fprintf(&fontRes, "/%s %d 0 R ", f.resource(), fontObj+i)
+	}
+	for i, img := range d.images {
+		pw.stream(imageObj+i, fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /%s /BitsPerComponent 8 /Filter /DCTDecode",
+			img.Width, img.Height, img.colorSpace), img.data)
+		fmt.Fprintf(&imageRes, "/%s %d 0 R ", img.name, imageObj+i)
+	}
+
+	resources := fmt.Sprintf("<< /Font << %s>> /XObject << %s>> >>", fontRes.String(), imageRes.String())
+	for i, page := range d.pages {
+		obj := pageObj + 2*i
+		pw.object(obj, fmt.Sprintf("<< /Type /Page /Parent %d 0 R /Resources %s /Contents %d 0 R >>", pages, resources, obj+1))
+
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		zw.Write(page.content.Bytes())
+		zw.Close()
+		pw.stream(obj+1, "/Filter /FlateDecode", compressed.Bytes())
+	}
+
+	xref := pw.buf.Len()
+	pw.printf("xref\n0 %d\n0000000000 65535 f \n", len(pw.offsets)+1)
+	for _, offset := range pw.offsets {
+		pw.printf("%010d 00000 n \n", offset)
+	}
+	pw.printf("trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(pw.offsets)+1, catalog, info, xref)
+
+	return pw.buf.WriteTo(w)
+}
+
+// writer accumulates a PDF file and the offset of each object in it.
+// Objects must be written in numeric order, starting at 1.
+type writer struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+func (w *writer) printf(format string, args ...any) {
+	fmt.Fprintf(&w.buf, format, args...)
+}
+
+func (w *writer) object(n int, body string) {
+	w.offsets = append(w.offsets, w.buf.Len())
+	w.printf("%d 0 obj\n%s\nendobj\n", n, body)
+}
+
+func (w *writer) stream(n int, dict string, data []byte) {
+	w.offsets = append(w.offsets, w.buf.Len())
+	w.printf("%d 0 obj\n<< %s /Length %d >>\nstream\n", n, dict, len(data))
+	w.buf.Write(data)
+	w.printf("\nendstream\nendobj\n")
+}
+
+// num formats a number compactly for a content stream.
+func num(f float64) string {
+	s := fmt.Sprintf("%.2f", f)
+	s = strings.TrimRight(s, "0")
+	s = strings.TrimSuffix(s, ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// escape escapes a string for a PDF literal string.
+func escape(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`, "\r", `\r`, "\n", `\n`)
+	return r.Replace(s)
+}
@@ -0,0 +1,115 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testJPEG(t *testing.T) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 40, 30))
+	for x := 0; x < 40; x++ {
+		for y := 0; y < 30; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 6), G: 100, B: uint8(y * 8), A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, nil))
+	return buf.Bytes()
+}
+
+func TestDocument_WriteTo(t *testing.T) {
+	doc := New(LetterWidth, LetterHeight)
+	doc.Title = "Inspection (draft)"
+
+	img, err := doc.AddJPEG(testJPEG(t))
+	require.NoError(t, err)
+	assert.Equal(t, 40, img.Width)
+	assert.Equal(t, 30, img.Height)
+
+	page := doc.AddPage()
+	page.Text(72, 72, HelveticaBold, 18, Black, "Report – café")
+	page.Rect(72, 100, 200, 20, RGB(240, 240, 240))
+	page.Line(72, 130, 540, 130, 1, Black)
+	page.Image(img, 72, 140, 80, 60)
+	doc.AddPage().Text(72, 72, Helvetica, 10, Black, "Second page")
+
+	var buf bytes.Buffer
+	n, err := doc.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+
+	out := buf.Bytes()
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4")))
+	assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+	assert.Contains(t, string(out), "/Count 2")
+	assert.Contains(t, string(out), `/Title (Inspection \(draft\))`)
+	assert.Contains(t, string(out), "/BaseFont /Helvetica-Bold")
+	assert.Contains(t, string(out), "/BaseFont /Helvetica-Oblique")
+	assert.Contains(t, string(out), "/Filter /DCTDecode")
+
+	// Every xref entry points at the object it numbers.
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	require.NotNil(t, m)
+	xref, err := strconv.Atoi(string(m[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(out[xref:], []byte("xref\n")))
+
+	entries := strings.Split(string(out[xref:]), "\n")[3:]
+	objects := 0
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry, " n ") {
+			break
+		}
+		objects++
+		offset, err := strconv.Atoi(entry[:10])
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(out[offset:], []byte(fmt.Sprintf("%d 0 obj\n", objects))), "object %d", objects)
+	}
+	assert.Equal(t, 3+3+1+2*2, objects) // catalog, pages, info, fonts, image, pages
+}
+
+func TestDocument_AddJPEGRejectsOtherFormats(t *testing.T) {
+	doc := New(LetterWidth, LetterHeight)
+	_, err := doc.AddJPEG([]byte("not an image"))
+	assert.Error(t, err)
+}
+
+func TestEncode(t *testing.T) {
+	assert.Equal(t, "a\\b", encode("a\\b"))
+	assert.Equal(t, "caf\xe9 \x96 \x93ok\x94", encode("café – “ok”"))
+	assert.Equal(t, "? a", encode("✓\ta"))
+	assert.Equal(t, `\(x\)`, escape("(x)"))
+}
+
+func TestTextWidth(t *testing.T) {
+	assert.InDelta(t, 5.56, TextWidth(Helvetica, 10, "a"), 0.001)
+	assert.InDelta(t, 6.11, TextWidth(HelveticaBold, 10, "b"), 0.001)
+	assert.Equal(t, TextWidth(Helvetica, 10, "Report"), TextWidth(HelveticaOblique, 10, "Report"))
+	assert.Greater(t, TextWidth(HelveticaBold, 10, "Report"), TextWidth(Helvetica, 10, "Report"))
+}
+
+func TestWrap(t *testing.T) {
+	lines := Wrap(Helvetica, 10, 60, "the quick brown fox jumps over the lazy dog")
+	assert.Greater(t, len(lines), 1)
+	for _, line := range lines {
+		assert.LessOrEqual(t, TextWidth(Helvetica, 10, line), 60.0, line)
+	}
+	assert.Equal(t, "the quick brown fox jumps over the lazy dog", strings.Join(lines, " "))
+
+	// Line breaks are kept and long words are broken.
+	assert.Equal(t, []string{"one", "", "two"}, Wrap(Helvetica, 10, 100, "one\n\ntwo"))
+	long := Wrap(Helvetica, 10, 30, "abcdefghijklmnop")
+	assert.Greater(t, len(long), 1)
+	assert.Equal(t, "abcdefghijklmnop", strings.Join(long, ""))
+}
@@ -0,0 +1,1170 @@
+// Package queuetest provides a conformance test suite for aletheia.Queue
+// implementations.
+package queuetest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Factory returns the queue under test and a function that creates an
+// organization jobs may be enqueued for. It is called once per subtest. The
+// queue must use aletheia.DefaultQueueConfig.
+type Factory func(t *testing.T) (aletheia.Queue, OrgFactory)
+
+// OrgFactory creates an organization and returns its ID.
+type OrgFactory func(t *testing.T) uuid.UUID
+
+// Run runs the conformance suite against the queue returned by newQueue.
+// Every subtest uses its own queue name, so implementations backed by shared
+// storage do not need to be emptied between subtests.
+func Run(t *testing.T, newQueue Factory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string)
+	}{
+		{"EnqueueDefaults", testEnqueueDefaults},
+		{"EnqueueOptions", testEnqueueOptions},
+		{"EnqueueWithoutOrganization", testEnqueueWithoutOrganization},
+		{"EnqueueScheduled", testEnqueueScheduled},
+		{"EnqueueUnique", testEnqueueUnique},
+		{"DequeueEmpty", testDequeueEmpty},
+		{"DequeueMarksRunning", testDequeueMarksRunning},
+		{"DequeueOrder", testDequeueOrder},
+		{"DequeueSkipsScheduled", testDequeueSkipsScheduled},
+		{"DequeueConcurrent", testDequeueConcurrent},
+		{"Complete", testComplete},
+		{"Fail", testFail},
+		{"FailRetries", testFailRetries},
+		{"FailPermanent", testFailPermanent},
+		{"GetJobNotFound", testGetJobNotFound},
+		{"CancelJob", testCancelJob},
+		{"GetPendingJobs", testGetPendingJobs},
+		{"Heartbeat", testHeartbeat},
+		{"UpdateProgress", testUpdateProgress},
+		{"ReapStaleJobs", testReapStaleJobs},
+		{"ReapedJobStaleWorker", testReapedJobStaleWorker},
+		{"GetRunningJobs", testGetRunningJobs},
+		{"ListJobs", testListJobs},
+		{"RetryJob", testRetryJob},
+		{"RetryJobs", testRetryJobs},
+		{"CancelJobs", testCancelJobs},
+		{"CleanupJobs", testCleanupJobs},
+		{"Dependencies", testDependencies},
+		{"DependenciesUnknown", testDependenciesUnknown},
+		{"DependencyPolicies", testDependencyPolicies},
+		{"DependencyCascade", testDependencyCascade},
+		{"DependencyAlreadyFailed", testDependencyAlreadyFailed},
+		{"EnqueueBatch", testEnqueueBatch},
+		{"RateLimits", testRateLimits},
+		{"RateLimitConcurrency", testRateLimitConcurrency},
+		{"RateLimitHourly", testRateLimitHourly},
+		{"Listen", testListen},
+		{"ListenJobEvents", testListenJobEvents},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, newOrg := newQueue(t)
+			tt.fn(t, q, newOrg(t), "queuetest-"+uuid.New().String()[:8])
+		})
+	}
+
+	t.Run("DequeueFair", func(t *testing.T) {
+		q, newOrg := newQueue(t)
+		testDequeueFair(t, q, newOrg(t), newOrg(t), "queuetest-"+uuid.New().String()[:8])
+	})
+}
+
+// testWorker is the worker ID the suite dequeues jobs as.
+const testWorker = "queuetest-worker"
+
+func newJob(orgID uuid.UUID, queueName string) *aletheia.Job {
+	return &aletheia.Job{
+		QueueName:      queueName,
+		JobType:        "queuetest",
+		OrganizationID: orgID,
+		Payload:        []byte(`{"key":"value"}`),
+	}
+}
+
+func enqueue(t *testing.T, q aletheia.Queue, job *aletheia.Job, opts ...aletheia.EnqueueOption) *aletheia.Job {
+	t.Helper()
+	require.NoError(t, q.Enqueue(context.Background(), job, opts...))
+	return job
+}
+
+func testEnqueueDefaults(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	job := enqueue(t, q, newJob(orgID, queueName))
+
+	assert.NotEqual(t, uuid.Nil, job.ID)
+	assert.Equal(t, aletheia.JobStatusPending, job.Status)
+	assert.Equal(t, aletheia.DefaultMaxAttempts, job.MaxAttempts)
+	assert.False(t, job.ScheduledAt.IsZero())
+	assert.False(t, job.CreatedAt.IsZero())
+
+	got, err := q.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, job.ID, got.ID)
+	assert.Equal(t, queueName, got.QueueName)
+	assert.Equal(t, "queuetest", got.JobType)
+	assert.Equal(t, orgID, got.OrganizationID)
+	assert.JSONEq(t, `{"key":"value"}`, string(got.Payload))
+	assert.Equal(t, aletheia.JobStatusPending, got.Status)
+	assert.Equal(t, 0, got.AttemptCount)
+	assert.Nil(t, got.StartedAt)
+	assert.Nil(t, got.CompletedAt)
+}
+
+func testEnqueueOptions(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	before := time.Now()
+	job := enqueue(t, q, newJob(orgID, queueName),
+		aletheia.WithPriority(7),
+		aletheia.WithMaxAttempts(5),
+		aletheia.WithDelay(time.Hour),
+	)
+
+	got, err := q.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 7, got.Priority)
+	assert.Equal(t, 5, got.MaxAttempts)
+	assert.True(t, got.ScheduledAt.After(before.Add(59*time.Minute)), "delay not applied: %v", got.ScheduledAt)
+
+	at := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+	job = enqueue(t, q, newJob(orgID, queueName), aletheia.WithScheduledAt(at))
+
+	got, err = q.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	assert.True(t, at.Equal(got.ScheduledAt), "want %v, got %v", at, got.ScheduledAt)
+}
+
+func testEnqueueWithoutOrganization(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	job := enqueue(t, q, newJob(uuid.Nil, queueName))
+
+	got, err := q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, job.ID, got.ID)
+	assert.Equal(t, uuid.Nil, got.OrganizationID)
+}
+
+func testEnqueueScheduled(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	schedule := "queuetest-" + uuid.New().String()
+	runAt := time.Now().Truncate(time.Minute)
+
+	// Concurrent instances enqueue each run once.
+	var mu sync.Mutex
+	var enqueued []uuid.UUID
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			job := newJob(uuid.Nil, queueName)
+			ok, err := q.EnqueueScheduled(ctx, schedule, runAt, job)
+			if assert.NoError(t, err) && ok {
+				mu.Lock()
+				enqueued = append(enqueued, job.ID)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	require.Len(t, enqueued, 1)
+
+	got, err := q.GetJob(ctx, enqueued[0])
+	require.NoError(t, err)
+	assert.Equal(t, aletheia.JobStatusPending, got.Status)
+
+	// The next run is enqueued separately.
+	ok, err := q.EnqueueScheduled(ctx, schedule, runAt.Add(time.Minute), newJob(uuid.Nil, queueName))
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func testEnqueueUnique(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	key := "photo:" + queueName
+
+	first := enqueue(t, q, newJob(orgID, queueName), aletheia.WithUniqueKey(key))
+	got, err := q.GetJob(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, key, got.UniqueKey)
+
+	// A pending duplicate is returned instead of enqueued.
+	dup := enqueue(t, q, newJob(orgID, queueName), aletheia.WithUniqueKey(key))
+	assert.Equal(t, first.ID, dup.ID)
+	assert.Equal(t, aletheia.JobStatusPending, dup.Status)
+
+	// So is a running one.
+	dequeued, err := q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+	require.NotNil(t, dequeued)
+	dup = enqueue(t, q, newJob(orgID, queueName), aletheia.WithUniqueKey(key))
+	assert.Equal(t, first.ID, dup.ID)
+	assert.Equal(t, aletheia.JobStatusRunning, dup.Status)
+
+	// Jobs without the key, or with another, are unaffected.
+	other := enqueue(t, q, newJob(orgID, queueName), aletheia.WithUniqueKey(key+":other"))
+	assert.NotEqual(t, first.ID, other.ID)
+	plain := enqueue(t, q, newJob(orgID, queueName))
+	assert.NotEqual(t, first.ID, plain.ID)
+
+	// A completed job only counts within the uniqueness window.
+	require.NoError(t, q.Complete(ctx, first.ID, testWorker, nil))
+	dup = enqueue(t, q, newJob(orgID, queueName), aletheia.WithUniqueKey(key), aletheia.WithUniqueFor(time.Hour))
+	assert.Equal(t, first.ID, dup.ID)
+	assert.Equal(t, aletheia.JobStatusCompleted, dup.Status)
+
+	again := enqueue(t, q, newJob(orgID, queueName), aletheia.WithUniqueKey(key))
+	assert.NotEqual(t, first.ID, again.ID)
+	assert.Equal(t, aletheia.JobStatusPending, again.Status)
+
+	jobs, total, err := q.ListJobs(ctx, aletheia.JobFilter{QueueName: &queueName})
+	require.NoError(t, err)
+	assert.Equal(t, 4, total)
+	assert.Len(t, jobs, 4)
+}
+
+func testDequeueEmpty(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	job, err := q.Dequeue(context.Background(), queueName, testWorker)
+	require.NoError(t, err)
+	assert.Nil(t, job)
+}
+
+func testDequeueMarksRunning(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	job := enqueue(t, q, newJob(orgID, queueName))
+
+	got, err := q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, job.ID, got.ID)
+	assert.Equal(t, aletheia.JobStatusRunning, got.Status)
+	assert.Equal(t, 1, got.AttemptCount)
+	assert.NotNil(t, got.StartedAt)
+	assert.NotNil(t, got.HeartbeatAt)
+	assert.Equal(t, testWorker, got.WorkerID)
+
+	stored, err := q.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, aletheia.JobStatusRunning, stored.Status)
+
+	// A running job is not handed out again.
+	again, err := q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+	assert.Nil(t, again)
+}
+
+func testDequeueOrder(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	base := time.Now().Add(-time.Minute)
+
+	older := newJob(orgID, queueName)
+	older.CreatedAt = base
+	newer := newJob(orgID, queueName)
+	newer.CreatedAt = base.Add(time.Second)
+	urgent := newJob(orgID, queueName)
+	urgent.CreatedAt = base.Add(2 * time.Second)
+
+	enqueue(t, q, newer)
+	enqueue(t, q, older)
+	enqueue(t, q, urgent, aletheia.WithPriority(10))
+
+	for _, want := range []*aletheia.Job{urgent, older, newer} {
+		got, err := q.Dequeue(ctx, queueName, testWorker)
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, want.ID, got.ID)
+	}
+}
+
+func testDequeueSkipsScheduled(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	enqueue(t, q, newJob(orgID, queueName), aletheia.WithScheduledAt(time.Now().Add(time.Hour)))
+
+	got, err := q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	// Jobs in other queues are not visible either.
+	enqueue(t, q, newJob(orgID, queueName+"-other"))
+	got, err = q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func testDequeueConcurrent(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	const jobs = 10
+	for i := 0; i < jobs; i++ {
+		enqueue(t, q, newJob(orgID, queueName))
+	}
+
+	var mu sync.Mutex
+	seen := make(map[uuid.UUID]int)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				job, err := q.Dequeue(ctx, queueName, testWorker)
+				if !assert.NoError(t, err) || job == nil {
+					return
+				}
+				mu.Lock()
+				seen[job.ID]++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, seen, jobs)
+	for id, n := range seen {
+		assert.Equal(t, 1, n, "job %s dequeued %d times", id, n)
+	}
+}
+
+func testDequeueFair(t *testing.T, q aletheia.Queue, busyOrg, quietOrg uuid.UUID, queueName string) {
+	ctx := context.Background()
+	base := time.Now().Add(-time.Minute)
+
+	var backlog []*aletheia.Job
+	for i := 0; i < 3; i++ {
+		job := newJob(busyOrg, queueName)
+		job.CreatedAt = base.Add(time.Duration(i) * time.Second)
+		backlog = append(backlog, enqueue(t, q, job))
+	}
+	quiet := newJob(quietOrg, queueName)
+	quiet.CreatedAt = base.Add(time.Minute)
+	enqueue(t, q, quiet)
+
+	// The quiet organization's job runs before the rest of the backlog,
+	// even though it was enqueued last.
+	for _, want := range []*aletheia.Job{backlog[0], quiet, backlog[1], backlog[2]} {
+		got, err := q.Dequeue(ctx, queueName, testWorker)
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, want.ID, got.ID)
+	}
+}
+
+func testComplete(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	job := enqueue(t, q, newJob(orgID, queueName))
+	_, err := q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+
+	require.NoError(t, q.Complete(ctx, job.ID, testWorker, []byte(`{"ok":true}`)))
+
+	got, err := q.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, aletheia.JobStatusCompleted, got.Status)
+	assert.JSONEq(t, `{"ok":true}`, string(got.Result))
+	assert.NotNil(t, got.CompletedAt)
+	assert.True(t, got.Status.IsTerminal())
+
+	err = q.Complete(ctx, uuid.New(), testWorker, nil)
+	assert.Equal(t, aletheia.ENOTFOUND, aletheia.ErrorCode(err))
+}
+
+func testFail(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	job := enqueue(t, q, newJob(orgID, queueName), aletheia.WithMaxAttempts(1))
+	_, err := q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+
+	require.NoError(t, q.Fail(ctx, job.ID, testWorker, errors.New("boom")))
+
+	// Exhausting the job's attempts moves it to the dead-letter state.
+	got, err := q.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, aletheia.JobStatusDead, got.Status)
+	assert.Equal(t, "boom", got.ErrorMessage)
+	assert.NotNil(t, got.CompletedAt)
+	require.Len(t, got.Errors, 1)
+	assert.Equal(t, 1, got.Errors[0].Attempt)
+	assert.Equal(t, "boom", got.Errors[0].Message)
+
+	err = q.Fail(ctx, uuid.New(), testWorker, errors.New("boom"))
+	assert.Equal(t, aletheia.ENOTFOUND, aletheia.ErrorCode(err))
+}
+
+func testFailRetries(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	job := enqueue(t, q, newJob(orgID, queueName), aletheia.WithMaxAttempts(2))
+
+	// The first failure reschedules the job with a backoff.
+	_, err := q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+	before := time.Now()
+	require.NoError(t, q.Fail(ctx, job.ID, testWorker, errors.New("first")))
+
+	got, err := q.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, aletheia.JobStatusPending, got.Status)
+	assert.Equal(t, 1, got.AttemptCount)
+	assert.True(t, got.ScheduledAt.After(before), "retry not delayed: %v", got.ScheduledAt)
+	assert.Nil(t, got.CompletedAt)
+	require.Len(t, got.Errors, 1)
+	assert.Equal(t, "first", got.Errors[0].Message)
+
+	// The job is not handed out again until the backoff elapses.
+	next, err := q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+	assert.Nil(t, next)
+
+}
+
+func testFailPermanent(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	job := enqueue(t, q, newJob(orgID, queueName), aletheia.WithMaxAttempts(5))
+	_, err := q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+
+	require.NoError(t, q.Fail(ctx, job.ID, testWorker, aletheia.Permanent(errors.New("bad payload"))))
+
+	got, err := q.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, aletheia.JobStatusFailed, got.Status)
+	assert.Equal(t, "bad payload", got.ErrorMessage)
+	assert.NotNil(t, got.CompletedAt)
+	require.Len(t, got.Errors, 1)
+}
+
+func testGetJobNotFound(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	_, err := q.GetJob(context.Background(), uuid.New())
+	assert.Equal(t, aletheia.ENOTFOUND, aletheia.ErrorCode(err))
+}
+
+func testCancelJob(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	pending := enqueue(t, q, newJob(orgID, queueName), aletheia.WithDelay(time.Hour))
+
+	require.NoError(t, q.CancelJob(ctx, pending.ID))
+
+	got, err := q.GetJob(ctx, pending.ID)
+	require.NoError(t, err)
+	assert.Equal(t, aletheia.JobStatusCancelled, got.Status)
+	assert.NotNil(t, got.CompletedAt)
+
+	// Cancelling again, or cancelling a running job, is invalid.
+	err = q.CancelJob(ctx, pending.ID)
+	assert.Equal(t, aletheia.EINVALID, aletheia.ErrorCode(err))
+
+	running := enqueue(t, q, newJob(orgID, queueName))
+	_, err = q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+	err = q.CancelJob(ctx, running.ID)
+	assert.Equal(t, aletheia.EINVALID, aletheia.ErrorCode(err))
+
+	err = q.CancelJob(ctx, uuid.New())
+	assert.Equal(t, aletheia.ENOTFOUND, aletheia.ErrorCode(err))
+}
+
+func testGetPendingJobs(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+
+	jobs, err := q.GetPendingJobs(ctx, orgID, queueName)
+	require.NoError(t, err)
+	assert.NotNil(t, jobs)
+	assert.Empty(t, jobs)
+
+	low := enqueue(t, q, newJob(orgID, queueName), aletheia.WithDelay(time.Hour))
+	high := enqueue(t, q, newJob(orgID, queueName), aletheia.WithDelay(time.Hour), aletheia.WithPriority(5))
+	cancelled := enqueue(t, q, newJob(orgID, queueName), aletheia.WithDelay(time.Hour))
+	require.NoError(t, q.CancelJob(ctx, cancelled.ID))
+	enqueue(t, q, newJob(orgID, queueName+"-other"))
+
+	jobs, err = q.GetPendingJobs(ctx, orgID, queueName)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, high.ID, jobs[0].ID)
+	assert.Equal(t, low.ID, jobs[1].ID)
+}
+
+func testHeartbeat(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	job := enqueue(t, q, newJob(orgID, queueName))
+
+	// Only running jobs have heartbeats.
+	err := q.Heartbeat(ctx, job.ID, testWorker)
+	assert.Equal(t, aletheia.ECONFLICT, aletheia.ErrorCode(err))
+
+	running, err := q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+	require.NotNil(t, running)
+
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, q.Heartbeat(ctx, job.ID, testWorker))
+
+	got, err := q.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.HeartbeatAt)
+	assert.True(t, got.HeartbeatAt.After(*running.HeartbeatAt), "heartbeat not advanced")
+
+	// Another worker cannot heartbeat a job it does not hold.
+	err = q.Heartbeat(ctx, job.ID, "other-worker")
+	assert.Equal(t, aletheia.ECONFLICT, aletheia.ErrorCode(err))
+
+	err = q.Heartbeat(ctx, uuid.New(), testWorker)
+	assert.Equal(t, aletheia.ENOTFOUND, aletheia.ErrorCode(err))
+}
+
+func testUpdateProgress(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	job := enqueue(t, q, newJob(orgID, queueName), aletheia.WithMaxAttempts(2))
+
+	// Only the worker holding a running job may report progress.
+	err := q.UpdateProgress(ctx, job.ID, testWorker, 10, "starting")
+	assert.Equal(t, aletheia.ECONFLICT, aletheia.ErrorCode(err))
+
+	_, err = q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+
+	require.NoError(t, q.UpdateProgress(ctx, job.ID, testWorker, 40, "analyzing"))
+	got, err := q.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 40, got.Progress)
+	assert.Equal(t, "analyzing", got.ProgressMessage)
+
+	err = q.UpdateProgress(ctx, job.ID, testWorker, 101, "")
+	assert.Equal(t, aletheia.EINVALID, aletheia.ErrorCode(err))
+	err = q.UpdateProgress(ctx, job.ID, "other-worker", 50, "")
+	assert.Equal(t, aletheia.ECONFLICT, aletheia.ErrorCode(err))
+	err = q.UpdateProgress(ctx, uuid.New(), testWorker, 50, "")
+	assert.Equal(t, aletheia.ENOTFOUND, aletheia.ErrorCode(err))
+
+	// Completing the job fills its progress.
+	require.NoError(t, q.Complete(ctx, job.ID, testWorker, nil))
+	got, err = q.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 100, got.Progress)
+}
+
+func testReapStaleJobs(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	retry := enqueue(t, q, newJob(orgID, queueName), aletheia.WithMaxAttempts(2))
+	_, err := q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+	exhausted := enqueue(t, q, newJob(orgID, queueName), aletheia.WithMaxAttempts(1))
+	_, err = q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+
+	// Fresh heartbeats are left alone.
+	_, err = q.ReapStaleJobs(ctx, time.Hour)
+	require.NoError(t, err)
+	got, err := q.GetJob(ctx, retry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, aletheia.JobStatusRunning, got.Status)
+
+	time.Sleep(20 * time.Millisecond)
+	reaped, err := q.ReapStaleJobs(ctx, 10*time.Millisecond)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, reaped, 2)
+
+	// A job with attempts left is returned to pending, counting the attempt.
+	got, err = q.GetJob(ctx, retry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, aletheia.JobStatusPending, got.Status)
+	assert.Equal(t, 1, got.AttemptCount)
+	assert.Empty(t, got.WorkerID)
+	require.Len(t, got.Errors, 1)
+	assert.Contains(t, got.Errors[0].Message, testWorker)
+
+	redelivered, err := q.Dequeue(ctx, queueName, "second-worker")
+	require.NoError(t, err)
+	require.NotNil(t, redelivered)
+	assert.Equal(t, retry.ID, redelivered.ID)
+	assert.Equal(t, 2, redelivered.AttemptCount)
+
+	// A job without attempts left is dead-lettered.
+	got, err = q.GetJob(ctx, exhausted.ID)
+	require.NoError(t, err)
+	assert.Equal(t, aletheia.JobStatusDead, got.Status)
+	assert.NotNil(t, got.CompletedAt)
+}
+
+func testReapedJobStaleWorker(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	job := enqueue(t, q, newJob(orgID, queueName), aletheia.WithMaxAttempts(3))
+	_, err := q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+
+	time.Sleep(20 * time.Millisecond)
+	_, err = q.ReapStaleJobs(ctx, 10*time.Millisecond)
+	require.NoError(t, err)
+
+	// The original worker can no longer report on the reclaimed job.
+	err = q.Fail(ctx, job.ID, testWorker, errors.New("late failure"))
+	assert.Equal(t, aletheia.ECONFLICT, aletheia.ErrorCode(err))
+	err = q.Complete(ctx, job.ID, testWorker, nil)
+	assert.Equal(t, aletheia.ECONFLICT, aletheia.ErrorCode(err))
+
+	got, err := q.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, aletheia.JobStatusPending, got.Status)
+	assert.Len(t, got.Errors, 1, "only the reclaim is recorded")
+
+	// Nor once another worker runs it.
+	redelivered, err := q.Dequeue(ctx, queueName, "second-worker")
+	require.NoError(t, err)
+	require.NotNil(t, redelivered)
+	require.Equal(t, job.ID, redelivered.ID)
+
+	err = q.Fail(ctx, job.ID, testWorker, errors.New("late failure"))
+	assert.Equal(t, aletheia.ECONFLICT, aletheia.ErrorCode(err))
+	err = q.Complete(ctx, job.ID, testWorker, nil)
+	assert.Equal(t, aletheia.ECONFLICT, aletheia.ErrorCode(err))
+
+	got, err = q.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, aletheia.JobStatusRunning, got.Status)
+	assert.Equal(t, "second-worker", got.WorkerID)
+	assert.Len(t, got.Errors, 1)
+
+	require.NoError(t, q.Complete(ctx, job.ID, "second-worker", nil))
+	got, err = q.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, aletheia.JobStatusCompleted, got.Status)
+}
+
+func testGetRunningJobs(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	job := enqueue(t, q, newJob(orgID, queueName))
+	enqueue(t, q, newJob(orgID, queueName), aletheia.WithDelay(time.Hour))
+	_, err := q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+
+	jobs, err := q.GetRunningJobs(ctx, queueName)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, job.ID, jobs[0].ID)
+	assert.Equal(t, testWorker, jobs[0].WorkerID)
+	assert.NotNil(t, jobs[0].HeartbeatAt)
+
+	all, err := q.GetRunningJobs(ctx, "")
+	require.NoError(t, err)
+	assert.NotEmpty(t, all)
+}
+
+func testListJobs(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	first := enqueue(t, q, newJob(orgID, queueName), aletheia.WithDelay(time.Hour))
+	time.Sleep(time.Millisecond)
+	second := enqueue(t, q, newJob(orgID, queueName), aletheia.WithDelay(time.Hour))
+	time.Sleep(time.Millisecond)
+	other := newJob(orgID, queueName)
+	other.JobType = "queuetest-other"
+	enqueue(t, q, other, aletheia.WithDelay(time.Hour))
+	require.NoError(t, q.CancelJob(ctx, other.ID))
+
+	// Jobs are listed newest first, with the total ignoring pagination.
+	jobs, total, err := q.ListJobs(ctx, aletheia.JobFilter{QueueName: &queueName})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, jobs, 3)
+	assert.Equal(t, other.ID, jobs[0].ID)
+	assert.Equal(t, second.ID, jobs[1].ID)
+	assert.Equal(t, first.ID, jobs[2].ID)
+
+	jobs, total, err = q.ListJobs(ctx, aletheia.JobFilter{QueueName: &queueName, Offset: 1, Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, second.ID, jobs[0].ID)
+
+	status := aletheia.JobStatusCancelled
+	jobs, total, err = q.ListJobs(ctx, aletheia.JobFilter{QueueName: &queueName, Status: &status})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, other.ID, jobs[0].ID)
+
+	jobType := "queuetest"
+	_, total, err = q.ListJobs(ctx, aletheia.JobFilter{QueueName: &queueName, JobType: &jobType, OrganizationID: &orgID})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+
+	otherOrg := uuid.New()
+	jobs, total, err = q.ListJobs(ctx, aletheia.JobFilter{QueueName: &queueName, OrganizationID: &otherOrg})
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.NotNil(t, jobs)
+	assert.Empty(t, jobs)
+}
+
+// deadJob enqueues a job and fails its only attempt, dead-lettering it.
+func deadJob(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) *aletheia.Job {
+	t.Helper()
+	ctx := context.Background()
+	job := enqueue(t, q, newJob(orgID, queueName), aletheia.WithMaxAttempts(1))
+	dequeued, err := q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+	require.NotNil(t, dequeued)
+	require.Equal(t, job.ID, dequeued.ID)
+	require.NoError(t, q.Fail(ctx, job.ID, testWorker, errors.New("boom")))
+	return job
+}
+
+func testRetryJob(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	job := deadJob(t, q, orgID, queueName)
+
+	require.NoError(t, q.RetryJob(ctx, job.ID))
+
+	// The job gets a fresh set of attempts but keeps its error history.
+	got, err := q.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, aletheia.JobStatusPending, got.Status)
+	assert.Equal(t, 0, got.AttemptCount)
+	assert.Empty(t, got.ErrorMessage)
+	assert.Nil(t, got.CompletedAt)
+	assert.Len(t, got.Errors, 1)
+
+	redelivered, err := q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+	require.NotNil(t, redelivered)
+	assert.Equal(t, job.ID, redelivered.ID)
+	assert.Equal(t, 1, redelivered.AttemptCount)
+
+	// Running and pending jobs cannot be retried.
+	err = q.RetryJob(ctx, job.ID)
+	assert.Equal(t, aletheia.EINVALID, aletheia.ErrorCode(err))
+
+	err = q.RetryJob(ctx, uuid.New())
+	assert.Equal(t, aletheia.ENOTFOUND, aletheia.ErrorCode(err))
+}
+
+func testRetryJobs(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	dead1 := deadJob(t, q, orgID, queueName)
+	dead2 := deadJob(t, q, orgID, queueName)
+	pending := enqueue(t, q, newJob(orgID, queueName), aletheia.WithDelay(time.Hour))
+
+	status := aletheia.JobStatusDead
+	retried, err := q.RetryJobs(ctx, aletheia.JobFilter{QueueName: &queueName, Status: &status})
+	require.NoError(t, err)
+	assert.Equal(t, 2, retried)
+
+	for _, id := range []uuid.UUID{dead1.ID, dead2.ID} {
+		got, err := q.GetJob(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, aletheia.JobStatusPending, got.Status)
+		assert.Equal(t, 0, got.AttemptCount)
+	}
+
+	// Jobs that cannot be retried are skipped.
+	retried, err = q.RetryJobs(ctx, aletheia.JobFilter{QueueName: &queueName})
+	require.NoError(t, err)
+	assert.Equal(t, 0, retried)
+
+	got, err := q.GetJob(ctx, pending.ID)
+	require.NoError(t, err)
+	assert.True(t, got.ScheduledAt.After(time.Now()), "pending job rescheduled")
+}
+
+func testCancelJobs(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	first := enqueue(t, q, newJob(orgID, queueName), aletheia.WithDelay(time.Hour))
+	second := enqueue(t, q, newJob(orgID, queueName), aletheia.WithDelay(time.Hour))
+	other := newJob(orgID, queueName)
+	other.JobType = "queuetest-other"
+	enqueue(t, q, other, aletheia.WithDelay(time.Hour))
+	dead := deadJob(t, q, orgID, queueName+"-dead")
+
+	jobType := "queuetest"
+	cancelled, err := q.CancelJobs(ctx, aletheia.JobFilter{QueueName: &queueName, JobType: &jobType})
+	require.NoError(t, err)
+	assert.Equal(t, 2, cancelled)
+
+	for _, id := range []uuid.UUID{first.ID, second.ID} {
+		got, err := q.GetJob(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, aletheia.JobStatusCancelled, got.Status)
+		assert.NotNil(t, got.CompletedAt)
+	}
+
+	got, err := q.GetJob(ctx, other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, aletheia.JobStatusPending, got.Status)
+
+	// Only pending jobs are cancelled.
+	deadQueue := queueName + "-dead"
+	cancelled, err = q.CancelJobs(ctx, aletheia.JobFilter{QueueName: &deadQueue})
+	require.NoError(t, err)
+	assert.Equal(t, 0, cancelled)
+
+	got, err = q.GetJob(ctx, dead.ID)
+	require.NoError(t, err)
+	assert.Equal(t, aletheia.JobStatusDead, got.Status)
+}
+
+func testCleanupJobs(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	old := enqueue(t, q, newJob(orgID, queueName))
+	_, err := q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+	require.NoError(t, q.Complete(ctx, old.ID, testWorker, nil))
+	pending := enqueue(t, q, newJob(orgID, queueName), aletheia.WithDelay(time.Hour))
+
+	time.Sleep(20 * time.Millisecond)
+	recent := enqueue(t, q, newJob(orgID, queueName), aletheia.WithDelay(time.Hour))
+	require.NoError(t, q.CancelJob(ctx, recent.ID))
+
+	deleted, err := q.CleanupJobs(ctx, 10*time.Millisecond)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, deleted, 1)
+
+	_, err = q.GetJob(ctx, old.ID)
+	assert.Equal(t, aletheia.ENOTFOUND, aletheia.ErrorCode(err))
+
+	// Unfinished and recently finished jobs are kept.
+	_, err = q.GetJob(ctx, pending.ID)
+	assert.NoError(t, err)
+	_, err = q.GetJob(ctx, recent.ID)
+	assert.NoError(t, err)
+}
+
+// runJob dequeues job and completes it, or fails it permanently if jobErr
+// is not nil.
+func runJob(t *testing.T, q aletheia.Queue, job *aletheia.Job, jobErr error) {
+	t.Helper()
+	ctx := context.Background()
+	dequeued, err := q.Dequeue(ctx, job.QueueName, testWorker)
+	require.NoError(t, err)
+	require.NotNil(t, dequeued)
+	require.Equal(t, job.ID, dequeued.ID)
+	if jobErr != nil {
+		require.NoError(t, q.Fail(ctx, job.ID, testWorker, aletheia.Permanent(jobErr)))
+		return
+	}
+	require.NoError(t, q.Complete(ctx, job.ID, testWorker, nil))
+}
+
+func testDependencies(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	first := enqueue(t, q, newJob(orgID, queueName))
+	second := enqueue(t, q, newJob(orgID, queueName))
+	child := enqueue(t, q, newJob(orgID, queueName),
+		aletheia.WithDependsOn(first.ID, second.ID, first.ID),
+		aletheia.WithPriority(10),
+	)
+
+	got, err := q.GetJob(ctx, child.ID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{first.ID, second.ID}, got.DependsOn)
+	assert.Equal(t, aletheia.DependencyPolicyFail, got.DependencyPolicy)
+	assert.Equal(t, aletheia.JobStatusPending, got.Status)
+
+	// Despite its priority, the child waits for both prerequisites.
+	runJob(t, q, first, nil)
+	runJob(t, q, second, nil)
+
+	next, err := q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, child.ID, next.ID)
+}
+
+func testDependenciesUnknown(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+
+	err := q.Enqueue(ctx, newJob(orgID, queueName), aletheia.WithDependsOn(uuid.New()))
+	assert.Equal(t, aletheia.EINVALID, aletheia.ErrorCode(err))
+
+	err = q.Enqueue(ctx, newJob(orgID, queueName), aletheia.WithDependencyPolicy("sometimes"))
+	assert.Equal(t, aletheia.EINVALID, aletheia.ErrorCode(err))
+}
+
+func testDependencyPolicies(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	ok := enqueue(t, q, newJob(orgID, queueName))
+	failing := enqueue(t, q, newJob(orgID, queueName))
+
+	failChild := enqueue(t, q, newJob(orgID, queueName), aletheia.WithDependsOn(ok.ID, failing.ID))
+	skipChild := enqueue(t, q, newJob(orgID, queueName),
+		aletheia.WithDependsOn(ok.ID, failing.ID),
+		aletheia.WithDependencyPolicy(aletheia.DependencyPolicySkip))
+	continueChild := enqueue(t, q, newJob(orgID, queueName),
+		aletheia.WithDependsOn(ok.ID, failing.ID),
+		aletheia.WithDependencyPolicy(aletheia.DependencyPolicyContinue))
+
+	runJob(t, q, ok, nil)
+	runJob(t, q, failing, errors.New("boom"))
+
+	got, err := q.GetJob(ctx, failChild.ID)
+	require.NoError(t, err)
+	assert.Equal(t, aletheia.JobStatusFailed, got.Status)
+	assert.Equal(t, aletheia.DependencyFailedMessage, got.ErrorMessage)
+	assert.NotNil(t, got.CompletedAt)
+
+	got, err = q.GetJob(ctx, skipChild.ID)
+	require.NoError(t, err)
+	assert.Equal(t, aletheia.JobStatusCancelled, got.Status)
+
+	// The continue policy runs once every prerequisite has finished.
+	next, err := q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, continueChild.ID, next.ID)
+}
+
+func testDependencyCascade(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	root := enqueue(t, q, newJob(orgID, queueName))
+	child := enqueue(t, q, newJob(orgID, queueName),
+		aletheia.WithDependsOn(root.ID),
+		aletheia.WithDependencyPolicy(aletheia.DependencyPolicySkip))
+	grandchild := enqueue(t, q, newJob(orgID, queueName), aletheia.WithDependsOn(child.ID))
+
+	// Cancelling the root skips the child, which fails the grandchild.
+	require.NoError(t, q.CancelJob(ctx, root.ID))
+
+	got, err := q.GetJob(ctx, child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, aletheia.JobStatusCancelled, got.Status)
+
+	got, err = q.GetJob(ctx, grandchild.ID)
+	require.NoError(t, err)
+	assert.Equal(t, aletheia.JobStatusFailed, got.Status)
+
+	next, err := q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+	assert.Nil(t, next)
+}
+
+func testDependencyAlreadyFailed(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	failed := deadJob(t, q, orgID, queueName)
+
+	// A job depending on a job that has already failed is resolved at once.
+	job := enqueue(t, q, newJob(orgID, queueName), aletheia.WithDependsOn(failed.ID))
+	assert.Equal(t, aletheia.JobStatusFailed, job.Status)
+
+	got, err := q.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, aletheia.JobStatusFailed, got.Status)
+}
+
+func testEnqueueBatch(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	batch := []*aletheia.Job{newJob(orgID, queueName), newJob(orgID, queueName), newJob(orgID, queueName)}
+	callback := newJob(orgID, queueName)
+	callback.JobType = "queuetest_callback"
+	callback.DependencyPolicy = aletheia.DependencyPolicyContinue
+
+	require.NoError(t, q.EnqueueBatch(ctx, batch, callback, aletheia.WithMaxAttempts(1)))
+
+	got, err := q.GetJob(ctx, callback.ID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{batch[0].ID, batch[1].ID, batch[2].ID}, got.DependsOn)
+	assert.Equal(t, aletheia.DependencyPolicyContinue, got.DependencyPolicy)
+	assert.Equal(t, aletheia.DefaultMaxAttempts, got.MaxAttempts)
+
+	// Batch jobs run first, whatever their order; one fails.
+	for i := range batch {
+		dequeued, err := q.Dequeue(ctx, queueName, testWorker)
+		require.NoError(t, err)
+		require.NotNil(t, dequeued)
+		assert.Equal(t, "queuetest", dequeued.JobType)
+		assert.Equal(t, 1, dequeued.MaxAttempts)
+		if i == 0 {
+			require.NoError(t, q.Fail(ctx, dequeued.ID, testWorker, errors.New("boom")))
+		} else {
+			require.NoError(t, q.Complete(ctx, dequeued.ID, testWorker, nil))
+		}
+	}
+
+	next, err := q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, callback.ID, next.ID)
+
+	// An empty batch is rejected.
+	err = q.EnqueueBatch(ctx, nil, newJob(orgID, queueName))
+	assert.Equal(t, aletheia.EINVALID, aletheia.ErrorCode(err))
+}
+
+func testRateLimits(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+
+	limits, err := q.ListRateLimits(ctx, orgID)
+	require.NoError(t, err)
+	assert.NotNil(t, limits)
+	assert.Empty(t, limits)
+
+	limit, err := aletheia.NewRateLimit(orgID, queueName, aletheia.RateLimitTierPro)
+	require.NoError(t, err)
+	require.NoError(t, q.SetRateLimit(ctx, limit))
+	assert.False(t, limit.UpdatedAt.IsZero())
+
+	// Setting a limit again replaces it.
+	limit.Tier = aletheia.RateLimitTierEnterprise
+	limit.MaxConcurrentJobs = 3
+	require.NoError(t, q.SetRateLimit(ctx, limit))
+
+	limits, err = q.ListRateLimits(ctx, orgID)
+	require.NoError(t, err)
+	require.Len(t, limits, 1)
+	assert.Equal(t, queueName, limits[0].QueueName)
+	assert.Equal(t, aletheia.RateLimitTierEnterprise, limits[0].Tier)
+	assert.Equal(t, 3, limits[0].MaxConcurrentJobs)
+
+	limit.MaxJobsPerHour = 0
+	err = q.SetRateLimit(ctx, limit)
+	assert.Equal(t, aletheia.EINVALID, aletheia.ErrorCode(err))
+
+	require.NoError(t, q.DeleteRateLimit(ctx, orgID, queueName))
+	err = q.DeleteRateLimit(ctx, orgID, queueName)
+	assert.Equal(t, aletheia.ENOTFOUND, aletheia.ErrorCode(err))
+}
+
+func testRateLimitConcurrency(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	limit, err := aletheia.NewRateLimit(orgID, queueName, aletheia.RateLimitTierFree)
+	require.NoError(t, err)
+	limit.MaxConcurrentJobs = 1
+	require.NoError(t, q.SetRateLimit(ctx, limit))
+
+	first := enqueue(t, q, newJob(orgID, queueName))
+	second := enqueue(t, q, newJob(orgID, queueName))
+
+	got, err := q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, first.ID, got.ID)
+
+	// The organization is at its concurrency limit.
+	got, err = q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	require.NoError(t, q.Complete(ctx, first.ID, testWorker, nil))
+
+	got, err = q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, second.ID, got.ID)
+}
+
+func testRateLimitHourly(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	ctx := context.Background()
+	limit, err := aletheia.NewRateLimit(orgID, queueName, aletheia.RateLimitTierFree)
+	require.NoError(t, err)
+	limit.MaxJobsPerHour = 1
+	require.NoError(t, q.SetRateLimit(ctx, limit))
+
+	first := enqueue(t, q, newJob(orgID, queueName))
+	enqueue(t, q, newJob(orgID, queueName))
+
+	got, err := q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	require.NoError(t, q.Complete(ctx, first.ID, testWorker, nil))
+
+	// Completing the job does not free up the hourly allowance.
+	got, err = q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	// Other queues are limited separately.
+	other := enqueue(t, q, newJob(orgID, queueName+"-other"))
+	got, err = q.Dequeue(ctx, queueName+"-other", testWorker)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, other.ID, got.ID)
+}
+
+func testListen(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	listener, ok := q.(aletheia.QueueListener)
+	if !ok {
+		t.Skip("queue does not implement aletheia.QueueListener")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	notifications, err := listener.Listen(ctx, []string{queueName})
+	require.NoError(t, err)
+
+	// Delayed jobs and other queues do not notify.
+	enqueue(t, q, newJob(orgID, queueName), aletheia.WithDelay(time.Hour))
+	enqueue(t, q, newJob(orgID, queueName+"-other"))
+	select {
+	case name := <-notifications:
+		t.Fatalf("unexpected notification for %q", name)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	enqueue(t, q, newJob(orgID, queueName))
+	select {
+	case name := <-notifications:
+		assert.Equal(t, queueName, name)
+	case <-time.After(2 * time.Second):
+		t.Fatal("no notification for enqueued job")
+	}
+
+	// The channel is closed once the context is done.
+	cancel()
+	for range notifications {
+	}
+}
+
+func testListenJobEvents(t *testing.T, q aletheia.Queue, orgID uuid.UUID, queueName string) {
+	listener, ok := q.(aletheia.QueueListener)
+	if !ok {
+		t.Skip("queue does not implement aletheia.QueueListener")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changes, err := listener.ListenJobEvents(ctx)
+	require.NoError(t, err)
+
+	// Events for other jobs may arrive in between, so wait for jobID.
+	waitForChange := func(jobID uuid.UUID, step string) {
+		t.Helper()
+		timeout := time.After(2 * time.Second)
+		for {
+			select {
+			case change := <-changes:
+				if change.JobID == jobID {
+					return
+				}
+			case <-timeout:
+				t.Fatalf("no job event after %s", step)
+			}
+		}
+	}
+
+	job := enqueue(t, q, newJob(orgID, queueName))
+	waitForChange(job.ID, "enqueue")
+
+	got, err := q.Dequeue(ctx, queueName, testWorker)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	waitForChange(job.ID, "dequeue")
+
+	require.NoError(t, q.UpdateProgress(ctx, job.ID, testWorker, 50, "Halfway"))
+	waitForChange(job.ID, "progress")
+
+	require.NoError(t, q.Complete(ctx, job.ID, testWorker, nil))
+	waitForChange(job.ID, "complete")
+
+	// The channel is closed once the context is done.
+	cancel()
+	for range changes {
+	}
+}
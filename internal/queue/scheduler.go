@@ -0,0 +1,204 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/google/uuid"
+)
+
+// maxSchedulerSleep bounds how long the scheduler sleeps between checks, so
+// it recovers promptly from clock jumps such as a suspended host.
+const maxSchedulerSleep = time.Minute
+
+// Schedule declares a job enqueued on a recurring schedule.
+type Schedule struct {
+	// Name identifies the schedule. Instances running the same schedule
+	// enqueue each run once, so it must be unique and stable across deploys.
+	Name string
+
+	// Spec is a cron expression such as "0 3 * * *", evaluated in UTC, a
+	// descriptor such as "@daily", or an interval such as "@every 1h".
+	Spec string
+
+	// Job is the template for each run. QueueName and JobType are required;
+	// ID, status, and timestamps are set per run.
+	Job aletheia.Job
+}
+
+// scheduleEntry is a registered schedule and its next run.
+type scheduleEntry struct {
+	Schedule
+	spec *cronSpec
+	next time.Time
+}
+
+// Scheduler enqueues jobs for registered schedules. Every aletheiad instance
+// may run a scheduler with the same schedules; the queue deduplicates runs
+// so each is enqueued once. Runs missed while no scheduler was running are
+// skipped rather than caught up.
+type Scheduler struct {
+	queue   aletheia.Queue
+	logger  *slog.Logger
+	entries []*scheduleEntry
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+}
+
+// NewScheduler creates a new scheduler
+func NewScheduler(queue aletheia.Queue, logger *slog.Logger) *Scheduler {
+	return &Scheduler{
+		queue:  queue,
+		logger: logger,
+	}
+}
+
+// Register adds a schedule. It must be called before Start.
+func (s *Scheduler) Register(schedule Schedule) error {
+	if schedule.Name == "" {
+		return fmt.Errorf("schedule name is required")
+	}
+	if schedule.Job.QueueName == "" || schedule.Job.JobType == "" {
+		return fmt.Errorf("schedule %s: job queue name and type are required", schedule.Name)
+	}
+
+	spec, err := parseCron(schedule.Spec)
+	if err != nil {
+		return fmt.Errorf("schedule %s: %w", schedule.Name, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		return fmt.Errorf("scheduler already started")
+	}
+	if slices.ContainsFunc(s.entries, func(e *scheduleEntry) bool { return e.Name == schedule.Name }) {
+		return fmt.Errorf("schedule %s already registered", schedule.Name)
+	}
+
+	s.entries = append(s.entries, &scheduleEntry{Schedule: schedule, spec: spec})
+	s.logger.Info("registered schedule",
+		slog.String("schedule", schedule.Name),
+		slog.String("spec", schedule.Spec),
+		slog.String("job_type", schedule.Job.JobType),
+	)
+	return nil
+}
+
+// Start starts enqueuing scheduled jobs in the background.
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		return fmt.Errorf("scheduler already started")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+
+	now := time.Now()
+	for _, e := range s.entries {
+		e.next = e.spec.Next(now)
+	}
+
+	s.wg.Add(1)
+	go s.run(ctx)
+
+	s.logger.Info("scheduler started", slog.Int("schedules", len(s.entries)))
+	return nil
+}
+
+// Stop stops the scheduler and waits for an in-progress enqueue to finish.
+func (s *Scheduler) Stop() error {
+	s.mu.Lock()
+	if s.cancel == nil {
+		s.mu.Unlock()
+		return fmt.Errorf("scheduler not started")
+	}
+	cancel := s.cancel
+	s.cancel = nil
+	s.mu.Unlock()
+
+	cancel()
+	s.wg.Wait()
+
+	s.logger.Info("scheduler stopped")
+	return nil
+}
+
+// run sleeps until the next run is due and enqueues every due run.
+func (s *Scheduler) run(ctx context.Context) {
+	defer s.wg.Done()
+
+	for {
+		timer := time.NewTimer(s.untilNext())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		now := time.Now()
+		for _, e := range s.entries {
+			if e.next.IsZero() || e.next.After(now) {
+				continue
+			}
+			s.enqueue(ctx, e)
+			e.next = e.spec.Next(now)
+		}
+	}
+}
+
+// untilNext returns how long to sleep before the earliest next run.
+func (s *Scheduler) untilNext() time.Duration {
+	wait := maxSchedulerSleep
+	for _, e := range s.entries {
+		if e.next.IsZero() {
+			continue
+		}
+		wait = min(wait, time.Until(e.next))
+	}
+	return max(wait, 0)
+}
+
+// enqueue enqueues the run of e that is due at e.next, unless another
+// instance already has.
+func (s *Scheduler) enqueue(ctx context.Context, e *scheduleEntry) {
+	job := e.Job
+	job.ID = uuid.Nil
+	job.Status = ""
+	job.CreatedAt = time.Time{}
+	job.ScheduledAt = e.next
+
+	enqueued, err := s.queue.EnqueueScheduled(ctx, e.Name, e.next, &job)
+	if err != nil {
+		s.logger.Error("failed to enqueue scheduled job",
+			slog.String("schedule", e.Name),
+			slog.Time("run_at", e.next),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+	if !enqueued {
+		s.logger.Debug("scheduled run already enqueued",
+			slog.String("schedule", e.Name),
+			slog.Time("run_at", e.next),
+		)
+		return
+	}
+
+	s.logger.Info("scheduled job enqueued",
+		slog.String("schedule", e.Name),
+		slog.String("job_id", job.ID.String()),
+		slog.Time("run_at", e.next),
+	)
+}
@@ -0,0 +1,91 @@
+package queue
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSchedule() Schedule {
+	return Schedule{
+		Name: "test_cleanup",
+		Spec: "@every 1s",
+		Job: aletheia.Job{
+			QueueName: "maintenance",
+			JobType:   "cleanup",
+		},
+	}
+}
+
+func TestScheduler_Register(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	scheduler := NewScheduler(mock.NewQueue(), logger)
+
+	require.NoError(t, scheduler.Register(testSchedule()))
+
+	// Duplicate names are rejected
+	assert.Error(t, scheduler.Register(testSchedule()))
+
+	// Invalid specs are rejected
+	invalid := testSchedule()
+	invalid.Name = "invalid"
+	invalid.Spec = "* * *"
+	assert.Error(t, scheduler.Register(invalid))
+
+	// Jobs need a queue and type
+	missing := testSchedule()
+	missing.Name = "missing"
+	missing.Job.JobType = ""
+	assert.Error(t, scheduler.Register(missing))
+}
+
+func TestScheduler_EnqueuesOncePerRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping scheduler timing test in short mode")
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	mockQueue := mock.NewQueue()
+
+	// Two instances run the same schedule
+	var schedulers []*Scheduler
+	for range 2 {
+		scheduler := NewScheduler(mockQueue, logger)
+		require.NoError(t, scheduler.Register(testSchedule()))
+		require.NoError(t, scheduler.Start(context.Background()))
+		schedulers = append(schedulers, scheduler)
+	}
+
+	time.Sleep(2500 * time.Millisecond)
+
+	for _, scheduler := range schedulers {
+		require.NoError(t, scheduler.Stop())
+	}
+
+	jobs, total, err := mockQueue.ListJobs(context.Background(), aletheia.JobFilter{})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, total, 2)
+	assert.LessOrEqual(t, total, 3)
+
+	// Each run is enqueued once, at its scheduled time
+	seen := make(map[time.Time]bool)
+	for _, job := range jobs {
+		assert.Equal(t, "cleanup", job.JobType)
+		assert.False(t, seen[job.ScheduledAt], "run %v enqueued twice", job.ScheduledAt)
+		seen[job.ScheduledAt] = true
+	}
+}
+
+func TestScheduler_StopWithoutStart(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	scheduler := NewScheduler(mock.NewQueue(), logger)
+
+	assert.Error(t, scheduler.Stop())
+}
@@ -1,41 +1,71 @@
+// Package queue runs worker pools that process jobs from an aletheia.Queue.
 package queue
 
 import (
 	"context"
 	"fmt"
 	"log/slog"
+	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/google/uuid"
+	"github.com/dukerupert/aletheia"
 )
 
-// JobHandler is a function that processes a job
-type JobHandler func(ctx context.Context, job *Job) (result map[string]interface{}, err error)
+// Config holds worker pool configuration.
+type Config struct {
+	WorkerCount       int           // Number of concurrent workers
+	PollInterval      time.Duration // How often to poll for new jobs
+	JobTimeout        time.Duration // Maximum time a job handler may run
+	ShutdownTimeout   time.Duration // How long to wait for graceful shutdown
+	HeartbeatInterval time.Duration // How often a busy worker records a heartbeat
+	ReapInterval      time.Duration // How often to reclaim jobs from crashed workers
+
+	// FallbackPollInterval replaces PollInterval when the queue pushes job
+	// notifications. Polling then only catches delayed and retried jobs,
+	// which become due without a notification, and lost notifications.
+	FallbackPollInterval time.Duration
+}
+
+// DefaultConfig returns default worker pool configuration.
+func DefaultConfig() Config {
+	return Config{
+		WorkerCount:          3,
+		PollInterval:         1 * time.Second,
+		JobTimeout:           60 * time.Second,
+		ShutdownTimeout:      30 * time.Second,
+		HeartbeatInterval:    10 * time.Second,
+		ReapInterval:         30 * time.Second,
+		FallbackPollInterval: 15 * time.Second,
+	}
+}
 
 // WorkerPool manages a pool of workers that process jobs from queues
 type WorkerPool struct {
-	queue    Queue
+	id       string // identifies this pool's process in worker IDs
+	queue    aletheia.Queue
 	logger   *slog.Logger
 	config   Config
-	handlers map[string]JobHandler // job_type -> handler
+	handlers map[string]aletheia.JobHandler // job_type -> handler
 	cancel   context.CancelFunc
 	wg       sync.WaitGroup
 	mu       sync.RWMutex
 }
 
 // NewWorkerPool creates a new worker pool
-func NewWorkerPool(queue Queue, logger *slog.Logger, config Config) *WorkerPool {
+func NewWorkerPool(queue aletheia.Queue, logger *slog.Logger, config Config) *WorkerPool {
 	return &WorkerPool{
+		id:       poolID(),
 		queue:    queue,
 		logger:   logger,
 		config:   config,
-		handlers: make(map[string]JobHandler),
+		handlers: make(map[string]aletheia.JobHandler),
 	}
 }
 
 // RegisterHandler registers a handler for a specific job type
-func (wp *WorkerPool) RegisterHandler(jobType string, handler JobHandler) {
+func (wp *WorkerPool) RegisterHandler(jobType string, handler aletheia.JobHandler) {
 	wp.mu.Lock()
 	defer wp.mu.Unlock()
 
@@ -57,17 +87,25 @@ func (wp *WorkerPool) Start(ctx context.Context, queueNames []string) error {
 	wp.cancel = cancel
 	wp.mu.Unlock()
 
+	// Subscribe to job notifications if the queue supports them
+	wake := wp.listen(workerCtx, queueNames)
+
 	// Start workers
 	for i := 0; i < wp.config.WorkerCount; i++ {
 		wp.wg.Add(1)
-		workerID := fmt.Sprintf("worker-%d", i+1)
+		workerID := fmt.Sprintf("%s/worker-%d", wp.id, i+1)
 
-		go wp.worker(workerCtx, workerID, queueNames)
+		go wp.worker(workerCtx, workerID, queueNames, wake)
 	}
 
+	// Reclaim jobs abandoned by crashed workers, in this or any other process
+	wp.wg.Add(1)
+	go wp.reaper(workerCtx)
+
 	wp.logger.Info("worker pool started",
 		slog.Int("worker_count", wp.config.WorkerCount),
 		slog.Any("queues", queueNames),
+		slog.Bool("notifications", wake != nil),
 	)
 
 	return nil
@@ -108,15 +146,25 @@ func (wp *WorkerPool) Stop() error {
 	}
 }
 
-// worker is the main worker loop
-func (wp *WorkerPool) worker(ctx context.Context, workerID string, queueNames []string) {
+// worker is the main worker loop. It checks for jobs when woken by a job
+// notification or on each poll tick. wake is nil if the queue does not
+// push notifications.
+func (wp *WorkerPool) worker(ctx context.Context, workerID string, queueNames []string, wake <-chan struct{}) {
 	defer wp.wg.Done()
 
 	wp.logger.Debug("worker started", slog.String("worker_id", workerID))
 
-	ticker := time.NewTicker(wp.config.PollInterval)
+	pollInterval := wp.config.PollInterval
+	if wake != nil && wp.config.FallbackPollInterval > 0 {
+		pollInterval = wp.config.FallbackPollInterval
+	}
+
+	ticker := time.NewTicker(pollInterval)
 	defer ticker.Stop()
 
+	// Pick up jobs enqueued before the pool started
+	wp.drain(ctx, workerID, queueNames)
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -124,110 +172,154 @@ func (wp *WorkerPool) worker(ctx context.Context, workerID string, queueNames []
 			return
 
 		case <-ticker.C:
-			if err := wp.processNextJob(ctx, workerID, queueNames); err != nil {
-				wp.logger.Error("failed to process job",
-					slog.String("worker_id", workerID),
-					slog.String("error", err.Error()),
-				)
-			}
+		case <-wake:
 		}
+
+		wp.drain(ctx, workerID, queueNames)
 	}
 }
 
-// processNextJob attempts to dequeue and process a single job
-func (wp *WorkerPool) processNextJob(ctx context.Context, workerID string, queueNames []string) error {
-	// Dequeue next job
-	opts := &DequeueOptions{
-		QueueNames: queueNames,
-		Timeout:    wp.config.PollInterval,
+// drain processes available jobs until the queues are empty.
+func (wp *WorkerPool) drain(ctx context.Context, workerID string, queueNames []string) {
+	for ctx.Err() == nil {
+		processed, err := wp.processNextJob(ctx, workerID, queueNames)
+		if err != nil {
+			wp.logger.Error("failed to process job",
+				slog.String("worker_id", workerID),
+				slog.String("error", err.Error()),
+			)
+			return
+		}
+		if !processed {
+			return
+		}
 	}
+}
 
-	job, err := wp.queue.Dequeue(ctx, workerID, opts)
-	if err != nil {
-		return fmt.Errorf("failed to dequeue job: %w", err)
+// listen subscribes to job notifications and returns a channel that wakes
+// one idle worker per notification. It returns nil, leaving workers to poll,
+// if the queue cannot push notifications.
+func (wp *WorkerPool) listen(ctx context.Context, queueNames []string) <-chan struct{} {
+	listener, ok := wp.queue.(aletheia.QueueListener)
+	if !ok {
+		return nil
 	}
 
-	if job == nil {
-		// No jobs available
+	notifications, err := listener.Listen(ctx, queueNames)
+	if err != nil {
+		wp.logger.Warn("job notifications unavailable, polling instead",
+			slog.String("error", err.Error()),
+		)
 		return nil
 	}
 
-	// Check rate limits before processing
-	if wp.config.EnableRateLimiting {
-		canProcess, err := wp.queue.CanProcessJob(ctx, job.OrganizationID, job.QueueName)
-		if err != nil {
-			return fmt.Errorf("failed to check rate limits: %w", err)
-		}
+	// Buffer a wakeup per worker; further wakeups while all are pending
+	// would be redundant since each worker drains the queues.
+	wake := make(chan struct{}, wp.config.WorkerCount)
 
-		if !canProcess {
-			// Re-queue the job for later
-			wp.logger.Debug("rate limit exceeded, requeueing job",
-				slog.String("job_id", job.ID.String()),
-				slog.String("org_id", job.OrganizationID.String()),
-			)
-
-			// Mark as pending again with a delay
-			if err := wp.queue.Fail(ctx, job.ID, "rate limit exceeded"); err != nil {
-				return fmt.Errorf("failed to requeue job: %w", err)
+	wp.wg.Add(1)
+	go func() {
+		defer wp.wg.Done()
+		for range notifications {
+			select {
+			case wake <- struct{}{}:
+			default:
 			}
+		}
+	}()
+
+	return wake
+}
 
-			return nil
+// processNextJob dequeues and processes a single job from the first queue
+// that has one available. It reports whether a job was processed.
+func (wp *WorkerPool) processNextJob(ctx context.Context, workerID string, queueNames []string) (bool, error) {
+	for _, queueName := range queueNames {
+		job, err := wp.queue.Dequeue(ctx, queueName, workerID)
+		if err != nil {
+			return false, fmt.Errorf("failed to dequeue job: %w", err)
+		}
+		if job == nil {
+			continue
 		}
+
+		return true, wp.executeJob(ctx, workerID, job)
 	}
 
-	// Process the job
-	return wp.executeJob(ctx, job)
+	// No jobs available
+	return false, nil
 }
 
 // executeJob runs the job handler and updates the job status
-func (wp *WorkerPool) executeJob(ctx context.Context, job *Job) error {
+func (wp *WorkerPool) executeJob(ctx context.Context, workerID string, job *aletheia.Job) error {
 	wp.logger.Info("processing job",
 		slog.String("job_id", job.ID.String()),
+		slog.String("worker_id", workerID),
 		slog.String("queue", job.QueueName),
 		slog.String("type", job.JobType),
 		slog.Int("attempt", job.AttemptCount),
 	)
 
+	// Record the outcome even if the pool is shutting down.
+	statusCtx := context.WithoutCancel(ctx)
+
 	// Find handler
 	wp.mu.RLock()
 	handler, exists := wp.handlers[job.JobType]
 	wp.mu.RUnlock()
 
 	if !exists {
-		errMsg := fmt.Sprintf("no handler registered for job type: %s", job.JobType)
 		wp.logger.Error("handler not found",
 			slog.String("job_id", job.ID.String()),
 			slog.String("job_type", job.JobType),
 		)
-		return wp.queue.Fail(ctx, job.ID, errMsg)
+		// Retrying cannot help until a handler is deployed.
+		return wp.queue.Fail(statusCtx, job.ID, workerID, aletheia.Permanent(fmt.Errorf("no handler registered for job type: %s", job.JobType)))
 	}
 
 	// Create job context with timeout
 	jobCtx, cancel := context.WithTimeout(ctx, wp.config.JobTimeout)
 	defer cancel()
 
+	// Let the handler report progress on its attempt
+	jobCtx = aletheia.WithProgress(jobCtx, func(ctx context.Context, percent int, message string) error {
+		return wp.queue.UpdateProgress(ctx, job.ID, workerID, percent, message)
+	})
+
+	// Keep the job's heartbeat fresh while the handler runs
+	var reclaimed atomic.Bool
+	heartbeatDone := make(chan struct{})
+	defer close(heartbeatDone)
+	go wp.heartbeat(statusCtx, workerID, job, func() {
+		reclaimed.Store(true)
+		cancel()
+	}, heartbeatDone)
+
 	// Execute handler
 	startTime := time.Now()
-	result, err := handler(jobCtx, job)
+	err := handler.Handle(jobCtx, job)
 	duration := time.Since(startTime)
 
+	// The job now belongs to another attempt, which reports its outcome
+	if reclaimed.Load() {
+		wp.logger.Warn("job reclaimed, discarding outcome",
+			slog.String("job_id", job.ID.String()),
+			slog.String("worker_id", workerID),
+			slog.Duration("duration", duration),
+		)
+		return nil
+	}
+
 	if err != nil {
 		wp.logger.Error("job failed",
 			slog.String("job_id", job.ID.String()),
 			slog.String("error", err.Error()),
+			slog.Int("attempt", job.AttemptCount),
+			slog.Bool("permanent", aletheia.IsPermanent(err)),
 			slog.Duration("duration", duration),
 		)
 
-		return wp.queue.Fail(ctx, job.ID, err.Error())
-	}
-
-	// Record successful processing for rate limiting
-	if wp.config.EnableRateLimiting {
-		if err := wp.queue.RecordJobProcessed(ctx, job.OrganizationID, job.QueueName); err != nil {
-			wp.logger.Warn("failed to record job processed",
-				slog.String("error", err.Error()),
-			)
-		}
+		return wp.reportOutcome(job, workerID, wp.queue.Fail(statusCtx, job.ID, workerID, err))
 	}
 
 	wp.logger.Info("job completed",
@@ -235,63 +327,106 @@ func (wp *WorkerPool) executeJob(ctx context.Context, job *Job) error {
 		slog.Duration("duration", duration),
 	)
 
-	return wp.queue.Complete(ctx, job.ID, result)
+	return wp.reportOutcome(job, workerID, wp.queue.Complete(statusCtx, job.ID, workerID, nil))
 }
 
-// GetHandler retrieves a registered handler (for testing)
-func (wp *WorkerPool) GetHandler(jobType string) (JobHandler, bool) {
-	wp.mu.RLock()
-	defer wp.mu.RUnlock()
-
-	handler, exists := wp.handlers[jobType]
-	return handler, exists
+// reportOutcome returns the error of recording a job's outcome, ignoring a
+// job reclaimed after its last heartbeat: its new attempt reports instead.
+func (wp *WorkerPool) reportOutcome(job *aletheia.Job, workerID string, err error) error {
+	if aletheia.IsErrorCode(err, aletheia.ECONFLICT) {
+		wp.logger.Warn("job reclaimed before its outcome was recorded",
+			slog.String("job_id", job.ID.String()),
+			slog.String("worker_id", workerID),
+		)
+		return nil
+	}
+	return err
 }
 
-// StartBackgroundCleanup starts a goroutine that periodically cleans up old jobs
-func (wp *WorkerPool) StartBackgroundCleanup(ctx context.Context) {
-	wp.wg.Add(1)
+// heartbeat records heartbeats for a running job until done is closed. If
+// the job has been reclaimed by another process, it calls reclaimed, which
+// cancels the handler.
+func (wp *WorkerPool) heartbeat(ctx context.Context, workerID string, job *aletheia.Job, reclaimed func(), done <-chan struct{}) {
+	interval := wp.config.HeartbeatInterval
+	if interval <= 0 {
+		interval = wp.config.JobTimeout / 3
+	}
 
-	go func() {
-		defer wp.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 
-		ticker := time.NewTicker(wp.config.CleanupInterval)
-		defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
 
-		for {
-			select {
-			case <-ctx.Done():
+		case <-ticker.C:
+			err := wp.queue.Heartbeat(ctx, job.ID, workerID)
+			if err == nil {
+				continue
+			}
+			if aletheia.IsErrorCode(err, aletheia.ECONFLICT) || aletheia.IsErrorCode(err, aletheia.ENOTFOUND) {
+				wp.logger.Warn("job reclaimed while running, cancelling",
+					slog.String("job_id", job.ID.String()),
+					slog.String("worker_id", workerID),
+				)
+				reclaimed()
 				return
-
-			case <-ticker.C:
-				if err := wp.cleanupOldJobs(ctx); err != nil {
-					wp.logger.Error("cleanup failed",
-						slog.String("error", err.Error()),
-					)
-				}
 			}
+			wp.logger.Error("failed to record heartbeat",
+				slog.String("job_id", job.ID.String()),
+				slog.String("error", err.Error()),
+			)
 		}
-	}()
-
-	wp.logger.Info("background cleanup started",
-		slog.Duration("interval", wp.config.CleanupInterval),
-		slog.Duration("retention", wp.config.CleanupRetention),
-	)
+	}
 }
 
-// cleanupOldJobs deletes old completed and failed jobs
-func (wp *WorkerPool) cleanupOldJobs(ctx context.Context) error {
-	// This would need to be implemented based on your Queue interface
-	// For now, we'll log that it ran
-	wp.logger.Debug("running job cleanup")
+// reaper periodically returns jobs whose worker stopped sending heartbeats
+// to the queue. A job is stale once it has gone JobTimeout without one.
+func (wp *WorkerPool) reaper(ctx context.Context) {
+	defer wp.wg.Done()
 
-	// If using PostgresQueue, you could add a cleanup method to the interface
-	// or run raw SQL here. For now, we'll skip the actual deletion.
+	interval := wp.config.ReapInterval
+	if interval <= 0 {
+		interval = wp.config.JobTimeout
+	}
 
-	return nil
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			reaped, err := wp.queue.ReapStaleJobs(ctx, wp.config.JobTimeout)
+			if err != nil {
+				wp.logger.Error("failed to reap stale jobs", slog.String("error", err.Error()))
+				continue
+			}
+			if reaped > 0 {
+				wp.logger.Warn("reclaimed stale jobs", slog.Int("count", reaped))
+			}
+		}
+	}
+}
+
+// poolID identifies this process in worker IDs, so the worker holding a job
+// can be traced to a host.
+func poolID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
 }
 
-// EnqueueJob is a convenience method to enqueue a job
-// This is a helper that can be used by handlers
-func (wp *WorkerPool) EnqueueJob(ctx context.Context, queueName, jobType string, organizationID uuid.UUID, payload map[string]interface{}, opts *EnqueueOptions) (*Job, error) {
-	return wp.queue.Enqueue(ctx, queueName, jobType, organizationID, payload, opts)
+// GetHandler retrieves a registered handler (for testing)
+func (wp *WorkerPool) GetHandler(jobType string) (aletheia.JobHandler, bool) {
+	wp.mu.RLock()
+	defer wp.mu.RUnlock()
+
+	handler, exists := wp.handlers[jobType]
+	return handler, exists
 }
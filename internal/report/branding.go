@@ -0,0 +1,98 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/pdf"
+)
+
+// logoMaxPx is the size logos are shrunk to before being embedded.
+const logoMaxPx = 360
+
+// chooseTemplate returns the template a report is generated with: the
+// requested one, or else the organization's default.
+func chooseTemplate(requested aletheia.ReportTemplate, org *aletheia.Organization) aletheia.ReportTemplate {
+	if requested.IsValid() {
+		return requested
+	}
+	if org.Branding.ReportTemplate.IsValid() {
+		return org.Branding.ReportTemplate
+	}
+	return aletheia.ReportTemplateFull
+}
+
+// logo returns an organization's logo as JPEG, flattened onto white so
+// transparent logos keep their look.
+func (g *Generator) logo(ctx context.Context, org *aletheia.Organization) ([]byte, error) {
+	return g.flatImage(ctx, org.Branding.LogoURL, logoMaxPx)
+}
+
+// flatImage returns the stored image at url as JPEG no larger than maxPx,
+// flattened onto white.
+func (g *Generator) flatImage(ctx context.Context, url string, maxPx int) ([]byte, error) {
+	key, ok := aletheia.StorageKey(g.Storage, url)
+	if !ok {
+		return nil, fmt.Errorf("image is not in storage: %s", url)
+	}
+
+	file, err := g.Storage.Open(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(io.LimitReader(file, aletheia.MaxUploadSize))
+	if err != nil {
+		return nil, fmt.Errorf("decoding image: %w", err)
+	}
+
+	b := img.Bounds()
+	flat := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(flat, flat.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), img, b.Min, draw.Over)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, shrink(flat, maxPx), &jpeg.Options{Quality: 90}); err != nil {
+		return nil, fmt.Errorf("encoding image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// parseColor parses a "#rrggbb" color.
+func parseColor(s string) (pdf.Color, bool) {
+	s, ok := strings.CutPrefix(s, "#")
+	if !ok || len(s) != 6 {
+		return pdf.Color{}, false
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return pdf.Color{}, false
+	}
+	return pdf.RGB(uint8(v>>16), uint8(v>>8), uint8(v)), true
+}
+
+// accent returns the organization's primary color, or the default banner
+// color if it has none.
+func accent(org *aletheia.Organization) pdf.Color {
+	if c, ok := parseColor(org.Branding.PrimaryColor); ok {
+		return c
+	}
+	return colorBanner
+}
+
+// contrast returns the text color readable on a background color.
+func contrast(background pdf.Color) pdf.Color {
+	if 0.299*background.R+0.587*background.G+0.114*background.B > 0.6 {
+		return colorText
+	}
+	return colorInverse
+}
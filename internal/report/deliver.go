@@ -0,0 +1,163 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/queue"
+	"github.com/google/uuid"
+)
+
+// Filename returns the name a report's PDF is downloaded or attached as.
+func Filename(report *aletheia.Report) string {
+	return fmt.Sprintf("inspection-report-v%d-%s.pdf", report.Version, report.CreatedAt.Format("2006-01-02"))
+}
+
+// NewDeliveryJob returns a notification email job that sends a report
+// delivery. Each delivery is sent by its own job, so a failure for one
+// recipient does not hold up the others.
+func NewDeliveryJob(delivery *aletheia.ReportDelivery, inspection *aletheia.Inspection, organizationID uuid.UUID) (*aletheia.Job, error) {
+	payload, err := json.Marshal(aletheia.ReportDeliveryPayload{DeliveryID: delivery.ID})
+	if err != nil {
+		return nil, err
+	}
+	return &aletheia.Job{
+		QueueName:      Queue,
+		JobType:        aletheia.JobTypeNotificationEmail,
+		OrganizationID: organizationID,
+		InspectionID:   inspection.ID,
+		Payload:        payload,
+		UniqueKey:      aletheia.JobTypeNotificationEmail + ":" + delivery.ID.String(),
+	}, nil
+}
+
+// Mailer emails reports to their recipients and records the outcome in
+// each report's delivery log.
+type Mailer struct {
+	Inspections   aletheia.InspectionService
+	Projects      aletheia.ProjectService
+	Organizations aletheia.OrganizationService
+	Reports       aletheia.ReportService
+	Storage       aletheia.FileStorage
+	Email         aletheia.EmailService
+	Logger        *slog.Logger
+}
+
+// Register registers the notification email handler.
+func (m *Mailer) Register(pool *queue.WorkerPool) {
+	pool.RegisterHandler(aletheia.JobTypeNotificationEmail, aletheia.JobHandlerFunc(m.handle))
+}
+
+func (m *Mailer) handle(ctx context.Context, job *aletheia.Job) error {
+	var payload aletheia.ReportDeliveryPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return aletheia.Permanent(fmt.Errorf("decoding payload: %w", err))
+	}
+
+	err := m.Send(ctx, payload.DeliveryID)
+	if err == nil {
+		return nil
+	}
+
+	// The delivery or its report was deleted after the job was queued
+	if aletheia.IsErrorCode(err, aletheia.ENOTFOUND) {
+		err = aletheia.Permanent(err)
+	}
+
+	// The log shows the latest failure; a later attempt may still succeed
+	if markErr := m.Reports.MarkReportDeliveryFailed(ctx, payload.DeliveryID, err.Error()); markErr != nil && !aletheia.IsErrorCode(markErr, aletheia.ENOTFOUND) {
+		m.Logger.Error("failed to record report delivery failure",
+			slog.String("delivery_id", payload.DeliveryID.String()),
+			slog.String("error", markErr.Error()))
+	}
+	return err
+}
+
+// Send emails a report delivery to its recipient and marks it sent.
+// Deliveries already sent are not sent again.
+func (m *Mailer) Send(ctx context.Context, deliveryID uuid.UUID) error {
+	delivery, err := m.Reports.FindReportDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return err
+	}
+	if delivery.Status == aletheia.DeliveryStatusSent || delivery.Status == aletheia.DeliveryStatusBounced {
+		return nil
+	}
+
+	report, err := m.Reports.FindReportByID(ctx, delivery.ReportID)
+	if err != nil {
+		return err
+	}
+	inspection, err := m.Inspections.FindInspectionByID(ctx, report.InspectionID)
+	if err != nil {
+		return err
+	}
+	project, err := m.Projects.FindProjectByID(ctx, inspection.ProjectID)
+	if err != nil {
+		return err
+	}
+	organization, err := m.Organizations.FindOrganizationByID(ctx, project.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	email := aletheia.ReportEmail{
+		To:           delivery.Recipient,
+		Subject:      fmt.Sprintf("Inspection report: %s", project.Name),
+		Message:      delivery.Message,
+		ReportURL:    report.StorageURL,
+		Organization: organization.Name,
+		Branding:     organization.Branding,
+	}
+	if delivery.Attached {
+		if email.Attachment, err = m.attachment(ctx, report); err != nil {
+			return err
+		}
+	}
+
+	messageID, err := m.Email.SendInspectionReport(ctx, email)
+	if err != nil {
+		return err
+	}
+	if err := m.Reports.MarkReportDeliverySent(ctx, delivery.ID, messageID); err != nil {
+		return err
+	}
+
+	m.Logger.Info("report delivered",
+		slog.String("delivery_id", delivery.ID.String()),
+		slog.String("report_id", report.ID.String()),
+		slog.Bool("attached", delivery.Attached))
+	return nil
+}
+
+// attachment reads a report's PDF from storage.
+func (m *Mailer) attachment(ctx context.Context, report *aletheia.Report) (*aletheia.Attachment, error) {
+	key, ok := aletheia.StorageKey(m.Storage, report.StorageURL)
+	if !ok {
+		return nil, aletheia.Permanent(fmt.Errorf("report %s is not in storage", report.ID))
+	}
+
+	file, err := m.Storage.Open(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, aletheia.MaxAttachmentSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading report: %w", err)
+	}
+	if len(content) > aletheia.MaxAttachmentSize {
+		return nil, aletheia.Permanent(fmt.Errorf("report %s is too large to attach", report.ID))
+	}
+
+	return &aletheia.Attachment{
+		Name:        Filename(report),
+		ContentType: "application/pdf",
+		Content:     content,
+	}, nil
+}
@@ -0,0 +1,139 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testMailer returns a mailer for a delivery of a stored report, and the
+// email service it sends through.
+func testMailer(t *testing.T, delivery *aletheia.ReportDelivery) (*Mailer, *mock.EmailService, *[]string) {
+	t.Helper()
+
+	org := &aletheia.Organization{ID: uuid.New(), Name: "Acme Construction", Branding: aletheia.OrganizationBranding{PrimaryColor: "#0f766e"}}
+	project := &aletheia.Project{ID: uuid.New(), OrganizationID: org.ID, Name: "Riverside Tower"}
+	inspection := &aletheia.Inspection{ID: uuid.New(), ProjectID: project.ID}
+	report := &aletheia.Report{
+		ID:           delivery.ReportID,
+		InspectionID: inspection.ID,
+		Version:      2,
+		StorageURL:   storageURL + "reports/r.pdf",
+		CreatedAt:    time.Date(2025, 12, 1, 9, 0, 0, 0, time.UTC),
+	}
+
+	var log []string
+	email := &mock.EmailService{}
+	m := &Mailer{
+		Inspections: &mock.InspectionService{
+			FindInspectionByIDFn: func(ctx context.Context, id uuid.UUID) (*aletheia.Inspection, error) { return inspection, nil },
+		},
+		Projects: &mock.ProjectService{
+			FindProjectByIDFn: func(ctx context.Context, id uuid.UUID) (*aletheia.Project, error) { return project, nil },
+		},
+		Organizations: &mock.OrganizationService{
+			FindOrganizationByIDFn: func(ctx context.Context, id uuid.UUID) (*aletheia.Organization, error) { return org, nil },
+		},
+		Reports: &mock.ReportService{
+			FindReportByIDFn: func(ctx context.Context, id uuid.UUID) (*aletheia.Report, error) { return report, nil },
+			FindReportDeliveryByIDFn: func(ctx context.Context, id uuid.UUID) (*aletheia.ReportDelivery, error) {
+				if id != delivery.ID {
+					return nil, aletheia.NotFound("Report delivery not found")
+				}
+				return delivery, nil
+			},
+			MarkReportDeliverySentFn: func(ctx context.Context, id uuid.UUID, messageID string) error {
+				log = append(log, "sent "+messageID)
+				return nil
+			},
+			MarkReportDeliveryFailedFn: func(ctx context.Context, id uuid.UUID, reason string) error {
+				log = append(log, "failed")
+				return nil
+			},
+		},
+		Storage: &mock.FileStorage{
+			OpenFn: func(ctx context.Context, key string) (io.ReadCloser, error) {
+				require.Equal(t, "reports/r.pdf", key)
+				return io.NopCloser(bytes.NewReader([]byte("%PDF-1.4"))), nil
+			},
+		},
+		Email:  email,
+		Logger: slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError})),
+	}
+	return m, email, &log
+}
+
+func TestMailer_Send(t *testing.T) {
+	t.Run("Attached", func(t *testing.T) {
+		delivery := &aletheia.ReportDelivery{ID: uuid.New(), ReportID: uuid.New(), Recipient: "owner@example.com", Attached: true, Message: "See attached.", Status: aletheia.DeliveryStatusPending}
+		m, email, log := testMailer(t, delivery)
+
+		require.NoError(t, m.Send(context.Background(), delivery.ID))
+		sent := email.LastEmail()
+		require.NotNil(t, sent)
+		assert.Equal(t, "owner@example.com", sent.To)
+		assert.Equal(t, "Inspection report: Riverside Tower", sent.Subject)
+		assert.Equal(t, "See attached.", sent.Message)
+		assert.Equal(t, "Acme Construction", sent.Organization)
+		assert.Equal(t, "#0f766e", sent.Branding.PrimaryColor)
+		require.NotNil(t, sent.Attachment)
+		assert.Equal(t, "inspection-report-v2-2025-12-01.pdf", sent.Attachment.Name)
+		assert.Equal(t, "application/pdf", sent.Attachment.ContentType)
+		assert.Equal(t, []byte("%PDF-1.4"), sent.Attachment.Content)
+		assert.Equal(t, []string{"sent mock-1"}, *log)
+	})
+
+	t.Run("Linked", func(t *testing.T) {
+		delivery := &aletheia.ReportDelivery{ID: uuid.New(), ReportID: uuid.New(), Recipient: "owner@example.com", Status: aletheia.DeliveryStatusPending}
+		m, email, _ := testMailer(t, delivery)
+
+		require.NoError(t, m.Send(context.Background(), delivery.ID))
+		sent := email.LastEmail()
+		require.NotNil(t, sent)
+		assert.Nil(t, sent.Attachment)
+		assert.Equal(t, storageURL+"reports/r.pdf", sent.ReportURL)
+	})
+
+	t.Run("AlreadySent", func(t *testing.T) {
+		delivery := &aletheia.ReportDelivery{ID: uuid.New(), ReportID: uuid.New(), Recipient: "owner@example.com", Status: aletheia.DeliveryStatusSent}
+		m, email, _ := testMailer(t, delivery)
+
+		require.NoError(t, m.Send(context.Background(), delivery.ID))
+		assert.Empty(t, email.SentEmails)
+	})
+}
+
+func TestMailer_HandleFailure(t *testing.T) {
+	delivery := &aletheia.ReportDelivery{ID: uuid.New(), ReportID: uuid.New(), Recipient: "owner@example.com", Status: aletheia.DeliveryStatusPending}
+	m, email, log := testMailer(t, delivery)
+	email.SendInspectionReportFn = func(ctx context.Context, email aletheia.ReportEmail) (string, error) {
+		return "", errors.New("connection refused")
+	}
+
+	payload, err := json.Marshal(aletheia.ReportDeliveryPayload{DeliveryID: delivery.ID})
+	require.NoError(t, err)
+
+	// Sending errors are retried
+	err = m.handle(context.Background(), &aletheia.Job{Payload: payload})
+	require.Error(t, err)
+	assert.False(t, aletheia.IsPermanent(err))
+	assert.Equal(t, []string{"failed"}, *log)
+
+	// A missing delivery is not
+	payload, err = json.Marshal(aletheia.ReportDeliveryPayload{DeliveryID: uuid.New()})
+	require.NoError(t, err)
+	err = m.handle(context.Background(), &aletheia.Job{Payload: payload})
+	assert.True(t, aletheia.IsPermanent(err))
+}
@@ -0,0 +1,113 @@
+package report
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/google/uuid"
+)
+
+// Check runs the pre-flight checks on an inspection: what its report would
+// include, and what should be fixed before generating it.
+func Check(ctx context.Context, inspectionService aletheia.InspectionService, photoService aletheia.PhotoService, violationService aletheia.ViolationService, inspection *aletheia.Inspection) (*aletheia.Preflight, error) {
+	photos, _, err := photoService.FindPhotos(ctx, aletheia.PhotoFilter{InspectionID: &inspection.ID})
+	if err != nil {
+		return nil, err
+	}
+	violations, _, err := violationService.FindViolations(ctx, aletheia.ViolationFilter{InspectionID: &inspection.ID})
+	if err != nil {
+		return nil, err
+	}
+	signoffs, err := inspectionService.FindSignoffs(ctx, inspection.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	p := preflight(inspection, len(photos), violations)
+
+	var confirmed []*aletheia.Violation
+	for _, v := range violations {
+		if v.Status == aletheia.ViolationStatusConfirmed {
+			confirmed = append(confirmed, v)
+		}
+	}
+	p.Findings = append(p.Findings, signoffFindings(signoffs, aletheia.NewReportSnapshot(photos, confirmed, nil))...)
+	return p, nil
+}
+
+func preflight(inspection *aletheia.Inspection, photos int, violations []*aletheia.Violation) *aletheia.Preflight {
+	p := &aletheia.Preflight{
+		InspectionID: inspection.ID,
+		BySeverity:   map[aletheia.Severity]int{},
+		Photos:       photos,
+		Findings:     []aletheia.PreflightFinding{},
+	}
+
+	var missingCode, pending []*aletheia.Violation
+	for _, v := range violations {
+		switch v.Status {
+		case aletheia.ViolationStatusConfirmed:
+			p.ConfirmedViolations++
+			p.BySeverity[v.Severity]++
+			if v.SafetyCodeID == uuid.Nil {
+				missingCode = append(missingCode, v)
+			}
+		case aletheia.ViolationStatusDismissed:
+			p.DismissedViolations++
+		case aletheia.ViolationStatusPending:
+			p.PendingViolations++
+			pending = append(pending, v)
+		}
+	}
+
+	// Confirmed violations are cited in the report, so they need a code
+	if len(missingCode) > 0 {
+		p.Findings = append(p.Findings, aletheia.PreflightFinding{
+			Code:         aletheia.PreflightMissingSafetyCode,
+			Severity:     aletheia.PreflightBlocking,
+			Message:      fmt.Sprintf("%s missing a safety code", plural(len(missingCode), "confirmed violation")),
+			ViolationIDs: violationIDs(missingCode),
+		})
+	}
+	if len(pending) > 0 {
+		p.Findings = append(p.Findings, aletheia.PreflightFinding{
+			Code:         aletheia.PreflightPendingViolations,
+			Severity:     aletheia.PreflightBlocking,
+			Message:      fmt.Sprintf("%s awaiting review", plural(len(pending), "violation")),
+			ViolationIDs: violationIDs(pending),
+		})
+	}
+	if photos == 0 {
+		p.Findings = append(p.Findings, aletheia.PreflightFinding{
+			Code:     aletheia.PreflightNoPhotos,
+			Severity: aletheia.PreflightWarning,
+			Message:  "No photos are attached",
+		})
+	}
+	if p.ConfirmedViolations == 0 {
+		p.Findings = append(p.Findings, aletheia.PreflightFinding{
+			Code:     aletheia.PreflightNoConfirmedViolations,
+			Severity: aletheia.PreflightWarning,
+			Message:  "No violations are confirmed",
+		})
+	}
+	if inspection.Status != aletheia.InspectionStatusCompleted {
+		p.Findings = append(p.Findings, aletheia.PreflightFinding{
+			Code:     aletheia.PreflightInspectionIncomplete,
+			Severity: aletheia.PreflightWarning,
+			Message:  "Inspection is not completed",
+		})
+	}
+
+	p.Ready = p.Err() == nil
+	return p
+}
+
+func violationIDs(violations []*aletheia.Violation) []uuid.UUID {
+	ids := make([]uuid.UUID, len(violations))
+	for i, v := range violations {
+		ids[i] = v.ID
+	}
+	return ids
+}
@@ -0,0 +1,80 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreflight(t *testing.T) {
+	inspection := &aletheia.Inspection{ID: uuid.New(), Status: aletheia.InspectionStatusCompleted}
+	violation := func(status aletheia.ViolationStatus, severity aletheia.Severity, coded bool) *aletheia.Violation {
+		v := &aletheia.Violation{ID: uuid.New(), Status: status, Severity: severity}
+		if coded {
+			v.SafetyCodeID = uuid.New()
+		}
+		return v
+	}
+
+	t.Run("Ready", func(t *testing.T) {
+		p := preflight(inspection, 3, []*aletheia.Violation{
+			violation(aletheia.ViolationStatusConfirmed, aletheia.SeverityCritical, true),
+			violation(aletheia.ViolationStatusConfirmed, aletheia.SeverityHigh, true),
+			violation(aletheia.ViolationStatusConfirmed, aletheia.SeverityHigh, true),
+			violation(aletheia.ViolationStatusDismissed, aletheia.SeverityLow, false),
+		})
+		assert.True(t, p.Ready)
+		assert.NoError(t, p.Err())
+		assert.Empty(t, p.Findings)
+		assert.Equal(t, 3, p.ConfirmedViolations)
+		assert.Equal(t, map[aletheia.Severity]int{aletheia.SeverityCritical: 1, aletheia.SeverityHigh: 2}, p.BySeverity)
+		assert.Equal(t, 1, p.DismissedViolations)
+		assert.Equal(t, 3, p.Photos)
+	})
+
+	t.Run("Blocking", func(t *testing.T) {
+		uncoded := violation(aletheia.ViolationStatusConfirmed, aletheia.SeverityHigh, false)
+		pending := violation(aletheia.ViolationStatusPending, aletheia.SeverityLow, false)
+		p := preflight(inspection, 1, []*aletheia.Violation{uncoded, pending})
+
+		assert.False(t, p.Ready)
+		assert.Equal(t, 1, p.PendingViolations)
+		assert.Equal(t, []aletheia.PreflightFinding{
+			{Code: aletheia.PreflightMissingSafetyCode, Severity: aletheia.PreflightBlocking, Message: "1 confirmed violation missing a safety code", ViolationIDs: []uuid.UUID{uncoded.ID}},
+			{Code: aletheia.PreflightPendingViolations, Severity: aletheia.PreflightBlocking, Message: "1 violation awaiting review", ViolationIDs: []uuid.UUID{pending.ID}},
+		}, p.Findings)
+
+		err := p.Err()
+		assert.True(t, aletheia.IsErrorCode(err, aletheia.EINVALID))
+		assert.Len(t, aletheia.ErrorFields(err), 2)
+	})
+
+	t.Run("Warnings", func(t *testing.T) {
+		draft := &aletheia.Inspection{ID: uuid.New(), Status: aletheia.InspectionStatusDraft}
+		p := preflight(draft, 0, nil)
+
+		assert.True(t, p.Ready)
+		assert.NoError(t, p.Err())
+		var codes []string
+		for _, f := range p.Findings {
+			assert.Equal(t, aletheia.PreflightWarning, f.Severity)
+			codes = append(codes, f.Code)
+		}
+		assert.Equal(t, []string{aletheia.PreflightNoPhotos, aletheia.PreflightNoConfirmedViolations, aletheia.PreflightInspectionIncomplete}, codes)
+	})
+}
+
+func TestSignoffFindings(t *testing.T) {
+	content := aletheia.NewReportSnapshot(nil, []*aletheia.Violation{{ID: uuid.New()}}, nil)
+	current := &aletheia.Signoff{Role: aletheia.SignoffInspector, ContentHash: content.ContentHash()}
+	outdated := &aletheia.Signoff{Role: aletheia.SignoffReviewer, ContentHash: "before"}
+
+	assert.Empty(t, signoffFindings([]*aletheia.Signoff{current}, content))
+	assert.Equal(t, []aletheia.PreflightFinding{{
+		Code:     aletheia.PreflightSignoffOutdated,
+		Severity: aletheia.PreflightWarning,
+		Message:  "Reviewer sign-off predates later changes and will be left out",
+	}}, signoffFindings([]*aletheia.Signoff{current, outdated}, content))
+}
@@ -0,0 +1,503 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png" // Register PNG photos for image.Decode
+	"io"
+	"log/slog"
+	"math"
+	"strings"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/pdf"
+	"github.com/google/uuid"
+)
+
+// Page layout in points.
+const (
+	margin       = 54.0
+	contentWidth = pdf.LetterWidth - 2*margin
+	footerTop    = pdf.LetterHeight - margin + 12
+	pageBottom   = pdf.LetterHeight - margin - 12
+
+	photoColumns   = 3
+	photoGap       = 12.0
+	thumbnailMaxPx = 480
+)
+
+var (
+	colorText    = pdf.RGB(24, 24, 27)
+	colorMuted   = pdf.RGB(113, 113, 122)
+	colorRule    = pdf.RGB(228, 228, 231)
+	colorPanel   = pdf.RGB(244, 244, 245)
+	colorBanner  = pdf.RGB(24, 24, 27)
+	colorInverse = pdf.RGB(255, 255, 255)
+)
+
+// severities lists the violation severities in report order.
+var severities = []aletheia.Severity{
+	aletheia.SeverityCritical,
+	aletheia.SeverityHigh,
+	aletheia.SeverityMedium,
+	aletheia.SeverityLow,
+}
+
+// severityColor returns the accent color of a severity.
+func severityColor(s aletheia.Severity) pdf.Color {
+	switch s {
+	case aletheia.SeverityCritical:
+		return pdf.RGB(185, 28, 28)
+	case aletheia.SeverityHigh:
+		return pdf.RGB(234, 88, 12)
+	case aletheia.SeverityMedium:
+		return pdf.RGB(202, 138, 4)
+	default:
+		return pdf.RGB(37, 99, 235)
+	}
+}
+
+// loadThumbnails downloads the photos of a report and shrinks them to JPEG
+// thumbnails, keyed by photo ID. Photos that cannot be read are left out and
+// shown as placeholders.
+func (g *Generator) loadThumbnails(ctx context.Context, photos []*aletheia.Photo) map[uuid.UUID][]byte {
+	thumbnails := make(map[uuid.UUID][]byte, len(photos))
+	for i, photo := range photos {
+		aletheia.ReportProgress(ctx, 20+50*i/len(photos), fmt.Sprintf("Preparing photo %d of %d", i+1, len(photos)))
+
+		data, err := g.thumbnail(ctx, photo)
+		if err != nil {
+			g.Logger.Warn("leaving photo out of report",
+				slog.String("photo_id", photo.ID.String()),
+				slog.String("error", err.Error()))
+			continue
+		}
+		thumbnails[photo.ID] = data
+	}
+	return thumbnails
+}
+
+// thumbnail returns a photo shrunk to fit thumbnailMaxPx, as JPEG. It starts
+// from the stored thumbnail when there is one.
+func (g *Generator) thumbnail(ctx context.Context, photo *aletheia.Photo) ([]byte, error) {
+	url := photo.ThumbnailURL
+	if url == "" {
+		url = photo.StorageURL
+	}
+	key, ok := aletheia.StorageKey(g.Storage, url)
+	if !ok {
+		return nil, fmt.Errorf("photo is not in storage: %s", url)
+	}
+
+	file, err := g.Storage.Open(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(io.LimitReader(file, 4*aletheia.MaxUploadSize))
+	if err != nil {
+		return nil, fmt.Errorf("decoding photo: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, shrink(img, thumbnailMaxPx), &jpeg.Options{Quality: 80}); err != nil {
+		return nil, fmt.Errorf("encoding thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// shrink scales img down to fit within maxPx on its longer side, averaging
+// the source pixels each output pixel covers.
+func shrink(img image.Image, maxPx int) image.Image {
+	b := img.Bounds()
+	scale := float64(maxPx) / float64(max(b.Dx(), b.Dy()))
+	if scale >= 1 {
+		return img
+	}
+
+	src := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(src, src.Bounds(), img, b.Min, draw.Src)
+
+	w := max(1, int(math.Round(float64(b.Dx())*scale)))
+	h := max(1, int(math.Round(float64(b.Dy())*scale)))
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		y0, y1 := y*b.Dy()/h, max((y+1)*b.Dy()/h, y*b.Dy()/h+1)
+		for x := 0; x < w; x++ {
+			x0, x1 := x*b.Dx()/w, max((x+1)*b.Dx()/w, x*b.Dx()/w+1)
+			var r, g, bl, n int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					r += int(row[sx*4])
+					g += int(row[sx*4+1])
+					bl += int(row[sx*4+2])
+					n++
+				}
+			}
+			i := dst.PixOffset(x, y)
+			dst.Pix[i], dst.Pix[i+1], dst.Pix[i+2], dst.Pix[i+3] = uint8(r/n), uint8(g/n), uint8(bl/n), 255
+		}
+	}
+	return dst
+}
+
+// layout places content top to bottom, starting new pages as needed.
+type layout struct {
+	doc  *pdf.Document
+	page *pdf.Page
+	y    float64
+}
+
+func (l *layout) newPage() {
+	l.page = l.doc.AddPage()
+	l.y = margin
+}
+
+// need starts a new page unless h more points fit on this one.
+func (l *layout) need(h float64) {
+	if l.y+h > pageBottom {
+		l.newPage()
+	}
+}
+
+// paragraph draws wrapped text at x, no wider than width.
+func (l *layout) paragraph(x, width float64, font pdf.Font, size float64, c pdf.Color, s string) {
+	leading := size * 1.35
+	for _, line := range pdf.Wrap(font, size, width, s) {
+		l.need(leading)
+		l.page.Text(x, l.y+size, font, size, c, line)
+		l.y += leading
+	}
+}
+
+// heading draws a section heading, keeping room for some content below it.
+func (l *layout) heading(s string) {
+	l.need(60)
+	l.y += 10
+	l.page.Text(margin, l.y+14, pdf.HelveticaBold, 14, colorText, s)
+	l.y += 20
+	l.page.Line(margin, l.y, margin+contentWidth, l.y, 0.75, colorRule)
+	l.y += 10
+}
+
+// render lays out an inspection report.
+func render(data *reportData, thumbnails map[uuid.UUID][]byte) *pdf.Document {
+	doc := pdf.New(pdf.LetterWidth, pdf.LetterHeight)
+	doc.Title = title(data.Template) + ": " + data.Project.Name
+	doc.Subject = "Inspection " + data.Inspection.ID.String()
+	if data.Inspector != nil {
+		doc.Author = data.Inspector.FullName()
+	}
+
+	l := &layout{doc: doc}
+	l.newPage()
+
+	renderHeader(l, data)
+	switch data.Template {
+	case aletheia.ReportTemplateExecutiveSummary:
+		renderSummary(l, data)
+		renderViolations(l, data, mostSevere(data.Violations), "Critical and High Violations",
+			"No critical or high severity violations were confirmed during this inspection.")
+	case aletheia.ReportTemplateViolationsOnly:
+		renderViolations(l, data, data.Violations, "Confirmed Violations",
+			"No violations were confirmed during this inspection.")
+	default:
+		renderSummary(l, data)
+		renderViolations(l, data, data.Violations, "Confirmed Violations",
+			"No violations were confirmed during this inspection.")
+		renderPhotos(l, data, thumbnails)
+	}
+	renderSignoffs(l, data)
+	renderDisclaimer(l, data.Organization)
+	renderFooters(doc, data.Project.Name+" · "+title(data.Template))
+
+	return doc
+}
+
+// title returns the title of a report template.
+func title(t aletheia.ReportTemplate) string {
+	switch t {
+	case aletheia.ReportTemplateExecutiveSummary:
+		return "Executive Summary"
+	case aletheia.ReportTemplateViolationsOnly:
+		return "Violations Report"
+	default:
+		return "Inspection Report"
+	}
+}
+
+// mostSevere returns the critical and high severity violations.
+func mostSevere(violations []*aletheia.Violation) []*aletheia.Violation {
+	var result []*aletheia.Violation
+	for _, v := range violations {
+		if v.Severity == aletheia.SeverityCritical || v.Severity == aletheia.SeverityHigh {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func renderHeader(l *layout, data *reportData) {
+	renderBanner(l, data.Organization, data.Logo, title(data.Template))
+
+	inspector := "Unknown"
+	if data.Inspector != nil {
+		inspector = data.Inspector.FullName()
+		if data.Inspector.Email != "" {
+			inspector += " (" + data.Inspector.Email + ")"
+		}
+	}
+
+	attendees := make([]string, len(data.Inspection.Attendees))
+	for i, attendee := range data.Inspection.Attendees {
+		attendees[i] = attendee.String()
+	}
+
+	renderDetails(l, [][2]string{
+		{"Project", data.Project.Name},
+		{"Address", data.Project.FullAddress()},
+		{"Project type", data.Project.ProjectType},
+		{"Inspection", data.Inspection.Title},
+		{"Inspection type", humanize(data.Inspection.InspectionType)},
+		{"Inspector", inspector},
+		{"Inspection date", data.Inspection.Date().Format("January 2, 2006")},
+		{"Site conditions", data.Inspection.Conditions()},
+		{"Attendees", strings.Join(attendees, "; ")},
+		{"Status", humanize(string(data.Inspection.Status))},
+		{"Notes", data.Inspection.Notes},
+		{"Report generated", data.GeneratedAt.Format("January 2, 2006 15:04 MST")},
+	})
+}
+
+// renderBanner draws the organization's banner across the top of the page.
+func renderBanner(l *layout, org *aletheia.Organization, logo []byte, heading string) {
+	const bannerHeight = 96.0
+	banner := accent(org)
+	text := contrast(banner)
+	l.page.Rect(0, 0, pdf.LetterWidth, bannerHeight, banner)
+	l.page.Text(margin, 44, pdf.HelveticaBold, 22, text, heading)
+	l.page.Text(margin, 64, pdf.Helvetica, 11, text, org.Name)
+	if address := org.Branding.Address; address != "" {
+		// Multi-line addresses are printed on one line
+		var parts []string
+		for _, line := range strings.Split(address, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				parts = append(parts, line)
+			}
+		}
+		l.page.Text(margin, 80, pdf.Helvetica, 9, text, strings.Join(parts, ", "))
+	}
+
+	// Fit the logo in the right of the banner
+	if logo := addThumbnail(l.doc, logo); logo != nil {
+		const boxWidth, boxHeight = 160.0, 64.0
+		scale := min(boxWidth/float64(logo.Width), boxHeight/float64(logo.Height))
+		w, h := float64(logo.Width)*scale, float64(logo.Height)*scale
+		l.page.Image(logo, margin+contentWidth-w, (bannerHeight-h)/2, w, h)
+	}
+	l.y = bannerHeight + 24
+}
+
+// renderDetails draws labelled rows, leaving out those with no value.
+func renderDetails(l *layout, rows [][2]string) {
+	for _, row := range rows {
+		if row[1] == "" {
+			continue
+		}
+		top := l.y
+		l.page.Text(margin, l.y+10, pdf.HelveticaBold, 10, colorMuted, row[0])
+		l.paragraph(margin+120, contentWidth-120, pdf.Helvetica, 10, colorText, row[1])
+		l.y = max(l.y, top+14) + 2
+	}
+}
+
+func renderSummary(l *layout, data *reportData) {
+	l.heading("Summary")
+
+	counts := map[aletheia.Severity]int{}
+	for _, v := range data.Violations {
+		counts[v.Severity]++
+	}
+
+	boxWidth := (contentWidth - 3*photoGap) / 4
+	l.need(64)
+	for i, severity := range severities {
+		x := margin + float64(i)*(boxWidth+photoGap)
+		l.page.Rect(x, l.y, boxWidth, 56, colorPanel)
+		l.page.Rect(x, l.y, 4, 56, severityColor(severity))
+		l.page.Text(x+14, l.y+28, pdf.HelveticaBold, 20, colorText, fmt.Sprint(counts[severity]))
+		l.page.Text(x+14, l.y+46, pdf.Helvetica, 9, colorMuted, humanize(string(severity)))
+	}
+	l.y += 56 + 12
+
+	l.paragraph(margin, contentWidth, pdf.Helvetica, 10, colorText, fmt.Sprintf(
+		"%s confirmed across %s.", plural(len(data.Violations), "violation"), plural(len(data.Photos), "photo")))
+}
+
+// renderViolations lists violations by severity, or the empty message if
+// there are none. Photos are referred to only in reports that show them.
+func renderViolations(l *layout, data *reportData, violations []*aletheia.Violation, heading, empty string) {
+	l.heading(heading)
+	if len(violations) == 0 {
+		l.paragraph(margin, contentWidth, pdf.Helvetica, 10, colorMuted, empty)
+		return
+	}
+
+	photoNumbers := map[uuid.UUID]int{}
+	if data.Template == aletheia.ReportTemplateFull {
+		for i, photo := range data.Photos {
+			photoNumbers[photo.ID] = i + 1
+		}
+	}
+
+	const indent = 14.0
+	n := 0
+	for _, severity := range severities {
+		var group []*aletheia.Violation
+		for _, v := range violations {
+			if v.Severity == severity {
+				group = append(group, v)
+			}
+		}
+		if len(group) == 0 {
+			continue
+		}
+
+		l.need(48)
+		l.y += 6
+		l.page.Rect(margin, l.y, 4, 14, severityColor(severity))
+		l.page.Text(margin+indent, l.y+11, pdf.HelveticaBold, 12, severityColor(severity),
+			fmt.Sprintf("%s (%d)", humanize(string(severity)), len(group)))
+		l.y += 22
+
+		for _, v := range group {
+			n++
+			l.need(40)
+			l.page.Text(margin+indent, l.y+10, pdf.HelveticaBold, 10, colorText, fmt.Sprintf("%d.", n))
+			l.paragraph(margin+2*indent+6, contentWidth-2*indent-6, pdf.HelveticaBold, 10, colorText, v.Description)
+
+			var details []string
+			if v.SafetyCode != nil {
+				details = append(details, "Code: "+v.SafetyCode.FullCode()+" – "+v.SafetyCode.Description)
+			}
+			if v.Location != "" {
+				details = append(details, "Location: "+v.Location)
+			}
+			if number, ok := photoNumbers[v.PhotoID]; ok {
+				details = append(details, fmt.Sprintf("Photo %d", number))
+			}
+			for _, detail := range details {
+				l.paragraph(margin+2*indent+6, contentWidth-2*indent-6, pdf.Helvetica, 9, colorMuted, detail)
+			}
+			l.y += 8
+		}
+	}
+}
+
+func renderPhotos(l *layout, data *reportData, thumbnails map[uuid.UUID][]byte) {
+	if len(data.Photos) == 0 {
+		return
+	}
+	l.heading("Photos")
+
+	counts := map[uuid.UUID]int{}
+	for _, v := range data.Violations {
+		counts[v.PhotoID]++
+	}
+
+	cellWidth := (contentWidth - (photoColumns-1)*photoGap) / photoColumns
+	cellHeight := cellWidth * 3 / 4
+	rowHeight := cellHeight + 30
+
+	for i, photo := range data.Photos {
+		col := i % photoColumns
+		if col == 0 {
+			if i > 0 {
+				l.y += rowHeight
+			}
+			l.need(rowHeight)
+		}
+		x := margin + float64(col)*(cellWidth+photoGap)
+
+		l.page.Rect(x, l.y, cellWidth, cellHeight, colorPanel)
+		img := addThumbnail(l.doc, thumbnails[photo.ID])
+		if img != nil {
+			// Fit the image in the cell, centered
+			scale := min(cellWidth/float64(img.Width), cellHeight/float64(img.Height))
+			w, h := float64(img.Width)*scale, float64(img.Height)*scale
+			l.page.Image(img, x+(cellWidth-w)/2, l.y+(cellHeight-h)/2, w, h)
+		} else {
+			label := "Image unavailable"
+			l.page.Text(x+(cellWidth-pdf.TextWidth(pdf.Helvetica, 9, label))/2, l.y+cellHeight/2+3, pdf.Helvetica, 9, colorMuted, label)
+		}
+
+		l.page.Text(x, l.y+cellHeight+14, pdf.HelveticaBold, 9, colorText, fmt.Sprintf("Photo %d", i+1))
+		if counts[photo.ID] > 0 {
+			caption := plural(counts[photo.ID], "confirmed violation")
+			l.page.Text(x+cellWidth-pdf.TextWidth(pdf.Helvetica, 9, caption), l.y+cellHeight+14, pdf.Helvetica, 9, colorMuted, caption)
+		}
+	}
+	l.y += rowHeight
+}
+
+// addThumbnail adds thumbnail data to doc, returning nil if there is none.
+func addThumbnail(doc *pdf.Document, data []byte) *pdf.Image {
+	if data == nil {
+		return nil
+	}
+	img, err := doc.AddJPEG(data)
+	if err != nil {
+		return nil
+	}
+	return img
+}
+
+// renderDisclaimer draws the organization's report footer, if it has one,
+// after the content.
+func renderDisclaimer(l *layout, org *aletheia.Organization) {
+	footer := strings.TrimSpace(org.Branding.ReportFooter)
+	if footer == "" {
+		return
+	}
+	l.need(40)
+	l.y += 16
+	l.page.Line(margin, l.y, margin+contentWidth, l.y, 0.5, colorRule)
+	l.y += 8
+	for _, paragraph := range strings.Split(footer, "\n") {
+		l.paragraph(margin, contentWidth, pdf.Helvetica, 8, colorMuted, paragraph)
+	}
+}
+
+// renderFooters draws a footer on every page, labelled on the left.
+func renderFooters(doc *pdf.Document, left string) {
+	pages := doc.Pages()
+	for i, page := range pages {
+		page.Line(margin, footerTop-10, margin+contentWidth, footerTop-10, 0.5, colorRule)
+		page.Text(margin, footerTop+2, pdf.Helvetica, 8, colorMuted, left)
+		right := fmt.Sprintf("Page %d of %d", i+1, len(pages))
+		page.Text(margin+contentWidth-pdf.TextWidth(pdf.Helvetica, 8, right), footerTop+2, pdf.Helvetica, 8, colorMuted, right)
+	}
+}
+
+// humanize turns an identifier such as "in_progress" into "In progress".
+func humanize(s string) string {
+	s = strings.ReplaceAll(s, "_", " ")
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// plural formats a count of things, such as "1 photo" or "3 photos".
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,312 @@
+// Package report generates PDF inspection reports in the background.
+package report
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/queue"
+	"github.com/dukerupert/aletheia/internal/signing"
+	"github.com/google/uuid"
+)
+
+// Queue is the queue report generation jobs run on.
+const Queue = aletheia.QueueDefault
+
+// NewJob returns a job that generates a new version of an inspection's
+// report on behalf of a user, with a template or, if it is empty, the
+// organization's default. Requests for an inspection whose report is already
+// being generated with the same template share that job.
+func NewJob(inspection *aletheia.Inspection, organizationID, requestedBy uuid.UUID, template aletheia.ReportTemplate) (*aletheia.Job, error) {
+	payload, err := json.Marshal(aletheia.ReportGenerationPayload{
+		InspectionID: inspection.ID,
+		RequestedBy:  requestedBy,
+		Template:     template,
+	})
+	if err != nil {
+		return nil, err
+	}
+	uniqueKey := aletheia.JobTypeReportGeneration + ":" + inspection.ID.String()
+	if template != "" {
+		uniqueKey += ":" + string(template)
+	}
+	return &aletheia.Job{
+		QueueName:      Queue,
+		JobType:        aletheia.JobTypeReportGeneration,
+		OrganizationID: organizationID,
+		InspectionID:   inspection.ID,
+		Payload:        payload,
+		UniqueKey:      uniqueKey,
+	}, nil
+}
+
+// Generator renders inspection reports, stores them, and records them.
+type Generator struct {
+	Inspections   aletheia.InspectionService
+	Projects      aletheia.ProjectService
+	Organizations aletheia.OrganizationService
+	Users         aletheia.UserService
+	Photos        aletheia.PhotoService
+	Violations    aletheia.ViolationService
+	SafetyCodes   aletheia.SafetyCodeService
+	Reports       aletheia.ReportService
+	Storage       aletheia.FileStorage
+	Queue         aletheia.Queue
+	Signer        *signing.Signer // nil if reports are not signed
+	Logger        *slog.Logger
+
+	// StorageQuota caps the bytes stored per organization. Summary reports
+	// are not stored past it. 0 means unlimited.
+	StorageQuota int64
+}
+
+// Register registers the report generation handlers.
+func (g *Generator) Register(pool *queue.WorkerPool) {
+	pool.RegisterHandler(aletheia.JobTypeReportGeneration, aletheia.JobHandlerFunc(g.handle))
+	pool.RegisterHandler(aletheia.JobTypeProjectSummary, aletheia.JobHandlerFunc(g.handleSummary))
+	pool.RegisterHandler(JobTypeQueueSummaries, aletheia.JobHandlerFunc(g.queueSummaries))
+}
+
+func (g *Generator) handle(ctx context.Context, job *aletheia.Job) error {
+	var payload aletheia.ReportGenerationPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return aletheia.Permanent(fmt.Errorf("decoding payload: %w", err))
+	}
+
+	report, err := g.Generate(ctx, payload.InspectionID, payload.RequestedBy, payload.Template)
+	if err != nil {
+		// The inspection was deleted after the job was queued
+		if aletheia.IsErrorCode(err, aletheia.ENOTFOUND) {
+			return aletheia.Permanent(err)
+		}
+		return err
+	}
+
+	g.Logger.Info("report generated",
+		slog.String("report_id", report.ID.String()),
+		slog.String("inspection_id", report.InspectionID.String()),
+		slog.Int("version", report.Version),
+		slog.String("template", string(report.Template)),
+		slog.Int64("size_bytes", report.SizeBytes))
+	return nil
+}
+
+// Generate renders the report of an inspection to PDF, uploads it, and
+// records it as a new version generated by a user, or by the system if
+// generatedBy is nil. The report uses the given template or, if it is empty,
+// the organization's default, and the organization's branding, and shows the
+// sign-offs that are still current. The PDF's SHA-256 is recorded and, if
+// the generator has a signer, signed. Progress is reported to the job
+// running it, if any.
+func (g *Generator) Generate(ctx context.Context, inspectionID, generatedBy uuid.UUID, template aletheia.ReportTemplate) (*aletheia.Report, error) {
+	aletheia.ReportProgress(ctx, 5, "Loading inspection")
+	data, err := g.load(ctx, inspectionID, template)
+	if err != nil {
+		return nil, err
+	}
+
+	var thumbnails map[uuid.UUID][]byte
+	if data.Template == aletheia.ReportTemplateFull {
+		aletheia.ReportProgress(ctx, 20, "Preparing photos")
+		thumbnails = g.loadThumbnails(ctx, data.Photos)
+	}
+
+	aletheia.ReportProgress(ctx, 70, "Rendering PDF")
+	var buf bytes.Buffer
+	if _, err := render(data, thumbnails).WriteTo(&buf); err != nil {
+		return nil, fmt.Errorf("rendering report: %w", err)
+	}
+	size := int64(buf.Len())
+	sum := sha256.Sum256(buf.Bytes())
+	hash := hex.EncodeToString(sum[:])
+
+	aletheia.ReportProgress(ctx, 85, "Uploading report")
+	// Every version gets its own file so earlier versions stay unchanged
+	key := fmt.Sprintf("reports/%s/%s-%s.pdf", inspectionID, data.GeneratedAt.Format("20060102-150405"), uuid.NewString()[:8])
+	url, err := g.Storage.Upload(ctx, key, &buf, "application/pdf")
+	if err != nil {
+		return nil, fmt.Errorf("uploading report: %w", err)
+	}
+
+	snapshot := aletheia.NewReportSnapshot(data.Photos, data.Violations, data.Signoffs)
+	report := &aletheia.Report{
+		InspectionID: inspectionID,
+		StorageURL:   url,
+		SizeBytes:    size,
+		SnapshotHash: snapshot.Hash(),
+		Snapshot:     snapshot,
+		GeneratedBy:  generatedBy,
+		Template:     data.Template,
+		SHA256:       hash,
+		Signoffs:     make([]aletheia.Signoff, len(data.Signoffs)),
+	}
+	for i, signoff := range data.Signoffs {
+		report.Signoffs[i] = *signoff
+	}
+	if g.Signer != nil {
+		report.Signature = g.Signer.Sign(inspectionID, hash)
+		report.SigningKeyID = g.Signer.KeyID()
+	}
+	if err := g.Reports.CreateReport(ctx, report); err != nil {
+		_ = g.Storage.Delete(ctx, key)
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// reportData is everything a report shows.
+type reportData struct {
+	Inspection   *aletheia.Inspection
+	Project      *aletheia.Project
+	Organization *aletheia.Organization
+	Inspector    *aletheia.User // nil if the inspector's account is gone
+	Photos       []*aletheia.Photo
+	Violations   []*aletheia.Violation // Confirmed only, with SafetyCode set
+	Signoffs     []*aletheia.Signoff   // Current only
+	Signatures   map[uuid.UUID][]byte  // JPEG images of drawn signatures by sign-off ID
+	Template     aletheia.ReportTemplate
+	Logo         []byte // JPEG; nil if the organization has none
+	GeneratedAt  time.Time
+}
+
+// load gathers the data for an inspection's report.
+func (g *Generator) load(ctx context.Context, inspectionID uuid.UUID, template aletheia.ReportTemplate) (*reportData, error) {
+	inspection, err := g.Inspections.FindInspectionByID(ctx, inspectionID)
+	if err != nil {
+		return nil, err
+	}
+	project, err := g.Projects.FindProjectByID(ctx, inspection.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	organization, err := g.Organizations.FindOrganizationByID(ctx, project.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &reportData{
+		Inspection:   inspection,
+		Project:      project,
+		Organization: organization,
+		Template:     chooseTemplate(template, organization),
+		GeneratedAt:  time.Now(),
+	}
+
+	if organization.Branding.LogoURL != "" {
+		if data.Logo, err = g.logo(ctx, organization); err != nil {
+			g.Logger.Warn("leaving logo out of report",
+				slog.String("organization_id", organization.ID.String()),
+				slog.String("error", err.Error()))
+		}
+	}
+
+	data.Inspector, err = g.Users.FindUserByID(ctx, inspection.InspectorID)
+	if err != nil && !aletheia.IsErrorCode(err, aletheia.ENOTFOUND) {
+		return nil, err
+	}
+
+	if data.Photos, data.Violations, err = findContent(ctx, g.Photos, g.Violations, inspectionID); err != nil {
+		return nil, err
+	}
+
+	signoffs, err := g.Inspections.FindSignoffs(ctx, inspectionID)
+	if err != nil {
+		return nil, err
+	}
+	data.Signoffs = currentSignoffs(signoffs, aletheia.NewReportSnapshot(data.Photos, data.Violations, nil))
+	data.Signatures = g.loadSignatures(ctx, data.Signoffs)
+
+	// Cite each violation's safety code
+	codes := map[uuid.UUID]*aletheia.SafetyCode{}
+	for _, v := range data.Violations {
+		if v.SafetyCodeID == uuid.Nil || v.SafetyCode != nil {
+			continue
+		}
+		code, ok := codes[v.SafetyCodeID]
+		if !ok {
+			code, err = g.SafetyCodes.FindSafetyCodeByID(ctx, v.SafetyCodeID)
+			if err != nil && !aletheia.IsErrorCode(err, aletheia.ENOTFOUND) {
+				return nil, err
+			}
+			codes[v.SafetyCodeID] = code
+		}
+		v.SafetyCode = code
+	}
+
+	SortViolations(data.Violations)
+
+	return data, nil
+}
+
+// SortViolations orders violations as reports list them: most severe first,
+// oldest first within a severity.
+func SortViolations(violations []*aletheia.Violation) {
+	slices.SortStableFunc(violations, func(a, b *aletheia.Violation) int {
+		if c := b.Severity.Weight() - a.Severity.Weight(); c != 0 {
+			return c
+		}
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+}
+
+// findContent returns the photos and confirmed violations of an inspection,
+// which are what its report shows.
+func findContent(ctx context.Context, photoService aletheia.PhotoService, violationService aletheia.ViolationService, inspectionID uuid.UUID) ([]*aletheia.Photo, []*aletheia.Violation, error) {
+	photos, _, err := photoService.FindPhotos(ctx, aletheia.PhotoFilter{InspectionID: &inspectionID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	confirmed := aletheia.ViolationStatusConfirmed
+	violations, _, err := violationService.FindViolations(ctx, aletheia.ViolationFilter{
+		InspectionID: &inspectionID,
+		Status:       &confirmed,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return photos, violations, nil
+}
+
+// Content returns a snapshot of the photos and confirmed violations of an
+// inspection, without its sign-offs. Its ContentHash is what sign-offs
+// approve.
+func Content(ctx context.Context, photos aletheia.PhotoService, violations aletheia.ViolationService, inspectionID uuid.UUID) (aletheia.ReportSnapshot, error) {
+	currentPhotos, currentViolations, err := findContent(ctx, photos, violations, inspectionID)
+	if err != nil {
+		return aletheia.ReportSnapshot{}, err
+	}
+	return aletheia.NewReportSnapshot(currentPhotos, currentViolations, nil), nil
+}
+
+// Status reports whether the latest report of an inspection still matches
+// its photos, confirmed violations, and sign-offs.
+func Status(ctx context.Context, reports aletheia.ReportService, inspections aletheia.InspectionService, photos aletheia.PhotoService, violations aletheia.ViolationService, inspectionID uuid.UUID) (*aletheia.ReportStatus, error) {
+	versions, err := reports.FindReports(ctx, inspectionID)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return aletheia.NewReportStatus(nil, aletheia.ReportSnapshot{}), nil
+	}
+
+	currentPhotos, currentViolations, err := findContent(ctx, photos, violations, inspectionID)
+	if err != nil {
+		return nil, err
+	}
+	signoffs, err := inspections.FindSignoffs(ctx, inspectionID)
+	if err != nil {
+		return nil, err
+	}
+	return aletheia.NewReportStatus(versions[0], aletheia.NewReportSnapshot(currentPhotos, currentViolations, signoffs)), nil
+}
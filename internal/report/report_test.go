@@ -0,0 +1,353 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/signing"
+	"github.com/dukerupert/aletheia/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const storageURL = "https://mock-storage.example.com/"
+
+func testPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		for y := 0; y < h; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// testGenerator returns a generator for an inspection with two photos, one
+// of which is missing from storage, and three confirmed violations.
+func testGenerator(t *testing.T) (*Generator, *aletheia.Inspection, map[string][]byte, *[]*aletheia.Report) {
+	t.Helper()
+
+	org := &aletheia.Organization{ID: uuid.New(), Name: "Acme Construction"}
+	project := &aletheia.Project{ID: uuid.New(), OrganizationID: org.ID, Name: "Riverside Tower", Address: "1 River Rd", City: "Portland"}
+	inspector := &aletheia.User{ID: uuid.New(), Email: "ines@example.com", FirstName: "Ines", LastName: "Park"}
+	inspection := &aletheia.Inspection{ID: uuid.New(), ProjectID: project.ID, InspectorID: inspector.ID, Status: aletheia.InspectionStatusCompleted, CreatedAt: time.Now()}
+
+	photos := []*aletheia.Photo{
+		{ID: uuid.New(), InspectionID: inspection.ID, StorageURL: storageURL + "photos/a.png", ThumbnailURL: storageURL + "photos/a_thumb.png"},
+		{ID: uuid.New(), InspectionID: inspection.ID, StorageURL: storageURL + "photos/missing.jpg"},
+	}
+	code := &aletheia.SafetyCode{ID: uuid.New(), Code: "1926.501", Description: "Fall protection"}
+	violations := []*aletheia.Violation{
+		{ID: uuid.New(), PhotoID: photos[0].ID, Description: "Loose cable", Severity: aletheia.SeverityLow, Status: aletheia.ViolationStatusConfirmed},
+		{ID: uuid.New(), PhotoID: photos[0].ID, SafetyCodeID: code.ID, Description: "Worker without harness near unprotected edge", Severity: aletheia.SeverityCritical, Status: aletheia.ViolationStatusConfirmed, Location: "Level 4"},
+		{ID: uuid.New(), PhotoID: photos[1].ID, Description: "Blocked exit (stairwell B)", Severity: aletheia.SeverityMedium, Status: aletheia.ViolationStatusConfirmed},
+	}
+
+	files := map[string][]byte{"photos/a_thumb.png": testPNG(t, 800, 600)}
+	var reports []*aletheia.Report
+
+	g := &Generator{
+		Inspections: &mock.InspectionService{
+			FindInspectionByIDFn: func(ctx context.Context, id uuid.UUID) (*aletheia.Inspection, error) {
+				if id != inspection.ID {
+					return nil, aletheia.NotFound("Inspection not found")
+				}
+				return inspection, nil
+			},
+		},
+		Projects: &mock.ProjectService{
+			FindProjectByIDFn: func(ctx context.Context, id uuid.UUID) (*aletheia.Project, error) { return project, nil },
+		},
+		Organizations: &mock.OrganizationService{
+			FindOrganizationByIDFn: func(ctx context.Context, id uuid.UUID) (*aletheia.Organization, error) { return org, nil },
+		},
+		Users: &mock.UserService{
+			FindUserByIDFn: func(ctx context.Context, id uuid.UUID) (*aletheia.User, error) { return inspector, nil },
+		},
+		Photos: &mock.PhotoService{
+			FindPhotosFn: func(ctx context.Context, filter aletheia.PhotoFilter) ([]*aletheia.Photo, int, error) {
+				return photos, len(photos), nil
+			},
+		},
+		Violations: &mock.ViolationService{
+			FindViolationsFn: func(ctx context.Context, filter aletheia.ViolationFilter) ([]*aletheia.Violation, int, error) {
+				require.NotNil(t, filter.Status)
+				assert.Equal(t, aletheia.ViolationStatusConfirmed, *filter.Status)
+				return violations, len(violations), nil
+			},
+		},
+		SafetyCodes: &mock.SafetyCodeService{
+			FindSafetyCodeByIDFn: func(ctx context.Context, id uuid.UUID) (*aletheia.SafetyCode, error) { return code, nil },
+		},
+		Reports: &mock.ReportService{
+			CreateReportFn: func(ctx context.Context, report *aletheia.Report) error {
+				report.ID = uuid.New()
+				reports = append(reports, report)
+				return nil
+			},
+		},
+		Storage: &mock.FileStorage{
+			OpenFn: func(ctx context.Context, key string) (io.ReadCloser, error) {
+				data, ok := files[key]
+				if !ok {
+					return nil, aletheia.NotFound("File not found")
+				}
+				return io.NopCloser(bytes.NewReader(data)), nil
+			},
+			UploadFn: func(ctx context.Context, key string, reader io.Reader, contentType string) (string, error) {
+				data, err := io.ReadAll(reader)
+				if err != nil {
+					return "", err
+				}
+				files[key] = data
+				return storageURL + key, nil
+			},
+		},
+		Logger: slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError})),
+	}
+
+	return g, inspection, files, &reports
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	g, inspection, files, reports := testGenerator(t)
+
+	var progress []int
+	ctx := aletheia.WithProgress(context.Background(), func(ctx context.Context, percent int, message string) error {
+		progress = append(progress, percent)
+		return nil
+	})
+
+	userID := uuid.New()
+	report, err := g.Generate(ctx, inspection.ID, userID, "")
+	require.NoError(t, err)
+	require.Len(t, *reports, 1)
+	assert.Equal(t, inspection.ID, report.InspectionID)
+	assert.NotEqual(t, uuid.Nil, report.ID)
+	assert.Equal(t, userID, report.GeneratedBy)
+	assert.Len(t, report.Snapshot.Photos, 2)
+	assert.Len(t, report.Snapshot.Violations, 3)
+	assert.Equal(t, report.Snapshot.Hash(), report.SnapshotHash)
+	assert.Equal(t, aletheia.ReportTemplateFull, report.Template)
+	assert.Empty(t, report.Signoffs)
+	assert.Empty(t, report.Signature, "no signer")
+
+	key := strings.TrimPrefix(report.StorageURL, storageURL)
+	assert.True(t, strings.HasPrefix(key, "reports/"+inspection.ID.String()+"/"), key)
+	assert.True(t, strings.HasSuffix(key, ".pdf"), key)
+
+	data := files[key]
+	assert.Equal(t, int64(len(data)), report.SizeBytes)
+	sum := sha256.Sum256(data)
+	assert.Equal(t, hex.EncodeToString(sum[:]), report.SHA256)
+	assert.True(t, bytes.HasPrefix(data, []byte("%PDF-")))
+	assert.Contains(t, string(data), "/Title (Inspection Report: Riverside Tower)")
+	assert.Contains(t, string(data), "/Author (Ines Park)")
+
+	// Another version is stored separately.
+	again, err := g.Generate(context.Background(), inspection.ID, userID, "")
+	require.NoError(t, err)
+	assert.NotEqual(t, report.StorageURL, again.StorageURL)
+
+	// Only the photo in storage is embedded, shrunk to a thumbnail.
+	assert.Equal(t, 1, strings.Count(string(data), "/Subtype /Image"))
+	assert.Contains(t, string(data), "/Width 480 /Height 360")
+
+	assert.IsNonDecreasing(t, progress)
+}
+
+func TestGenerator_Branding(t *testing.T) {
+	g, inspection, files, _ := testGenerator(t)
+
+	org, err := g.Organizations.FindOrganizationByID(context.Background(), uuid.Nil)
+	require.NoError(t, err)
+	org.Branding = aletheia.OrganizationBranding{
+		LogoURL:        storageURL + "organizations/logo.png",
+		PrimaryColor:   "#0f766e",
+		Address:        "12 Harbor St\nPortland, OR",
+		ReportFooter:   "For the client's use only.",
+		ReportTemplate: aletheia.ReportTemplateViolationsOnly,
+	}
+	files["organizations/logo.png"] = testPNG(t, 800, 200)
+
+	// Without a template, the organization's default is used
+	report, err := g.Generate(context.Background(), inspection.ID, uuid.Nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, aletheia.ReportTemplateViolationsOnly, report.Template)
+
+	data := string(files[strings.TrimPrefix(report.StorageURL, storageURL)])
+	assert.Contains(t, data, "/Title (Violations Report: Riverside Tower)")
+	// Only the logo is embedded, shrunk to fit
+	assert.Equal(t, 1, strings.Count(data, "/Subtype /Image"))
+	assert.Contains(t, data, "/Width 360 /Height 90")
+
+	report, err = g.Generate(context.Background(), inspection.ID, uuid.Nil, aletheia.ReportTemplateExecutiveSummary)
+	require.NoError(t, err)
+	assert.Equal(t, aletheia.ReportTemplateExecutiveSummary, report.Template)
+	assert.Contains(t, string(files[strings.TrimPrefix(report.StorageURL, storageURL)]), "/Title (Executive Summary: Riverside Tower)")
+
+	// A logo that cannot be read is left out
+	delete(files, "organizations/logo.png")
+	report, err = g.Generate(context.Background(), inspection.ID, uuid.Nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, 0, strings.Count(string(files[strings.TrimPrefix(report.StorageURL, storageURL)]), "/Subtype /Image"))
+}
+
+func TestGenerator_Signoffs(t *testing.T) {
+	g, inspection, files, _ := testGenerator(t)
+	ctx := context.Background()
+
+	signer, err := signing.NewSigner(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32)))
+	require.NoError(t, err)
+	g.Signer = signer
+
+	photos, _, err := g.Photos.FindPhotos(ctx, aletheia.PhotoFilter{})
+	require.NoError(t, err)
+	confirmed := aletheia.ViolationStatusConfirmed
+	violations, _, err := g.Violations.FindViolations(ctx, aletheia.ViolationFilter{Status: &confirmed})
+	require.NoError(t, err)
+	content := aletheia.NewReportSnapshot(photos, violations, nil).ContentHash()
+
+	files["signatures/ines.png"] = testPNG(t, 600, 150)
+	signoffs := []*aletheia.Signoff{
+		{ID: uuid.New(), Role: aletheia.SignoffReviewer, SignerName: "Rae Reviewer", SignatureKind: aletheia.SignatureTyped, Signature: "Rae Reviewer", ContentHash: content, SignedAt: time.Now()},
+		{ID: uuid.New(), Role: aletheia.SignoffInspector, SignerName: "Ines Park", SignatureKind: aletheia.SignatureDrawn, Signature: storageURL + "signatures/ines.png", ContentHash: content, SignedAt: time.Now()},
+		{ID: uuid.New(), Role: aletheia.SignoffReviewer, SignerName: "Old Reviewer", SignatureKind: aletheia.SignatureTyped, Signature: "Old Reviewer", ContentHash: "outdated", SignedAt: time.Now()},
+	}
+	g.Inspections.(*mock.InspectionService).FindSignoffsFn = func(ctx context.Context, inspectionID uuid.UUID) ([]*aletheia.Signoff, error) {
+		return signoffs, nil
+	}
+
+	report, err := g.Generate(ctx, inspection.ID, uuid.Nil, "")
+	require.NoError(t, err)
+
+	// Only current sign-offs are shown, inspector first
+	require.Len(t, report.Signoffs, 2)
+	assert.Equal(t, aletheia.SignoffInspector, report.Signoffs[0].Role)
+	assert.Equal(t, "Rae Reviewer", report.Signoffs[1].SignerName)
+	assert.Len(t, report.Snapshot.Signoffs, 2)
+
+	data := string(files[strings.TrimPrefix(report.StorageURL, storageURL)])
+	assert.Contains(t, data, "/BaseFont /Helvetica-Oblique")
+	// The photo thumbnail and the drawn signature are embedded
+	assert.Equal(t, 2, strings.Count(data, "/Subtype /Image"))
+
+	assert.Equal(t, signer.KeyID(), report.SigningKeyID)
+	assert.True(t, signer.Verify(inspection.ID, report.SHA256, report.Signature))
+}
+
+func TestGenerator_HandleMissingInspection(t *testing.T) {
+	g, _, _, reports := testGenerator(t)
+
+	payload, err := json.Marshal(aletheia.ReportGenerationPayload{InspectionID: uuid.New()})
+	require.NoError(t, err)
+
+	err = g.handle(context.Background(), &aletheia.Job{Payload: payload})
+	require.Error(t, err)
+	assert.True(t, aletheia.IsPermanent(err))
+	assert.Empty(t, *reports)
+}
+
+func TestNewJob(t *testing.T) {
+	inspection := &aletheia.Inspection{ID: uuid.New()}
+	orgID, userID := uuid.New(), uuid.New()
+
+	job, err := NewJob(inspection, orgID, userID, aletheia.ReportTemplateExecutiveSummary)
+	require.NoError(t, err)
+	assert.Equal(t, aletheia.JobTypeReportGeneration, job.JobType)
+	assert.Equal(t, Queue, job.QueueName)
+	assert.Equal(t, orgID, job.OrganizationID)
+	assert.Equal(t, inspection.ID, job.InspectionID)
+	assert.NotEmpty(t, job.UniqueKey)
+
+	var payload aletheia.ReportGenerationPayload
+	require.NoError(t, json.Unmarshal(job.Payload, &payload))
+	assert.Equal(t, inspection.ID, payload.InspectionID)
+	assert.Equal(t, userID, payload.RequestedBy)
+	assert.Equal(t, aletheia.ReportTemplateExecutiveSummary, payload.Template)
+
+	// Requests for another template are not merged into this job
+	other, err := NewJob(inspection, orgID, userID, aletheia.ReportTemplateFull)
+	require.NoError(t, err)
+	assert.NotEqual(t, job.UniqueKey, other.UniqueKey)
+}
+
+func TestStatus(t *testing.T) {
+	g, inspection, _, reports := testGenerator(t)
+	ctx := context.Background()
+	g.Reports.(*mock.ReportService).FindReportsFn = func(ctx context.Context, inspectionID uuid.UUID) ([]*aletheia.Report, error) {
+		versions := slices.Clone(*reports)
+		slices.Reverse(versions)
+		return versions, nil
+	}
+	status := func() *aletheia.ReportStatus {
+		t.Helper()
+		status, err := Status(ctx, g.Reports, g.Inspections, g.Photos, g.Violations, inspection.ID)
+		require.NoError(t, err)
+		return status
+	}
+
+	assert.Nil(t, status().Latest)
+
+	_, err := g.Generate(ctx, inspection.ID, uuid.Nil, "")
+	require.NoError(t, err)
+	current := status()
+	assert.False(t, current.Stale)
+	assert.Equal(t, "Report up to date", current.Summary)
+
+	// Edit one violation and dismiss another
+	confirmed := aletheia.ViolationStatusConfirmed
+	violations, _, err := g.Violations.FindViolations(ctx, aletheia.ViolationFilter{Status: &confirmed})
+	require.NoError(t, err)
+	violations[0].Description = "Loose cable across walkway"
+	g.Violations.(*mock.ViolationService).FindViolationsFn = func(ctx context.Context, filter aletheia.ViolationFilter) ([]*aletheia.Violation, int, error) {
+		return violations[:2], 2, nil
+	}
+
+	stale := status()
+	assert.True(t, stale.Stale)
+	assert.Equal(t, aletheia.ReportChanges{Violations: 2}, stale.Changes)
+	assert.Equal(t, "Report out of date (2 violations changed)", stale.Summary)
+
+	// Signing off on the inspection as it is now also changes the report
+	_, err = g.Generate(ctx, inspection.ID, uuid.Nil, "")
+	require.NoError(t, err)
+	assert.False(t, status().Stale)
+
+	photos, _, err := g.Photos.FindPhotos(ctx, aletheia.PhotoFilter{})
+	require.NoError(t, err)
+	signoff := &aletheia.Signoff{ID: uuid.New(), Role: aletheia.SignoffInspector, SignatureKind: aletheia.SignatureTyped,
+		ContentHash: aletheia.NewReportSnapshot(photos, violations[:2], nil).ContentHash()}
+	g.Inspections.(*mock.InspectionService).FindSignoffsFn = func(ctx context.Context, inspectionID uuid.UUID) ([]*aletheia.Signoff, error) {
+		return []*aletheia.Signoff{signoff}, nil
+	}
+	signed := status()
+	assert.True(t, signed.Stale)
+	assert.Equal(t, aletheia.ReportChanges{Signoffs: 1}, signed.Changes)
+	assert.Equal(t, "Report out of date (sign-off changed)", signed.Summary)
+}
+
+func TestShrink(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 1000, 10))
+	assert.Equal(t, image.Rect(0, 0, 480, 5), shrink(img, 480).Bounds())
+	assert.Same(t, img, shrink(img, 2000))
+}
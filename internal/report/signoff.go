@@ -0,0 +1,123 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/pdf"
+	"github.com/google/uuid"
+)
+
+// signatureMaxPx is the size drawn signatures are shrunk to before being
+// embedded.
+const signatureMaxPx = 480
+
+// currentSignoffs returns the sign-offs that approve an inspection's
+// content as it is now, inspector first.
+func currentSignoffs(signoffs []*aletheia.Signoff, content aletheia.ReportSnapshot) []*aletheia.Signoff {
+	var current []*aletheia.Signoff
+	for _, s := range signoffs {
+		if s.Current(content) {
+			current = append(current, s)
+		}
+	}
+	slices.SortStableFunc(current, func(a, b *aletheia.Signoff) int {
+		return roleOrder(a.Role) - roleOrder(b.Role)
+	})
+	return current
+}
+
+func roleOrder(r aletheia.SignoffRole) int {
+	if r == aletheia.SignoffInspector {
+		return 0
+	}
+	return 1
+}
+
+// loadSignatures returns the drawn signatures of sign-offs as JPEG.
+// Signatures that cannot be read are left out.
+func (g *Generator) loadSignatures(ctx context.Context, signoffs []*aletheia.Signoff) map[uuid.UUID][]byte {
+	signatures := map[uuid.UUID][]byte{}
+	for _, s := range signoffs {
+		if s.SignatureKind != aletheia.SignatureDrawn {
+			continue
+		}
+		data, err := g.flatImage(ctx, s.Signature, signatureMaxPx)
+		if err != nil {
+			g.Logger.Warn("leaving signature out of report",
+				slog.String("signoff_id", s.ID.String()),
+				slog.String("error", err.Error()))
+			continue
+		}
+		signatures[s.ID] = data
+	}
+	return signatures
+}
+
+// signoffFindings warns of sign-offs made before the inspection's content
+// last changed, which reports leave out.
+func signoffFindings(signoffs []*aletheia.Signoff, content aletheia.ReportSnapshot) []aletheia.PreflightFinding {
+	var findings []aletheia.PreflightFinding
+	for _, s := range signoffs {
+		if s.Current(content) {
+			continue
+		}
+		findings = append(findings, aletheia.PreflightFinding{
+			Code:     aletheia.PreflightSignoffOutdated,
+			Severity: aletheia.PreflightWarning,
+			Message:  fmt.Sprintf("%s sign-off predates later changes and will be left out", humanize(string(s.Role))),
+		})
+	}
+	return findings
+}
+
+// renderSignoffs draws the sign-offs side by side, if there are any.
+func renderSignoffs(l *layout, data *reportData) {
+	if len(data.Signoffs) == 0 {
+		return
+	}
+	l.heading("Sign-off")
+
+	const signatureHeight = 48.0
+	columnWidth := (contentWidth - photoGap) / 2
+	rowHeight := 16 + signatureHeight + 36
+
+	for i, signoff := range data.Signoffs {
+		col := i % 2
+		if col == 0 {
+			if i > 0 {
+				l.y += rowHeight
+			}
+			l.need(rowHeight)
+		}
+		x := margin + float64(col)*(columnWidth+photoGap)
+		line := l.y + 16 + signatureHeight
+
+		l.page.Text(x, l.y+10, pdf.HelveticaBold, 9, colorMuted, strings.ToUpper(string(signoff.Role)))
+		if img := addThumbnail(l.doc, data.Signatures[signoff.ID]); img != nil {
+			// Stand the signature on the line
+			scale := min(columnWidth/float64(img.Width), signatureHeight/float64(img.Height))
+			w, h := float64(img.Width)*scale, float64(img.Height)*scale
+			l.page.Image(img, x, line-h, w, h)
+		} else if signoff.SignatureKind == aletheia.SignatureTyped {
+			size := 20.0
+			if w := pdf.TextWidth(pdf.HelveticaOblique, size, signoff.Signature); w > columnWidth {
+				size *= columnWidth / w
+			}
+			l.page.Text(x, line-8, pdf.HelveticaOblique, size, colorText, signoff.Signature)
+		} else {
+			l.page.Text(x, line-8, pdf.Helvetica, 9, colorMuted, "Signature image unavailable")
+		}
+		l.page.Line(x, line, x+columnWidth, line, 0.75, colorText)
+		l.page.Text(x, line+14, pdf.Helvetica, 10, colorText, signoff.SignerName)
+		l.page.Text(x, line+28, pdf.Helvetica, 9, colorMuted, "Signed "+signoff.SignedAt.Format("January 2, 2006 15:04 MST"))
+	}
+	l.y += rowHeight
+
+	l.paragraph(margin, contentWidth, pdf.Helvetica, 9, colorMuted,
+		"Each signer approved the photos and confirmed violations in this report as they were when signed.")
+}
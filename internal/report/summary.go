@@ -0,0 +1,659 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/pdf"
+	"github.com/dukerupert/aletheia/internal/queue"
+	"github.com/google/uuid"
+)
+
+// JobTypeQueueSummaries is the scheduled job that queues a summary report
+// of every active project.
+const JobTypeQueueSummaries = "queue_project_summaries"
+
+// NewSummaryJob returns a job that generates a summary report of a project
+// from from until to, on behalf of a user or, if requestedBy is nil, on a
+// schedule. Requests for the same project and period share a job.
+func NewSummaryJob(project *aletheia.Project, from, to time.Time, requestedBy uuid.UUID) (*aletheia.Job, error) {
+	payload, err := json.Marshal(aletheia.ProjectSummaryPayload{
+		ProjectID:   project.ID,
+		From:        from,
+		To:          to,
+		RequestedBy: requestedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &aletheia.Job{
+		QueueName:      Queue,
+		JobType:        aletheia.JobTypeProjectSummary,
+		OrganizationID: project.OrganizationID,
+		Payload:        payload,
+		UniqueKey:      fmt.Sprintf("%s:%s:%d:%d", aletheia.JobTypeProjectSummary, project.ID, from.Unix(), to.Unix()),
+	}, nil
+}
+
+// summariesPayload is the payload of the scheduled job queueing summaries.
+type summariesPayload struct {
+	Days int `json:"days"`
+}
+
+// SummarySchedule returns the schedule that queues a summary report of every
+// active project, covering the days before each run up to midnight UTC.
+// Projects with nothing to report for the period are skipped.
+func SummarySchedule(spec string, days int) (queue.Schedule, error) {
+	if days < 1 || time.Duration(days)*24*time.Hour > aletheia.MaxSummaryPeriod {
+		return queue.Schedule{}, fmt.Errorf("summary period of %d days is out of range", days)
+	}
+	payload, err := json.Marshal(summariesPayload{Days: days})
+	if err != nil {
+		return queue.Schedule{}, err
+	}
+	return queue.Schedule{
+		Name: JobTypeQueueSummaries,
+		Spec: spec,
+		Job: aletheia.Job{
+			QueueName:   aletheia.QueueLow,
+			JobType:     JobTypeQueueSummaries,
+			Payload:     payload,
+			MaxAttempts: 1,
+			UniqueKey:   JobTypeQueueSummaries,
+		},
+	}, nil
+}
+
+func (g *Generator) queueSummaries(ctx context.Context, job *aletheia.Job) error {
+	var payload summariesPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return aletheia.Permanent(fmt.Errorf("decoding payload: %w", err))
+	}
+
+	to := aletheia.SummaryIntervalDay.Start(job.ScheduledAt)
+	from := to.AddDate(0, 0, -payload.Days)
+
+	active := "active"
+	projects, _, err := g.Projects.FindProjects(ctx, aletheia.ProjectFilter{Status: &active})
+	if err != nil {
+		return fmt.Errorf("listing projects: %w", err)
+	}
+	for _, project := range projects {
+		summaryJob, err := NewSummaryJob(project, from, to, uuid.Nil)
+		if err != nil {
+			return err
+		}
+		if err := g.Queue.Enqueue(ctx, summaryJob); err != nil {
+			return fmt.Errorf("queueing summary of project %s: %w", project.ID, err)
+		}
+	}
+
+	g.Logger.Info("project summaries queued",
+		slog.Int("projects", len(projects)),
+		slog.Time("from", from),
+		slog.Time("to", to))
+	return nil
+}
+
+func (g *Generator) handleSummary(ctx context.Context, job *aletheia.Job) error {
+	var payload aletheia.ProjectSummaryPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return aletheia.Permanent(fmt.Errorf("decoding payload: %w", err))
+	}
+
+	project, err := g.Projects.FindProjectByID(ctx, payload.ProjectID)
+	if err != nil {
+		// The project was deleted after the job was queued
+		if aletheia.IsErrorCode(err, aletheia.ENOTFOUND) {
+			return aletheia.Permanent(err)
+		}
+		return err
+	}
+
+	aletheia.ReportProgress(ctx, 10, "Summarizing project")
+	summary, err := Summarize(ctx, g.Inspections, g.Violations, project, payload.From, payload.To)
+	if err != nil {
+		return err
+	}
+	// Scheduled summaries of quiet projects would only be noise
+	if payload.RequestedBy == uuid.Nil && summary.IsEmpty() {
+		g.Logger.Info("skipping empty project summary", slog.String("project_id", project.ID.String()))
+		return nil
+	}
+
+	report, err := g.storeSummary(ctx, project, summary, payload.RequestedBy)
+	if err != nil {
+		// Retrying will not free up storage
+		if aletheia.IsErrorCode(err, aletheia.EFORBIDDEN) {
+			return aletheia.Permanent(err)
+		}
+		return err
+	}
+
+	g.Logger.Info("project summary generated",
+		slog.String("summary_report_id", report.ID.String()),
+		slog.String("project_id", project.ID.String()),
+		slog.Int64("size_bytes", report.SizeBytes))
+	return nil
+}
+
+// GenerateSummary summarizes a project from from until to, renders the
+// summary to PDF, uploads it, and records it as generated by a user, or by
+// the system if generatedBy is nil.
+func (g *Generator) GenerateSummary(ctx context.Context, projectID, generatedBy uuid.UUID, from, to time.Time) (*aletheia.SummaryReport, error) {
+	project, err := g.Projects.FindProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := Summarize(ctx, g.Inspections, g.Violations, project, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return g.storeSummary(ctx, project, summary, generatedBy)
+}
+
+func (g *Generator) storeSummary(ctx context.Context, project *aletheia.Project, summary *aletheia.ProjectSummary, generatedBy uuid.UUID) (*aletheia.SummaryReport, error) {
+	organization, err := g.Organizations.FindOrganizationByID(ctx, project.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	data := &summaryData{
+		Summary:      summary,
+		Project:      project,
+		Organization: organization,
+		GeneratedAt:  time.Now(),
+	}
+	if organization.Branding.LogoURL != "" {
+		if data.Logo, err = g.logo(ctx, organization); err != nil {
+			g.Logger.Warn("leaving logo out of summary",
+				slog.String("organization_id", organization.ID.String()),
+				slog.String("error", err.Error()))
+		}
+	}
+
+	aletheia.ReportProgress(ctx, 60, "Rendering PDF")
+	var buf bytes.Buffer
+	if _, err := renderSummaryReport(data).WriteTo(&buf); err != nil {
+		return nil, fmt.Errorf("rendering summary: %w", err)
+	}
+	size := int64(buf.Len())
+	if err := g.checkStorageQuota(ctx, organization.ID, size); err != nil {
+		return nil, err
+	}
+
+	aletheia.ReportProgress(ctx, 85, "Uploading report")
+	key := fmt.Sprintf("reports/projects/%s/%s-%s-%s.pdf", project.ID,
+		summary.From.Format("20060102"), summary.To.Format("20060102"), uuid.NewString()[:8])
+	url, err := g.Storage.Upload(ctx, key, &buf, "application/pdf")
+	if err != nil {
+		return nil, fmt.Errorf("uploading summary: %w", err)
+	}
+
+	report := &aletheia.SummaryReport{
+		ProjectID:   project.ID,
+		From:        summary.From,
+		To:          summary.To,
+		StorageURL:  url,
+		SizeBytes:   size,
+		GeneratedBy: generatedBy,
+		Summary:     summary,
+	}
+	if err := g.Reports.CreateSummaryReport(ctx, report); err != nil {
+		_ = g.Storage.Delete(ctx, key)
+		return nil, err
+	}
+	return report, nil
+}
+
+// checkStorageQuota returns EFORBIDDEN if storing size more bytes for the
+// organization would exceed the storage quota.
+func (g *Generator) checkStorageQuota(ctx context.Context, orgID uuid.UUID, size int64) error {
+	if g.StorageQuota <= 0 {
+		return nil
+	}
+
+	usage, err := g.Organizations.GetStorageUsage(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	usage.QuotaBytes = g.StorageQuota
+
+	if usage.ExceedsQuota(size) {
+		return aletheia.Forbidden("Organization storage quota exceeded (%d of %d bytes used)", usage.TotalBytes, usage.QuotaBytes)
+	}
+	return nil
+}
+
+// SummaryFilename returns the name a summary report is downloaded as.
+func SummaryFilename(report *aletheia.SummaryReport) string {
+	// The period ends at midnight, so the last day is the one before
+	return fmt.Sprintf("project-summary-%s-to-%s.pdf",
+		report.From.UTC().Format("2006-01-02"), report.To.UTC().Add(-time.Nanosecond).Format("2006-01-02"))
+}
+
+// Summarize aggregates the inspections of a project performed, and its
+// violations detected and resolved, from from until to.
+func Summarize(ctx context.Context, inspectionService aletheia.InspectionService, violationService aletheia.ViolationService, project *aletheia.Project, from, to time.Time) (*aletheia.ProjectSummary, error) {
+	inspections, _, err := inspectionService.FindInspections(ctx, aletheia.InspectionFilter{ProjectID: &project.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	// Violations resolved before the period began have no bearing on it
+	var violations []*aletheia.ViolationExportRow
+	err = violationService.ExportViolations(ctx, aletheia.ViolationExportFilter{
+		OrganizationID: project.OrganizationID,
+		ProjectID:      &project.ID,
+		CreatedTo:      &to,
+	}, func(row *aletheia.ViolationExportRow) error {
+		if !row.Status.IsResolved() || !row.CreatedAt.Before(from) || (row.ResolvedAt != nil && !row.ResolvedAt.Before(from)) {
+			violations = append(violations, row)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	summary := summarize(from, to, inspections, violations)
+	summary.ProjectID = project.ID
+	summary.ProjectName = project.Name
+	return summary, nil
+}
+
+// summarize aggregates inspections and violations over a period.
+func summarize(from, to time.Time, inspections []*aletheia.Inspection, violations []*aletheia.ViolationExportRow) *aletheia.ProjectSummary {
+	within := func(t time.Time) bool { return !t.Before(from) && t.Before(to) }
+
+	s := &aletheia.ProjectSummary{
+		From:        from,
+		To:          to,
+		Interval:    aletheia.SummaryIntervalFor(from, to),
+		Inspections: aletheia.SummaryInspections{ByStatus: map[aletheia.InspectionStatus]int{}},
+		BySeverity:  map[aletheia.Severity]int{},
+	}
+	for _, severity := range severities {
+		s.BySeverity[severity] = 0
+	}
+
+	for _, inspection := range inspections {
+		if within(inspection.Date()) {
+			s.Inspections.Total++
+			s.Inspections.ByStatus[inspection.Status]++
+		}
+	}
+
+	// One trend point per interval, the first starting with the period
+	for start := s.Interval.Start(from); start.Before(to); start = s.Interval.Next(start) {
+		s.Trend = append(s.Trend, aletheia.SummaryTrendPoint{Start: later(start, from)})
+	}
+	trendPoint := func(t time.Time) *aletheia.SummaryTrendPoint {
+		i, _ := slices.BinarySearchFunc(s.Trend, t, func(p aletheia.SummaryTrendPoint, t time.Time) int {
+			return p.Start.Compare(t)
+		})
+		if i == len(s.Trend) || s.Trend[i].Start.After(t) {
+			i--
+		}
+		return &s.Trend[i]
+	}
+
+	type tally struct {
+		aletheia.SafetyCodeTally
+		inspections map[uuid.UUID]bool
+	}
+	codes := map[string]*tally{}
+	var hours []float64
+
+	for _, v := range violations {
+		if isOpen(v, from) {
+			s.Violations.OpenAtStart++
+		}
+		if isOpen(v, to) {
+			s.Violations.OpenAtEnd++
+		}
+
+		if within(v.CreatedAt) {
+			s.Violations.Detected++
+			trendPoint(v.CreatedAt).Opened++
+			switch v.Status {
+			case aletheia.ViolationStatusConfirmed:
+				s.Violations.Confirmed++
+			case aletheia.ViolationStatusDismissed:
+				s.Violations.Dismissed++
+			default:
+				s.Violations.Pending++
+			}
+
+			if v.Status == aletheia.ViolationStatusConfirmed {
+				s.BySeverity[v.Severity]++
+				t, ok := codes[v.SafetyCode]
+				if !ok {
+					t = &tally{
+						SafetyCodeTally: aletheia.SafetyCodeTally{Code: v.SafetyCode, Description: v.SafetyCodeDescription},
+						inspections:     map[uuid.UUID]bool{},
+					}
+					codes[v.SafetyCode] = t
+				}
+				t.Violations++
+				t.inspections[v.InspectionID] = true
+			}
+		}
+
+		if v.Status.IsResolved() && v.ResolvedAt != nil && within(*v.ResolvedAt) {
+			s.Violations.Closed++
+			trendPoint(*v.ResolvedAt).Closed++
+			hours = append(hours, max(0, v.ResolvedAt.Sub(v.CreatedAt).Hours()))
+		}
+	}
+
+	for i := range s.Trend {
+		end := to
+		if i+1 < len(s.Trend) {
+			end = s.Trend[i+1].Start
+		}
+		for _, v := range violations {
+			if isOpen(v, end) {
+				s.Trend[i].Open++
+			}
+		}
+	}
+
+	s.BySafetyCode = []aletheia.SafetyCodeTally{}
+	s.RepeatOffenders = []aletheia.SafetyCodeTally{}
+	for _, t := range codes {
+		t.Inspections = len(t.inspections)
+		s.BySafetyCode = append(s.BySafetyCode, t.SafetyCodeTally)
+		if t.Code != "" && t.Inspections > 1 {
+			s.RepeatOffenders = append(s.RepeatOffenders, t.SafetyCodeTally)
+		}
+	}
+	slices.SortFunc(s.BySafetyCode, func(a, b aletheia.SafetyCodeTally) int {
+		if c := b.Violations - a.Violations; c != 0 {
+			return c
+		}
+		return strings.Compare(a.Code, b.Code)
+	})
+	slices.SortFunc(s.RepeatOffenders, func(a, b aletheia.SafetyCodeTally) int {
+		if c := b.Inspections - a.Inspections; c != 0 {
+			return c
+		}
+		if c := b.Violations - a.Violations; c != 0 {
+			return c
+		}
+		return strings.Compare(a.Code, b.Code)
+	})
+
+	s.Resolution = resolution(hours)
+	return s
+}
+
+// isOpen returns true if a violation was pending at t. Violations resolved
+// at an unknown time are taken to have never been open.
+func isOpen(v *aletheia.ViolationExportRow, t time.Time) bool {
+	if !v.CreatedAt.Before(t) {
+		return false
+	}
+	if !v.Status.IsResolved() {
+		return true
+	}
+	return v.ResolvedAt != nil && v.ResolvedAt.After(t)
+}
+
+// resolution describes resolution times given in hours, rounded to a tenth
+// of an hour.
+func resolution(hours []float64) aletheia.SummaryResolution {
+	r := aletheia.SummaryResolution{Resolved: len(hours)}
+	if len(hours) == 0 {
+		return r
+	}
+	slices.Sort(hours)
+
+	var total float64
+	for _, h := range hours {
+		total += h
+	}
+	median := hours[len(hours)/2]
+	if len(hours)%2 == 0 {
+		median = (hours[len(hours)/2-1] + median) / 2
+	}
+
+	round := func(h float64) float64 { return math.Round(h*10) / 10 }
+	r.AverageHours = round(total / float64(len(hours)))
+	r.MedianHours = round(median)
+	r.LongestHours = round(hours[len(hours)-1])
+	return r
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// summaryData is everything a summary report is rendered from.
+type summaryData struct {
+	Summary      *aletheia.ProjectSummary
+	Project      *aletheia.Project
+	Organization *aletheia.Organization
+	Logo         []byte // JPEG; nil if the organization has none
+	GeneratedAt  time.Time
+}
+
+// renderSummaryReport lays out a project summary report.
+func renderSummaryReport(data *summaryData) *pdf.Document {
+	s := data.Summary
+	doc := pdf.New(pdf.LetterWidth, pdf.LetterHeight)
+	doc.Title = "Project Summary: " + data.Project.Name
+	doc.Subject = "Project " + data.Project.ID.String() + ", " + period(s)
+
+	l := &layout{doc: doc}
+	l.newPage()
+
+	renderBanner(l, data.Organization, data.Logo, "Project Summary")
+	renderDetails(l, [][2]string{
+		{"Project", data.Project.Name},
+		{"Address", data.Project.FullAddress()},
+		{"Project type", data.Project.ProjectType},
+		{"Period", period(s)},
+		{"Report generated", data.GeneratedAt.Format("January 2, 2006 15:04 MST")},
+	})
+
+	renderOverview(l, s)
+	renderSeverities(l, s)
+	renderTrend(l, s)
+	renderSafetyCodes(l, s.BySafetyCode, "Violations by Safety Code",
+		"No violations were confirmed in this period.")
+	renderSafetyCodes(l, s.RepeatOffenders, "Repeat Offenders",
+		"No safety code was cited in more than one inspection in this period.")
+	renderResolution(l, s)
+	renderDisclaimer(l, data.Organization)
+	renderFooters(doc, data.Project.Name+" · Project Summary")
+
+	return doc
+}
+
+// period formats the days a summary covers.
+func period(s *aletheia.ProjectSummary) string {
+	last := s.To.UTC().Add(-time.Nanosecond)
+	return s.From.UTC().Format("January 2, 2006") + " to " + last.Format("January 2, 2006")
+}
+
+// renderBoxes draws a row of labelled counts.
+func renderBoxes(l *layout, boxes [][2]string, colors []pdf.Color) {
+	n := float64(len(boxes))
+	boxWidth := (contentWidth - (n-1)*photoGap) / n
+	l.need(64)
+	for i, box := range boxes {
+		x := margin + float64(i)*(boxWidth+photoGap)
+		l.page.Rect(x, l.y, boxWidth, 56, colorPanel)
+		if colors != nil {
+			l.page.Rect(x, l.y, 4, 56, colors[i])
+		}
+		l.page.Text(x+14, l.y+28, pdf.HelveticaBold, 20, colorText, box[0])
+		l.page.Text(x+14, l.y+46, pdf.Helvetica, 9, colorMuted, box[1])
+	}
+	l.y += 56 + 12
+}
+
+func renderOverview(l *layout, s *aletheia.ProjectSummary) {
+	l.heading("Overview")
+	renderBoxes(l, [][2]string{
+		{fmt.Sprint(s.Inspections.Total), "Inspections"},
+		{fmt.Sprint(s.Violations.Detected), "Violations detected"},
+		{fmt.Sprint(s.Violations.Closed), "Violations closed"},
+		{fmt.Sprint(s.Violations.OpenAtEnd), "Open at period end"},
+	}, nil)
+
+	if s.Inspections.Total > 0 {
+		statuses := make([]string, 0, len(s.Inspections.ByStatus))
+		for _, status := range []aletheia.InspectionStatus{
+			aletheia.InspectionStatusDraft,
+			aletheia.InspectionStatusInProgress,
+			aletheia.InspectionStatusCompleted,
+		} {
+			if n := s.Inspections.ByStatus[status]; n > 0 {
+				statuses = append(statuses, fmt.Sprintf("%d %s", n, strings.ToLower(humanize(string(status)))))
+			}
+		}
+		l.paragraph(margin, contentWidth, pdf.Helvetica, 10, colorText, fmt.Sprintf(
+			"%s performed: %s.", plural(s.Inspections.Total, "inspection"), strings.Join(statuses, ", ")))
+	}
+	l.paragraph(margin, contentWidth, pdf.Helvetica, 10, colorText, fmt.Sprintf(
+		"Of the %s detected, %d confirmed, %d dismissed and %d still pending review. %d open when the period began.",
+		plural(s.Violations.Detected, "violation"), s.Violations.Confirmed, s.Violations.Dismissed,
+		s.Violations.Pending, s.Violations.OpenAtStart))
+}
+
+func renderSeverities(l *layout, s *aletheia.ProjectSummary) {
+	l.heading("Confirmed Violations by Severity")
+	boxes := make([][2]string, len(severities))
+	colors := make([]pdf.Color, len(severities))
+	for i, severity := range severities {
+		boxes[i] = [2]string{fmt.Sprint(s.BySeverity[severity]), humanize(string(severity))}
+		colors[i] = severityColor(severity)
+	}
+	renderBoxes(l, boxes, colors)
+}
+
+// table draws rows of cells in columns starting at x offsets from the
+// margin, the first row as a header. Numeric columns are right-aligned at
+// the start of the next column.
+type table struct {
+	columns []float64
+	numeric []bool
+}
+
+func (t table) render(l *layout, rows [][]string) {
+	const rowHeight = 18.0
+	right := func(i int) float64 {
+		if i+1 < len(t.columns) {
+			return margin + t.columns[i+1] - 12
+		}
+		return margin + contentWidth
+	}
+	for r, row := range rows {
+		// Repeat the header at the top of each page
+		if r > 0 && l.y+rowHeight > pageBottom {
+			l.newPage()
+			t.row(l, rows[0], right, true)
+		}
+		l.need(rowHeight)
+		t.row(l, row, right, r == 0)
+	}
+}
+
+func (t table) row(l *layout, row []string, right func(int) float64, header bool) {
+	const rowHeight = 18.0
+	font, c := pdf.Helvetica, colorText
+	if header {
+		font, c = pdf.HelveticaBold, colorMuted
+	}
+	for i, cell := range row {
+		x := margin + t.columns[i]
+		if t.numeric[i] {
+			x = right(i) - pdf.TextWidth(font, 9, cell)
+		} else if lines := pdf.Wrap(font, 9, right(i)-x, cell); len(lines) > 0 && lines[0] != cell {
+			// Cut cells too long for their column
+			cell = strings.TrimRight(lines[0], " ,.") + "…"
+		}
+		l.page.Text(x, l.y+12, font, 9, c, cell)
+	}
+	l.y += rowHeight
+	l.page.Line(margin, l.y-3, margin+contentWidth, l.y-3, 0.5, colorRule)
+}
+
+func renderTrend(l *layout, s *aletheia.ProjectSummary) {
+	l.heading("Open and Closed Violations")
+	label := map[aletheia.SummaryInterval]string{
+		aletheia.SummaryIntervalDay:   "Day",
+		aletheia.SummaryIntervalWeek:  "Week of",
+		aletheia.SummaryIntervalMonth: "Month",
+	}[s.Interval]
+	rows := [][]string{{label, "Opened", "Closed", "Open at end"}}
+	for _, point := range s.Trend {
+		start := point.Start.UTC().Format("Jan 2, 2006")
+		if s.Interval == aletheia.SummaryIntervalMonth {
+			start = point.Start.UTC().Format("January 2006")
+		}
+		rows = append(rows, []string{start, fmt.Sprint(point.Opened), fmt.Sprint(point.Closed), fmt.Sprint(point.Open)})
+	}
+	table{
+		columns: []float64{0, 180, 280, 380},
+		numeric: []bool{false, true, true, true},
+	}.render(l, rows)
+}
+
+func renderSafetyCodes(l *layout, tallies []aletheia.SafetyCodeTally, heading, empty string) {
+	l.heading(heading)
+	if len(tallies) == 0 {
+		l.paragraph(margin, contentWidth, pdf.Helvetica, 10, colorMuted, empty)
+		return
+	}
+	rows := [][]string{{"Code", "Description", "Violations", "Inspections"}}
+	for _, t := range tallies {
+		code := t.Code
+		if code == "" {
+			code = "Uncoded"
+		}
+		rows = append(rows, []string{code, t.Description, fmt.Sprint(t.Violations), fmt.Sprint(t.Inspections)})
+	}
+	table{
+		columns: []float64{0, 110, 340, 420},
+		numeric: []bool{false, false, true, true},
+	}.render(l, rows)
+}
+
+func renderResolution(l *layout, s *aletheia.ProjectSummary) {
+	l.heading("Time to Resolution")
+	r := s.Resolution
+	if r.Resolved == 0 {
+		l.paragraph(margin, contentWidth, pdf.Helvetica, 10, colorMuted,
+			"No violations with a recorded resolution time were closed in this period.")
+		return
+	}
+	renderBoxes(l, [][2]string{
+		{fmt.Sprint(r.Resolved), "Resolved"},
+		{duration(r.AverageHours), "Average"},
+		{duration(r.MedianHours), "Median"},
+		{duration(r.LongestHours), "Longest"},
+	}, nil)
+	l.paragraph(margin, contentWidth, pdf.Helvetica, 9, colorMuted,
+		"From detection until a violation was confirmed or dismissed.")
+}
+
+// duration formats a number of hours, as days once there are more than two.
+func duration(hours float64) string {
+	if hours > 48 {
+		return fmt.Sprintf("%.1f days", hours/24)
+	}
+	return fmt.Sprintf("%.1f hours", hours)
+}
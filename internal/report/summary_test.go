@@ -0,0 +1,322 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(day int, hour int) time.Time {
+	return time.Date(2025, time.March, day, hour, 0, 0, 0, time.UTC)
+}
+
+func TestSummarize(t *testing.T) {
+	from, to := date(3, 0), date(10, 0) // Monday to Monday
+	first, second, earlier := uuid.New(), uuid.New(), uuid.New()
+
+	inspections := []*aletheia.Inspection{
+		{ID: earlier, Status: aletheia.InspectionStatusCompleted, CreatedAt: date(1, 9)},
+		{ID: first, Status: aletheia.InspectionStatusCompleted, CreatedAt: date(3, 9)},
+		{ID: second, Status: aletheia.InspectionStatusInProgress, CreatedAt: date(5, 9)},
+	}
+	resolved := func(day, hour int) *time.Time { t := date(day, hour); return &t }
+	violations := []*aletheia.ViolationExportRow{
+		// Open since before the period, confirmed on its second day
+		{InspectionID: earlier, SafetyCode: "1926.501", Severity: aletheia.SeverityHigh, Status: aletheia.ViolationStatusConfirmed, CreatedAt: date(1, 9), ResolvedAt: resolved(4, 9)},
+		// Open since before the period and still pending
+		{InspectionID: earlier, Severity: aletheia.SeverityLow, Status: aletheia.ViolationStatusPending, CreatedAt: date(1, 9)},
+		{InspectionID: first, SafetyCode: "1926.501", SafetyCodeDescription: "Fall protection", Severity: aletheia.SeverityCritical, Status: aletheia.ViolationStatusConfirmed, CreatedAt: date(3, 9), ResolvedAt: resolved(3, 21)},
+		{InspectionID: first, SafetyCode: "1926.451", Severity: aletheia.SeverityMedium, Status: aletheia.ViolationStatusDismissed, CreatedAt: date(3, 9), ResolvedAt: resolved(4, 9)},
+		{InspectionID: second, SafetyCode: "1926.501", SafetyCodeDescription: "Fall protection", Severity: aletheia.SeverityHigh, Status: aletheia.ViolationStatusConfirmed, CreatedAt: date(5, 9), ResolvedAt: resolved(8, 9)},
+		{InspectionID: second, Severity: aletheia.SeverityLow, Status: aletheia.ViolationStatusConfirmed, CreatedAt: date(5, 9), ResolvedAt: resolved(5, 10)},
+		// Confirmed before resolution times were recorded
+		{InspectionID: second, SafetyCode: "1926.451", Severity: aletheia.SeverityMedium, Status: aletheia.ViolationStatusConfirmed, CreatedAt: date(5, 9)},
+		{InspectionID: second, Severity: aletheia.SeverityHigh, Status: aletheia.ViolationStatusPending, CreatedAt: date(9, 9)},
+	}
+
+	s := summarize(from, to, inspections, violations)
+
+	assert.Equal(t, aletheia.SummaryIntervalDay, s.Interval)
+	assert.Equal(t, 2, s.Inspections.Total)
+	assert.Equal(t, map[aletheia.InspectionStatus]int{
+		aletheia.InspectionStatusCompleted:  1,
+		aletheia.InspectionStatusInProgress: 1,
+	}, s.Inspections.ByStatus)
+
+	assert.Equal(t, aletheia.SummaryViolations{
+		Detected:    6,
+		Confirmed:   4,
+		Dismissed:   1,
+		Pending:     1,
+		Closed:      5,
+		OpenAtStart: 2,
+		OpenAtEnd:   2,
+	}, s.Violations)
+	assert.Equal(t, map[aletheia.Severity]int{
+		aletheia.SeverityCritical: 1,
+		aletheia.SeverityHigh:     1,
+		aletheia.SeverityMedium:   1,
+		aletheia.SeverityLow:      1,
+	}, s.BySeverity)
+
+	assert.Equal(t, []aletheia.SafetyCodeTally{
+		{Code: "1926.501", Description: "Fall protection", Violations: 2, Inspections: 2},
+		{Code: "", Violations: 1, Inspections: 1},
+		{Code: "1926.451", Violations: 1, Inspections: 1},
+	}, s.BySafetyCode)
+	assert.Equal(t, []aletheia.SafetyCodeTally{
+		{Code: "1926.501", Description: "Fall protection", Violations: 2, Inspections: 2},
+	}, s.RepeatOffenders)
+
+	require.Len(t, s.Trend, 7)
+	assert.Equal(t, aletheia.SummaryTrendPoint{Start: date(3, 0), Opened: 2, Closed: 1, Open: 3}, s.Trend[0])
+	assert.Equal(t, aletheia.SummaryTrendPoint{Start: date(4, 0), Opened: 0, Closed: 2, Open: 1}, s.Trend[1])
+	assert.Equal(t, aletheia.SummaryTrendPoint{Start: date(5, 0), Opened: 3, Closed: 1, Open: 2}, s.Trend[2])
+	assert.Equal(t, aletheia.SummaryTrendPoint{Start: date(9, 0), Opened: 1, Closed: 0, Open: 2}, s.Trend[6])
+
+	// 72, 12, 24, 72 and 1 hours to resolve, and one resolved at an unknown time
+	assert.Equal(t, aletheia.SummaryResolution{Resolved: 5, AverageHours: 36.2, MedianHours: 24, LongestHours: 72}, s.Resolution)
+	assert.False(t, s.IsEmpty())
+}
+
+func TestSummarize_Empty(t *testing.T) {
+	s := summarize(date(1, 0), date(31, 12), nil, nil)
+	assert.True(t, s.IsEmpty())
+	assert.Len(t, s.BySeverity, 4)
+	assert.NotNil(t, s.BySafetyCode)
+	assert.NotNil(t, s.RepeatOffenders)
+	require.Len(t, s.Trend, 31)
+	assert.Equal(t, date(1, 0), s.Trend[0].Start)
+	assert.Zero(t, s.Resolution)
+}
+
+func TestSummarize_Weekly(t *testing.T) {
+	// A Wednesday to the Monday ten weeks later
+	from, to := date(5, 0), date(5, 0).AddDate(0, 0, 68)
+	created := date(5, 12)
+	s := summarize(from, to, nil, []*aletheia.ViolationExportRow{
+		{Status: aletheia.ViolationStatusPending, CreatedAt: created},
+	})
+
+	assert.Equal(t, aletheia.SummaryIntervalWeek, s.Interval)
+	require.Len(t, s.Trend, 10)
+	// The first week is cut short by the start of the period
+	assert.Equal(t, from, s.Trend[0].Start)
+	assert.Equal(t, date(10, 0), s.Trend[1].Start)
+	assert.Equal(t, time.Monday, s.Trend[9].Start.Weekday())
+	for _, point := range s.Trend {
+		assert.Equal(t, 1, point.Open)
+	}
+}
+
+// testSummaryGenerator returns a generator for a project with one
+// inspection and one violation in March 2025.
+func testSummaryGenerator(t *testing.T) (*Generator, *aletheia.Project, map[string][]byte, *[]*aletheia.SummaryReport) {
+	t.Helper()
+
+	org := &aletheia.Organization{ID: uuid.New(), Name: "Acme Construction"}
+	project := &aletheia.Project{ID: uuid.New(), OrganizationID: org.ID, Name: "Riverside Tower", Status: "active"}
+	inspection := &aletheia.Inspection{ID: uuid.New(), ProjectID: project.ID, Status: aletheia.InspectionStatusCompleted, CreatedAt: date(3, 9)}
+
+	files := map[string][]byte{}
+	var reports []*aletheia.SummaryReport
+
+	g := &Generator{
+		Inspections: &mock.InspectionService{
+			FindInspectionsFn: func(ctx context.Context, filter aletheia.InspectionFilter) ([]*aletheia.Inspection, int, error) {
+				require.NotNil(t, filter.ProjectID)
+				assert.Equal(t, project.ID, *filter.ProjectID)
+				return []*aletheia.Inspection{inspection}, 1, nil
+			},
+		},
+		Projects: &mock.ProjectService{
+			FindProjectByIDFn: func(ctx context.Context, id uuid.UUID) (*aletheia.Project, error) {
+				if id != project.ID {
+					return nil, aletheia.NotFound("Project not found")
+				}
+				return project, nil
+			},
+		},
+		Organizations: &mock.OrganizationService{
+			FindOrganizationByIDFn: func(ctx context.Context, id uuid.UUID) (*aletheia.Organization, error) { return org, nil },
+		},
+		Violations: &mock.ViolationService{
+			ExportViolationsFn: func(ctx context.Context, filter aletheia.ViolationExportFilter, fn func(*aletheia.ViolationExportRow) error) error {
+				assert.Equal(t, org.ID, filter.OrganizationID)
+				require.NotNil(t, filter.ProjectID)
+				assert.Equal(t, project.ID, *filter.ProjectID)
+				return fn(&aletheia.ViolationExportRow{
+					InspectionID: inspection.ID,
+					SafetyCode:   "1926.501",
+					Severity:     aletheia.SeverityHigh,
+					Status:       aletheia.ViolationStatusConfirmed,
+					CreatedAt:    date(3, 9),
+				})
+			},
+		},
+		Reports: &mock.ReportService{
+			CreateSummaryReportFn: func(ctx context.Context, report *aletheia.SummaryReport) error {
+				report.ID = uuid.New()
+				reports = append(reports, report)
+				return nil
+			},
+		},
+		Storage: &mock.FileStorage{
+			UploadFn: func(ctx context.Context, key string, reader io.Reader, contentType string) (string, error) {
+				data, err := io.ReadAll(reader)
+				if err != nil {
+					return "", err
+				}
+				files[key] = data
+				return storageURL + key, nil
+			},
+			DeleteFn: func(ctx context.Context, key string) error {
+				delete(files, key)
+				return nil
+			},
+		},
+		Logger: slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError})),
+	}
+
+	return g, project, files, &reports
+}
+
+func TestGenerator_GenerateSummary(t *testing.T) {
+	g, project, files, reports := testSummaryGenerator(t)
+
+	userID := uuid.New()
+	report, err := g.GenerateSummary(context.Background(), project.ID, userID, date(1, 0), date(11, 0))
+	require.NoError(t, err)
+	require.Len(t, *reports, 1)
+	assert.Equal(t, project.ID, report.ProjectID)
+	assert.Equal(t, userID, report.GeneratedBy)
+	assert.Equal(t, date(1, 0), report.From)
+	assert.Equal(t, date(11, 0), report.To)
+	require.NotNil(t, report.Summary)
+	assert.Equal(t, 1, report.Summary.Inspections.Total)
+	assert.Equal(t, 1, report.Summary.BySeverity[aletheia.SeverityHigh])
+	assert.Equal(t, "project-summary-2025-03-01-to-2025-03-10.pdf", SummaryFilename(report))
+
+	key := strings.TrimPrefix(report.StorageURL, storageURL)
+	assert.True(t, strings.HasPrefix(key, "reports/projects/"+project.ID.String()+"/20250301-20250311-"), key)
+
+	data := files[key]
+	assert.Equal(t, int64(len(data)), report.SizeBytes)
+	assert.True(t, bytes.HasPrefix(data, []byte("%PDF-")))
+	assert.Contains(t, string(data), "/Title (Project Summary: Riverside Tower)")
+}
+
+func TestGenerator_GenerateSummaryNotStored(t *testing.T) {
+	g, project, files, _ := testSummaryGenerator(t)
+	g.Reports = &mock.ReportService{
+		CreateSummaryReportFn: func(ctx context.Context, report *aletheia.SummaryReport) error {
+			return aletheia.NotFound("Project not found")
+		},
+	}
+
+	_, err := g.GenerateSummary(context.Background(), project.ID, uuid.New(), date(1, 0), date(11, 0))
+	require.Error(t, err)
+	assert.Empty(t, files, "upload is deleted")
+}
+
+func TestGenerator_SummaryStorageQuota(t *testing.T) {
+	g, project, files, reports := testSummaryGenerator(t)
+	g.StorageQuota = 1 << 20
+	used := g.StorageQuota - 100
+	g.Organizations.(*mock.OrganizationService).GetStorageUsageFn = func(ctx context.Context, orgID uuid.UUID) (*aletheia.StorageUsage, error) {
+		assert.Equal(t, project.OrganizationID, orgID)
+		return &aletheia.StorageUsage{OrganizationID: orgID, TotalBytes: used}, nil
+	}
+
+	// A summary that does not fit is neither uploaded nor recorded
+	_, err := g.GenerateSummary(context.Background(), project.ID, uuid.New(), date(1, 0), date(11, 0))
+	assert.True(t, aletheia.IsErrorCode(err, aletheia.EFORBIDDEN))
+	assert.Empty(t, files)
+	assert.Empty(t, *reports)
+
+	// Scheduled summaries are not retried
+	job, err := NewSummaryJob(project, date(1, 0), date(11, 0), uuid.Nil)
+	require.NoError(t, err)
+	err = g.handleSummary(context.Background(), job)
+	assert.True(t, aletheia.IsPermanent(err))
+	assert.Empty(t, files)
+
+	used = 0
+	_, err = g.GenerateSummary(context.Background(), project.ID, uuid.New(), date(1, 0), date(11, 0))
+	require.NoError(t, err)
+	assert.Len(t, *reports, 1)
+}
+
+func TestGenerator_HandleSummary(t *testing.T) {
+	g, project, _, reports := testSummaryGenerator(t)
+
+	// Scheduled summaries of periods with no activity are skipped
+	job, err := NewSummaryJob(project, date(20, 0), date(27, 0), uuid.Nil)
+	require.NoError(t, err)
+	require.NoError(t, g.handleSummary(context.Background(), job))
+	assert.Empty(t, *reports)
+
+	// Requested ones are not
+	job, err = NewSummaryJob(project, date(20, 0), date(27, 0), uuid.New())
+	require.NoError(t, err)
+	require.NoError(t, g.handleSummary(context.Background(), job))
+	assert.Len(t, *reports, 1)
+
+	// The project was deleted
+	job, err = NewSummaryJob(&aletheia.Project{ID: uuid.New()}, date(20, 0), date(27, 0), uuid.Nil)
+	require.NoError(t, err)
+	err = g.handleSummary(context.Background(), job)
+	require.Error(t, err)
+	assert.True(t, aletheia.IsPermanent(err))
+}
+
+func TestGenerator_QueueSummaries(t *testing.T) {
+	g, project, _, _ := testSummaryGenerator(t)
+
+	var jobs []*aletheia.Job
+	g.Projects = &mock.ProjectService{
+		FindProjectsFn: func(ctx context.Context, filter aletheia.ProjectFilter) ([]*aletheia.Project, int, error) {
+			require.NotNil(t, filter.Status)
+			assert.Equal(t, "active", *filter.Status)
+			return []*aletheia.Project{project}, 1, nil
+		},
+	}
+	g.Queue = &mock.Queue{
+		EnqueueFn: func(ctx context.Context, job *aletheia.Job, opts ...aletheia.EnqueueOption) error {
+			jobs = append(jobs, job)
+			return nil
+		},
+	}
+
+	schedule, err := SummarySchedule("0 6 * * 1", 7)
+	require.NoError(t, err)
+	job := schedule.Job
+	job.ScheduledAt = date(10, 6)
+	require.NoError(t, g.queueSummaries(context.Background(), &job))
+
+	require.Len(t, jobs, 1)
+	assert.Equal(t, aletheia.JobTypeProjectSummary, jobs[0].JobType)
+	assert.Equal(t, project.OrganizationID, jobs[0].OrganizationID)
+
+	var payload aletheia.ProjectSummaryPayload
+	require.NoError(t, json.Unmarshal(jobs[0].Payload, &payload))
+	assert.Equal(t, project.ID, payload.ProjectID)
+	assert.Equal(t, date(3, 0), payload.From)
+	assert.Equal(t, date(10, 0), payload.To)
+	assert.Equal(t, uuid.Nil, payload.RequestedBy)
+
+	_, err = SummarySchedule("@daily", 400)
+	assert.Error(t, err)
+}
@@ -0,0 +1,71 @@
+// Package signing signs the hashes of generated reports so that anyone can
+// check a downloaded report came from this server unaltered.
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// MessageFormat describes the message signed for a report.
+const MessageFormat = messagePrefix + "<inspection id>:<sha256>"
+
+const messagePrefix = "aletheia-report:"
+
+// Signer signs report hashes with an Ed25519 key.
+type Signer struct {
+	key   ed25519.PrivateKey
+	keyID string
+}
+
+// NewSigner returns a signer for a base64-encoded 32-byte Ed25519 seed.
+func NewSigner(seed string) (*Signer, error) {
+	b, err := base64.StdEncoding.DecodeString(seed)
+	if err != nil {
+		return nil, fmt.Errorf("decoding signing key: %w", err)
+	}
+	if len(b) != ed25519.SeedSize {
+		return nil, fmt.Errorf("signing key must be %d bytes, got %d", ed25519.SeedSize, len(b))
+	}
+
+	key := ed25519.NewKeyFromSeed(b)
+	sum := sha256.Sum256(key.Public().(ed25519.PublicKey))
+	return &Signer{key: key, keyID: hex.EncodeToString(sum[:8])}, nil
+}
+
+// KeyID identifies the signer's key, so that signatures made with an
+// earlier key can be told apart after the key changes.
+func (s *Signer) KeyID() string {
+	return s.keyID
+}
+
+// PublicKey returns the base64-encoded public key signatures are checked with.
+func (s *Signer) PublicKey() string {
+	return base64.StdEncoding.EncodeToString(s.key.Public().(ed25519.PublicKey))
+}
+
+// Sign returns the base64-encoded signature of a report of an inspection
+// whose PDF has a hex SHA-256.
+func (s *Signer) Sign(inspectionID uuid.UUID, sha256 string) string {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(s.key, message(inspectionID, sha256)))
+}
+
+// Verify returns true if signature is the signer's signature of a report.
+func (s *Signer) Verify(inspectionID uuid.UUID, sha256, signature string) bool {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	return ed25519.Verify(s.key.Public().(ed25519.PublicKey), message(inspectionID, sha256), sig)
+}
+
+// message is what is signed for a report. Binding the inspection keeps a
+// signature from vouching for the same file under another inspection.
+func message(inspectionID uuid.UUID, sha256 string) []byte {
+	return []byte(messagePrefix + inspectionID.String() + ":" + sha256)
+}
@@ -0,0 +1,61 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSeed(b byte) string {
+	return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(rune(b)), ed25519.SeedSize)))
+}
+
+func TestSigner(t *testing.T) {
+	signer, err := NewSigner(testSeed('a'))
+	require.NoError(t, err)
+
+	inspectionID := uuid.New()
+	hash := strings.Repeat("ab", 32)
+	sig := signer.Sign(inspectionID, hash)
+
+	assert.True(t, signer.Verify(inspectionID, hash, sig))
+	assert.False(t, signer.Verify(inspectionID, strings.Repeat("cd", 32), sig), "other file")
+	assert.False(t, signer.Verify(uuid.New(), hash, sig), "other inspection")
+	assert.False(t, signer.Verify(inspectionID, hash, "not base64!"))
+
+	// The public key checks signatures without the signer
+	pub, err := base64.StdEncoding.DecodeString(signer.PublicKey())
+	require.NoError(t, err)
+	raw, err := base64.StdEncoding.DecodeString(sig)
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(pub, []byte("aletheia-report:"+inspectionID.String()+":"+hash), raw))
+}
+
+func TestSignerKeyID(t *testing.T) {
+	a, err := NewSigner(testSeed('a'))
+	require.NoError(t, err)
+	again, err := NewSigner(testSeed('a'))
+	require.NoError(t, err)
+	b, err := NewSigner(testSeed('b'))
+	require.NoError(t, err)
+
+	assert.Len(t, a.KeyID(), 16)
+	assert.Equal(t, a.KeyID(), again.KeyID())
+	assert.NotEqual(t, a.KeyID(), b.KeyID())
+
+	inspectionID := uuid.New()
+	assert.False(t, b.Verify(inspectionID, "hash", a.Sign(inspectionID, "hash")))
+}
+
+func TestNewSignerInvalid(t *testing.T) {
+	_, err := NewSigner("not base64!")
+	assert.Error(t, err)
+
+	_, err = NewSigner(base64.StdEncoding.EncodeToString([]byte("short")))
+	assert.Error(t, err)
+}
@@ -0,0 +1,225 @@
+// Package xlsx writes single-sheet spreadsheets in the Office Open XML
+// format read by Excel, Numbers and LibreOffice. Rows are written as they
+// come, so sheets of any length can be streamed without holding them in
+// memory.
+//
+// Strings are stored inline rather than in a shared string table, which
+// keeps the writer streaming at the cost of a somewhat larger file.
+package xlsx
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cell styles, indexes into cellXfs in styles.xml.
+const (
+	styleDefault = 0
+	styleHeader  = 1
+	styleDate    = 2
+)
+
+// MaxRows is the most rows a sheet can hold.
+const MaxRows = 1048576
+
+// Writer writes a spreadsheet one row at a time. Close must be called to
+// finish the file.
+type Writer struct {
+	zip  *zip.Writer
+	w    *bufio.Writer
+	rows int
+}
+
+// NewWriter starts a spreadsheet with one sheet of the given name.
+func NewWriter(w io.Writer, sheet string) (*Writer, error) {
+	z := zip.NewWriter(w)
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", contentTypes},
+		{"_rels/.rels", rootRels},
+		{"xl/workbook.xml", fmt.Sprintf(workbook, escape(sheetName(sheet)))},
+		{"xl/_rels/workbook.xml.rels", workbookRels},
+		{"xl/styles.xml", styles},
+	}
+	for _, part := range parts {
+		f, err := z.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, part.body); err != nil {
+			return nil, err
+		}
+	}
+
+	// The sheet is written last so it can be streamed
+	f, err := z.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	bw := bufio.NewWriter(f)
+	if _, err := bw.WriteString(sheetHeader); err != nil {
+		return nil, err
+	}
+	return &Writer{zip: z, w: bw}, nil
+}
+
+// WriteHeader writes a row of bold column titles.
+func (w *Writer) WriteHeader(titles ...string) error {
+	values := make([]any, len(titles))
+	for i, t := range titles {
+		values[i] = t
+	}
+	return w.writeRow(values, styleHeader)
+}
+
+// WriteRow writes a row. Values may be strings, integers, floats, or
+// times; nil and zero times leave the cell empty. Times are written in UTC.
+func (w *Writer) WriteRow(values ...any) error {
+	return w.writeRow(values, styleDefault)
+}
+
+func (w *Writer) writeRow(values []any, style int) error {
+	if w.rows == MaxRows {
+		return fmt.Errorf("xlsx: sheet is limited to %d rows", MaxRows)
+	}
+	w.rows++
+
+	fmt.Fprintf(w.w, `<row r="%d">`, w.rows)
+	for i, v := range values {
+		ref := column(i) + strconv.Itoa(w.rows)
+		switch v := v.(type) {
+		case nil:
+		case string:
+			if v == "" {
+				continue
+			}
+			fmt.Fprintf(w.w, `<c r="%s" t="inlineStr"%s><is><t xml:space="preserve">%s</t></is></c>`, ref, styleAttr(style), escape(v))
+		case int:
+			fmt.Fprintf(w.w, `<c r="%s"%s><v>%d</v></c>`, ref, styleAttr(style), v)
+		case int64:
+			fmt.Fprintf(w.w, `<c r="%s"%s><v>%d</v></c>`, ref, styleAttr(style), v)
+		case float64:
+			fmt.Fprintf(w.w, `<c r="%s"%s><v>%s</v></c>`, ref, styleAttr(style), strconv.FormatFloat(v, 'f', -1, 64))
+		case time.Time:
+			if v.IsZero() {
+				continue
+			}
+			fmt.Fprintf(w.w, `<c r="%s"%s><v>%s</v></c>`, ref, styleAttr(styleDate), strconv.FormatFloat(serial(v), 'f', -1, 64))
+		default:
+			return fmt.Errorf("xlsx: unsupported cell value %T", v)
+		}
+	}
+	_, err := w.w.WriteString("</row>")
+	return err
+}
+
+// Close finishes the sheet and the file. It does not close the underlying
+// writer.
+func (w *Writer) Close() error {
+	if _, err := w.w.WriteString(sheetFooter); err != nil {
+		return err
+	}
+	if err := w.w.Flush(); err != nil {
+		return err
+	}
+	return w.zip.Close()
+}
+
+func styleAttr(style int) string {
+	if style == styleDefault {
+		return ""
+	}
+	return fmt.Sprintf(` s="%d"`, style)
+}
+
+// column returns the letters of a zero-based column index: A, B, ... Z, AA.
+func column(i int) string {
+	var b []byte
+	for i++; i > 0; i = (i - 1) / 26 {
+		b = append([]byte{byte('A' + (i-1)%26)}, b...)
+	}
+	return string(b)
+}
+
+// epoch is day zero of spreadsheet date serials.
+var epoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// serial returns t as a spreadsheet date: days since the epoch, with the
+// time of day as the fraction.
+func serial(t time.Time) float64 {
+	d := t.UTC().Sub(epoch)
+	// Whole seconds are precise enough and keep the value short
+	return float64(d/time.Second) / 86400
+}
+
+// sheetName makes s a valid sheet name: at most 31 characters, none of
+// which are []:*?/\.
+func sheetName(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '-'
+		}
+		return r
+	}, s)
+	if s == "" {
+		return "Sheet1"
+	}
+	if r := []rune(s); len(r) > 31 {
+		s = string(r[:31])
+	}
+	return s
+}
+
+// escape escapes s for XML text, replacing characters XML cannot hold.
+func escape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+const contentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+	`</Types>`
+
+const rootRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const workbook = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>` +
+	`</workbook>`
+
+const workbookRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+	`</Relationships>`
+
+// styles defines the default, header (bold), and date cell styles.
+const styles = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd hh:mm"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="3">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`</cellXfs>` +
+	`</styleSheet>`
+
+// The header row stays in view while scrolling.
+const sheetHeader = xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>` +
+	`<sheetData>`
+
+const sheetFooter = `</sheetData></worksheet>`
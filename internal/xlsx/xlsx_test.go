@@ -0,0 +1,89 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readPart returns a part of a spreadsheet file.
+func readPart(t *testing.T, data []byte, name string) []byte {
+	t.Helper()
+	z, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	f, err := z.Open(name)
+	require.NoError(t, err)
+	defer f.Close()
+	part, err := io.ReadAll(f)
+	require.NoError(t, err)
+	return part
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, "Violations: 2025/12")
+	require.NoError(t, err)
+	require.NoError(t, w.WriteHeader("Name", "Count", "Score", "Created"))
+	require.NoError(t, w.WriteRow("Blocked exit <B> & \"C\"", 3, 0.5, time.Date(2025, 12, 1, 12, 0, 0, 0, time.UTC)))
+	require.NoError(t, w.WriteRow("", nil, int64(7), time.Time{}))
+	require.NoError(t, w.Close())
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml"} {
+		require.NoError(t, xml.Unmarshal(readPart(t, buf.Bytes(), name), new(struct{})), name)
+	}
+	assert.Contains(t, string(readPart(t, buf.Bytes(), "xl/workbook.xml")), `name="Violations- 2025-12"`)
+
+	var sheet struct {
+		Rows []struct {
+			R     int `xml:"r,attr"`
+			Cells []struct {
+				Ref   string `xml:"r,attr"`
+				Type  string `xml:"t,attr"`
+				Style int    `xml:"s,attr"`
+				Value string `xml:"v"`
+				Text  string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	require.NoError(t, xml.Unmarshal(readPart(t, buf.Bytes(), "xl/worksheets/sheet1.xml"), &sheet))
+	require.Len(t, sheet.Rows, 3)
+
+	header := sheet.Rows[0].Cells
+	require.Len(t, header, 4)
+	assert.Equal(t, "Name", header[0].Text)
+	assert.Equal(t, styleHeader, header[0].Style)
+
+	row := sheet.Rows[1].Cells
+	require.Len(t, row, 4)
+	assert.Equal(t, "A2", row[0].Ref)
+	assert.Equal(t, "inlineStr", row[0].Type)
+	assert.Equal(t, `Blocked exit <B> & "C"`, row[0].Text)
+	assert.Equal(t, "3", row[1].Value)
+	assert.Equal(t, "0.5", row[2].Value)
+	assert.Equal(t, "45992.5", row[3].Value)
+	assert.Equal(t, styleDate, row[3].Style)
+
+	// Empty values leave no cell
+	row = sheet.Rows[2].Cells
+	require.Len(t, row, 1)
+	assert.Equal(t, "C3", row[0].Ref)
+	assert.Equal(t, "7", row[0].Value)
+}
+
+func TestWriter_UnsupportedValue(t *testing.T) {
+	w, err := NewWriter(io.Discard, "Sheet")
+	require.NoError(t, err)
+	assert.Error(t, w.WriteRow(struct{}{}))
+}
+
+func TestColumn(t *testing.T) {
+	for i, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"} {
+		assert.Equal(t, want, column(i), i)
+	}
+}
@@ -11,12 +11,12 @@ var _ aletheia.AIService = (*AIService)(nil)
 
 // AIService is a mock implementation of aletheia.AIService.
 type AIService struct {
-	AnalyzePhotoFn func(ctx context.Context, photoURL string, safetyCodes []*aletheia.SafetyCode) (*aletheia.AnalysisResult, error)
+	AnalyzePhotoFn func(ctx context.Context, req aletheia.AnalysisRequest) (*aletheia.AnalysisResult, error)
 }
 
-func (s *AIService) AnalyzePhoto(ctx context.Context, photoURL string, safetyCodes []*aletheia.SafetyCode) (*aletheia.AnalysisResult, error) {
+func (s *AIService) AnalyzePhoto(ctx context.Context, req aletheia.AnalysisRequest) (*aletheia.AnalysisResult, error) {
 	if s.AnalyzePhotoFn != nil {
-		return s.AnalyzePhotoFn(ctx, photoURL, safetyCodes)
+		return s.AnalyzePhotoFn(ctx, req)
 	}
 	// Return empty result by default
 	return &aletheia.AnalysisResult{
@@ -2,6 +2,7 @@ package mock
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/dukerupert/aletheia"
 )
@@ -14,7 +15,7 @@ type EmailService struct {
 	SendVerificationEmailFn  func(ctx context.Context, to, name, token string) error
 	SendPasswordResetEmailFn func(ctx context.Context, to, name, token string) error
 	SendWelcomeEmailFn       func(ctx context.Context, to, name string) error
-	SendInspectionReportFn   func(ctx context.Context, to []string, subject string, reportURL string) error
+	SendInspectionReportFn   func(ctx context.Context, email aletheia.ReportEmail) (string, error)
 
 	// Tracking sent emails for assertions
 	SentEmails []SentEmail
@@ -22,13 +23,17 @@ type EmailService struct {
 
 // SentEmail records details of a sent email for testing assertions.
 type SentEmail struct {
-	Type      string
-	To        string
-	ToList    []string
-	Name      string
-	Token     string
-	Subject   string
-	ReportURL string
+	Type       string
+	To         string
+	Name       string
+	Token      string
+	Subject    string
+	Message    string
+	ReportURL  string
+	Attachment *aletheia.Attachment
+
+	Organization string
+	Branding     aletheia.OrganizationBranding
 }
 
 func (s *EmailService) SendVerificationEmail(ctx context.Context, to, name, token string) error {
@@ -69,17 +74,22 @@ func (s *EmailService) SendWelcomeEmail(ctx context.Context, to, name string) er
 	return nil
 }
 
-func (s *EmailService) SendInspectionReport(ctx context.Context, to []string, subject string, reportURL string) error {
+func (s *EmailService) SendInspectionReport(ctx context.Context, email aletheia.ReportEmail) (string, error) {
 	s.SentEmails = append(s.SentEmails, SentEmail{
-		Type:      "inspection_report",
-		ToList:    to,
-		Subject:   subject,
-		ReportURL: reportURL,
+		Type:       "inspection_report",
+		To:         email.To,
+		Subject:    email.Subject,
+		Message:    email.Message,
+		ReportURL:  email.ReportURL,
+		Attachment: email.Attachment,
+
+		Organization: email.Organization,
+		Branding:     email.Branding,
 	})
 	if s.SendInspectionReportFn != nil {
-		return s.SendInspectionReportFn(ctx, to, subject, reportURL)
+		return s.SendInspectionReportFn(ctx, email)
 	}
-	return nil
+	return fmt.Sprintf("mock-%d", len(s.SentEmails)), nil
 }
 
 // Reset clears all sent emails.
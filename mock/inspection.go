@@ -20,6 +20,11 @@ type InspectionService struct {
 	UpdateInspectionStatusFn func(ctx context.Context, id uuid.UUID, status aletheia.InspectionStatus) (*aletheia.Inspection, error)
 	DeleteInspectionFn       func(ctx context.Context, id uuid.UUID) error
 	GetInspectionStatsFn     func(ctx context.Context, id uuid.UUID) (*aletheia.InspectionStats, error)
+	FindInspectionStatsFn    func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*aletheia.InspectionStats, error)
+
+	FindSignoffsFn  func(ctx context.Context, inspectionID uuid.UUID) ([]*aletheia.Signoff, error)
+	CreateSignoffFn func(ctx context.Context, signoff *aletheia.Signoff) error
+	DeleteSignoffFn func(ctx context.Context, id uuid.UUID) error
 }
 
 func (s *InspectionService) FindInspectionByID(ctx context.Context, id uuid.UUID) (*aletheia.Inspection, error) {
@@ -75,3 +80,39 @@ func (s *InspectionService) GetInspectionStats(ctx context.Context, id uuid.UUID
 		InspectionID: id,
 	}, nil
 }
+
+func (s *InspectionService) FindInspectionStats(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*aletheia.InspectionStats, error) {
+	if s.FindInspectionStatsFn != nil {
+		return s.FindInspectionStatsFn(ctx, ids)
+	}
+	stats := make(map[uuid.UUID]*aletheia.InspectionStats, len(ids))
+	for _, id := range ids {
+		stats[id] = &aletheia.InspectionStats{InspectionID: id}
+	}
+	return stats, nil
+}
+
+func (s *InspectionService) FindSignoffs(ctx context.Context, inspectionID uuid.UUID) ([]*aletheia.Signoff, error) {
+	if s.FindSignoffsFn != nil {
+		return s.FindSignoffsFn(ctx, inspectionID)
+	}
+	return []*aletheia.Signoff{}, nil
+}
+
+func (s *InspectionService) CreateSignoff(ctx context.Context, signoff *aletheia.Signoff) error {
+	if s.CreateSignoffFn != nil {
+		return s.CreateSignoffFn(ctx, signoff)
+	}
+	if signoff.ID == uuid.Nil {
+		signoff.ID = uuid.New()
+	}
+	signoff.SignedAt = time.Now()
+	return nil
+}
+
+func (s *InspectionService) DeleteSignoff(ctx context.Context, id uuid.UUID) error {
+	if s.DeleteSignoffFn != nil {
+		return s.DeleteSignoffFn(ctx, id)
+	}
+	return nil
+}
@@ -24,6 +24,7 @@ type OrganizationService struct {
 	RemoveMemberFn           func(ctx context.Context, memberID uuid.UUID) error
 	ListMembersFn            func(ctx context.Context, orgID uuid.UUID) ([]*aletheia.OrganizationMember, error)
 	RequireMembershipFn      func(ctx context.Context, orgID, userID uuid.UUID, allowedRoles ...aletheia.OrganizationRole) (*aletheia.OrganizationMember, error)
+	GetStorageUsageFn        func(ctx context.Context, orgID uuid.UUID) (*aletheia.StorageUsage, error)
 }
 
 func (s *OrganizationService) FindOrganizationByID(ctx context.Context, id uuid.UUID) (*aletheia.Organization, error) {
@@ -117,3 +118,13 @@ func (s *OrganizationService) RequireMembership(ctx context.Context, orgID, user
 		CreatedAt:      time.Now(),
 	}, nil
 }
+
+func (s *OrganizationService) GetStorageUsage(ctx context.Context, orgID uuid.UUID) (*aletheia.StorageUsage, error) {
+	if s.GetStorageUsageFn != nil {
+		return s.GetStorageUsageFn(ctx, orgID)
+	}
+	return &aletheia.StorageUsage{
+		OrganizationID: orgID,
+		Projects:       []*aletheia.ProjectStorageUsage{},
+	}, nil
+}
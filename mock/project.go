@@ -19,6 +19,10 @@ type ProjectService struct {
 	UpdateProjectFn   func(ctx context.Context, id uuid.UUID, upd aletheia.ProjectUpdate) (*aletheia.Project, error)
 	DeleteProjectFn   func(ctx context.Context, id uuid.UUID) error
 	GetProjectStatsFn func(ctx context.Context, id uuid.UUID) (*aletheia.ProjectStats, error)
+
+	FindProjectContactsFn  func(ctx context.Context, projectID uuid.UUID) ([]*aletheia.ProjectContact, error)
+	CreateProjectContactFn func(ctx context.Context, contact *aletheia.ProjectContact) error
+	DeleteProjectContactFn func(ctx context.Context, id uuid.UUID) error
 }
 
 func (s *ProjectService) FindProjectByID(ctx context.Context, id uuid.UUID) (*aletheia.Project, error) {
@@ -67,3 +71,28 @@ func (s *ProjectService) GetProjectStats(ctx context.Context, id uuid.UUID) (*al
 		ProjectID: id,
 	}, nil
 }
+
+func (s *ProjectService) FindProjectContacts(ctx context.Context, projectID uuid.UUID) ([]*aletheia.ProjectContact, error) {
+	if s.FindProjectContactsFn != nil {
+		return s.FindProjectContactsFn(ctx, projectID)
+	}
+	return []*aletheia.ProjectContact{}, nil
+}
+
+func (s *ProjectService) CreateProjectContact(ctx context.Context, contact *aletheia.ProjectContact) error {
+	if s.CreateProjectContactFn != nil {
+		return s.CreateProjectContactFn(ctx, contact)
+	}
+	if contact.ID == uuid.Nil {
+		contact.ID = uuid.New()
+	}
+	contact.CreatedAt = time.Now()
+	return nil
+}
+
+func (s *ProjectService) DeleteProjectContact(ctx context.Context, id uuid.UUID) error {
+	if s.DeleteProjectContactFn != nil {
+		return s.DeleteProjectContactFn(ctx, id)
+	}
+	return nil
+}
@@ -2,6 +2,9 @@ package mock
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"sort"
 	"sync"
 	"time"
 
@@ -9,28 +12,71 @@ import (
 	"github.com/google/uuid"
 )
 
-// Compile-time interface check
-var _ aletheia.Queue = (*Queue)(nil)
+// Compile-time interface checks
+var (
+	_ aletheia.Queue         = (*Queue)(nil)
+	_ aletheia.QueueListener = (*Queue)(nil)
+)
 
 // Queue is a mock implementation of aletheia.Queue.
 type Queue struct {
 	EnqueueFn       func(ctx context.Context, job *aletheia.Job, opts ...aletheia.EnqueueOption) error
-	DequeueFn       func(ctx context.Context, queueName string) (*aletheia.Job, error)
-	CompleteFn      func(ctx context.Context, jobID uuid.UUID, result []byte) error
-	FailFn          func(ctx context.Context, jobID uuid.UUID, errMsg string) error
+	EnqueueBatchFn  func(ctx context.Context, jobs []*aletheia.Job, callback *aletheia.Job, opts ...aletheia.EnqueueOption) error
+	EnqueueScheduledFn func(ctx context.Context, schedule string, runAt time.Time, job *aletheia.Job, opts ...aletheia.EnqueueOption) (bool, error)
+	CleanupJobsFn   func(ctx context.Context, retention time.Duration) (int, error)
+	DequeueFn       func(ctx context.Context, queueName, workerID string) (*aletheia.Job, error)
+	HeartbeatFn     func(ctx context.Context, jobID uuid.UUID, workerID string) error
+	UpdateProgressFn func(ctx context.Context, jobID uuid.UUID, workerID string, percent int, message string) error
+	ReapStaleJobsFn func(ctx context.Context, timeout time.Duration) (int, error)
+	CompleteFn      func(ctx context.Context, jobID uuid.UUID, workerID string, result []byte) error
+	FailFn          func(ctx context.Context, jobID uuid.UUID, workerID string, jobErr error) error
 	GetJobFn        func(ctx context.Context, jobID uuid.UUID) (*aletheia.Job, error)
 	CancelJobFn     func(ctx context.Context, jobID uuid.UUID) error
 	GetPendingJobsFn func(ctx context.Context, orgID uuid.UUID, queueName string) ([]*aletheia.Job, error)
+	GetRunningJobsFn func(ctx context.Context, queueName string) ([]*aletheia.Job, error)
+	ListJobsFn       func(ctx context.Context, filter aletheia.JobFilter) ([]*aletheia.Job, int, error)
+	RetryJobFn       func(ctx context.Context, jobID uuid.UUID) error
+	RetryJobsFn      func(ctx context.Context, filter aletheia.JobFilter) (int, error)
+	CancelJobsFn     func(ctx context.Context, filter aletheia.JobFilter) (int, error)
+	ListRateLimitsFn func(ctx context.Context, orgID uuid.UUID) ([]*aletheia.RateLimit, error)
+	SetRateLimitFn   func(ctx context.Context, limit *aletheia.RateLimit) error
+	DeleteRateLimitFn func(ctx context.Context, orgID uuid.UUID, queueName string) error
+	ListenFn         func(ctx context.Context, queueNames []string) (<-chan string, error)
+	ListenJobEventsFn func(ctx context.Context) (<-chan aletheia.JobChange, error)
+
+	// Config controls retry backoff and rate limits for the in-memory queue.
+	Config aletheia.QueueConfig
 
 	// In-memory job storage for testing
-	mu   sync.RWMutex
-	jobs map[uuid.UUID]*aletheia.Job
+	mu         sync.RWMutex
+	jobs       map[uuid.UUID]*aletheia.Job
+	rateLimits map[rateLimitKey]*aletheia.RateLimit
+	runs       map[scheduledRun]time.Time // claimed runs -> claim time
+	listeners  map[chan string][]string
+	watchers   map[chan aletheia.JobChange]struct{}
+}
+
+// scheduledRun identifies a run of a recurring schedule.
+type scheduledRun struct {
+	schedule string
+	runAt    time.Time
+}
+
+// rateLimitKey identifies an organization's rate limit for a queue.
+type rateLimitKey struct {
+	orgID     uuid.UUID
+	queueName string
 }
 
 // NewQueue creates a new mock queue with initialized storage.
 func NewQueue() *Queue {
 	return &Queue{
-		jobs: make(map[uuid.UUID]*aletheia.Job),
+		Config: aletheia.DefaultQueueConfig(),
+		jobs:       make(map[uuid.UUID]*aletheia.Job),
+		rateLimits: make(map[rateLimitKey]*aletheia.RateLimit),
+		runs:       make(map[scheduledRun]time.Time),
+		listeners:  make(map[chan string][]string),
+		watchers:   make(map[chan aletheia.JobChange]struct{}),
 	}
 }
 
@@ -39,51 +85,302 @@ func (q *Queue) Enqueue(ctx context.Context, job *aletheia.Job, opts ...aletheia
 		return q.EnqueueFn(ctx, job, opts...)
 	}
 
+	aletheia.PrepareJob(job, opts...)
+
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
-	if job.ID == uuid.Nil {
-		job.ID = uuid.New()
+	return q.insert(job)
+}
+
+func (q *Queue) EnqueueBatch(ctx context.Context, jobs []*aletheia.Job, callback *aletheia.Job, opts ...aletheia.EnqueueOption) error {
+	if q.EnqueueBatchFn != nil {
+		return q.EnqueueBatchFn(ctx, jobs, callback, opts...)
 	}
-	if job.Status == "" {
-		job.Status = aletheia.JobStatusPending
+
+	if len(jobs) == 0 {
+		return aletheia.Invalid("Batch must contain at least one job")
 	}
-	if job.CreatedAt.IsZero() {
-		job.CreatedAt = time.Now()
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	ids := make([]uuid.UUID, len(jobs))
+	for i, job := range jobs {
+		aletheia.PrepareJob(job, opts...)
+		if err := q.check(job); err != nil {
+			return err
+		}
+		ids[i] = job.ID
 	}
-	if job.ScheduledAt.IsZero() {
-		job.ScheduledAt = time.Now()
+	aletheia.PrepareJob(callback, aletheia.WithDependsOn(ids...))
+	if err := callback.ValidateDependencies(); err != nil {
+		return err
 	}
-	if job.MaxAttempts == 0 {
-		job.MaxAttempts = 3
+	for _, id := range callback.DependsOn {
+		if _, ok := q.jobs[id]; !ok && !slices.Contains(ids, id) {
+			return aletheia.Invalid("Prerequisite job not found")
+		}
 	}
 
-	q.jobs[job.ID] = job
-	return nil
+	for i, job := range jobs {
+		q.insert(job)
+		if job.ID != ids[i] {
+			// Wait on the active duplicate that replaced the job instead
+			callback.DependsOn = replaceID(callback.DependsOn, ids[i], job.ID)
+		}
+	}
+	return q.insert(callback)
+}
+
+// replaceID returns ids with old replaced by id, keeping each ID once.
+func replaceID(ids []uuid.UUID, old, id uuid.UUID) []uuid.UUID {
+	var out []uuid.UUID
+	for _, v := range ids {
+		if v == old {
+			v = id
+		}
+		if !slices.Contains(out, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func (q *Queue) EnqueueScheduled(ctx context.Context, schedule string, runAt time.Time, job *aletheia.Job, opts ...aletheia.EnqueueOption) (bool, error) {
+	if q.EnqueueScheduledFn != nil {
+		return q.EnqueueScheduledFn(ctx, schedule, runAt, job, opts...)
+	}
+
+	aletheia.PrepareJob(job, opts...)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	run := scheduledRun{schedule: schedule, runAt: runAt.UTC()}
+	if _, ok := q.runs[run]; ok {
+		return false, nil
+	}
+	if err := q.insert(job); err != nil {
+		return false, err
+	}
+	q.runs[run] = time.Now()
+	return true, nil
+}
+
+func (q *Queue) CleanupJobs(ctx context.Context, retention time.Duration) (int, error) {
+	if q.CleanupJobsFn != nil {
+		return q.CleanupJobsFn(ctx, retention)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	cutoff := time.Now().Add(-retention)
+	deleted := 0
+	for id, job := range q.jobs {
+		if job.Status.IsTerminal() && job.CompletedAt != nil && job.CompletedAt.Before(cutoff) {
+			delete(q.jobs, id)
+			deleted++
+		}
+	}
+	for run, claimedAt := range q.runs {
+		if claimedAt.Before(cutoff) {
+			delete(q.runs, run)
+		}
+	}
+	return deleted, nil
 }
 
-func (q *Queue) Dequeue(ctx context.Context, queueName string) (*aletheia.Job, error) {
+func (q *Queue) Listen(ctx context.Context, queueNames []string) (<-chan string, error) {
+	if q.ListenFn != nil {
+		return q.ListenFn(ctx, queueNames)
+	}
+
+	ch := make(chan string, len(queueNames))
+
+	q.mu.Lock()
+	q.listeners[ch] = queueNames
+	q.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		q.mu.Lock()
+		delete(q.listeners, ch)
+		close(ch)
+		q.mu.Unlock()
+	}()
+
+	return ch, nil
+}
+
+func (q *Queue) ListenJobEvents(ctx context.Context) (<-chan aletheia.JobChange, error) {
+	if q.ListenJobEventsFn != nil {
+		return q.ListenJobEventsFn(ctx)
+	}
+
+	ch := make(chan aletheia.JobChange, 64)
+
+	q.mu.Lock()
+	q.watchers[ch] = struct{}{}
+	q.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		q.mu.Lock()
+		delete(q.watchers, ch)
+		close(ch)
+		q.mu.Unlock()
+	}()
+
+	return ch, nil
+}
+
+func (q *Queue) Dequeue(ctx context.Context, queueName, workerID string) (*aletheia.Job, error) {
 	if q.DequeueFn != nil {
-		return q.DequeueFn(ctx, queueName)
+		return q.DequeueFn(ctx, queueName, workerID)
 	}
 
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
+	// Count each organization's running jobs and jobs started this hour.
+	now := time.Now()
+	running := map[uuid.UUID]int{}
+	started := map[uuid.UUID]int{}
 	for _, job := range q.jobs {
-		if job.QueueName == queueName && job.Status == aletheia.JobStatusPending {
-			job.Status = aletheia.JobStatusRunning
-			now := time.Now()
-			job.StartedAt = &now
-			return job, nil
+		if job.QueueName != queueName {
+			continue
+		}
+		if job.Status == aletheia.JobStatusRunning {
+			running[job.OrganizationID]++
+		}
+		if job.StartedAt != nil && job.StartedAt.After(now.Add(-time.Hour)) {
+			started[job.OrganizationID]++
+		}
+	}
+
+	// Pick the highest-priority job that is due, preferring organizations
+	// with fewer running jobs, then the oldest.
+	var next *aletheia.Job
+	for _, job := range q.jobs {
+		if job.QueueName != queueName || job.Status != aletheia.JobStatusPending || job.ScheduledAt.After(now) {
+			continue
+		}
+		if !q.dependenciesMet(job) {
+			continue
+		}
+		if q.Config.EnableRateLimiting {
+			maxPerHour, maxConcurrent := q.limits(job.OrganizationID, queueName)
+			if (maxConcurrent > 0 && running[job.OrganizationID] >= maxConcurrent) ||
+				(maxPerHour > 0 && started[job.OrganizationID] >= maxPerHour) {
+				continue
+			}
+		}
+		if next == nil || fairLess(job, next, running) {
+			next = job
+		}
+	}
+	if next == nil {
+		return nil, nil
+	}
+
+	next.Status = aletheia.JobStatusRunning
+	next.StartedAt = &now
+	next.HeartbeatAt = &now
+	next.WorkerID = workerID
+	next.AttemptCount++
+	next.Progress = 0
+	next.ProgressMessage = ""
+	q.notifyWatchers(aletheia.JobChange{JobID: next.ID})
+	return copyJob(next), nil
+}
+
+func (q *Queue) Heartbeat(ctx context.Context, jobID uuid.UUID, workerID string) error {
+	if q.HeartbeatFn != nil {
+		return q.HeartbeatFn(ctx, jobID, workerID)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job, ok := q.jobs[jobID]
+	if !ok {
+		return aletheia.NotFound("Job not found")
+	}
+	if job.Status != aletheia.JobStatusRunning || job.WorkerID != workerID {
+		return aletheia.Conflict("Job is no longer held by this worker")
+	}
+	now := time.Now()
+	job.HeartbeatAt = &now
+	return nil
+}
+
+func (q *Queue) UpdateProgress(ctx context.Context, jobID uuid.UUID, workerID string, percent int, message string) error {
+	if q.UpdateProgressFn != nil {
+		return q.UpdateProgressFn(ctx, jobID, workerID, percent, message)
+	}
+
+	if percent < 0 || percent > 100 {
+		return aletheia.Invalid("Progress must be between 0 and 100")
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job, ok := q.jobs[jobID]
+	if !ok {
+		return aletheia.NotFound("Job not found")
+	}
+	if job.Status != aletheia.JobStatusRunning || job.WorkerID != workerID {
+		return aletheia.Conflict("Job is no longer held by this worker")
+	}
+	now := time.Now()
+	job.Progress = percent
+	job.ProgressMessage = message
+	job.HeartbeatAt = &now
+	q.notifyWatchers(aletheia.JobChange{JobID: job.ID})
+	return nil
+}
+
+func (q *Queue) ReapStaleJobs(ctx context.Context, timeout time.Duration) (int, error) {
+	if q.ReapStaleJobsFn != nil {
+		return q.ReapStaleJobsFn(ctx, timeout)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	cutoff := now.Add(-timeout)
+	reaped := 0
+	for _, job := range q.jobs {
+		if job.Status != aletheia.JobStatusRunning || job.HeartbeatAt == nil || !job.HeartbeatAt.Before(cutoff) {
+			continue
+		}
+
+		msg := "worker " + job.WorkerID + " stopped sending heartbeats"
+		job.ErrorMessage = msg
+		job.Errors = append(job.Errors, aletheia.JobError{Attempt: job.AttemptCount, Message: msg, FailedAt: now})
+		job.WorkerID = ""
+		job.HeartbeatAt = nil
+		if job.AttemptCount >= job.MaxAttempts {
+			job.Status = aletheia.JobStatusDead
+			job.CompletedAt = &now
+			q.finishDependents(job.ID)
+		} else {
+			job.Status = aletheia.JobStatusPending
+			job.ScheduledAt = now
 		}
+		q.notifyWatchers(aletheia.JobChange{JobID: job.ID})
+		reaped++
 	}
-	return nil, nil
+	return reaped, nil
 }
 
-func (q *Queue) Complete(ctx context.Context, jobID uuid.UUID, result []byte) error {
+func (q *Queue) Complete(ctx context.Context, jobID uuid.UUID, workerID string, result []byte) error {
 	if q.CompleteFn != nil {
-		return q.CompleteFn(ctx, jobID, result)
+		return q.CompleteFn(ctx, jobID, workerID, result)
 	}
 
 	q.mu.Lock()
@@ -93,16 +390,26 @@ func (q *Queue) Complete(ctx context.Context, jobID uuid.UUID, result []byte) er
 	if !ok {
 		return aletheia.NotFound("Job not found")
 	}
+	if job.Status != aletheia.JobStatusRunning || job.WorkerID != workerID {
+		return aletheia.Conflict("Job is no longer held by this worker")
+	}
 	job.Status = aletheia.JobStatusCompleted
 	job.Result = result
+	job.Progress = 100
 	now := time.Now()
 	job.CompletedAt = &now
+	q.notifyWatchers(aletheia.JobChange{JobID: job.ID})
+	q.finishDependents(job.ID)
 	return nil
 }
 
-func (q *Queue) Fail(ctx context.Context, jobID uuid.UUID, errMsg string) error {
+func (q *Queue) Fail(ctx context.Context, jobID uuid.UUID, workerID string, jobErr error) error {
 	if q.FailFn != nil {
-		return q.FailFn(ctx, jobID, errMsg)
+		return q.FailFn(ctx, jobID, workerID, jobErr)
+	}
+
+	if jobErr == nil {
+		jobErr = errors.New("job failed")
 	}
 
 	q.mu.Lock()
@@ -112,10 +419,29 @@ func (q *Queue) Fail(ctx context.Context, jobID uuid.UUID, errMsg string) error
 	if !ok {
 		return aletheia.NotFound("Job not found")
 	}
-	job.Status = aletheia.JobStatusFailed
-	job.ErrorMessage = errMsg
+	if job.Status != aletheia.JobStatusRunning || job.WorkerID != workerID {
+		return aletheia.Conflict("Job is no longer held by this worker")
+	}
+
 	now := time.Now()
+	job.ErrorMessage = jobErr.Error()
+	job.Errors = append(job.Errors, aletheia.JobError{
+		Attempt:  job.AttemptCount,
+		Message:  jobErr.Error(),
+		FailedAt: now,
+	})
+
+	if job.CanRetry(jobErr) {
+		job.Status = aletheia.JobStatusPending
+		job.ScheduledAt = now.Add(q.Config.RetryDelay(job.AttemptCount))
+		q.notifyWatchers(aletheia.JobChange{JobID: job.ID})
+		return nil
+	}
+
+	job.Status = job.FailedStatus(jobErr)
 	job.CompletedAt = &now
+	q.notifyWatchers(aletheia.JobChange{JobID: job.ID})
+	q.finishDependents(job.ID)
 	return nil
 }
 
@@ -131,7 +457,7 @@ func (q *Queue) GetJob(ctx context.Context, jobID uuid.UUID) (*aletheia.Job, err
 	if !ok {
 		return nil, aletheia.NotFound("Job not found")
 	}
-	return job, nil
+	return copyJob(job), nil
 }
 
 func (q *Queue) CancelJob(ctx context.Context, jobID uuid.UUID) error {
@@ -150,6 +476,10 @@ func (q *Queue) CancelJob(ctx context.Context, jobID uuid.UUID) error {
 		return aletheia.Invalid("Can only cancel pending jobs")
 	}
 	job.Status = aletheia.JobStatusCancelled
+	now := time.Now()
+	job.CompletedAt = &now
+	q.notifyWatchers(aletheia.JobChange{JobID: job.ID})
+	q.finishDependents(job.ID)
 	return nil
 }
 
@@ -161,20 +491,345 @@ func (q *Queue) GetPendingJobs(ctx context.Context, orgID uuid.UUID, queueName s
 	q.mu.RLock()
 	defer q.mu.RUnlock()
 
-	var result []*aletheia.Job
+	result := []*aletheia.Job{}
 	for _, job := range q.jobs {
 		if job.OrganizationID == orgID && job.QueueName == queueName && job.Status == aletheia.JobStatusPending {
-			result = append(result, job)
+			result = append(result, copyJob(job))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return jobLess(result[i], result[j]) })
+	return result, nil
+}
+
+func (q *Queue) GetRunningJobs(ctx context.Context, queueName string) ([]*aletheia.Job, error) {
+	if q.GetRunningJobsFn != nil {
+		return q.GetRunningJobsFn(ctx, queueName)
+	}
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	result := []*aletheia.Job{}
+	for _, job := range q.jobs {
+		if job.Status == aletheia.JobStatusRunning && (queueName == "" || job.QueueName == queueName) {
+			result = append(result, copyJob(job))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].StartedAt.Before(*result[j].StartedAt) })
+	return result, nil
+}
+
+func (q *Queue) ListJobs(ctx context.Context, filter aletheia.JobFilter) ([]*aletheia.Job, int, error) {
+	if q.ListJobsFn != nil {
+		return q.ListJobsFn(ctx, filter)
+	}
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	result := []*aletheia.Job{}
+	for _, job := range q.jobs {
+		if jobMatches(job, filter) {
+			result = append(result, copyJob(job))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.After(result[j].CreatedAt) })
+
+	total := len(result)
+	if filter.Offset > 0 {
+		result = result[min(filter.Offset, len(result)):]
+	}
+	if filter.Limit > 0 && filter.Limit < len(result) {
+		result = result[:filter.Limit]
+	}
+	return result, total, nil
+}
+
+func (q *Queue) RetryJob(ctx context.Context, jobID uuid.UUID) error {
+	if q.RetryJobFn != nil {
+		return q.RetryJobFn(ctx, jobID)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job, ok := q.jobs[jobID]
+	if !ok {
+		return aletheia.NotFound("Job not found")
+	}
+	if !job.Status.IsRetryable() {
+		return aletheia.Invalid("Can only retry failed, dead, or cancelled jobs")
+	}
+	q.retry(job)
+	return nil
+}
+
+func (q *Queue) RetryJobs(ctx context.Context, filter aletheia.JobFilter) (int, error) {
+	if q.RetryJobsFn != nil {
+		return q.RetryJobsFn(ctx, filter)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	retried := 0
+	for _, job := range q.jobs {
+		if job.Status.IsRetryable() && jobMatches(job, filter) {
+			q.retry(job)
+			retried++
+		}
+	}
+	return retried, nil
+}
+
+func (q *Queue) CancelJobs(ctx context.Context, filter aletheia.JobFilter) (int, error) {
+	if q.CancelJobsFn != nil {
+		return q.CancelJobsFn(ctx, filter)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	var cancelled []uuid.UUID
+	for _, job := range q.jobs {
+		if job.Status == aletheia.JobStatusPending && jobMatches(job, filter) {
+			job.Status = aletheia.JobStatusCancelled
+			job.CompletedAt = &now
+			cancelled = append(cancelled, job.ID)
+		}
+	}
+	for _, id := range cancelled {
+		q.notifyWatchers(aletheia.JobChange{JobID: id})
+		q.finishDependents(id)
+	}
+	return len(cancelled), nil
+}
+
+func (q *Queue) ListRateLimits(ctx context.Context, orgID uuid.UUID) ([]*aletheia.RateLimit, error) {
+	if q.ListRateLimitsFn != nil {
+		return q.ListRateLimitsFn(ctx, orgID)
+	}
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	result := []*aletheia.RateLimit{}
+	for key, limit := range q.rateLimits {
+		if key.orgID == orgID {
+			c := *limit
+			result = append(result, &c)
 		}
 	}
+	sort.Slice(result, func(i, j int) bool { return result[i].QueueName < result[j].QueueName })
 	return result, nil
 }
 
-// Reset clears all jobs from the mock queue.
+func (q *Queue) SetRateLimit(ctx context.Context, limit *aletheia.RateLimit) error {
+	if q.SetRateLimitFn != nil {
+		return q.SetRateLimitFn(ctx, limit)
+	}
+
+	if err := limit.Validate(); err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	key := rateLimitKey{orgID: limit.OrganizationID, queueName: limit.QueueName}
+	now := time.Now()
+	limit.CreatedAt = now
+	if existing, ok := q.rateLimits[key]; ok {
+		limit.CreatedAt = existing.CreatedAt
+	}
+	limit.UpdatedAt = now
+
+	c := *limit
+	q.rateLimits[key] = &c
+	return nil
+}
+
+func (q *Queue) DeleteRateLimit(ctx context.Context, orgID uuid.UUID, queueName string) error {
+	if q.DeleteRateLimitFn != nil {
+		return q.DeleteRateLimitFn(ctx, orgID, queueName)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	key := rateLimitKey{orgID: orgID, queueName: queueName}
+	if _, ok := q.rateLimits[key]; !ok {
+		return aletheia.NotFound("Rate limit not found")
+	}
+	delete(q.rateLimits, key)
+	return nil
+}
+
+// check returns EINVALID if a prepared job's dependencies are invalid or
+// missing. The caller must hold q.mu.
+func (q *Queue) check(job *aletheia.Job) error {
+	if err := job.ValidateDependencies(); err != nil {
+		return err
+	}
+	for _, id := range job.DependsOn {
+		if _, ok := q.jobs[id]; !ok {
+			return aletheia.Invalid("Prerequisite job not found")
+		}
+	}
+	return nil
+}
+
+// insert stores a prepared job, resolving it at once if a prerequisite has
+// already failed, and wakes listeners. A unique job with an active duplicate
+// is replaced by the duplicate instead. The caller must hold q.mu.
+func (q *Queue) insert(job *aletheia.Job) error {
+	if err := q.check(job); err != nil {
+		return err
+	}
+
+	if existing := q.duplicateOf(job); existing != nil {
+		*job = *copyJob(existing)
+		return nil
+	}
+
+	stored := copyJob(job)
+	q.jobs[job.ID] = stored
+	q.resolve(stored)
+	job.Status = stored.Status
+	job.ErrorMessage = stored.ErrorMessage
+	job.CompletedAt = stored.CompletedAt
+	q.notifyWatchers(aletheia.JobChange{JobID: job.ID, InspectionID: job.InspectionID})
+
+	if job.Status == aletheia.JobStatusPending && !job.ScheduledAt.After(time.Now()) {
+		q.notifyListeners(job.QueueName)
+	}
+	return nil
+}
+
+// duplicateOf returns the newest active duplicate of a unique job, or nil.
+// The caller must hold q.mu.
+func (q *Queue) duplicateOf(job *aletheia.Job) *aletheia.Job {
+	var newest *aletheia.Job
+	for _, existing := range q.jobs {
+		if job.DuplicateOf(existing) && (newest == nil || existing.CreatedAt.After(newest.CreatedAt)) {
+			newest = existing
+		}
+	}
+	return newest
+}
+
+// dependenciesMet reports whether job's prerequisites have completed or,
+// if it continues regardless, finished. Deleted prerequisites count as met.
+// The caller must hold q.mu.
+func (q *Queue) dependenciesMet(job *aletheia.Job) bool {
+	for _, id := range job.DependsOn {
+		dep, ok := q.jobs[id]
+		if !ok || dep.Status == aletheia.JobStatusCompleted {
+			continue
+		}
+		if job.DependencyPolicy != aletheia.DependencyPolicyContinue || !dep.Status.IsTerminal() {
+			return false
+		}
+	}
+	return true
+}
+
+// resolve fails or skips a pending job with a prerequisite that did not
+// complete, as its policy requires, and resolves its own dependents in
+// turn. The caller must hold q.mu.
+func (q *Queue) resolve(job *aletheia.Job) {
+	status := job.DependencyPolicy.ResolvedStatus()
+	if job.Status != aletheia.JobStatusPending || status == "" {
+		return
+	}
+	for _, id := range job.DependsOn {
+		dep, ok := q.jobs[id]
+		if ok && dep.Status.IsTerminal() && dep.Status != aletheia.JobStatusCompleted {
+			now := time.Now()
+			job.Status = status
+			job.CompletedAt = &now
+			job.ErrorMessage = aletheia.DependencyFailedMessage
+			q.notifyWatchers(aletheia.JobChange{JobID: job.ID})
+			q.finishDependents(job.ID)
+			return
+		}
+	}
+}
+
+// finishDependents resolves the pending dependents of a job that has
+// finished and wakes listeners for any that may now run. The caller must
+// hold q.mu.
+func (q *Queue) finishDependents(jobID uuid.UUID) {
+	for _, job := range q.jobs {
+		if job.Status != aletheia.JobStatusPending || !slices.Contains(job.DependsOn, jobID) {
+			continue
+		}
+		q.resolve(job)
+		if job.Status == aletheia.JobStatusPending {
+			q.notifyListeners(job.QueueName)
+		}
+	}
+}
+
+// limits returns an organization's hourly and concurrent job limits for a
+// queue, where zero is unlimited. The caller must hold q.mu.
+func (q *Queue) limits(orgID uuid.UUID, queueName string) (maxPerHour, maxConcurrent int) {
+	if limit, ok := q.rateLimits[rateLimitKey{orgID: orgID, queueName: queueName}]; ok {
+		return limit.MaxJobsPerHour, limit.MaxConcurrentJobs
+	}
+	return q.Config.DefaultMaxJobsPerHour, q.Config.DefaultMaxConcurrentJobs
+}
+
+// retry resets job for a fresh round of attempts and wakes listeners.
+// The caller must hold q.mu.
+func (q *Queue) retry(job *aletheia.Job) {
+	job.Status = aletheia.JobStatusPending
+	job.AttemptCount = 0
+	job.ScheduledAt = time.Now()
+	job.StartedAt = nil
+	job.CompletedAt = nil
+	job.Result = nil
+	job.ErrorMessage = ""
+	job.WorkerID = ""
+	job.HeartbeatAt = nil
+	job.Progress = 0
+	job.ProgressMessage = ""
+	q.notifyListeners(job.QueueName)
+	q.notifyWatchers(aletheia.JobChange{JobID: job.ID})
+}
+
+// notifyListeners wakes listeners subscribed to queueName. The caller must
+// hold q.mu.
+func (q *Queue) notifyListeners(queueName string) {
+	for ch, queueNames := range q.listeners {
+		if slices.Contains(queueNames, queueName) {
+			select {
+			case ch <- queueName:
+			default:
+			}
+		}
+	}
+}
+
+// notifyWatchers tells job event listeners that a job was enqueued or
+// changed. The caller must hold q.mu.
+func (q *Queue) notifyWatchers(change aletheia.JobChange) {
+	for ch := range q.watchers {
+		select {
+		case ch <- change:
+		default:
+		}
+	}
+}
+
+// Reset clears all jobs and rate limits from the mock queue.
 func (q *Queue) Reset() {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 	q.jobs = make(map[uuid.UUID]*aletheia.Job)
+	q.rateLimits = make(map[rateLimitKey]*aletheia.RateLimit)
+	q.runs = make(map[scheduledRun]time.Time)
 }
 
 // AllJobs returns all jobs in the mock queue.
@@ -184,7 +839,7 @@ func (q *Queue) AllJobs() []*aletheia.Job {
 
 	result := make([]*aletheia.Job, 0, len(q.jobs))
 	for _, job := range q.jobs {
-		result = append(result, job)
+		result = append(result, copyJob(job))
 	}
 	return result
 }
@@ -197,8 +852,57 @@ func (q *Queue) JobsByType(jobType string) []*aletheia.Job {
 	var result []*aletheia.Job
 	for _, job := range q.jobs {
 		if job.JobType == jobType {
-			result = append(result, job)
+			result = append(result, copyJob(job))
 		}
 	}
 	return result
 }
+
+// jobLess orders jobs the way the queue dequeues them: highest priority
+// first, then oldest first.
+func jobLess(a, b *aletheia.Job) bool {
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+	return a.CreatedAt.Before(b.CreatedAt)
+}
+
+// fairLess orders jobs the way Dequeue picks them: highest priority first,
+// then organizations with the fewest running jobs, then oldest first.
+func fairLess(a, b *aletheia.Job, running map[uuid.UUID]int) bool {
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+	if ra, rb := running[a.OrganizationID], running[b.OrganizationID]; ra != rb {
+		return ra < rb
+	}
+	return a.CreatedAt.Before(b.CreatedAt)
+}
+
+// jobMatches reports whether job satisfies filter, ignoring pagination.
+func jobMatches(job *aletheia.Job, filter aletheia.JobFilter) bool {
+	if filter.QueueName != nil && job.QueueName != *filter.QueueName {
+		return false
+	}
+	if filter.JobType != nil && job.JobType != *filter.JobType {
+		return false
+	}
+	if filter.Status != nil && job.Status != *filter.Status {
+		return false
+	}
+	if filter.OrganizationID != nil && job.OrganizationID != *filter.OrganizationID {
+		return false
+	}
+	if filter.InspectionID != nil && job.InspectionID != *filter.InspectionID {
+		return false
+	}
+	return true
+}
+
+// copyJob returns a copy of job so callers cannot mutate stored state.
+func copyJob(job *aletheia.Job) *aletheia.Job {
+	c := *job
+	c.Errors = slices.Clone(job.Errors)
+	c.DependsOn = slices.Clone(job.DependsOn)
+	return &c
+}
@@ -0,0 +1,15 @@
+package mock
+
+import (
+	"testing"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/queue/queuetest"
+	"github.com/google/uuid"
+)
+
+func TestQueue_Conformance(t *testing.T) {
+	queuetest.Run(t, func(t *testing.T) (aletheia.Queue, queuetest.OrgFactory) {
+		return NewQueue(), func(t *testing.T) uuid.UUID { return uuid.New() }
+	})
+}
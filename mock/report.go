@@ -0,0 +1,135 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/google/uuid"
+)
+
+// Compile-time interface check
+var _ aletheia.ReportService = (*ReportService)(nil)
+
+// ReportService is a mock implementation of aletheia.ReportService.
+type ReportService struct {
+	FindReportByIDFn     func(ctx context.Context, id uuid.UUID) (*aletheia.Report, error)
+	FindReportsFn        func(ctx context.Context, inspectionID uuid.UUID) ([]*aletheia.Report, error)
+	FindReportBySHA256Fn func(ctx context.Context, sha256 string) (*aletheia.Report, error)
+	CreateReportFn       func(ctx context.Context, report *aletheia.Report) error
+
+	FindReportDeliveryByIDFn    func(ctx context.Context, id uuid.UUID) (*aletheia.ReportDelivery, error)
+	FindReportDeliveriesFn      func(ctx context.Context, reportID uuid.UUID) ([]*aletheia.ReportDelivery, error)
+	CreateReportDeliveryFn      func(ctx context.Context, delivery *aletheia.ReportDelivery) error
+	MarkReportDeliverySentFn    func(ctx context.Context, id uuid.UUID, messageID string) error
+	MarkReportDeliveryFailedFn  func(ctx context.Context, id uuid.UUID, reason string) error
+	MarkReportDeliveryBouncedFn func(ctx context.Context, messageID, reason string, bouncedAt time.Time) error
+
+	FindSummaryReportByIDFn func(ctx context.Context, id uuid.UUID) (*aletheia.SummaryReport, error)
+	FindSummaryReportsFn    func(ctx context.Context, projectID uuid.UUID) ([]*aletheia.SummaryReport, error)
+	CreateSummaryReportFn   func(ctx context.Context, report *aletheia.SummaryReport) error
+}
+
+func (s *ReportService) FindReportByID(ctx context.Context, id uuid.UUID) (*aletheia.Report, error) {
+	if s.FindReportByIDFn != nil {
+		return s.FindReportByIDFn(ctx, id)
+	}
+	return nil, aletheia.NotFound("Report not found")
+}
+
+func (s *ReportService) FindReports(ctx context.Context, inspectionID uuid.UUID) ([]*aletheia.Report, error) {
+	if s.FindReportsFn != nil {
+		return s.FindReportsFn(ctx, inspectionID)
+	}
+	return []*aletheia.Report{}, nil
+}
+
+func (s *ReportService) FindReportBySHA256(ctx context.Context, sha256 string) (*aletheia.Report, error) {
+	if s.FindReportBySHA256Fn != nil {
+		return s.FindReportBySHA256Fn(ctx, sha256)
+	}
+	return nil, aletheia.NotFound("Report not found")
+}
+
+func (s *ReportService) CreateReport(ctx context.Context, report *aletheia.Report) error {
+	if s.CreateReportFn != nil {
+		return s.CreateReportFn(ctx, report)
+	}
+	if report.ID == uuid.Nil {
+		report.ID = uuid.New()
+	}
+	report.CreatedAt = time.Now()
+	return nil
+}
+
+func (s *ReportService) FindReportDeliveryByID(ctx context.Context, id uuid.UUID) (*aletheia.ReportDelivery, error) {
+	if s.FindReportDeliveryByIDFn != nil {
+		return s.FindReportDeliveryByIDFn(ctx, id)
+	}
+	return nil, aletheia.NotFound("Report delivery not found")
+}
+
+func (s *ReportService) FindReportDeliveries(ctx context.Context, reportID uuid.UUID) ([]*aletheia.ReportDelivery, error) {
+	if s.FindReportDeliveriesFn != nil {
+		return s.FindReportDeliveriesFn(ctx, reportID)
+	}
+	return []*aletheia.ReportDelivery{}, nil
+}
+
+func (s *ReportService) CreateReportDelivery(ctx context.Context, delivery *aletheia.ReportDelivery) error {
+	if s.CreateReportDeliveryFn != nil {
+		return s.CreateReportDeliveryFn(ctx, delivery)
+	}
+	if delivery.ID == uuid.Nil {
+		delivery.ID = uuid.New()
+	}
+	delivery.Status = aletheia.DeliveryStatusPending
+	delivery.CreatedAt = time.Now()
+	return nil
+}
+
+func (s *ReportService) MarkReportDeliverySent(ctx context.Context, id uuid.UUID, messageID string) error {
+	if s.MarkReportDeliverySentFn != nil {
+		return s.MarkReportDeliverySentFn(ctx, id, messageID)
+	}
+	return nil
+}
+
+func (s *ReportService) MarkReportDeliveryFailed(ctx context.Context, id uuid.UUID, reason string) error {
+	if s.MarkReportDeliveryFailedFn != nil {
+		return s.MarkReportDeliveryFailedFn(ctx, id, reason)
+	}
+	return nil
+}
+
+func (s *ReportService) MarkReportDeliveryBounced(ctx context.Context, messageID, reason string, bouncedAt time.Time) error {
+	if s.MarkReportDeliveryBouncedFn != nil {
+		return s.MarkReportDeliveryBouncedFn(ctx, messageID, reason, bouncedAt)
+	}
+	return aletheia.NotFound("Report delivery not found")
+}
+
+func (s *ReportService) FindSummaryReportByID(ctx context.Context, id uuid.UUID) (*aletheia.SummaryReport, error) {
+	if s.FindSummaryReportByIDFn != nil {
+		return s.FindSummaryReportByIDFn(ctx, id)
+	}
+	return nil, aletheia.NotFound("Summary report not found")
+}
+
+func (s *ReportService) FindSummaryReports(ctx context.Context, projectID uuid.UUID) ([]*aletheia.SummaryReport, error) {
+	if s.FindSummaryReportsFn != nil {
+		return s.FindSummaryReportsFn(ctx, projectID)
+	}
+	return []*aletheia.SummaryReport{}, nil
+}
+
+func (s *ReportService) CreateSummaryReport(ctx context.Context, report *aletheia.SummaryReport) error {
+	if s.CreateSummaryReportFn != nil {
+		return s.CreateSummaryReportFn(ctx, report)
+	}
+	if report.ID == uuid.Nil {
+		report.ID = uuid.New()
+	}
+	report.CreatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,90 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/google/uuid"
+)
+
+// Compile-time interface check
+var _ aletheia.ShareLinkService = (*ShareLinkService)(nil)
+
+// ShareLinkService is a mock implementation of aletheia.ShareLinkService.
+type ShareLinkService struct {
+	FindShareLinkByIDFn       func(ctx context.Context, id uuid.UUID) (*aletheia.ShareLink, error)
+	FindShareLinkByTokenFn    func(ctx context.Context, token string) (*aletheia.ShareLink, error)
+	FindShareLinksFn          func(ctx context.Context, inspectionID uuid.UUID) ([]*aletheia.ShareLink, error)
+	CreateShareLinkFn         func(ctx context.Context, link *aletheia.ShareLink, password string) error
+	RevokeShareLinkFn         func(ctx context.Context, id uuid.UUID) (*aletheia.ShareLink, error)
+	VerifyShareLinkPasswordFn func(ctx context.Context, link *aletheia.ShareLink, password string) error
+	RecordShareLinkViewFn     func(ctx context.Context, view *aletheia.ShareLinkView) error
+	FindShareLinkViewsFn      func(ctx context.Context, shareLinkID uuid.UUID, limit int) ([]*aletheia.ShareLinkView, error)
+}
+
+func (s *ShareLinkService) FindShareLinkByID(ctx context.Context, id uuid.UUID) (*aletheia.ShareLink, error) {
+	if s.FindShareLinkByIDFn != nil {
+		return s.FindShareLinkByIDFn(ctx, id)
+	}
+	return nil, aletheia.NotFound("Share link not found")
+}
+
+func (s *ShareLinkService) FindShareLinkByToken(ctx context.Context, token string) (*aletheia.ShareLink, error) {
+	if s.FindShareLinkByTokenFn != nil {
+		return s.FindShareLinkByTokenFn(ctx, token)
+	}
+	return nil, aletheia.NotFound("Share link not found")
+}
+
+func (s *ShareLinkService) FindShareLinks(ctx context.Context, inspectionID uuid.UUID) ([]*aletheia.ShareLink, error) {
+	if s.FindShareLinksFn != nil {
+		return s.FindShareLinksFn(ctx, inspectionID)
+	}
+	return []*aletheia.ShareLink{}, nil
+}
+
+func (s *ShareLinkService) CreateShareLink(ctx context.Context, link *aletheia.ShareLink, password string) error {
+	if s.CreateShareLinkFn != nil {
+		return s.CreateShareLinkFn(ctx, link, password)
+	}
+	if link.ID == uuid.Nil {
+		link.ID = uuid.New()
+	}
+	link.Token = uuid.NewString()
+	link.HasPassword = password != ""
+	link.CreatedAt = time.Now()
+	return nil
+}
+
+func (s *ShareLinkService) RevokeShareLink(ctx context.Context, id uuid.UUID) (*aletheia.ShareLink, error) {
+	if s.RevokeShareLinkFn != nil {
+		return s.RevokeShareLinkFn(ctx, id)
+	}
+	return nil, aletheia.NotFound("Share link not found")
+}
+
+func (s *ShareLinkService) VerifyShareLinkPassword(ctx context.Context, link *aletheia.ShareLink, password string) error {
+	if s.VerifyShareLinkPasswordFn != nil {
+		return s.VerifyShareLinkPasswordFn(ctx, link, password)
+	}
+	return nil
+}
+
+func (s *ShareLinkService) RecordShareLinkView(ctx context.Context, view *aletheia.ShareLinkView) error {
+	if s.RecordShareLinkViewFn != nil {
+		return s.RecordShareLinkViewFn(ctx, view)
+	}
+	if view.ID == uuid.Nil {
+		view.ID = uuid.New()
+	}
+	view.ViewedAt = time.Now()
+	return nil
+}
+
+func (s *ShareLinkService) FindShareLinkViews(ctx context.Context, shareLinkID uuid.UUID, limit int) ([]*aletheia.ShareLinkView, error) {
+	if s.FindShareLinkViewsFn != nil {
+		return s.FindShareLinkViewsFn(ctx, shareLinkID, limit)
+	}
+	return []*aletheia.ShareLinkView{}, nil
+}
@@ -2,6 +2,7 @@ package mock
 
 import (
 	"context"
+	"fmt"
 	"io"
 
 	"github.com/dukerupert/aletheia"
@@ -16,6 +17,12 @@ type FileStorage struct {
 	DeleteFn  func(ctx context.Context, key string) error
 	GetURLFn  func(key string) string
 	ExistsFn  func(ctx context.Context, key string) (bool, error)
+	OpenFn    func(ctx context.Context, key string) (io.ReadCloser, error)
+
+	CreateMultipartUploadFn   func(ctx context.Context, key string, contentType string) (string, error)
+	UploadPartFn              func(ctx context.Context, key string, uploadID string, partNumber int, reader io.Reader) (string, error)
+	CompleteMultipartUploadFn func(ctx context.Context, key string, uploadID string, parts []aletheia.StoragePart) (string, error)
+	AbortMultipartUploadFn    func(ctx context.Context, key string, uploadID string) error
 }
 
 func (s *FileStorage) Upload(ctx context.Context, key string, reader io.Reader, contentType string) (string, error) {
@@ -45,3 +52,38 @@ func (s *FileStorage) Exists(ctx context.Context, key string) (bool, error) {
 	}
 	return false, nil
 }
+
+func (s *FileStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	if s.OpenFn != nil {
+		return s.OpenFn(ctx, key)
+	}
+	return nil, aletheia.NotFound("File not found")
+}
+
+func (s *FileStorage) CreateMultipartUpload(ctx context.Context, key string, contentType string) (string, error) {
+	if s.CreateMultipartUploadFn != nil {
+		return s.CreateMultipartUploadFn(ctx, key, contentType)
+	}
+	return "mock-upload-id", nil
+}
+
+func (s *FileStorage) UploadPart(ctx context.Context, key string, uploadID string, partNumber int, reader io.Reader) (string, error) {
+	if s.UploadPartFn != nil {
+		return s.UploadPartFn(ctx, key, uploadID, partNumber, reader)
+	}
+	return fmt.Sprintf("mock-etag-%d", partNumber), nil
+}
+
+func (s *FileStorage) CompleteMultipartUpload(ctx context.Context, key string, uploadID string, parts []aletheia.StoragePart) (string, error) {
+	if s.CompleteMultipartUploadFn != nil {
+		return s.CompleteMultipartUploadFn(ctx, key, uploadID, parts)
+	}
+	return "https://mock-storage.example.com/" + key, nil
+}
+
+func (s *FileStorage) AbortMultipartUpload(ctx context.Context, key string, uploadID string) error {
+	if s.AbortMultipartUploadFn != nil {
+		return s.AbortMultipartUploadFn(ctx, key, uploadID)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/google/uuid"
+)
+
+// Compile-time interface check
+var _ aletheia.UploadService = (*UploadService)(nil)
+
+// UploadService is a mock implementation of aletheia.UploadService.
+type UploadService struct {
+	FindUploadByIDFn        func(ctx context.Context, id uuid.UUID) (*aletheia.Upload, error)
+	CreateUploadFn          func(ctx context.Context, upload *aletheia.Upload) error
+	WriteUploadChunkFn      func(ctx context.Context, id uuid.UUID, offset int64, data []byte) (*aletheia.Upload, error)
+	UpdateUploadFn          func(ctx context.Context, id uuid.UUID, upd aletheia.UploadUpdate) (*aletheia.Upload, error)
+	AbortUploadFn           func(ctx context.Context, id uuid.UUID) error
+	CleanupExpiredUploadsFn func(ctx context.Context) (int, error)
+}
+
+func (s *UploadService) FindUploadByID(ctx context.Context, id uuid.UUID) (*aletheia.Upload, error) {
+	if s.FindUploadByIDFn != nil {
+		return s.FindUploadByIDFn(ctx, id)
+	}
+	return nil, aletheia.NotFound("Upload not found")
+}
+
+func (s *UploadService) CreateUpload(ctx context.Context, upload *aletheia.Upload) error {
+	if s.CreateUploadFn != nil {
+		return s.CreateUploadFn(ctx, upload)
+	}
+	if upload.ID == uuid.Nil {
+		upload.ID = uuid.New()
+	}
+	upload.Status = aletheia.UploadStatusInProgress
+	upload.ExpiresAt = time.Now().Add(aletheia.DefaultUploadExpiry)
+	upload.CreatedAt = time.Now()
+	upload.UpdatedAt = time.Now()
+	return nil
+}
+
+func (s *UploadService) WriteUploadChunk(ctx context.Context, id uuid.UUID, offset int64, data []byte) (*aletheia.Upload, error) {
+	if s.WriteUploadChunkFn != nil {
+		return s.WriteUploadChunkFn(ctx, id, offset, data)
+	}
+	return nil, aletheia.NotFound("Upload not found")
+}
+
+func (s *UploadService) UpdateUpload(ctx context.Context, id uuid.UUID, upd aletheia.UploadUpdate) (*aletheia.Upload, error) {
+	if s.UpdateUploadFn != nil {
+		return s.UpdateUploadFn(ctx, id, upd)
+	}
+	return nil, aletheia.NotFound("Upload not found")
+}
+
+func (s *UploadService) AbortUpload(ctx context.Context, id uuid.UUID) error {
+	if s.AbortUploadFn != nil {
+		return s.AbortUploadFn(ctx, id)
+	}
+	return nil
+}
+
+func (s *UploadService) CleanupExpiredUploads(ctx context.Context) (int, error) {
+	if s.CleanupExpiredUploadsFn != nil {
+		return s.CleanupExpiredUploadsFn(ctx)
+	}
+	return 0, nil
+}
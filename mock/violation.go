@@ -23,6 +23,7 @@ type ViolationService struct {
 	SetViolationPendingFn      func(ctx context.Context, id uuid.UUID) (*aletheia.Violation, error)
 	DeleteViolationFn          func(ctx context.Context, id uuid.UUID) error
 	GetViolationsByInspectionFn func(ctx context.Context, inspectionID uuid.UUID) ([]*aletheia.Violation, error)
+	ExportViolationsFn         func(ctx context.Context, filter aletheia.ViolationExportFilter, fn func(*aletheia.ViolationExportRow) error) error
 }
 
 func (s *ViolationService) FindViolationByID(ctx context.Context, id uuid.UUID) (*aletheia.Violation, error) {
@@ -109,3 +110,10 @@ func (s *ViolationService) GetViolationsByInspection(ctx context.Context, inspec
 	}
 	return []*aletheia.Violation{}, nil
 }
+
+func (s *ViolationService) ExportViolations(ctx context.Context, filter aletheia.ViolationExportFilter, fn func(*aletheia.ViolationExportRow) error) error {
+	if s.ExportViolationsFn != nil {
+		return s.ExportViolationsFn(ctx, filter, fn)
+	}
+	return nil
+}
@@ -9,10 +9,32 @@ import (
 
 // Organization represents a company or entity that conducts inspections.
 type Organization struct {
-	ID        uuid.UUID `json:"id"`
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID        uuid.UUID            `json:"id"`
+	Name      string               `json:"name"`
+	Branding  OrganizationBranding `json:"branding"`
+	CreatedAt time.Time            `json:"createdAt"`
+	UpdatedAt time.Time            `json:"updatedAt"`
+}
+
+// OrganizationBranding is how an organization's reports and report emails
+// look. Empty fields fall back to the defaults.
+type OrganizationBranding struct {
+	// LogoURL is the storage URL of the organization's logo.
+	LogoURL string `json:"logoUrl"`
+
+	// PrimaryColor is the accent color, as "#rrggbb".
+	PrimaryColor string `json:"primaryColor"`
+
+	// Address is the company address printed on reports.
+	Address string `json:"address"`
+
+	// ReportFooter is a disclaimer printed at the end of every report and
+	// report email.
+	ReportFooter string `json:"reportFooter"`
+
+	// ReportTemplate is the template reports are generated with unless
+	// another is requested.
+	ReportTemplate ReportTemplate `json:"reportTemplate"`
 }
 
 // OrganizationRole represents a user's role within an organization.
@@ -104,6 +126,13 @@ type OrganizationService interface {
 	// RequireMembership verifies a user is a member of an organization.
 	// Returns EFORBIDDEN if not a member or role is insufficient.
 	RequireMembership(ctx context.Context, orgID, userID uuid.UUID, allowedRoles ...OrganizationRole) (*OrganizationMember, error)
+
+	// Storage operations
+
+	// GetStorageUsage reports the bytes stored by an organization's photos
+	// and reports, and reserved by its uploads in progress, broken down by
+	// project.
+	GetStorageUsage(ctx context.Context, orgID uuid.UUID) (*StorageUsage, error)
 }
 
 // OrganizationFilter defines criteria for filtering organizations.
@@ -120,4 +149,11 @@ type OrganizationFilter struct {
 // OrganizationUpdate defines fields that can be updated on an organization.
 type OrganizationUpdate struct {
 	Name *string
+
+	// Branding
+	LogoURL        *string
+	PrimaryColor   *string
+	Address        *string
+	ReportFooter   *string
+	ReportTemplate *ReportTemplate
 }
@@ -13,6 +13,7 @@ type Photo struct {
 	InspectionID uuid.UUID `json:"inspectionId"`
 	StorageURL   string    `json:"storageUrl"`
 	ThumbnailURL string    `json:"thumbnailUrl,omitempty"`
+	SizeBytes    int64     `json:"sizeBytes"`
 	CreatedAt    time.Time `json:"createdAt"`
 
 	// Joined fields (populated by some queries)
@@ -10,16 +10,10 @@ import (
 // Compile-time interface check
 var _ aletheia.AIService = (*MockAIService)(nil)
 
-// NewAIService creates an AI service based on the provider configuration.
+// NewAIService creates the mock AI service. The Claude service lives in
+// internal/ai, which reads photos from storage.
 func NewAIService(logger *slog.Logger, cfg aletheia.AIConfig) aletheia.AIService {
-	switch cfg.Provider {
-	case "claude":
-		// TODO: Implement Claude AI service
-		logger.Warn("claude AI service not yet implemented, falling back to mock")
-		return &MockAIService{logger: logger}
-	default:
-		return &MockAIService{logger: logger}
-	}
+	return &MockAIService{logger: logger}
 }
 
 // MockAIService is a mock implementation that returns empty results.
@@ -28,10 +22,10 @@ type MockAIService struct {
 }
 
 // AnalyzePhoto returns a mock analysis result.
-func (s *MockAIService) AnalyzePhoto(ctx context.Context, photoURL string, safetyCodes []*aletheia.SafetyCode) (*aletheia.AnalysisResult, error) {
+func (s *MockAIService) AnalyzePhoto(ctx context.Context, req aletheia.AnalysisRequest) (*aletheia.AnalysisResult, error) {
 	s.logger.Info("MOCK AI: Analyzing photo",
-		slog.String("photo_url", photoURL),
-		slog.Int("safety_codes_count", len(safetyCodes)))
+		slog.String("photo_url", req.PhotoURL),
+		slog.Int("safety_codes_count", len(req.SafetyCodes)))
 
 	return &aletheia.AnalysisResult{
 		Violations: []aletheia.DetectedViolation{},
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"encoding/json"
 	"math/big"
 	"time"
 
@@ -89,6 +90,22 @@ func fromPgTimestampPtr(t pgtype.Timestamptz) *time.Time {
 
 // Numeric conversions
 
+// toPgFloat8Ptr converts a float64 pointer to pgtype.Float8.
+func toPgFloat8Ptr(f *float64) pgtype.Float8 {
+	if f == nil {
+		return pgtype.Float8{Valid: false}
+	}
+	return pgtype.Float8{Float64: *f, Valid: true}
+}
+
+// fromPgFloat8Ptr converts a pgtype.Float8 to float64 pointer (nil if not valid).
+func fromPgFloat8Ptr(f pgtype.Float8) *float64 {
+	if !f.Valid {
+		return nil
+	}
+	return &f.Float64
+}
+
 // toPgNumeric converts a float64 to pgtype.Numeric.
 func toPgNumeric(f float64) pgtype.Numeric {
 	if f == 0 {
@@ -139,8 +156,15 @@ func toDomainUsers(users []database.User) []*aletheia.User {
 
 func toDomainOrganization(o database.Organization) *aletheia.Organization {
 	return &aletheia.Organization{
-		ID:        fromPgUUID(o.ID),
-		Name:      o.Name,
+		ID:   fromPgUUID(o.ID),
+		Name: o.Name,
+		Branding: aletheia.OrganizationBranding{
+			LogoURL:        o.LogoUrl,
+			PrimaryColor:   o.PrimaryColor,
+			Address:        o.Address,
+			ReportFooter:   o.ReportFooter,
+			ReportTemplate: aletheia.ReportTemplate(o.ReportTemplate),
+		},
 		CreatedAt: fromPgTimestamp(o.CreatedAt),
 		UpdatedAt: fromPgTimestamp(o.UpdatedAt),
 	}
@@ -200,17 +224,60 @@ func toDomainProjects(projects []database.Project) []*aletheia.Project {
 	return result
 }
 
+func toDomainProjectStats(s database.GetProjectStatsRow) *aletheia.ProjectStats {
+	return &aletheia.ProjectStats{
+		ProjectID:           fromPgUUID(s.ProjectID),
+		InspectionCount:     int(s.InspectionCount),
+		PhotoCount:          int(s.PhotoCount),
+		ViolationCount:      int(s.ViolationCount),
+		OpenViolations:      int(s.OpenViolations),
+		ClosedViolations:    int(s.ClosedViolations),
+		ConfirmedViolations: int(s.ConfirmedViolations),
+		DismissedViolations: int(s.DismissedViolations),
+	}
+}
+
+func toDomainProjectContact(c database.ProjectContact) *aletheia.ProjectContact {
+	return &aletheia.ProjectContact{
+		ID:        fromPgUUID(c.ID),
+		ProjectID: fromPgUUID(c.ProjectID),
+		Name:      c.Name,
+		Email:     c.Email,
+		Role:      fromPgText(c.Role),
+		CreatedAt: fromPgTimestamp(c.CreatedAt),
+	}
+}
+
+func toDomainProjectContacts(contacts []database.ProjectContact) []*aletheia.ProjectContact {
+	result := make([]*aletheia.ProjectContact, len(contacts))
+	for i, c := range contacts {
+		result[i] = toDomainProjectContact(c)
+	}
+	return result
+}
+
 // Inspection conversions
 
 func toDomainInspection(i database.Inspection) *aletheia.Inspection {
-	return &aletheia.Inspection{
-		ID:          fromPgUUID(i.ID),
-		ProjectID:   fromPgUUID(i.ProjectID),
-		InspectorID: fromPgUUID(i.InspectorID),
-		Status:      aletheia.InspectionStatus(i.Status),
-		CreatedAt:   fromPgTimestamp(i.CreatedAt),
-		UpdatedAt:   fromPgTimestamp(i.UpdatedAt),
+	inspection := &aletheia.Inspection{
+		ID:             fromPgUUID(i.ID),
+		ProjectID:      fromPgUUID(i.ProjectID),
+		InspectorID:    fromPgUUID(i.InspectorID),
+		Status:         aletheia.InspectionStatus(i.Status),
+		Title:          i.Title,
+		InspectionType: fromPgText(i.InspectionType),
+		ScheduledAt:    fromPgTimestampPtr(i.ScheduledAt),
+		PerformedAt:    fromPgTimestampPtr(i.PerformedAt),
+		Notes:          fromPgText(i.Notes),
+		Weather:        fromPgText(i.Weather),
+		TemperatureF:   fromPgFloat8Ptr(i.TemperatureF),
+		CreatedAt:      fromPgTimestamp(i.CreatedAt),
+		UpdatedAt:      fromPgTimestamp(i.UpdatedAt),
+	}
+	if err := json.Unmarshal(i.Attendees, &inspection.Attendees); err != nil || inspection.Attendees == nil {
+		inspection.Attendees = []aletheia.Attendee{}
 	}
+	return inspection
 }
 
 func toDomainInspections(inspections []database.Inspection) []*aletheia.Inspection {
@@ -221,6 +288,45 @@ func toDomainInspections(inspections []database.Inspection) []*aletheia.Inspecti
 	return result
 }
 
+func toDomainInspectionStats(s database.ListInspectionStatsRow) *aletheia.InspectionStats {
+	return &aletheia.InspectionStats{
+		InspectionID:   fromPgUUID(s.InspectionID),
+		PhotoCount:     int(s.PhotoCount),
+		ViolationCount: int(s.ViolationCount),
+		PendingCount:   int(s.PendingCount),
+		ConfirmedCount: int(s.ConfirmedCount),
+		DismissedCount: int(s.DismissedCount),
+		CriticalCount:  int(s.CriticalCount),
+		HighCount:      int(s.HighCount),
+		MediumCount:    int(s.MediumCount),
+		LowCount:       int(s.LowCount),
+	}
+}
+
+// Sign-off conversions
+
+func toDomainSignoff(s database.InspectionSignoff) *aletheia.Signoff {
+	return &aletheia.Signoff{
+		ID:            fromPgUUID(s.ID),
+		InspectionID:  fromPgUUID(s.InspectionID),
+		Role:          aletheia.SignoffRole(s.Role),
+		SignerID:      fromPgUUID(s.SignerID),
+		SignerName:    s.SignerName,
+		SignatureKind: aletheia.SignatureKind(s.SignatureKind),
+		Signature:     s.Signature,
+		ContentHash:   s.ContentHash,
+		SignedAt:      fromPgTimestamp(s.SignedAt),
+	}
+}
+
+func toDomainSignoffs(signoffs []database.InspectionSignoff) []*aletheia.Signoff {
+	result := make([]*aletheia.Signoff, len(signoffs))
+	for i, s := range signoffs {
+		result[i] = toDomainSignoff(s)
+	}
+	return result
+}
+
 // Photo conversions
 
 func toDomainPhoto(p database.Photo) *aletheia.Photo {
@@ -229,6 +335,7 @@ func toDomainPhoto(p database.Photo) *aletheia.Photo {
 		InspectionID: fromPgUUID(p.InspectionID),
 		StorageURL:   p.StorageUrl,
 		ThumbnailURL: fromPgText(p.ThumbnailUrl),
+		SizeBytes:    p.SizeBytes,
 		CreatedAt:    fromPgTimestamp(p.CreatedAt),
 	}
 }
@@ -241,6 +348,140 @@ func toDomainPhotos(photos []database.Photo) []*aletheia.Photo {
 	return result
 }
 
+// Report conversions
+
+func toDomainReport(r database.Report) *aletheia.Report {
+	report := &aletheia.Report{
+		ID:           fromPgUUID(r.ID),
+		InspectionID: fromPgUUID(r.InspectionID),
+		Version:      int(r.Version),
+		StorageURL:   r.StorageUrl,
+		SizeBytes:    r.SizeBytes,
+		SnapshotHash: r.SnapshotHash,
+		GeneratedBy:  fromPgUUID(r.GeneratedBy),
+		Template:     aletheia.ReportTemplate(r.Template),
+		CreatedAt:    fromPgTimestamp(r.CreatedAt),
+		SHA256:       r.Sha256,
+		Signature:    r.Signature,
+		SigningKeyID: r.SigningKeyID,
+	}
+	// Reports from before snapshots were taken have an empty snapshot
+	_ = json.Unmarshal(r.Snapshot, &report.Snapshot)
+	if err := json.Unmarshal(r.Signoffs, &report.Signoffs); err != nil || report.Signoffs == nil {
+		report.Signoffs = []aletheia.Signoff{}
+	}
+	return report
+}
+
+func toDomainReports(reports []database.Report) []*aletheia.Report {
+	result := make([]*aletheia.Report, len(reports))
+	for i, r := range reports {
+		result[i] = toDomainReport(r)
+	}
+	return result
+}
+
+// Summary report conversions
+
+func toDomainSummaryReport(r database.SummaryReport) *aletheia.SummaryReport {
+	report := &aletheia.SummaryReport{
+		ID:          fromPgUUID(r.ID),
+		ProjectID:   fromPgUUID(r.ProjectID),
+		From:        fromPgTimestamp(r.PeriodStart),
+		To:          fromPgTimestamp(r.PeriodEnd),
+		StorageURL:  r.StorageUrl,
+		SizeBytes:   r.SizeBytes,
+		GeneratedBy: fromPgUUID(r.GeneratedBy),
+		CreatedAt:   fromPgTimestamp(r.CreatedAt),
+	}
+	var summary aletheia.ProjectSummary
+	if err := json.Unmarshal(r.Summary, &summary); err == nil {
+		report.Summary = &summary
+	}
+	return report
+}
+
+func toDomainSummaryReports(reports []database.SummaryReport) []*aletheia.SummaryReport {
+	result := make([]*aletheia.SummaryReport, len(reports))
+	for i, r := range reports {
+		result[i] = toDomainSummaryReport(r)
+	}
+	return result
+}
+
+// Report delivery conversions
+
+func toDomainReportDelivery(d database.ReportDelivery) *aletheia.ReportDelivery {
+	return &aletheia.ReportDelivery{
+		ID:                fromPgUUID(d.ID),
+		ReportID:          fromPgUUID(d.ReportID),
+		Recipient:         d.Recipient,
+		ContactID:         fromPgUUID(d.ContactID),
+		Attached:          d.Attached,
+		Message:           fromPgText(d.Message),
+		Status:            aletheia.DeliveryStatus(d.Status),
+		ProviderMessageID: fromPgText(d.ProviderMessageID),
+		Error:             fromPgText(d.Error),
+		SentBy:            fromPgUUID(d.SentBy),
+		SentAt:            fromPgTimestampPtr(d.SentAt),
+		BouncedAt:         fromPgTimestampPtr(d.BouncedAt),
+		CreatedAt:         fromPgTimestamp(d.CreatedAt),
+	}
+}
+
+func toDomainReportDeliveries(deliveries []database.ReportDelivery) []*aletheia.ReportDelivery {
+	result := make([]*aletheia.ReportDelivery, len(deliveries))
+	for i, d := range deliveries {
+		result[i] = toDomainReportDelivery(d)
+	}
+	return result
+}
+
+// Share link conversions
+
+func toDomainShareLink(l database.ShareLink) *aletheia.ShareLink {
+	return &aletheia.ShareLink{
+		ID:           fromPgUUID(l.ID),
+		InspectionID: fromPgUUID(l.InspectionID),
+		ReportID:     fromPgUUID(l.ReportID),
+		Kind:         aletheia.ShareLinkKind(l.Kind),
+		HasPassword:  l.PasswordHash.Valid,
+		ExpiresAt:    fromPgTimestamp(l.ExpiresAt),
+		RevokedAt:    fromPgTimestampPtr(l.RevokedAt),
+		ViewCount:    int(l.ViewCount),
+		LastViewedAt: fromPgTimestampPtr(l.LastViewedAt),
+		CreatedBy:    fromPgUUID(l.CreatedBy),
+		CreatedAt:    fromPgTimestamp(l.CreatedAt),
+		PasswordHash: fromPgText(l.PasswordHash),
+	}
+}
+
+func toDomainShareLinks(links []database.ShareLink) []*aletheia.ShareLink {
+	result := make([]*aletheia.ShareLink, len(links))
+	for i, l := range links {
+		result[i] = toDomainShareLink(l)
+	}
+	return result
+}
+
+func toDomainShareLinkView(v database.ShareLinkView) *aletheia.ShareLinkView {
+	return &aletheia.ShareLinkView{
+		ID:          fromPgUUID(v.ID),
+		ShareLinkID: fromPgUUID(v.ShareLinkID),
+		IPAddress:   fromPgText(v.IpAddress),
+		UserAgent:   fromPgText(v.UserAgent),
+		ViewedAt:    fromPgTimestamp(v.ViewedAt),
+	}
+}
+
+func toDomainShareLinkViews(views []database.ShareLinkView) []*aletheia.ShareLinkView {
+	result := make([]*aletheia.ShareLinkView, len(views))
+	for i, v := range views {
+		result[i] = toDomainShareLinkView(v)
+	}
+	return result
+}
+
 // Violation conversions
 
 func toDomainViolation(v database.DetectedViolation) *aletheia.Violation {
@@ -261,6 +502,7 @@ func toDomainViolation(v database.DetectedViolation) *aletheia.Violation {
 		ConfidenceScore: confidence,
 		Location:        fromPgText(v.Location),
 		CreatedAt:       fromPgTimestamp(v.CreatedAt),
+		ResolvedAt:      fromPgTimestampPtr(v.ResolvedAt),
 	}
 }
 
@@ -272,6 +514,27 @@ func toDomainViolations(violations []database.DetectedViolation) []*aletheia.Vio
 	return result
 }
 
+func toDomainViolationExportRow(r database.ListViolationsForExportRow) *aletheia.ViolationExportRow {
+	return &aletheia.ViolationExportRow{
+		ID:                    fromPgUUID(r.ID),
+		ProjectID:             fromPgUUID(r.ProjectID),
+		ProjectName:           r.ProjectName,
+		InspectionID:          fromPgUUID(r.InspectionID),
+		InspectionStatus:      aletheia.InspectionStatus(r.InspectionStatus),
+		InspectionDate:        fromPgTimestamp(r.InspectionDate),
+		PhotoID:               fromPgUUID(r.PhotoID),
+		PhotoURL:              r.PhotoUrl,
+		SafetyCode:            fromPgText(r.SafetyCode),
+		SafetyCodeDescription: fromPgText(r.SafetyCodeDescription),
+		Description:           r.Description,
+		Severity:              aletheia.Severity(r.Severity),
+		Status:                aletheia.ViolationStatus(r.Status),
+		Location:              fromPgText(r.Location),
+		CreatedAt:             fromPgTimestamp(r.CreatedAt),
+		ResolvedAt:            fromPgTimestampPtr(r.ResolvedAt),
+	}
+}
+
 // SafetyCode conversions
 
 func toDomainSafetyCode(s database.SafetyCode) *aletheia.SafetyCode {
@@ -313,3 +576,44 @@ func toDomainSessions(sessions []database.Session) []*aletheia.Session {
 	}
 	return result
 }
+
+// Upload conversions
+
+func toDomainUpload(u database.Upload) *aletheia.Upload {
+	upload := &aletheia.Upload{
+		ID:           fromPgUUID(u.ID),
+		InspectionID: fromPgUUID(u.InspectionID),
+		UserID:       fromPgUUID(u.UserID),
+		Filename:     fromPgText(u.Filename),
+		ContentType:  u.ContentType,
+		Length:       u.UploadLength,
+		Offset:       u.UploadOffset,
+		StorageKey:   u.StorageKey,
+		StorageURL:   fromPgText(u.StorageUrl),
+		Status:       aletheia.UploadStatus(u.Status),
+		ExpiresAt:    fromPgTimestamp(u.ExpiresAt),
+		CreatedAt:    fromPgTimestamp(u.CreatedAt),
+		UpdatedAt:    fromPgTimestamp(u.UpdatedAt),
+	}
+	if u.PhotoID.Valid {
+		photoID := fromPgUUID(u.PhotoID)
+		upload.PhotoID = &photoID
+	}
+	return upload
+}
+
+// Storage usage conversions
+
+func toDomainProjectStorageUsage(r database.ListProjectStorageUsageRow) *aletheia.ProjectStorageUsage {
+	return &aletheia.ProjectStorageUsage{
+		ProjectID:          fromPgUUID(r.ProjectID),
+		ProjectName:        r.ProjectName,
+		PhotoCount:         r.PhotoCount,
+		PhotoBytes:         r.PhotoBytes,
+		ReportCount:        r.ReportCount,
+		ReportBytes:        r.ReportBytes,
+		SummaryReportBytes: r.SummaryReportBytes,
+		UploadBytes:        r.UploadBytes,
+		TotalBytes:         r.PhotoBytes + r.ReportBytes + r.SummaryReportBytes + r.UploadBytes,
+	}
+}
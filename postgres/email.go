@@ -1,23 +1,31 @@
 package postgres
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
+	"html/template"
 	"log/slog"
+	"net/mail"
+	"strings"
 
 	"github.com/dukerupert/aletheia"
+	"github.com/google/uuid"
+	"github.com/keighl/postmark"
 )
 
-// Compile-time interface check
-var _ aletheia.EmailService = (*MockEmailService)(nil)
+// Compile-time interface checks
+var (
+	_ aletheia.EmailService = (*MockEmailService)(nil)
+	_ aletheia.EmailService = (*PostmarkEmailService)(nil)
+)
 
 // NewEmailService creates an email service based on the provider configuration.
 func NewEmailService(logger *slog.Logger, cfg aletheia.EmailConfig) aletheia.EmailService {
 	switch cfg.Provider {
 	case "postmark":
-		// TODO: Implement Postmark email service
-		logger.Warn("postmark email service not yet implemented, falling back to mock")
-		return &MockEmailService{logger: logger, cfg: cfg}
+		return NewPostmarkEmailService(logger, cfg)
 	default:
 		return &MockEmailService{logger: logger, cfg: cfg}
 	}
@@ -60,10 +68,222 @@ func (s *MockEmailService) SendWelcomeEmail(ctx context.Context, to, name string
 }
 
 // SendInspectionReport logs the inspection report email instead of sending it.
-func (s *MockEmailService) SendInspectionReport(ctx context.Context, to []string, subject string, reportURL string) error {
-	s.logger.Info("MOCK EMAIL: Inspection report",
-		slog.Any("to", to),
-		slog.String("subject", subject),
-		slog.String("report_url", reportURL))
-	return nil
+func (s *MockEmailService) SendInspectionReport(ctx context.Context, email aletheia.ReportEmail) (string, error) {
+	attrs := []any{
+		slog.String("to", email.To),
+		slog.String("subject", email.Subject),
+		slog.String("report_url", email.ReportURL),
+	}
+	if email.Attachment != nil {
+		attrs = append(attrs,
+			slog.String("attachment", email.Attachment.Name),
+			slog.Int("attachment_bytes", len(email.Attachment.Content)))
+	}
+	s.logger.Info("MOCK EMAIL: Inspection report", attrs...)
+	return "mock-" + uuid.NewString(), nil
+}
+
+// Postmark API error codes.
+const (
+	postmarkInvalidEmail      = 300
+	postmarkInactiveRecipient = 406
+)
+
+// PostmarkEmailService sends emails through Postmark.
+type PostmarkEmailService struct {
+	client *postmark.Client
+	logger *slog.Logger
+	cfg    aletheia.EmailConfig
+}
+
+// NewPostmarkEmailService creates an email service that sends through
+// Postmark with the configured server token.
+func NewPostmarkEmailService(logger *slog.Logger, cfg aletheia.EmailConfig) *PostmarkEmailService {
+	return &PostmarkEmailService{
+		client: postmark.NewClient(cfg.PostmarkServerToken, ""),
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+// SendVerificationEmail sends an email verification link.
+func (s *PostmarkEmailService) SendVerificationEmail(ctx context.Context, to, name, token string) error {
+	verifyURL := fmt.Sprintf("%s/verify?token=%s", s.cfg.VerifyBaseURL, token)
+	_, err := s.send(postmark.Email{
+		To:       to,
+		Subject:  "Verify your email address",
+		TextBody: fmt.Sprintf("Hi %s,\n\nPlease verify your email address by opening this link:\n%s\n", name, verifyURL),
+		Tag:      "email-verification",
+	})
+	return err
+}
+
+// SendPasswordResetEmail sends a password reset link.
+func (s *PostmarkEmailService) SendPasswordResetEmail(ctx context.Context, to, name, token string) error {
+	resetURL := fmt.Sprintf("%s/reset-password?token=%s", s.cfg.VerifyBaseURL, token)
+	_, err := s.send(postmark.Email{
+		To:       to,
+		Subject:  "Reset your password",
+		TextBody: fmt.Sprintf("Hi %s,\n\nReset your password by opening this link:\n%s\n\nIf you did not ask to reset your password, ignore this email.\n", name, resetURL),
+		Tag:      "password-reset",
+	})
+	return err
+}
+
+// SendWelcomeEmail sends a welcome email to a new user.
+func (s *PostmarkEmailService) SendWelcomeEmail(ctx context.Context, to, name string) error {
+	_, err := s.send(postmark.Email{
+		To:       to,
+		Subject:  "Welcome to " + s.cfg.FromName,
+		TextBody: fmt.Sprintf("Hi %s,\n\nWelcome to %s. Your account is ready.\n", name, s.cfg.FromName),
+		Tag:      "welcome",
+	})
+	return err
+}
+
+// defaultReportColor is the accent of report emails from organizations
+// without a primary color.
+const defaultReportColor = "#18181b"
+
+// reportEmailTemplate is the HTML body of report emails, styled with the
+// sending organization's branding.
+var reportEmailTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<body style="margin:0;padding:0;background:#f4f4f5;font-family:Helvetica,Arial,sans-serif;color:#18181b;">
+<table width="100%" cellpadding="0" cellspacing="0" style="background:#f4f4f5;padding:24px 0;">
+<tr><td align="center">
+<table width="600" cellpadding="0" cellspacing="0" style="background:#ffffff;">
+<tr><td style="background:{{.Color}};padding:24px;">
+{{- if .LogoURL}}<img src="{{.LogoURL}}" alt="{{.Organization}}" style="max-height:48px;max-width:200px;background:#ffffff;padding:4px;">{{else}}<span style="font-size:20px;font-weight:bold;color:#ffffff;">{{.Organization}}</span>{{end -}}
+</td></tr>
+<tr><td style="padding:24px;font-size:15px;line-height:1.5;">
+{{- range .Message}}<p style="margin:0 0 16px;">{{.}}</p>{{end}}
+{{- if .Attached}}
+<p style="margin:0 0 16px;">The inspection report is attached.</p>
+{{- else}}
+<p style="margin:0 0 24px;"><a href="{{.ReportURL}}" style="display:inline-block;background:{{.Color}};color:#ffffff;padding:10px 18px;text-decoration:none;">Download the inspection report</a></p>
+{{- end}}
+</td></tr>
+<tr><td style="padding:16px 24px;border-top:1px solid #e4e4e7;font-size:12px;line-height:1.4;color:#71717a;">
+<p style="margin:0;">{{.Organization}}{{range .Address}}<br>{{.}}{{end}}</p>
+{{- range .Footer}}<p style="margin:8px 0 0;">{{.}}</p>{{end}}
+</td></tr>
+</table>
+</td></tr>
+</table>
+</body>
+</html>
+`))
+
+// SendInspectionReport sends an inspection report with the PDF attached, or
+// a link to it, on behalf of an organization and styled with its branding.
+func (s *PostmarkEmailService) SendInspectionReport(ctx context.Context, email aletheia.ReportEmail) (string, error) {
+	html, err := reportEmailHTML(email)
+	if err != nil {
+		return "", aletheia.Permanent(fmt.Errorf("rendering report email: %w", err))
+	}
+
+	msg := postmark.Email{
+		To:       email.To,
+		Subject:  email.Subject,
+		HtmlBody: html,
+		TextBody: reportEmailText(email),
+		Tag:      "inspection-report",
+	}
+	if email.Organization != "" {
+		from := mail.Address{Name: email.Organization + " via " + s.cfg.FromName, Address: s.cfg.FromAddress}
+		msg.From = from.String()
+	}
+	if a := email.Attachment; a != nil {
+		msg.Attachments = []postmark.Attachment{{
+			Name:        a.Name,
+			Content:     base64.StdEncoding.EncodeToString(a.Content),
+			ContentType: a.ContentType,
+		}}
+	}
+	return s.send(msg)
+}
+
+func reportEmailHTML(email aletheia.ReportEmail) (string, error) {
+	color := email.Branding.PrimaryColor
+	if color == "" {
+		color = defaultReportColor
+	}
+
+	var buf bytes.Buffer
+	err := reportEmailTemplate.Execute(&buf, map[string]any{
+		"Organization": email.Organization,
+		"Color":        color,
+		"LogoURL":      email.Branding.LogoURL,
+		"Address":      lines(email.Branding.Address),
+		"Footer":       lines(email.Branding.ReportFooter),
+		"Message":      lines(email.Message),
+		"Attached":     email.Attachment != nil,
+		"ReportURL":    email.ReportURL,
+	})
+	return buf.String(), err
+}
+
+func reportEmailText(email aletheia.ReportEmail) string {
+	var body strings.Builder
+	if email.Message != "" {
+		body.WriteString(email.Message + "\n\n")
+	}
+	if email.Attachment != nil {
+		body.WriteString("The inspection report is attached.\n")
+	} else {
+		fmt.Fprintf(&body, "Download the inspection report:\n%s\n", email.ReportURL)
+	}
+	if email.Organization != "" {
+		body.WriteString("\n--\n" + email.Organization + "\n")
+		if email.Branding.Address != "" {
+			body.WriteString(email.Branding.Address + "\n")
+		}
+	}
+	if email.Branding.ReportFooter != "" {
+		body.WriteString("\n" + email.Branding.ReportFooter + "\n")
+	}
+	return body.String()
+}
+
+// lines splits text into its non-blank lines.
+func lines(s string) []string {
+	var result []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			result = append(result, line)
+		}
+	}
+	return result
+}
+
+// send sends an email, from the configured sender unless it has a sender,
+// and returns its message ID.
+func (s *PostmarkEmailService) send(email postmark.Email) (string, error) {
+	if email.From == "" {
+		email.From = fmt.Sprintf("%s <%s>", s.cfg.FromName, s.cfg.FromAddress)
+	}
+
+	res, err := s.client.SendEmail(email)
+	if res.ErrorCode != 0 {
+		err = fmt.Errorf("postmark error %d: %s", res.ErrorCode, res.Message)
+		// Invalid or inactive (previously bounced) recipients will not
+		// succeed on retry
+		if res.ErrorCode == postmarkInvalidEmail || res.ErrorCode == postmarkInactiveRecipient {
+			err = aletheia.Permanent(err)
+		}
+	}
+	if err != nil {
+		s.logger.Error("failed to send email via Postmark",
+			slog.String("to", email.To),
+			slog.String("tag", email.Tag),
+			slog.String("error", err.Error()))
+		return "", fmt.Errorf("sending %s email: %w", email.Tag, err)
+	}
+
+	s.logger.Info("email sent via Postmark",
+		slog.String("to", email.To),
+		slog.String("tag", email.Tag),
+		slog.String("message_id", res.MessageID))
+	return res.MessageID, nil
 }
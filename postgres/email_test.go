@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPostmarkService(t *testing.T, handler http.HandlerFunc) *PostmarkEmailService {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	s := NewPostmarkEmailService(slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError})), aletheia.EmailConfig{
+		Provider:            "postmark",
+		FromAddress:         "reports@example.com",
+		FromName:            "Aletheia",
+		PostmarkServerToken: "server-token",
+	})
+	s.client.BaseURL = server.URL
+	return s
+}
+
+func TestPostmarkEmailService_SendInspectionReport(t *testing.T) {
+	var sent map[string]any
+	s := newTestPostmarkService(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/email", r.URL.Path)
+		assert.Equal(t, "server-token", r.Header.Get("X-Postmark-Server-Token"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&sent))
+		json.NewEncoder(w).Encode(map[string]any{"MessageID": "msg-123", "ErrorCode": 0})
+	})
+
+	messageID, err := s.SendInspectionReport(context.Background(), aletheia.ReportEmail{
+		To:      "owner@example.com",
+		Subject: "Inspection report: Riverside Tower",
+		Message: "Please review.",
+		Attachment: &aletheia.Attachment{
+			Name:        "report.pdf",
+			ContentType: "application/pdf",
+			Content:     []byte("%PDF-1.4"),
+		},
+		Organization: "Acme Construction",
+		Branding: aletheia.OrganizationBranding{
+			PrimaryColor: "#0f766e",
+			ReportFooter: "For the client's use only.",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "msg-123", messageID)
+
+	assert.Equal(t, `"Acme Construction via Aletheia" <reports@example.com>`, sent["From"])
+	assert.Equal(t, "owner@example.com", sent["To"])
+	assert.Contains(t, sent["TextBody"], "Please review.")
+	assert.Contains(t, sent["TextBody"], "For the client's use only.")
+	assert.Contains(t, sent["HtmlBody"], "background:#0f766e")
+	assert.Contains(t, sent["HtmlBody"], "For the client&#39;s use only.")
+	attachments := sent["Attachments"].([]any)
+	require.Len(t, attachments, 1)
+	attachment := attachments[0].(map[string]any)
+	assert.Equal(t, "report.pdf", attachment["Name"])
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("%PDF-1.4")), attachment["Content"])
+}
+
+func TestPostmarkEmailService_InactiveRecipient(t *testing.T) {
+	s := newTestPostmarkService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(map[string]any{"ErrorCode": 406, "Message": "You tried to send to a recipient that has been marked as inactive."})
+	})
+
+	_, err := s.SendInspectionReport(context.Background(), aletheia.ReportEmail{
+		To:        "bounced@example.com",
+		Subject:   "Inspection report",
+		ReportURL: "https://example.com/r.pdf",
+	})
+	require.Error(t, err)
+	assert.True(t, aletheia.IsPermanent(err))
+}
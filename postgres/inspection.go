@@ -2,11 +2,16 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"strings"
 
 	"github.com/dukerupert/aletheia"
 	"github.com/dukerupert/aletheia/internal/database"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // Compile-time check that InspectionService implements aletheia.InspectionService.
@@ -29,23 +34,29 @@ func (s *InspectionService) FindInspectionByID(ctx context.Context, id uuid.UUID
 }
 
 func (s *InspectionService) FindInspections(ctx context.Context, filter aletheia.InspectionFilter) ([]*aletheia.Inspection, int, error) {
-	var inspections []database.Inspection
-	var err error
-
-	// Choose query based on filter criteria
-	if filter.ProjectID != nil && filter.Status != nil {
-		inspections, err = s.db.queries.ListInspectionsByStatus(ctx, database.ListInspectionsByStatusParams{
-			ProjectID: toPgUUID(*filter.ProjectID),
-			Status:    database.InspectionStatus(*filter.Status),
-		})
-	} else if filter.ProjectID != nil {
-		inspections, err = s.db.queries.ListInspections(ctx, toPgUUID(*filter.ProjectID))
-	} else if filter.InspectorID != nil {
-		inspections, err = s.db.queries.ListInspectionsByInspector(ctx, toPgUUID(*filter.InspectorID))
-	} else {
+	if filter.ProjectID == nil && filter.InspectorID == nil {
 		return nil, 0, aletheia.Invalid("ProjectID or InspectorID is required")
 	}
 
+	params := database.SearchInspectionsParams{
+		InspectionType: toPgTextPtr(filter.InspectionType),
+		ScheduledFrom:  toPgTimestampPtr(filter.ScheduledFrom),
+		ScheduledTo:    toPgTimestampPtr(filter.ScheduledTo),
+		PerformedFrom:  toPgTimestampPtr(filter.PerformedFrom),
+		PerformedTo:    toPgTimestampPtr(filter.PerformedTo),
+		Search:         toPgTextPtr(escapeLike(filter.Search)),
+	}
+	if filter.ProjectID != nil {
+		params.ProjectID = toPgUUID(*filter.ProjectID)
+	}
+	if filter.InspectorID != nil {
+		params.InspectorID = toPgUUID(*filter.InspectorID)
+	}
+	if filter.Status != nil {
+		params.Status = database.NullInspectionStatus{InspectionStatus: database.InspectionStatus(*filter.Status), Valid: true}
+	}
+
+	inspections, err := s.db.queries.SearchInspections(ctx, params)
 	if err != nil {
 		return nil, 0, aletheia.Internal("Failed to list inspections", err)
 	}
@@ -62,11 +73,37 @@ func (s *InspectionService) FindInspections(ctx context.Context, filter aletheia
 	return toDomainInspections(inspections), total, nil
 }
 
+// likeEscaper escapes the characters LIKE treats specially, using its
+// default escape character, the backslash.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike returns s with LIKE wildcards escaped so it matches literally.
+func escapeLike(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := likeEscaper.Replace(*s)
+	return &v
+}
+
 func (s *InspectionService) CreateInspection(ctx context.Context, inspection *aletheia.Inspection) error {
+	attendees, err := marshalAttendees(inspection.Attendees)
+	if err != nil {
+		return err
+	}
+
 	dbInspection, err := s.db.queries.CreateInspection(ctx, database.CreateInspectionParams{
-		ProjectID:   toPgUUID(inspection.ProjectID),
-		InspectorID: toPgUUID(inspection.InspectorID),
-		Status:      database.InspectionStatus(inspection.Status),
+		ProjectID:      toPgUUID(inspection.ProjectID),
+		InspectorID:    toPgUUID(inspection.InspectorID),
+		Status:         database.InspectionStatus(inspection.Status),
+		Title:          inspection.Title,
+		InspectionType: toPgText(inspection.InspectionType),
+		ScheduledAt:    toPgTimestampPtr(inspection.ScheduledAt),
+		PerformedAt:    toPgTimestampPtr(inspection.PerformedAt),
+		Notes:          toPgText(inspection.Notes),
+		Weather:        toPgText(inspection.Weather),
+		TemperatureF:   toPgFloat8Ptr(inspection.TemperatureF),
+		Attendees:      attendees,
 	})
 	if err != nil {
 		if isForeignKeyViolation(err) {
@@ -75,48 +112,123 @@ func (s *InspectionService) CreateInspection(ctx context.Context, inspection *al
 		return aletheia.Internal("Failed to create inspection", err)
 	}
 
-	// Update inspection with generated values
-	inspection.ID = fromPgUUID(dbInspection.ID)
-	inspection.CreatedAt = fromPgTimestamp(dbInspection.CreatedAt)
-	inspection.UpdatedAt = fromPgTimestamp(dbInspection.UpdatedAt)
-
+	*inspection = *toDomainInspection(dbInspection)
 	return nil
 }
 
 func (s *InspectionService) UpdateInspection(ctx context.Context, id uuid.UUID, upd aletheia.InspectionUpdate) (*aletheia.Inspection, error) {
-	// Currently only status can be updated
+	tx, err := s.db.pool.Begin(ctx)
+	if err != nil {
+		return nil, aletheia.Internal("Failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.db.queries.WithTx(tx)
+
+	// Status changes are validated against the current status
 	if upd.Status != nil {
-		return s.UpdateInspectionStatus(ctx, id, *upd.Status)
+		if _, err := updateInspectionStatus(ctx, qtx, id, *upd.Status); err != nil {
+			return nil, err
+		}
 	}
 
-	// No update requested, just return current inspection
-	return s.FindInspectionByID(ctx, id)
+	params := database.UpdateInspectionParams{
+		ID:                  toPgUUID(id),
+		Title:               toPgTextPtr(upd.Title),
+		InspectionType:      toPgTextPtr(upd.InspectionType),
+		ClearInspectionType: upd.ClearInspectionType,
+		ScheduledAt:         toPgTimestampPtr(upd.ScheduledAt),
+		ClearScheduledAt:    upd.ClearScheduledAt,
+		PerformedAt:         toPgTimestampPtr(upd.PerformedAt),
+		ClearPerformedAt:    upd.ClearPerformedAt,
+		Notes:               toPgTextPtr(upd.Notes),
+		ClearNotes:          upd.ClearNotes,
+		Weather:             toPgTextPtr(upd.Weather),
+		ClearWeather:        upd.ClearWeather,
+		TemperatureF:        toPgFloat8Ptr(upd.TemperatureF),
+		ClearTemperatureF:   upd.ClearTemperatureF,
+	}
+	if upd.Attendees != nil {
+		attendees, err := marshalAttendees(*upd.Attendees)
+		if err != nil {
+			return nil, err
+		}
+		params.Attendees = attendees
+	}
+
+	inspection, err := qtx.UpdateInspection(ctx, params)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, aletheia.NotFound("Inspection not found")
+		}
+		return nil, aletheia.Internal("Failed to update inspection", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, aletheia.Internal("Failed to commit transaction", err)
+	}
+
+	return toDomainInspection(inspection), nil
+}
+
+// marshalAttendees encodes attendees for storage, as an empty array if there
+// are none.
+func marshalAttendees(attendees []aletheia.Attendee) ([]byte, error) {
+	if attendees == nil {
+		attendees = []aletheia.Attendee{}
+	}
+	data, err := json.Marshal(attendees)
+	if err != nil {
+		return nil, aletheia.Internal("Failed to encode attendees", err)
+	}
+	return data, nil
 }
 
 func (s *InspectionService) UpdateInspectionStatus(ctx context.Context, id uuid.UUID, status aletheia.InspectionStatus) (*aletheia.Inspection, error) {
-	// Get current inspection to validate status transition
-	current, err := s.FindInspectionByID(ctx, id)
+	tx, err := s.db.pool.Begin(ctx)
+	if err != nil {
+		return nil, aletheia.Internal("Failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	inspection, err := updateInspectionStatus(ctx, s.db.queries.WithTx(tx), id, status)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := tx.Commit(ctx); err != nil {
+		return nil, aletheia.Internal("Failed to commit transaction", err)
+	}
+
+	return toDomainInspection(inspection), nil
+}
+
+// updateInspectionStatus changes an inspection's status within a
+// transaction, locking it so the transition is validated against the status
+// it replaces.
+func updateInspectionStatus(ctx context.Context, qtx *database.Queries, id uuid.UUID, status aletheia.InspectionStatus) (database.Inspection, error) {
+	current, err := qtx.GetInspectionForUpdate(ctx, toPgUUID(id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return database.Inspection{}, aletheia.NotFound("Inspection not found")
+		}
+		return database.Inspection{}, aletheia.Internal("Failed to fetch inspection", err)
+	}
+
 	// Validate status transition
-	if !current.Status.CanTransitionTo(status) {
-		return nil, aletheia.Invalid("Invalid status transition from %s to %s", current.Status, status)
+	from := aletheia.InspectionStatus(current.Status)
+	if !from.CanTransitionTo(status) {
+		return database.Inspection{}, aletheia.Invalid("Invalid status transition from %s to %s", from, status)
 	}
 
-	inspection, err := s.db.queries.UpdateInspectionStatus(ctx, database.UpdateInspectionStatusParams{
+	inspection, err := qtx.UpdateInspectionStatus(ctx, database.UpdateInspectionStatusParams{
 		ID:     toPgUUID(id),
 		Status: database.InspectionStatus(status),
 	})
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, aletheia.NotFound("Inspection not found")
-		}
-		return nil, aletheia.Internal("Failed to update inspection status", err)
+		return database.Inspection{}, aletheia.Internal("Failed to update inspection status", err)
 	}
-
-	return toDomainInspection(inspection), nil
+	return inspection, nil
 }
 
 func (s *InspectionService) DeleteInspection(ctx context.Context, id uuid.UUID) error {
@@ -131,9 +243,83 @@ func (s *InspectionService) DeleteInspection(ctx context.Context, id uuid.UUID)
 }
 
 func (s *InspectionService) GetInspectionStats(ctx context.Context, id uuid.UUID) (*aletheia.InspectionStats, error) {
-	// TODO: This would require custom queries to aggregate stats
-	// For now, return empty stats
-	return &aletheia.InspectionStats{
-		InspectionID: id,
-	}, nil
+	stats, err := s.FindInspectionStats(ctx, []uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+	if stats[id] == nil {
+		return nil, aletheia.NotFound("Inspection not found")
+	}
+	return stats[id], nil
+}
+
+func (s *InspectionService) FindInspectionStats(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*aletheia.InspectionStats, error) {
+	result := make(map[uuid.UUID]*aletheia.InspectionStats, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	pgIDs := make([]pgtype.UUID, len(ids))
+	for i, id := range ids {
+		pgIDs[i] = toPgUUID(id)
+	}
+	rows, err := s.db.queries.ListInspectionStats(ctx, pgIDs)
+	if err != nil {
+		return nil, aletheia.Internal("Failed to fetch inspection stats", err)
+	}
+	for _, row := range rows {
+		stats := toDomainInspectionStats(row)
+		result[stats.InspectionID] = stats
+	}
+	return result, nil
+}
+
+func (s *InspectionService) FindSignoffs(ctx context.Context, inspectionID uuid.UUID) ([]*aletheia.Signoff, error) {
+	signoffs, err := s.db.queries.ListInspectionSignoffs(ctx, toPgUUID(inspectionID))
+	if err != nil {
+		return nil, aletheia.Internal("Failed to list sign-offs", err)
+	}
+	return toDomainSignoffs(signoffs), nil
+}
+
+func (s *InspectionService) CreateSignoff(ctx context.Context, signoff *aletheia.Signoff) error {
+	if !signoff.Role.IsValid() {
+		return aletheia.Invalid("Invalid sign-off role: %s", signoff.Role)
+	}
+	if !signoff.SignatureKind.IsValid() {
+		return aletheia.Invalid("Invalid signature kind: %s", signoff.SignatureKind)
+	}
+
+	dbSignoff, err := s.db.queries.CreateInspectionSignoff(ctx, database.CreateInspectionSignoffParams{
+		InspectionID:  toPgUUID(signoff.InspectionID),
+		Role:          database.SignoffRole(signoff.Role),
+		SignerID:      toPgUUID(signoff.SignerID),
+		SignerName:    signoff.SignerName,
+		SignatureKind: database.SignatureKind(signoff.SignatureKind),
+		Signature:     signoff.Signature,
+		ContentHash:   signoff.ContentHash,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return aletheia.Conflict("Inspection already has a %s sign-off", signoff.Role)
+		}
+		var pgErr *pgconn.PgError
+		if isForeignKeyViolation(err) && errors.As(err, &pgErr) && pgErr.ConstraintName == "inspection_signoffs_signer_id_fkey" {
+			return aletheia.NotFound("User not found")
+		}
+		if isForeignKeyViolation(err) {
+			return aletheia.NotFound("Inspection not found")
+		}
+		return aletheia.Internal("Failed to create sign-off", err)
+	}
+
+	*signoff = *toDomainSignoff(dbSignoff)
+	return nil
+}
+
+func (s *InspectionService) DeleteSignoff(ctx context.Context, id uuid.UUID) error {
+	if err := s.db.queries.DeleteInspectionSignoff(ctx, toPgUUID(id)); err != nil {
+		return aletheia.Internal("Failed to delete sign-off", err)
+	}
+	return nil
 }
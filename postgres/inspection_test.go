@@ -0,0 +1,194 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspectionAndProjectStats(t *testing.T) {
+	pool := setupTestPool(t)
+	db := NewDB(pool)
+	ctx := context.Background()
+
+	inspectionID := createTestInspection(t, pool)
+	var projectID, inspectorID uuid.UUID
+	require.NoError(t, pool.QueryRow(ctx,
+		`SELECT project_id, inspector_id FROM inspections WHERE id = $1`, inspectionID).Scan(&projectID, &inspectorID))
+
+	// A second inspection with nothing in it
+	emptyID := uuid.New()
+	_, err := pool.Exec(ctx, `INSERT INTO inspections (id, project_id, inspector_id) VALUES ($1, $2, $3)`, emptyID, projectID, inspectorID)
+	require.NoError(t, err)
+
+	photos := []uuid.UUID{uuid.New(), uuid.New()}
+	for _, id := range photos {
+		_, err := pool.Exec(ctx, `INSERT INTO photos (id, inspection_id, storage_url) VALUES ($1, $2, 'https://example.com/photo.jpg')`, id, inspectionID)
+		require.NoError(t, err)
+	}
+	for _, v := range []struct {
+		photo    uuid.UUID
+		status   string
+		severity string
+	}{
+		{photos[0], "pending", "critical"},
+		{photos[0], "confirmed", "high"},
+		{photos[1], "dismissed", "low"},
+	} {
+		_, err := pool.Exec(ctx,
+			`INSERT INTO detected_violations (photo_id, description, status, severity) VALUES ($1, 'Test violation', $2, $3)`,
+			v.photo, v.status, v.severity)
+		require.NoError(t, err)
+	}
+
+	stats, err := db.InspectionService.FindInspectionStats(ctx, []uuid.UUID{inspectionID, emptyID, uuid.New()})
+	require.NoError(t, err)
+	require.Len(t, stats, 2, "missing inspections are left out")
+	assert.Equal(t, &aletheia.InspectionStats{
+		InspectionID:   inspectionID,
+		PhotoCount:     2,
+		ViolationCount: 3,
+		PendingCount:   1,
+		ConfirmedCount: 1,
+		DismissedCount: 1,
+		CriticalCount:  1,
+		HighCount:      1,
+		LowCount:       1,
+	}, stats[inspectionID])
+	assert.Equal(t, &aletheia.InspectionStats{InspectionID: emptyID}, stats[emptyID])
+
+	one, err := db.InspectionService.GetInspectionStats(ctx, inspectionID)
+	require.NoError(t, err)
+	assert.Equal(t, stats[inspectionID], one)
+	_, err = db.InspectionService.GetInspectionStats(ctx, uuid.New())
+	assert.True(t, aletheia.IsErrorCode(err, aletheia.ENOTFOUND))
+
+	projectStats, err := db.ProjectService.GetProjectStats(ctx, projectID)
+	require.NoError(t, err)
+	assert.Equal(t, &aletheia.ProjectStats{
+		ProjectID:           projectID,
+		InspectionCount:     2,
+		PhotoCount:          2,
+		ViolationCount:      3,
+		OpenViolations:      1,
+		ClosedViolations:    2,
+		ConfirmedViolations: 1,
+		DismissedViolations: 1,
+	}, projectStats)
+	_, err = db.ProjectService.GetProjectStats(ctx, uuid.New())
+	assert.True(t, aletheia.IsErrorCode(err, aletheia.ENOTFOUND))
+}
+
+func TestInspectionDetails(t *testing.T) {
+	pool := setupTestPool(t)
+	db := NewDB(pool)
+	ctx := context.Background()
+
+	existingID := createTestInspection(t, pool)
+	existing, err := db.InspectionService.FindInspectionByID(ctx, existingID)
+	require.NoError(t, err)
+	assert.Empty(t, existing.Title)
+	assert.Empty(t, existing.Attendees)
+
+	scheduled := time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC)
+	temperature := 58.5
+	inspection := &aletheia.Inspection{
+		ProjectID:      existing.ProjectID,
+		InspectorID:    existing.InspectorID,
+		Status:         aletheia.InspectionStatusDraft,
+		Title:          "Level 3 framing",
+		InspectionType: "framing",
+		ScheduledAt:    &scheduled,
+		Notes:          "Guardrails missing on the east stair",
+		Weather:        "Overcast",
+		TemperatureF:   &temperature,
+		Attendees: []aletheia.Attendee{
+			{Name: "Sam Lee", Company: "Acme Construction", Role: "Superintendent"},
+		},
+	}
+	require.NoError(t, db.InspectionService.CreateInspection(ctx, inspection))
+	assert.Equal(t, "Level 3 framing", inspection.Title)
+	assert.True(t, scheduled.Equal(*inspection.ScheduledAt))
+	assert.Nil(t, inspection.PerformedAt)
+	assert.Equal(t, "Overcast, 58.5°F", inspection.Conditions())
+	assert.Equal(t, "Sam Lee (Superintendent, Acme Construction)", inspection.Attendees[0].String())
+
+	performed := scheduled.Add(2 * time.Hour)
+	inProgress := aletheia.InspectionStatusInProgress
+	notes := ""
+	updated, err := db.InspectionService.UpdateInspection(ctx, inspection.ID, aletheia.InspectionUpdate{
+		Status:      &inProgress,
+		PerformedAt: &performed,
+		Notes:       &notes,
+		Attendees:   &[]aletheia.Attendee{},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, aletheia.InspectionStatusInProgress, updated.Status)
+	assert.Equal(t, "Level 3 framing", updated.Title, "omitted fields are unchanged")
+	assert.True(t, performed.Equal(updated.Date()))
+	assert.Empty(t, updated.Notes)
+	assert.Empty(t, updated.Attendees)
+
+	_, err = db.InspectionService.UpdateInspection(ctx, uuid.New(), aletheia.InspectionUpdate{Notes: &notes})
+	assert.True(t, aletheia.IsErrorCode(err, aletheia.ENOTFOUND))
+
+	// A rejected status change leaves the other fields unchanged
+	draft, retitled := aletheia.InspectionStatusDraft, "Retitled"
+	_, err = db.InspectionService.UpdateInspection(ctx, inspection.ID, aletheia.InspectionUpdate{
+		Status: &draft,
+		Title:  &retitled,
+	})
+	assert.True(t, aletheia.IsErrorCode(err, aletheia.EINVALID))
+	unchanged, err := db.InspectionService.FindInspectionByID(ctx, inspection.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Level 3 framing", unchanged.Title)
+	assert.Equal(t, aletheia.InspectionStatusInProgress, unchanged.Status)
+
+	find := func(filter aletheia.InspectionFilter) []uuid.UUID {
+		t.Helper()
+		filter.ProjectID = &existing.ProjectID
+		inspections, _, err := db.InspectionService.FindInspections(ctx, filter)
+		require.NoError(t, err)
+		ids := make([]uuid.UUID, len(inspections))
+		for i, inspection := range inspections {
+			ids[i] = inspection.ID
+		}
+		return ids
+	}
+	framing, search := "framing", "FRAMING"
+	from, to := scheduled.Add(-time.Hour), scheduled.Add(time.Hour)
+	assert.ElementsMatch(t, []uuid.UUID{existingID, inspection.ID}, find(aletheia.InspectionFilter{}))
+	assert.Equal(t, []uuid.UUID{inspection.ID}, find(aletheia.InspectionFilter{InspectionType: &framing}))
+	assert.Equal(t, []uuid.UUID{inspection.ID}, find(aletheia.InspectionFilter{Search: &search}))
+	assert.Equal(t, []uuid.UUID{inspection.ID}, find(aletheia.InspectionFilter{ScheduledFrom: &from, ScheduledTo: &to}))
+	assert.Empty(t, find(aletheia.InspectionFilter{PerformedFrom: &from, PerformedTo: &to}))
+
+	// Clearing a field takes precedence over a value for it
+	progress, sunny := "Framing 50% complete", "Sunny"
+	cleared, err := db.InspectionService.UpdateInspection(ctx, inspection.ID, aletheia.InspectionUpdate{
+		Notes:               &progress,
+		Weather:             &sunny,
+		ClearInspectionType: true,
+		ClearScheduledAt:    true,
+		ClearWeather:        true,
+		ClearTemperatureF:   true,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, cleared.InspectionType)
+	assert.Nil(t, cleared.ScheduledAt)
+	assert.Empty(t, cleared.Weather)
+	assert.Nil(t, cleared.TemperatureF)
+	assert.Equal(t, progress, cleared.Notes)
+	assert.True(t, performed.Equal(*cleared.PerformedAt), "fields not cleared are unchanged")
+
+	// LIKE wildcards in the search term match literally
+	percent, wildcard, underscore := "50%", "5%c", "3_framing"
+	assert.Equal(t, []uuid.UUID{inspection.ID}, find(aletheia.InspectionFilter{Search: &percent}))
+	assert.Empty(t, find(aletheia.InspectionFilter{Search: &wildcard}))
+	assert.Empty(t, find(aletheia.InspectionFilter{Search: &underscore}))
+}
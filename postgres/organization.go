@@ -36,14 +36,20 @@ func (s *OrganizationService) FindUserOrganizations(ctx context.Context, userID
 
 	result := make([]*aletheia.OrganizationWithRole, len(rows))
 	for i, row := range rows {
+		org := toDomainOrganization(database.Organization{
+			ID:             row.ID,
+			Name:           row.Name,
+			CreatedAt:      row.CreatedAt,
+			UpdatedAt:      row.UpdatedAt,
+			LogoUrl:        row.LogoUrl,
+			PrimaryColor:   row.PrimaryColor,
+			Address:        row.Address,
+			ReportFooter:   row.ReportFooter,
+			ReportTemplate: row.ReportTemplate,
+		})
 		result[i] = &aletheia.OrganizationWithRole{
-			Organization: aletheia.Organization{
-				ID:        fromPgUUID(row.ID),
-				Name:      row.Name,
-				CreatedAt: fromPgTimestamp(row.CreatedAt),
-				UpdatedAt: fromPgTimestamp(row.UpdatedAt),
-			},
-			Role: aletheia.OrganizationRole(row.Role),
+			Organization: *org,
+			Role:         aletheia.OrganizationRole(row.Role),
 		}
 	}
 	return result, nil
@@ -79,11 +85,18 @@ func (s *OrganizationService) CreateOrganization(ctx context.Context, org *aleth
 
 func (s *OrganizationService) UpdateOrganization(ctx context.Context, id uuid.UUID, upd aletheia.OrganizationUpdate) (*aletheia.Organization, error) {
 	params := database.UpdateOrganizationParams{
-		ID: toPgUUID(id),
-	}
-
-	if upd.Name != nil {
-		params.Name = *upd.Name
+		ID:           toPgUUID(id),
+		Name:         toPgTextPtr(upd.Name),
+		LogoUrl:      toPgTextPtr(upd.LogoURL),
+		PrimaryColor: toPgTextPtr(upd.PrimaryColor),
+		Address:      toPgTextPtr(upd.Address),
+		ReportFooter: toPgTextPtr(upd.ReportFooter),
+	}
+	if upd.ReportTemplate != nil {
+		params.ReportTemplate = database.NullReportTemplate{
+			ReportTemplate: database.ReportTemplate(*upd.ReportTemplate),
+			Valid:          true,
+		}
 	}
 
 	org, err := s.db.queries.UpdateOrganization(ctx, params)
@@ -196,3 +209,25 @@ func (s *OrganizationService) RequireMembership(ctx context.Context, orgID, user
 
 	return nil, aletheia.Forbidden("Insufficient permissions for this operation")
 }
+
+func (s *OrganizationService) GetStorageUsage(ctx context.Context, orgID uuid.UUID) (*aletheia.StorageUsage, error) {
+	rows, err := s.db.queries.ListProjectStorageUsage(ctx, toPgUUID(orgID))
+	if err != nil {
+		return nil, aletheia.Internal("Failed to fetch storage usage", err)
+	}
+
+	usage := &aletheia.StorageUsage{
+		OrganizationID: orgID,
+		Projects:       make([]*aletheia.ProjectStorageUsage, len(rows)),
+	}
+	for i, row := range rows {
+		project := toDomainProjectStorageUsage(row)
+		usage.PhotoBytes += project.PhotoBytes
+		usage.ReportBytes += project.ReportBytes
+		usage.SummaryReportBytes += project.SummaryReportBytes
+		usage.UploadBytes += project.UploadBytes
+		usage.TotalBytes += project.TotalBytes
+		usage.Projects[i] = project
+	}
+	return usage, nil
+}
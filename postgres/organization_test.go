@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStorageUsage(t *testing.T) {
+	pool := setupTestPool(t)
+	db := NewDB(pool)
+	uploads := NewUploadService(db, newMultipartStorage())
+	ctx := context.Background()
+
+	// An upload in progress reserves its length in its project
+	upload := createTestUpload(t, pool, uploads, 3000, time.Time{})
+	var projectID, orgID uuid.UUID
+	require.NoError(t, pool.QueryRow(ctx,
+		`SELECT p.id, p.organization_id FROM projects p JOIN inspections i ON i.project_id = p.id WHERE i.id = $1`,
+		upload.InspectionID).Scan(&projectID, &orgID))
+
+	_, err := pool.Exec(ctx,
+		`INSERT INTO photos (inspection_id, storage_url, size_bytes) VALUES ($1, 'https://example.com/photo.jpg', 1000)`,
+		upload.InspectionID)
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx,
+		`INSERT INTO summary_reports (project_id, period_start, period_end, storage_url, size_bytes) VALUES ($1, NOW() - INTERVAL '7 days', NOW(), 'https://example.com/summary.pdf', 500)`,
+		projectID)
+	require.NoError(t, err)
+
+	// Completed and expired uploads reserve nothing
+	_, err = pool.Exec(ctx, `
+		INSERT INTO uploads (inspection_id, user_id, content_type, upload_length, storage_key, multipart_upload_id, status, expires_at)
+		SELECT inspection_id, user_id, content_type, 5000, storage_key || '-done', 'done', 'completed'::upload_status, expires_at FROM uploads WHERE id = $1
+		UNION ALL
+		SELECT inspection_id, user_id, content_type, 7000, storage_key || '-expired', 'expired', 'in_progress'::upload_status, NOW() - INTERVAL '1 minute' FROM uploads WHERE id = $1`,
+		upload.ID)
+	require.NoError(t, err)
+
+	usage, err := db.OrganizationService.GetStorageUsage(ctx, orgID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), usage.PhotoBytes)
+	assert.Equal(t, int64(500), usage.SummaryReportBytes)
+	assert.Equal(t, int64(3000), usage.UploadBytes)
+	assert.Equal(t, int64(4500), usage.TotalBytes)
+	require.Len(t, usage.Projects, 1)
+	assert.Equal(t, projectID, usage.Projects[0].ProjectID)
+	assert.Equal(t, int64(3000), usage.Projects[0].UploadBytes)
+	assert.Equal(t, int64(500), usage.Projects[0].SummaryReportBytes)
+
+	usage.QuotaBytes = 5000
+	assert.False(t, usage.ExceedsQuota(500))
+	assert.True(t, usage.ExceedsQuota(501))
+}
@@ -55,6 +55,7 @@ func (s *PhotoService) CreatePhoto(ctx context.Context, photo *aletheia.Photo) e
 		InspectionID: toPgUUID(photo.InspectionID),
 		StorageUrl:   photo.StorageURL,
 		ThumbnailUrl: toPgText(photo.ThumbnailURL),
+		SizeBytes:    photo.SizeBytes,
 	})
 	if err != nil {
 		if isForeignKeyViolation(err) {
@@ -71,19 +72,27 @@ func (s *PhotoService) CreatePhoto(ctx context.Context, photo *aletheia.Photo) e
 }
 
 func (s *PhotoService) UpdatePhoto(ctx context.Context, id uuid.UUID, upd aletheia.PhotoUpdate) (*aletheia.Photo, error) {
-	// Currently no update query exists in sqlc, just return current photo
-	// TODO: Add UpdatePhoto query to sqlc
 	photo, err := s.FindPhotoByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	// Apply updates in memory (would need sqlc query to persist)
-	if upd.ThumbnailURL != nil {
-		photo.ThumbnailURL = *upd.ThumbnailURL
+	if upd.ThumbnailURL == nil {
+		return photo, nil
 	}
 
-	return photo, nil
+	dbPhoto, err := s.db.queries.UpdatePhotoThumbnail(ctx, database.UpdatePhotoThumbnailParams{
+		ID:           toPgUUID(id),
+		ThumbnailUrl: toPgText(*upd.ThumbnailURL),
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, aletheia.NotFound("Photo not found")
+		}
+		return nil, aletheia.Internal("Failed to update photo", err)
+	}
+
+	return toDomainPhoto(dbPhoto), nil
 }
 
 func (s *PhotoService) DeletePhoto(ctx context.Context, id uuid.UUID) error {
@@ -21,6 +21,8 @@ type DB struct {
 	ViolationService    aletheia.ViolationService
 	SafetyCodeService   aletheia.SafetyCodeService
 	SessionService      aletheia.SessionService
+	ReportService       aletheia.ReportService
+	ShareLinkService    aletheia.ShareLinkService
 }
 
 // NewDB creates a new database wrapper with all services initialized.
@@ -40,6 +42,8 @@ func NewDB(pool *pgxpool.Pool) *DB {
 	db.ViolationService = &ViolationService{db: db}
 	db.SafetyCodeService = &SafetyCodeService{db: db}
 	db.SessionService = &SessionService{db: db}
+	db.ReportService = &ReportService{db: db}
+	db.ShareLinkService = &ShareLinkService{db: db}
 
 	return db
 }
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"slices"
 
 	"github.com/dukerupert/aletheia"
 	"github.com/dukerupert/aletheia/internal/database"
@@ -29,16 +30,27 @@ func (s *ProjectService) FindProjectByID(ctx context.Context, id uuid.UUID) (*al
 }
 
 func (s *ProjectService) FindProjects(ctx context.Context, filter aletheia.ProjectFilter) ([]*aletheia.Project, int, error) {
-	// Currently sqlc only supports filtering by organization_id
-	if filter.OrganizationID == nil {
-		return nil, 0, aletheia.Invalid("Organization ID is required")
-	}
+	var projects []database.Project
+	var err error
 
-	projects, err := s.db.queries.ListProjects(ctx, toPgUUID(*filter.OrganizationID))
+	// Choose query based on filter criteria
+	if filter.OrganizationID != nil {
+		projects, err = s.db.queries.ListProjects(ctx, toPgUUID(*filter.OrganizationID))
+	} else if filter.Status != nil {
+		projects, err = s.db.queries.ListProjectsByStatus(ctx, toPgText(*filter.Status))
+	} else {
+		return nil, 0, aletheia.Invalid("Organization ID or status is required")
+	}
 	if err != nil {
 		return nil, 0, aletheia.Internal("Failed to list projects", err)
 	}
 
+	if filter.OrganizationID != nil && filter.Status != nil {
+		projects = slices.DeleteFunc(projects, func(p database.Project) bool {
+			return fromPgText(p.Status) != *filter.Status
+		})
+	}
+
 	// Apply offset/limit in memory
 	total := len(projects)
 	if filter.Offset > 0 && filter.Offset < len(projects) {
@@ -135,9 +147,55 @@ func (s *ProjectService) DeleteProject(ctx context.Context, id uuid.UUID) error
 }
 
 func (s *ProjectService) GetProjectStats(ctx context.Context, id uuid.UUID) (*aletheia.ProjectStats, error) {
-	// TODO: This would require custom queries to aggregate stats
-	// For now, return empty stats
-	return &aletheia.ProjectStats{
-		ProjectID: id,
-	}, nil
+	stats, err := s.db.queries.GetProjectStats(ctx, toPgUUID(id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, aletheia.NotFound("Project not found")
+		}
+		return nil, aletheia.Internal("Failed to fetch project stats", err)
+	}
+	return toDomainProjectStats(stats), nil
+}
+
+func (s *ProjectService) FindProjectContacts(ctx context.Context, projectID uuid.UUID) ([]*aletheia.ProjectContact, error) {
+	contacts, err := s.db.queries.ListProjectContacts(ctx, toPgUUID(projectID))
+	if err != nil {
+		return nil, aletheia.Internal("Failed to list project contacts", err)
+	}
+	return toDomainProjectContacts(contacts), nil
+}
+
+func (s *ProjectService) CreateProjectContact(ctx context.Context, contact *aletheia.ProjectContact) error {
+	dbContact, err := s.db.queries.CreateProjectContact(ctx, database.CreateProjectContactParams{
+		ProjectID: toPgUUID(contact.ProjectID),
+		Name:      contact.Name,
+		Email:     contact.Email,
+		Role:      toPgText(contact.Role),
+	})
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return aletheia.NotFound("Project not found")
+		}
+		if isUniqueViolation(err) {
+			return aletheia.Conflict("Project already has a contact with this email")
+		}
+		return aletheia.Internal("Failed to create project contact", err)
+	}
+
+	*contact = *toDomainProjectContact(dbContact)
+	return nil
+}
+
+func (s *ProjectService) DeleteProjectContact(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.db.queries.GetProjectContact(ctx, toPgUUID(id)); err != nil {
+		if err == pgx.ErrNoRows {
+			return aletheia.NotFound("Project contact not found")
+		}
+		return aletheia.Internal("Failed to fetch project contact", err)
+	}
+
+	if err := s.db.queries.DeleteProjectContact(ctx, toPgUUID(id)); err != nil {
+		return aletheia.Internal("Failed to delete project contact", err)
+	}
+	return nil
 }
@@ -2,18 +2,45 @@ package postgres
 
 import (
 	"context"
-	"database/sql"
-	"fmt"
+	"encoding/json"
+	"errors"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/database"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-// Compile-time interface check
-var _ aletheia.Queue = (*Queue)(nil)
+// Compile-time interface checks
+var (
+	_ aletheia.Queue         = (*Queue)(nil)
+	_ aletheia.QueueListener = (*Queue)(nil)
+)
+
+const (
+	// listenRetryDelay is how long a listener waits before reconnecting after
+	// its connection drops.
+	listenRetryDelay = 5 * time.Second
+
+	// jobEventsChannel is the notification channel for job changes.
+	jobEventsChannel = "job_events"
+
+	// jobEventBuffer is how many job events ListenJobEvents holds for a
+	// slow receiver.
+	jobEventBuffer = 64
+)
+
+// jobColumns lists the columns scanned by scanJob, in order. It must be
+// selected from the unaliased jobs table.
+const jobColumns = `id, queue_name, job_type, organization_id, payload, status,
+	priority, max_attempts, attempt_count, scheduled_at, created_at,
+	started_at, completed_at, result, error_message, errors, worker_id,
+	heartbeat_at, inspection_id, progress, progress_message, dependency_policy, unique_key,
+	ARRAY(SELECT depends_on_id FROM job_dependencies WHERE job_id = jobs.id ORDER BY depends_on_id)`
 
 // NewQueue creates a queue implementation based on the configuration.
 func NewQueue(pool *pgxpool.Pool, logger *slog.Logger, cfg aletheia.QueueConfig) aletheia.Queue {
@@ -33,323 +60,1413 @@ type Queue struct {
 
 // Enqueue adds a job to the queue.
 func (q *Queue) Enqueue(ctx context.Context, job *aletheia.Job, opts ...aletheia.EnqueueOption) error {
-	// Set defaults
-	if job.ID == uuid.Nil {
-		job.ID = uuid.New()
+	aletheia.PrepareJob(job, opts...)
+	jobID := job.ID
+
+	tx, err := q.pool.Begin(ctx)
+	if err != nil {
+		return aletheia.Internal("Failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertJob(ctx, tx, job); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return aletheia.Internal("Failed to commit job", err)
+	}
+
+	if job.ID != jobID {
+		q.logger.Debug("duplicate job not enqueued",
+			slog.String("job_id", job.ID.String()),
+			slog.String("unique_key", job.UniqueKey))
+		return nil
+	}
+
+	q.logger.Debug("job enqueued",
+		slog.String("job_id", job.ID.String()),
+		slog.String("job_type", job.JobType),
+		slog.String("queue", job.QueueName))
+
+	// Delayed jobs are found by fallback polling.
+	if job.Status == aletheia.JobStatusPending && !job.ScheduledAt.After(time.Now()) {
+		q.notifyQueue(ctx, job.QueueName)
+	}
+	q.notifyEnqueued(ctx, job)
+
+	return nil
+}
+
+// EnqueueBatch enqueues jobs and a callback that depends on all of them in
+// one transaction.
+func (q *Queue) EnqueueBatch(ctx context.Context, jobs []*aletheia.Job, callback *aletheia.Job, opts ...aletheia.EnqueueOption) error {
+	if len(jobs) == 0 {
+		return aletheia.Invalid("Batch must contain at least one job")
+	}
+
+	tx, err := q.pool.Begin(ctx)
+	if err != nil {
+		return aletheia.Internal("Failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	queues := map[string]bool{}
+	ids := make([]uuid.UUID, len(jobs))
+	for i, job := range jobs {
+		aletheia.PrepareJob(job, opts...)
+		if err := insertJob(ctx, tx, job); err != nil {
+			return err
+		}
+		ids[i] = job.ID
+		queues[job.QueueName] = true
 	}
-	if job.Status == "" {
-		job.Status = aletheia.JobStatusPending
+
+	aletheia.PrepareJob(callback, aletheia.WithDependsOn(ids...))
+	if err := insertJob(ctx, tx, callback); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return aletheia.Internal("Failed to commit batch", err)
+	}
+
+	q.logger.Debug("job batch enqueued",
+		slog.String("callback_id", callback.ID.String()),
+		slog.String("callback_type", callback.JobType),
+		slog.Int("jobs", len(jobs)))
+
+	for queueName := range queues {
+		q.notifyQueue(ctx, queueName)
+	}
+	q.notifyEnqueued(ctx, append([]*aletheia.Job{callback}, jobs...)...)
+
+	return nil
+}
+
+// EnqueueScheduled claims a scheduled run and enqueues its job in one
+// transaction, so exactly one caller enqueues each run.
+func (q *Queue) EnqueueScheduled(ctx context.Context, schedule string, runAt time.Time, job *aletheia.Job, opts ...aletheia.EnqueueOption) (bool, error) {
+	aletheia.PrepareJob(job, opts...)
+
+	tx, err := q.pool.Begin(ctx)
+	if err != nil {
+		return false, aletheia.Internal("Failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO scheduled_job_runs (schedule_name, run_at, job_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+	`, schedule, runAt, job.ID)
+	if err != nil {
+		return false, aletheia.Internal("Failed to claim scheduled run", err)
 	}
-	if job.CreatedAt.IsZero() {
-		job.CreatedAt = time.Now()
+	if tag.RowsAffected() == 0 {
+		return false, nil // Another instance enqueued this run
 	}
-	if job.ScheduledAt.IsZero() {
-		job.ScheduledAt = time.Now()
+
+	if err := insertJob(ctx, tx, job); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, aletheia.Internal("Failed to commit scheduled job", err)
+	}
+
+	q.logger.Debug("scheduled job enqueued",
+		slog.String("job_id", job.ID.String()),
+		slog.String("schedule", schedule),
+		slog.Time("run_at", runAt))
+
+	if !job.ScheduledAt.After(time.Now()) {
+		q.notifyQueue(ctx, job.QueueName)
+	}
+	q.notifyEnqueued(ctx, job)
+
+	return true, nil
+}
+
+// insertJob inserts a prepared job and its dependencies using db, which
+// must be a transaction if the job has dependencies or a unique key. A job
+// whose prerequisites have already failed is resolved immediately, updating
+// job.Status. If a unique job has an active duplicate, job is overwritten
+// with the duplicate and nothing is inserted.
+func insertJob(ctx context.Context, db database.DBTX, job *aletheia.Job) error {
+	if err := job.ValidateDependencies(); err != nil {
+		return err
+	}
+
+	if job.UniqueKey != "" {
+		existing, err := findDuplicateJob(ctx, db, job)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			*job = *existing
+			return nil
+		}
 	}
-	if job.MaxAttempts == 0 {
-		job.MaxAttempts = 3
+
+	// The payload column is JSONB NOT NULL.
+	payload := job.Payload
+	if len(payload) == 0 {
+		payload = []byte("{}")
 	}
 
 	query := `
 		INSERT INTO jobs (
 			id, queue_name, job_type, organization_id, payload, status,
-			priority, max_attempts, attempt_count, scheduled_at, created_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			priority, max_attempts, attempt_count, scheduled_at, created_at,
+			dependency_policy, inspection_id, unique_key
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 	`
 
-	_, err := q.pool.Exec(ctx, query,
+	_, err := db.Exec(ctx, query,
 		job.ID,
 		job.QueueName,
 		job.JobType,
-		job.OrganizationID,
-		job.Payload,
+		nullUUID(job.OrganizationID),
+		payload,
 		job.Status,
 		job.Priority,
 		job.MaxAttempts,
 		job.AttemptCount,
 		job.ScheduledAt,
 		job.CreatedAt,
+		job.DependencyPolicy,
+		nullUUID(job.InspectionID),
+		nullString(job.UniqueKey),
 	)
 	if err != nil {
-		return fmt.Errorf("enqueueing job: %w", err)
+		if isForeignKeyViolation(err) {
+			return aletheia.NotFound("Organization or inspection not found")
+		}
+		return aletheia.Internal("Failed to enqueue job", err)
 	}
 
-	q.logger.Debug("job enqueued",
-		slog.String("job_id", job.ID.String()),
-		slog.String("job_type", job.JobType),
-		slog.String("queue", job.QueueName))
-
-	return nil
-}
-
-// Dequeue retrieves the next available job from a queue.
-func (q *Queue) Dequeue(ctx context.Context, queueName string) (*aletheia.Job, error) {
-	query := `
-		UPDATE jobs
-		SET status = $1, started_at = $2, attempt_count = attempt_count + 1
-		WHERE id = (
-			SELECT id FROM jobs
-			WHERE queue_name = $3
-			AND status = $4
-			AND scheduled_at <= $5
-			ORDER BY priority DESC, created_at ASC
-			FOR UPDATE SKIP LOCKED
-			LIMIT 1
-		)
-		RETURNING id, queue_name, job_type, organization_id, payload, status,
-			priority, max_attempts, attempt_count, scheduled_at, created_at,
-			started_at, completed_at, result, error_message, worker_id
-	`
-
-	now := time.Now()
-	row := q.pool.QueryRow(ctx, query,
-		aletheia.JobStatusRunning,
-		now,
-		queueName,
-		aletheia.JobStatusPending,
-		now,
-	)
-
-	job := &aletheia.Job{}
-	var completedAt sql.NullTime
-	var result, errorMessage, workerID sql.NullString
+	if len(job.DependsOn) == 0 {
+		return nil
+	}
 
-	err := row.Scan(
-		&job.ID,
-		&job.QueueName,
-		&job.JobType,
-		&job.OrganizationID,
-		&job.Payload,
-		&job.Status,
-		&job.Priority,
-		&job.MaxAttempts,
-		&job.AttemptCount,
-		&job.ScheduledAt,
-		&job.CreatedAt,
-		&job.StartedAt,
-		&completedAt,
-		&result,
-		&errorMessage,
-		&workerID,
-	)
+	_, err = db.Exec(ctx, `
+		INSERT INTO job_dependencies (job_id, depends_on_id)
+		SELECT $1, unnest($2::uuid[])
+	`, job.ID, job.DependsOn)
 	if err != nil {
-		if err.Error() == "no rows in result set" {
-			return nil, nil // No jobs available
+		if isForeignKeyViolation(err) {
+			return aletheia.Invalid("Prerequisite job not found")
 		}
-		return nil, fmt.Errorf("dequeuing job: %w", err)
+		return aletheia.Internal("Failed to record job dependencies", err)
 	}
 
-	if completedAt.Valid {
-		job.CompletedAt = &completedAt.Time
-	}
-	if result.Valid {
-		job.Result = []byte(result.String)
-	}
-	if errorMessage.Valid {
-		job.ErrorMessage = errorMessage.String
+	resolved, err := resolveJobs(ctx, db, []uuid.UUID{job.ID})
+	if err != nil {
+		return err
 	}
-	if workerID.Valid {
-		job.WorkerID = workerID.String
+	if len(resolved) > 0 {
+		job.Status = job.DependencyPolicy.ResolvedStatus()
+		job.ErrorMessage = aletheia.DependencyFailedMessage
 	}
 
-	return job, nil
+	return nil
 }
 
-// Complete marks a job as completed.
-func (q *Queue) Complete(ctx context.Context, jobID uuid.UUID, result []byte) error {
+// findDuplicateJob returns the newest active duplicate of a unique job, or
+// nil if there is none. It takes a transaction-scoped advisory lock on the
+// job's key first, so concurrent enqueues of the same key serialize until
+// the first one commits.
+func findDuplicateJob(ctx context.Context, db database.DBTX, job *aletheia.Job) (*aletheia.Job, error) {
+	lockKey := job.OrganizationID.String() + "/" + job.UniqueKey
+	if _, err := db.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended($1, 0))`, lockKey); err != nil {
+		return nil, aletheia.Internal("Failed to lock unique job key", err)
+	}
+
+	var completedAfter *time.Time
+	if job.UniqueFor > 0 {
+		t := time.Now().Add(-job.UniqueFor)
+		completedAfter = &t
+	}
+
 	query := `
-		UPDATE jobs
-		SET status = $1, completed_at = $2, result = $3
-		WHERE id = $4
+		SELECT ` + jobColumns + ` FROM jobs
+		WHERE unique_key = $1
+		AND organization_id IS NOT DISTINCT FROM $2
+		AND (status = ANY($3) OR (status = $4 AND completed_at > $5))
+		ORDER BY created_at DESC
+		LIMIT 1
 	`
 
-	_, err := q.pool.Exec(ctx, query,
+	existing, err := scanJob(db.QueryRow(ctx, query,
+		job.UniqueKey,
+		nullUUID(job.OrganizationID),
+		[]string{string(aletheia.JobStatusPending), string(aletheia.JobStatusRunning)},
 		aletheia.JobStatusCompleted,
-		time.Now(),
-		result,
-		jobID,
-	)
+		completedAfter,
+	))
 	if err != nil {
-		return fmt.Errorf("completing job: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, aletheia.Internal("Failed to look up duplicate job", err)
 	}
+	return existing, nil
+}
 
-	q.logger.Debug("job completed", slog.String("job_id", jobID.String()))
-	return nil
+// finishDependents resolves the pending dependents of finished jobs, then
+// their dependents in turn, according to each one's DependencyPolicy. It
+// returns the finished jobs along with those it resolved, whose dependents
+// may now be runnable.
+func finishDependents(ctx context.Context, db database.DBTX, finished []uuid.UUID) ([]uuid.UUID, error) {
+	all := finished
+	for len(finished) > 0 {
+		candidates, err := dependentsOf(ctx, db, finished)
+		if err != nil {
+			return nil, err
+		}
+		if finished, err = resolveJobs(ctx, db, candidates); err != nil {
+			return nil, err
+		}
+		all = append(all, finished...)
+	}
+	return all, nil
 }
 
-// Fail marks a job as failed.
-func (q *Queue) Fail(ctx context.Context, jobID uuid.UUID, errMsg string) error {
+// resolveJobs fails or skips the pending jobs among candidates that have a
+// prerequisite that did not complete, unless their policy is to continue.
+// Returns the IDs of the jobs resolved.
+func resolveJobs(ctx context.Context, db database.DBTX, candidates []uuid.UUID) ([]uuid.UUID, error) {
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
 	query := `
-		UPDATE jobs
-		SET status = $1, completed_at = $2, error_message = $3
-		WHERE id = $4
+		UPDATE jobs j
+		SET status = CASE WHEN j.dependency_policy = 'skip' THEN 'cancelled' ELSE 'failed' END,
+			completed_at = now(), error_message = $2
+		WHERE j.id = ANY($1)
+		AND j.status = 'pending'
+		AND j.dependency_policy <> 'continue'
+		AND EXISTS (
+			SELECT 1 FROM job_dependencies d
+			JOIN jobs p ON p.id = d.depends_on_id
+			WHERE d.job_id = j.id AND p.status = ANY($3)
+		)
+		RETURNING j.id
 	`
 
-	_, err := q.pool.Exec(ctx, query,
-		aletheia.JobStatusFailed,
-		time.Now(),
-		errMsg,
-		jobID,
-	)
+	rows, err := db.Query(ctx, query, candidates, aletheia.DependencyFailedMessage, unsuccessfulStatuses())
+	if err != nil {
+		return nil, aletheia.Internal("Failed to resolve dependent jobs", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
 	if err != nil {
-		return fmt.Errorf("failing job: %w", err)
+		return nil, aletheia.Internal("Failed to resolve dependent jobs", err)
 	}
+	return ids, nil
+}
 
-	q.logger.Debug("job failed",
-		slog.String("job_id", jobID.String()),
-		slog.String("error", errMsg))
-	return nil
+// dependentsOf returns the IDs of jobs that depend on any of jobIDs.
+func dependentsOf(ctx context.Context, db database.DBTX, jobIDs []uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := db.Query(ctx, `SELECT DISTINCT job_id FROM job_dependencies WHERE depends_on_id = ANY($1)`, jobIDs)
+	if err != nil {
+		return nil, aletheia.Internal("Failed to list dependent jobs", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return nil, aletheia.Internal("Failed to list dependent jobs", err)
+	}
+	return ids, nil
 }
 
-// GetJob retrieves a job by its ID.
-func (q *Queue) GetJob(ctx context.Context, jobID uuid.UUID) (*aletheia.Job, error) {
-	query := `
-		SELECT id, queue_name, job_type, organization_id, payload, status,
-			priority, max_attempts, attempt_count, scheduled_at, created_at,
-			started_at, completed_at, result, error_message, worker_id
-		FROM jobs
-		WHERE id = $1
-	`
+// notifyDependents wakes workers for queues holding pending jobs that depend
+// on jobIDs, which have finished.
+func (q *Queue) notifyDependents(ctx context.Context, jobIDs []uuid.UUID) {
+	rows, err := q.pool.Query(ctx, `
+		SELECT DISTINCT j.queue_name
+		FROM job_dependencies d
+		JOIN jobs j ON j.id = d.job_id
+		WHERE d.depends_on_id = ANY($1) AND j.status = $2
+	`, jobIDs, aletheia.JobStatusPending)
+	if err != nil {
+		q.logger.Warn("failed to notify dependent jobs", slog.String("error", err.Error()))
+		return
+	}
+
+	queueNames, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		q.logger.Warn("failed to notify dependent jobs", slog.String("error", err.Error()))
+		return
+	}
+	for _, queueName := range queueNames {
+		q.notifyQueue(ctx, queueName)
+	}
+}
 
-	row := q.pool.QueryRow(ctx, query, jobID)
+// nullUUID returns nil for uuid.Nil so it is stored as NULL.
+func nullUUID(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
 
-	job := &aletheia.Job{}
-	var startedAt, completedAt sql.NullTime
-	var result, errorMessage, workerID sql.NullString
+// nullString returns nil for an empty string so it is stored as NULL.
+func nullString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
 
-	err := row.Scan(
-		&job.ID,
-		&job.QueueName,
-		&job.JobType,
-		&job.OrganizationID,
-		&job.Payload,
-		&job.Status,
-		&job.Priority,
-		&job.MaxAttempts,
-		&job.AttemptCount,
-		&job.ScheduledAt,
-		&job.CreatedAt,
-		&startedAt,
-		&completedAt,
-		&result,
-		&errorMessage,
-		&workerID,
-	)
-	if err != nil {
-		if err.Error() == "no rows in result set" {
-			return nil, aletheia.NotFound("Job not found")
-		}
-		return nil, fmt.Errorf("getting job: %w", err)
+// notifyQueue wakes workers listening on queueName. A lost notification
+// only delays the job until the next poll, so errors are logged, not
+// returned.
+func (q *Queue) notifyQueue(ctx context.Context, queueName string) {
+	if _, err := q.pool.Exec(ctx, `SELECT pg_notify($1, $2)`, jobChannel(queueName), queueName); err != nil {
+		q.logger.Warn("failed to notify queue",
+			slog.String("queue", queueName),
+			slog.String("error", err.Error()))
 	}
+}
 
-	if startedAt.Valid {
-		job.StartedAt = &startedAt.Time
+// Listen subscribes to job notifications for queueNames on a dedicated
+// connection, reconnecting if it drops.
+func (q *Queue) Listen(ctx context.Context, queueNames []string) (<-chan string, error) {
+	channels := make([]string, len(queueNames))
+	for i, name := range queueNames {
+		channels[i] = jobChannel(name)
 	}
-	if completedAt.Valid {
-		job.CompletedAt = &completedAt.Time
+
+	ch := make(chan string, len(queueNames))
+	send := func(payload string) { notify(ch, payload) }
+	reconnected := func() {
+		// Notifications sent while disconnected were lost, so have
+		// workers check every queue.
+		for _, name := range queueNames {
+			notify(ch, name)
+		}
 	}
-	if result.Valid {
-		job.Result = []byte(result.String)
+
+	if err := q.listen(ctx, channels, send, reconnected, func() { close(ch) }); err != nil {
+		return nil, aletheia.Internal("Failed to listen for jobs", err)
 	}
-	if errorMessage.Valid {
-		job.ErrorMessage = errorMessage.String
+	return ch, nil
+}
+
+// ListenJobEvents subscribes to job changes on a dedicated connection,
+// reconnecting if it drops.
+func (q *Queue) ListenJobEvents(ctx context.Context) (<-chan aletheia.JobChange, error) {
+	ch := make(chan aletheia.JobChange, jobEventBuffer)
+	send := func(change aletheia.JobChange) {
+		select {
+		case ch <- change:
+		case <-ctx.Done():
+		}
 	}
-	if workerID.Valid {
-		job.WorkerID = workerID.String
+	parse := func(payload string) {
+		if change, err := parseJobChange(payload); err == nil {
+			send(change)
+		}
 	}
+	// Changes made while disconnected were lost, so report that any job
+	// may have changed.
+	reconnected := func() { send(aletheia.JobChange{}) }
 
-	return job, nil
+	if err := q.listen(ctx, []string{jobEventsChannel}, parse, reconnected, func() { close(ch) }); err != nil {
+		return nil, aletheia.Internal("Failed to listen for job events", err)
+	}
+	return ch, nil
 }
 
-// CancelJob cancels a pending job.
-func (q *Queue) CancelJob(ctx context.Context, jobID uuid.UUID) error {
-	query := `
-		UPDATE jobs
-		SET status = $1, completed_at = $2
-		WHERE id = $3 AND status = $4
-	`
+// parseJobChange parses a job event payload: the job ID, followed by a colon
+// and the inspection ID if one was sent.
+func parseJobChange(payload string) (aletheia.JobChange, error) {
+	var change aletheia.JobChange
+	jobID, inspectionID, hasInspection := strings.Cut(payload, ":")
 
-	result, err := q.pool.Exec(ctx, query,
-		aletheia.JobStatusCancelled,
-		time.Now(),
-		jobID,
-		aletheia.JobStatusPending,
-	)
-	if err != nil {
-		return fmt.Errorf("cancelling job: %w", err)
+	var err error
+	if change.JobID, err = uuid.Parse(jobID); err != nil {
+		return change, err
+	}
+	if hasInspection {
+		if change.InspectionID, err = uuid.Parse(inspectionID); err != nil {
+			return change, err
+		}
 	}
+	return change, nil
+}
 
-	if result.RowsAffected() == 0 {
-		return aletheia.Invalid("Can only cancel pending jobs")
+// listen subscribes a dedicated connection to channels and passes each
+// notification's payload to send until ctx is done, then calls done. If the
+// connection drops it reconnects and calls reconnected.
+func (q *Queue) listen(ctx context.Context, channels []string, send func(payload string), reconnected, done func()) error {
+	conn, err := q.listenConn(ctx, channels)
+	if err != nil {
+		return err
 	}
 
-	q.logger.Debug("job cancelled", slog.String("job_id", jobID.String()))
+	go func() {
+		defer done()
+		for {
+			err := q.waitForNotifications(ctx, conn, send)
+			conn.Close(context.Background())
+			if ctx.Err() != nil {
+				return
+			}
+
+			q.logger.Warn("job listener disconnected", slog.String("error", err.Error()))
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(listenRetryDelay):
+				}
+				if conn, err = q.listenConn(ctx, channels); err == nil {
+					break
+				}
+				q.logger.Warn("job listener reconnect failed", slog.String("error", err.Error()))
+			}
+			reconnected()
+		}
+	}()
+
 	return nil
 }
 
-// GetPendingJobs retrieves pending jobs for an organization.
-func (q *Queue) GetPendingJobs(ctx context.Context, orgID uuid.UUID, queueName string) ([]*aletheia.Job, error) {
-	query := `
-		SELECT id, queue_name, job_type, organization_id, payload, status,
-			priority, max_attempts, attempt_count, scheduled_at, created_at,
-			started_at, completed_at, result, error_message, worker_id
-		FROM jobs
-		WHERE organization_id = $1 AND queue_name = $2 AND status = $3
-		ORDER BY priority DESC, created_at ASC
-	`
-
-	rows, err := q.pool.Query(ctx, query, orgID, queueName, aletheia.JobStatusPending)
+// listenConn opens a connection outside the pool, since LISTEN state is
+// tied to the session, and subscribes it to each channel.
+func (q *Queue) listenConn(ctx context.Context, channels []string) (*pgx.Conn, error) {
+	conn, err := pgx.ConnectConfig(ctx, q.pool.Config().ConnConfig.Copy())
 	if err != nil {
-		return nil, fmt.Errorf("querying pending jobs: %w", err)
+		return nil, err
 	}
-	defer rows.Close()
 
-	var jobs []*aletheia.Job
-	for rows.Next() {
-		job := &aletheia.Job{}
-		var startedAt, completedAt sql.NullTime
-		var result, errorMessage, workerID sql.NullString
-
-		err := rows.Scan(
-			&job.ID,
-			&job.QueueName,
-			&job.JobType,
-			&job.OrganizationID,
-			&job.Payload,
-			&job.Status,
-			&job.Priority,
-			&job.MaxAttempts,
-			&job.AttemptCount,
-			&job.ScheduledAt,
-			&job.CreatedAt,
-			&startedAt,
-			&completedAt,
-			&result,
-			&errorMessage,
-			&workerID,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("scanning job: %w", err)
+	for _, channel := range channels {
+		if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+			conn.Close(context.Background())
+			return nil, err
 		}
+	}
 
-		if startedAt.Valid {
-			job.StartedAt = &startedAt.Time
-		}
-		if completedAt.Valid {
-			job.CompletedAt = &completedAt.Time
-		}
-		if result.Valid {
-			job.Result = []byte(result.String)
-		}
-		if errorMessage.Valid {
-			job.ErrorMessage = errorMessage.String
-		}
-		if workerID.Valid {
-			job.WorkerID = workerID.String
+	return conn, nil
+}
+
+// waitForNotifications passes notification payloads to send until the
+// connection fails or ctx is done.
+func (q *Queue) waitForNotifications(ctx context.Context, conn *pgx.Conn, send func(payload string)) error {
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
 		}
+		send(n.Payload)
+	}
+}
 
-		jobs = append(jobs, job)
+// notify sends queueName on ch unless it is full. A full channel already
+// holds pending wakeups, so dropping is harmless.
+func notify(ch chan<- string, queueName string) {
+	select {
+	case ch <- queueName:
+	default:
 	}
+}
 
-	return jobs, nil
+// jobChannel returns the notification channel for a queue.
+func jobChannel(queueName string) string {
+	return "jobs_" + queueName
+}
+
+// notifyJobEvents tells job event listeners that jobIDs changed.
+func (q *Queue) notifyJobEvents(ctx context.Context, jobIDs ...uuid.UUID) {
+	q.sendJobEvents(ctx, jobIDs, []*uuid.UUID{})
+}
+
+// notifyEnqueued tells job event listeners that jobs were enqueued,
+// including the inspection each works on.
+func (q *Queue) notifyEnqueued(ctx context.Context, jobs ...*aletheia.Job) {
+	jobIDs := make([]uuid.UUID, len(jobs))
+	inspectionIDs := make([]*uuid.UUID, len(jobs))
+	for i, job := range jobs {
+		jobIDs[i] = job.ID
+		inspectionIDs[i] = nullUUID(job.InspectionID)
+	}
+	q.sendJobEvents(ctx, jobIDs, inspectionIDs)
+}
+
+// sendJobEvents notifies job event listeners of each job in jobIDs, along
+// with the matching entry of inspectionIDs if there is one. Notifications
+// are delivered when the statement commits, and identical ones are merged. A lost
+// notification only delays the update until the next poll, so errors are
+// logged, not returned.
+func (q *Queue) sendJobEvents(ctx context.Context, jobIDs []uuid.UUID, inspectionIDs []*uuid.UUID) {
+	if len(jobIDs) == 0 {
+		return
+	}
+
+	query := `
+		SELECT pg_notify($1, job_id::text || COALESCE(':' || inspection_id::text, ''))
+		FROM unnest($2::uuid[], $3::uuid[]) AS t(job_id, inspection_id)
+	`
+
+	if _, err := q.pool.Exec(ctx, query, jobEventsChannel, jobIDs, inspectionIDs); err != nil {
+		q.logger.Warn("failed to notify job events", slog.String("error", err.Error()))
+	}
+}
+
+// Dequeue retrieves the next available job from a queue, sharing workers
+// fairly between organizations and honouring their rate limits.
+func (q *Queue) Dequeue(ctx context.Context, queueName, workerID string) (*aletheia.Job, error) {
+	// Dequeues from a queue are serialized so concurrent workers see each
+	// other's running jobs when checking limits. The lock is released when
+	// the transaction ends.
+	tx, err := q.pool.Begin(ctx)
+	if err != nil {
+		return nil, aletheia.Internal("Failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended($1, 0))`, jobChannel(queueName)); err != nil {
+		return nil, aletheia.Internal("Failed to lock queue", err)
+	}
+
+	// usage counts each organization's running jobs and the jobs it started
+	// in the last hour. A NULL limit is unlimited.
+	query := `
+		WITH usage AS (
+			SELECT organization_id,
+				count(*) FILTER (WHERE status = $4) AS running,
+				count(*) FILTER (WHERE started_at > $2::timestamptz - interval '1 hour') AS started
+			FROM jobs
+			WHERE queue_name = $3
+			AND (status = $4 OR started_at > $2::timestamptz - interval '1 hour')
+			GROUP BY organization_id
+		)
+		UPDATE jobs
+		SET status = $4, started_at = $2, heartbeat_at = $2,
+			attempt_count = attempt_count + 1, worker_id = $5,
+			progress = 0, progress_message = NULL
+		WHERE id = (
+			SELECT j.id FROM jobs j
+			LEFT JOIN usage u ON u.organization_id = j.organization_id
+			LEFT JOIN organization_rate_limits l
+				ON l.organization_id = j.organization_id AND l.queue_name = j.queue_name
+			WHERE j.queue_name = $3
+			AND j.status = $1
+			AND j.scheduled_at <= $2
+			AND ` + dependenciesMet + `
+			AND (NOT $6::boolean OR (
+				COALESCE(u.running, 0) < COALESCE(l.max_concurrent_jobs, $7, 2147483647)
+				AND COALESCE(u.started, 0) < COALESCE(l.max_jobs_per_hour, $8, 2147483647)
+			))
+			ORDER BY j.priority DESC, COALESCE(u.running, 0) ASC, j.created_at ASC
+			FOR UPDATE OF j SKIP LOCKED
+			LIMIT 1
+		)
+		RETURNING ` + jobColumns
+
+	row := tx.QueryRow(ctx, query,
+		aletheia.JobStatusPending,
+		time.Now(),
+		queueName,
+		aletheia.JobStatusRunning,
+		workerID,
+		q.cfg.EnableRateLimiting,
+		positiveOrNil(q.cfg.DefaultMaxConcurrentJobs),
+		positiveOrNil(q.cfg.DefaultMaxJobsPerHour),
+	)
+
+	job, err := scanJob(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil // No jobs available
+		}
+		return nil, aletheia.Internal("Failed to dequeue job", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, aletheia.Internal("Failed to commit dequeue", err)
+	}
+
+	q.notifyJobEvents(ctx, job.ID)
+	return job, nil
+}
+
+// dependenciesMet matches jobs j whose prerequisites have completed or, for
+// jobs that continue regardless, finished.
+const dependenciesMet = `NOT EXISTS (
+	SELECT 1 FROM job_dependencies d
+	JOIN jobs p ON p.id = d.depends_on_id
+	WHERE d.job_id = j.id
+	AND p.status <> 'completed'
+	AND (j.dependency_policy <> 'continue' OR p.status IN ('pending', 'running'))
+)`
+
+// positiveOrNil returns nil for a non-positive limit, which SQL treats as
+// unlimited.
+func positiveOrNil(n int) *int {
+	if n <= 0 {
+		return nil
+	}
+	return &n
+}
+
+// Heartbeat records that workerID is still processing a job.
+func (q *Queue) Heartbeat(ctx context.Context, jobID uuid.UUID, workerID string) error {
+	query := `
+		UPDATE jobs
+		SET heartbeat_at = $1
+		WHERE id = $2 AND status = $3 AND worker_id = $4
+	`
+
+	tag, err := q.pool.Exec(ctx, query, time.Now(), jobID, aletheia.JobStatusRunning, workerID)
+	if err != nil {
+		return aletheia.Internal("Failed to record heartbeat", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		if _, err := q.GetJob(ctx, jobID); err != nil {
+			return err
+		}
+		return aletheia.Conflict("Job is no longer held by this worker")
+	}
+
+	return nil
+}
+
+// UpdateProgress records the progress of a job running on workerID.
+func (q *Queue) UpdateProgress(ctx context.Context, jobID uuid.UUID, workerID string, percent int, message string) error {
+	if percent < 0 || percent > 100 {
+		return aletheia.Invalid("Progress must be between 0 and 100")
+	}
+
+	query := `
+		UPDATE jobs
+		SET progress = $1, progress_message = NULLIF($2, ''), heartbeat_at = $3
+		WHERE id = $4 AND status = $5 AND worker_id = $6
+	`
+
+	tag, err := q.pool.Exec(ctx, query, percent, message, time.Now(), jobID, aletheia.JobStatusRunning, workerID)
+	if err != nil {
+		return aletheia.Internal("Failed to record job progress", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		if _, err := q.GetJob(ctx, jobID); err != nil {
+			return err
+		}
+		return aletheia.Conflict("Job is no longer held by this worker")
+	}
+
+	q.notifyJobEvents(ctx, jobID)
+	return nil
+}
+
+// ReapStaleJobs reclaims running jobs whose heartbeat is older than timeout.
+func (q *Queue) ReapStaleJobs(ctx context.Context, timeout time.Duration) (int, error) {
+	// A job dequeued before heartbeats existed falls back to started_at.
+	query := `
+		UPDATE jobs
+		SET status = CASE WHEN attempt_count >= max_attempts THEN $1 ELSE $2 END,
+			completed_at = CASE WHEN attempt_count >= max_attempts THEN $3::timestamptz END,
+			scheduled_at = CASE WHEN attempt_count >= max_attempts THEN scheduled_at ELSE $3 END,
+			error_message = 'worker ' || COALESCE(worker_id, 'unknown') || ' stopped sending heartbeats',
+			errors = errors || jsonb_build_array(jsonb_build_object(
+				'attempt', attempt_count,
+				'message', 'worker ' || COALESCE(worker_id, 'unknown') || ' stopped sending heartbeats',
+				'failedAt', $3::timestamptz
+			)),
+			worker_id = NULL,
+			heartbeat_at = NULL
+		WHERE status = $4 AND COALESCE(heartbeat_at, started_at) < $5
+		RETURNING id, status
+	`
+
+	tx, err := q.pool.Begin(ctx)
+	if err != nil {
+		return 0, aletheia.Internal("Failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	now := time.Now()
+	rows, err := tx.Query(ctx, query,
+		aletheia.JobStatusDead,
+		aletheia.JobStatusPending,
+		now,
+		aletheia.JobStatusRunning,
+		now.Add(-timeout),
+	)
+	if err != nil {
+		return 0, aletheia.Internal("Failed to reap stale jobs", err)
+	}
+	defer rows.Close()
+
+	var reaped, dead []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		var status aletheia.JobStatus
+		if err := rows.Scan(&id, &status); err != nil {
+			return 0, aletheia.Internal("Failed to scan reaped job", err)
+		}
+		reaped = append(reaped, id)
+		if status == aletheia.JobStatusDead {
+			dead = append(dead, id)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, aletheia.Internal("Failed to reap stale jobs", err)
+	}
+
+	finished, err := finishDependents(ctx, tx, dead)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, aletheia.Internal("Failed to commit reaped jobs", err)
+	}
+
+	for _, id := range reaped {
+		q.logger.Warn("reclaimed stale job", slog.String("job_id", id.String()))
+	}
+	if len(finished) > 0 {
+		q.notifyDependents(ctx, finished)
+	}
+	q.notifyJobEvents(ctx, append(reaped, finished...)...)
+
+	return len(reaped), nil
+}
+
+// Complete marks a job running on workerID as completed.
+func (q *Queue) Complete(ctx context.Context, jobID uuid.UUID, workerID string, result []byte) error {
+	query := `
+		UPDATE jobs
+		SET status = $1, completed_at = $2, result = $3, progress = 100
+		WHERE id = $4 AND status = $5 AND worker_id = $6
+	`
+
+	tag, err := q.pool.Exec(ctx, query,
+		aletheia.JobStatusCompleted,
+		time.Now(),
+		result,
+		jobID,
+		aletheia.JobStatusRunning,
+		workerID,
+	)
+	if err != nil {
+		return aletheia.Internal("Failed to complete job", err)
+	}
+	if tag.RowsAffected() == 0 {
+		if _, err := q.GetJob(ctx, jobID); err != nil {
+			return err
+		}
+		return aletheia.Conflict("Job is no longer held by this worker")
+	}
+
+	q.logger.Debug("job completed", slog.String("job_id", jobID.String()))
+	q.notifyDependents(ctx, []uuid.UUID{jobID})
+	q.notifyJobEvents(ctx, jobID)
+	return nil
+}
+
+// Fail records a failed attempt of a job running on workerID, rescheduling
+// the job with backoff or marking it dead or failed once it cannot be
+// retried.
+func (q *Queue) Fail(ctx context.Context, jobID uuid.UUID, workerID string, jobErr error) error {
+	if jobErr == nil {
+		jobErr = errors.New("job failed")
+	}
+
+	tx, err := q.pool.Begin(ctx)
+	if err != nil {
+		return aletheia.Internal("Failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	job, err := scanJob(tx.QueryRow(ctx, `SELECT `+jobColumns+` FROM jobs WHERE id = $1 FOR UPDATE`, jobID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return aletheia.NotFound("Job not found")
+		}
+		return aletheia.Internal("Failed to fetch job", err)
+	}
+	if job.Status != aletheia.JobStatusRunning || job.WorkerID != workerID {
+		return aletheia.Conflict("Job is no longer held by this worker")
+	}
+
+	now := time.Now()
+	entry, err := json.Marshal([]aletheia.JobError{{
+		Attempt:  job.AttemptCount,
+		Message:  jobErr.Error(),
+		FailedAt: now,
+	}})
+	if err != nil {
+		return aletheia.Internal("Failed to encode job error", err)
+	}
+
+	status := aletheia.JobStatusPending
+	scheduledAt := now.Add(q.cfg.RetryDelay(job.AttemptCount))
+	var completedAt *time.Time
+	if !job.CanRetry(jobErr) {
+		status = job.FailedStatus(jobErr)
+		scheduledAt = job.ScheduledAt
+		completedAt = &now
+	}
+
+	query := `
+		UPDATE jobs
+		SET status = $1, scheduled_at = $2, completed_at = $3,
+			error_message = $4, errors = errors || $5::jsonb
+		WHERE id = $6 AND status = $7 AND worker_id = $8
+	`
+
+	if _, err := tx.Exec(ctx, query, status, scheduledAt, completedAt, jobErr.Error(), entry, jobID, aletheia.JobStatusRunning, workerID); err != nil {
+		return aletheia.Internal("Failed to fail job", err)
+	}
+
+	var finished []uuid.UUID
+	if status != aletheia.JobStatusPending {
+		if finished, err = finishDependents(ctx, tx, []uuid.UUID{jobID}); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return aletheia.Internal("Failed to commit job failure", err)
+	}
+
+	if status == aletheia.JobStatusPending {
+		q.logger.Debug("job retry scheduled",
+			slog.String("job_id", jobID.String()),
+			slog.Int("attempt", job.AttemptCount),
+			slog.Time("scheduled_at", scheduledAt),
+			slog.String("error", jobErr.Error()))
+		q.notifyJobEvents(ctx, jobID)
+		return nil
+	}
+
+	q.logger.Debug("job failed",
+		slog.String("job_id", jobID.String()),
+		slog.String("status", string(status)),
+		slog.Int("attempt", job.AttemptCount),
+		slog.String("error", jobErr.Error()))
+	q.notifyDependents(ctx, finished)
+	q.notifyJobEvents(ctx, finished...)
+	return nil
+}
+
+// CleanupJobs deletes jobs that finished more than retention ago.
+func (q *Queue) CleanupJobs(ctx context.Context, retention time.Duration) (int, error) {
+	cutoff := time.Now().Add(-retention)
+
+	tag, err := q.pool.Exec(ctx, `
+		DELETE FROM jobs
+		WHERE status = ANY($1) AND completed_at < $2
+	`, terminalStatuses(), cutoff)
+	if err != nil {
+		return 0, aletheia.Internal("Failed to clean up jobs", err)
+	}
+
+	if _, err := q.pool.Exec(ctx, `DELETE FROM scheduled_job_runs WHERE created_at < $1`, cutoff); err != nil {
+		return 0, aletheia.Internal("Failed to clean up scheduled runs", err)
+	}
+
+	deleted := int(tag.RowsAffected())
+	q.logger.Info("cleaned up old jobs",
+		slog.Int("deleted", deleted),
+		slog.Time("cutoff", cutoff))
+	return deleted, nil
+}
+
+// GetJob retrieves a job by its ID.
+func (q *Queue) GetJob(ctx context.Context, jobID uuid.UUID) (*aletheia.Job, error) {
+	query := `SELECT ` + jobColumns + ` FROM jobs WHERE id = $1`
+
+	job, err := scanJob(q.pool.QueryRow(ctx, query, jobID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, aletheia.NotFound("Job not found")
+		}
+		return nil, aletheia.Internal("Failed to fetch job", err)
+	}
+
+	return job, nil
+}
+
+// CancelJob cancels a pending job.
+func (q *Queue) CancelJob(ctx context.Context, jobID uuid.UUID) error {
+	query := `
+		UPDATE jobs
+		SET status = $1, completed_at = $2
+		WHERE id = $3 AND status = $4
+	`
+
+	tx, err := q.pool.Begin(ctx)
+	if err != nil {
+		return aletheia.Internal("Failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, query,
+		aletheia.JobStatusCancelled,
+		time.Now(),
+		jobID,
+		aletheia.JobStatusPending,
+	)
+	if err != nil {
+		return aletheia.Internal("Failed to cancel job", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		// Distinguish a missing job from one that is no longer pending.
+		if _, err := q.GetJob(ctx, jobID); err != nil {
+			return err
+		}
+		return aletheia.Invalid("Can only cancel pending jobs")
+	}
+
+	finished, err := finishDependents(ctx, tx, []uuid.UUID{jobID})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return aletheia.Internal("Failed to commit job cancellation", err)
+	}
+
+	q.logger.Debug("job cancelled", slog.String("job_id", jobID.String()))
+	q.notifyDependents(ctx, finished)
+	q.notifyJobEvents(ctx, finished...)
+	return nil
+}
+
+// GetPendingJobs retrieves pending jobs for an organization.
+func (q *Queue) GetPendingJobs(ctx context.Context, orgID uuid.UUID, queueName string) ([]*aletheia.Job, error) {
+	query := `
+		SELECT ` + jobColumns + `
+		FROM jobs
+		WHERE organization_id = $1 AND queue_name = $2 AND status = $3
+		ORDER BY priority DESC, created_at ASC
+	`
+
+	rows, err := q.pool.Query(ctx, query, orgID, queueName, aletheia.JobStatusPending)
+	if err != nil {
+		return nil, aletheia.Internal("Failed to list pending jobs", err)
+	}
+	defer rows.Close()
+
+	jobs := []*aletheia.Job{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, aletheia.Internal("Failed to scan job", err)
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, aletheia.Internal("Failed to list pending jobs", err)
+	}
+
+	return jobs, nil
+}
+
+// GetRunningJobs retrieves running jobs, optionally limited to one queue.
+func (q *Queue) GetRunningJobs(ctx context.Context, queueName string) ([]*aletheia.Job, error) {
+	query := `
+		SELECT ` + jobColumns + `
+		FROM jobs
+		WHERE status = $1 AND ($2 = '' OR queue_name = $2)
+		ORDER BY started_at ASC
+	`
+
+	rows, err := q.pool.Query(ctx, query, aletheia.JobStatusRunning, queueName)
+	if err != nil {
+		return nil, aletheia.Internal("Failed to list running jobs", err)
+	}
+	defer rows.Close()
+
+	jobs := []*aletheia.Job{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, aletheia.Internal("Failed to scan job", err)
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, aletheia.Internal("Failed to list running jobs", err)
+	}
+
+	return jobs, nil
+}
+
+// jobFilterWhere matches the JobFilter arguments passed as $1 to $5, in the
+// order of jobFilterArgs.
+const jobFilterWhere = `
+	($1::text IS NULL OR queue_name = $1)
+	AND ($2::text IS NULL OR job_type = $2)
+	AND ($3::text IS NULL OR status = $3)
+	AND ($4::uuid IS NULL OR organization_id = $4)
+	AND ($5::uuid IS NULL OR inspection_id = $5)`
+
+// jobFilterArgs returns the arguments for jobFilterWhere.
+func jobFilterArgs(filter aletheia.JobFilter) []any {
+	return []any{filter.QueueName, filter.JobType, filter.Status, filter.OrganizationID, filter.InspectionID}
+}
+
+// ListJobs retrieves jobs matching filter, newest first.
+func (q *Queue) ListJobs(ctx context.Context, filter aletheia.JobFilter) ([]*aletheia.Job, int, error) {
+	args := jobFilterArgs(filter)
+
+	var total int
+	if err := q.pool.QueryRow(ctx, `SELECT count(*) FROM jobs WHERE `+jobFilterWhere, args...).Scan(&total); err != nil {
+		return nil, 0, aletheia.Internal("Failed to count jobs", err)
+	}
+
+	// A NULL limit returns all rows.
+	var limit *int
+	if filter.Limit > 0 {
+		limit = &filter.Limit
+	}
+
+	query := `
+		SELECT ` + jobColumns + `
+		FROM jobs
+		WHERE ` + jobFilterWhere + `
+		ORDER BY created_at DESC, id
+		LIMIT $6 OFFSET $7
+	`
+
+	rows, err := q.pool.Query(ctx, query, append(args, limit, max(filter.Offset, 0))...)
+	if err != nil {
+		return nil, 0, aletheia.Internal("Failed to list jobs", err)
+	}
+	defer rows.Close()
+
+	jobs := []*aletheia.Job{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, 0, aletheia.Internal("Failed to scan job", err)
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, aletheia.Internal("Failed to list jobs", err)
+	}
+
+	return jobs, total, nil
+}
+
+// retryJobSet resets a job for a fresh round of attempts. The error history
+// is kept so the earlier failures stay visible.
+const retryJobSet = `
+	status = 'pending', attempt_count = 0, scheduled_at = now(),
+	started_at = NULL, completed_at = NULL, result = NULL,
+	error_message = NULL, worker_id = NULL, heartbeat_at = NULL,
+	progress = 0, progress_message = NULL`
+
+// RetryJob returns a failed, dead, or cancelled job to pending.
+func (q *Queue) RetryJob(ctx context.Context, jobID uuid.UUID) error {
+	query := `
+		UPDATE jobs
+		SET ` + retryJobSet + `
+		WHERE id = $1 AND status = ANY($2)
+		RETURNING queue_name
+	`
+
+	var queueName string
+	err := q.pool.QueryRow(ctx, query, jobID, retryableStatuses()).Scan(&queueName)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return aletheia.Internal("Failed to retry job", err)
+		}
+		// Distinguish a missing job from one that cannot be retried.
+		if _, err := q.GetJob(ctx, jobID); err != nil {
+			return err
+		}
+		return aletheia.Invalid("Can only retry failed, dead, or cancelled jobs")
+	}
+
+	q.logger.Info("job retried", slog.String("job_id", jobID.String()))
+	q.notifyQueue(ctx, queueName)
+	q.notifyJobEvents(ctx, jobID)
+	return nil
+}
+
+// RetryJobs retries every retryable job matching filter.
+func (q *Queue) RetryJobs(ctx context.Context, filter aletheia.JobFilter) (int, error) {
+	query := `
+		UPDATE jobs
+		SET ` + retryJobSet + `
+		WHERE ` + jobFilterWhere + ` AND status = ANY($6)
+		RETURNING id, queue_name
+	`
+
+	rows, err := q.pool.Query(ctx, query, append(jobFilterArgs(filter), retryableStatuses())...)
+	if err != nil {
+		return 0, aletheia.Internal("Failed to retry jobs", err)
+	}
+	defer rows.Close()
+
+	var retried []uuid.UUID
+	queues := map[string]bool{}
+	for rows.Next() {
+		var id uuid.UUID
+		var queueName string
+		if err := rows.Scan(&id, &queueName); err != nil {
+			return len(retried), aletheia.Internal("Failed to scan retried job", err)
+		}
+		queues[queueName] = true
+		retried = append(retried, id)
+	}
+	if err := rows.Err(); err != nil {
+		return len(retried), aletheia.Internal("Failed to retry jobs", err)
+	}
+
+	q.logger.Info("jobs retried", slog.Int("count", len(retried)))
+	for queueName := range queues {
+		q.notifyQueue(ctx, queueName)
+	}
+	q.notifyJobEvents(ctx, retried...)
+	return len(retried), nil
+}
+
+// CancelJobs cancels every pending job matching filter.
+func (q *Queue) CancelJobs(ctx context.Context, filter aletheia.JobFilter) (int, error) {
+	query := `
+		UPDATE jobs
+		SET status = $6, completed_at = $7
+		WHERE ` + jobFilterWhere + ` AND status = $8
+		RETURNING id
+	`
+
+	tx, err := q.pool.Begin(ctx)
+	if err != nil {
+		return 0, aletheia.Internal("Failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	args := append(jobFilterArgs(filter), aletheia.JobStatusCancelled, time.Now(), aletheia.JobStatusPending)
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return 0, aletheia.Internal("Failed to cancel jobs", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return 0, aletheia.Internal("Failed to cancel jobs", err)
+	}
+
+	finished, err := finishDependents(ctx, tx, ids)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, aletheia.Internal("Failed to commit job cancellation", err)
+	}
+
+	q.logger.Info("jobs cancelled", slog.Int("count", len(ids)))
+	if len(finished) > 0 {
+		q.notifyDependents(ctx, finished)
+	}
+	q.notifyJobEvents(ctx, finished...)
+	return len(ids), nil
+}
+
+// rateLimitColumns lists the columns scanned by scanRateLimit, in order.
+const rateLimitColumns = `organization_id, queue_name, tier, max_jobs_per_hour,
+	max_concurrent_jobs, created_at, updated_at`
+
+// ListRateLimits retrieves the rate limits set for an organization.
+func (q *Queue) ListRateLimits(ctx context.Context, orgID uuid.UUID) ([]*aletheia.RateLimit, error) {
+	query := `
+		SELECT ` + rateLimitColumns + `
+		FROM organization_rate_limits
+		WHERE organization_id = $1
+		ORDER BY queue_name
+	`
+
+	rows, err := q.pool.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, aletheia.Internal("Failed to list rate limits", err)
+	}
+	defer rows.Close()
+
+	limits := []*aletheia.RateLimit{}
+	for rows.Next() {
+		limit, err := scanRateLimit(rows)
+		if err != nil {
+			return nil, aletheia.Internal("Failed to scan rate limit", err)
+		}
+		limits = append(limits, limit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, aletheia.Internal("Failed to list rate limits", err)
+	}
+
+	return limits, nil
+}
+
+// SetRateLimit creates or replaces the rate limit for an organization's queue.
+func (q *Queue) SetRateLimit(ctx context.Context, limit *aletheia.RateLimit) error {
+	if err := limit.Validate(); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO organization_rate_limits (
+			organization_id, queue_name, tier, max_jobs_per_hour, max_concurrent_jobs
+		) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (organization_id, queue_name) DO UPDATE
+		SET tier = EXCLUDED.tier,
+			max_jobs_per_hour = EXCLUDED.max_jobs_per_hour,
+			max_concurrent_jobs = EXCLUDED.max_concurrent_jobs,
+			updated_at = now()
+		RETURNING ` + rateLimitColumns
+
+	stored, err := scanRateLimit(q.pool.QueryRow(ctx, query,
+		limit.OrganizationID,
+		limit.QueueName,
+		limit.Tier,
+		limit.MaxJobsPerHour,
+		limit.MaxConcurrentJobs,
+	))
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return aletheia.NotFound("Organization not found")
+		}
+		return aletheia.Internal("Failed to set rate limit", err)
+	}
+	*limit = *stored
+
+	q.logger.Info("rate limit set",
+		slog.String("organization_id", limit.OrganizationID.String()),
+		slog.String("queue", limit.QueueName),
+		slog.String("tier", limit.Tier))
+	return nil
+}
+
+// DeleteRateLimit removes an organization's rate limit for a queue.
+func (q *Queue) DeleteRateLimit(ctx context.Context, orgID uuid.UUID, queueName string) error {
+	tag, err := q.pool.Exec(ctx,
+		`DELETE FROM organization_rate_limits WHERE organization_id = $1 AND queue_name = $2`,
+		orgID, queueName)
+	if err != nil {
+		return aletheia.Internal("Failed to delete rate limit", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return aletheia.NotFound("Rate limit not found")
+	}
+	return nil
+}
+
+// scanRateLimit scans a row selected with rateLimitColumns.
+func scanRateLimit(row pgx.Row) (*aletheia.RateLimit, error) {
+	limit := &aletheia.RateLimit{}
+	err := row.Scan(
+		&limit.OrganizationID,
+		&limit.QueueName,
+		&limit.Tier,
+		&limit.MaxJobsPerHour,
+		&limit.MaxConcurrentJobs,
+		&limit.CreatedAt,
+		&limit.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return limit, nil
+}
+
+// terminalStatuses returns the statuses of jobs that have finished.
+func terminalStatuses() []string {
+	return []string{
+		string(aletheia.JobStatusCompleted),
+		string(aletheia.JobStatusFailed),
+		string(aletheia.JobStatusCancelled),
+		string(aletheia.JobStatusDead),
+	}
+}
+
+// retryableStatuses returns the statuses RetryJob accepts.
+func retryableStatuses() []string {
+	return []string{
+		string(aletheia.JobStatusFailed),
+		string(aletheia.JobStatusCancelled),
+		string(aletheia.JobStatusDead),
+	}
+}
+
+// unsuccessfulStatuses returns the statuses of jobs that finished without
+// completing.
+func unsuccessfulStatuses() []string {
+	return []string{
+		string(aletheia.JobStatusFailed),
+		string(aletheia.JobStatusCancelled),
+		string(aletheia.JobStatusDead),
+	}
+}
+
+// scanJob scans a row selected with jobColumns.
+func scanJob(row pgx.Row) (*aletheia.Job, error) {
+	job := &aletheia.Job{}
+	var orgID, inspectionID *uuid.UUID
+	var errorMessage, workerID, progressMessage, uniqueKey *string
+
+	err := row.Scan(
+		&job.ID,
+		&job.QueueName,
+		&job.JobType,
+		&orgID,
+		&job.Payload,
+		&job.Status,
+		&job.Priority,
+		&job.MaxAttempts,
+		&job.AttemptCount,
+		&job.ScheduledAt,
+		&job.CreatedAt,
+		&job.StartedAt,
+		&job.CompletedAt,
+		&job.Result,
+		&errorMessage,
+		&job.Errors,
+		&workerID,
+		&job.HeartbeatAt,
+		&inspectionID,
+		&job.Progress,
+		&progressMessage,
+		&job.DependencyPolicy,
+		&uniqueKey,
+		&job.DependsOn,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if orgID != nil {
+		job.OrganizationID = *orgID
+	}
+	if inspectionID != nil {
+		job.InspectionID = *inspectionID
+	}
+	if progressMessage != nil {
+		job.ProgressMessage = *progressMessage
+	}
+	if uniqueKey != nil {
+		job.UniqueKey = *uniqueKey
+	}
+	if errorMessage != nil {
+		job.ErrorMessage = *errorMessage
+	}
+	if workerID != nil {
+		job.WorkerID = *workerID
+	}
+	if len(job.DependsOn) == 0 {
+		job.DependsOn = nil
+	}
+
+	return job, nil
 }
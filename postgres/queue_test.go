@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/queue/queuetest"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+// setupTestPool connects to the database named by GOOSE_DBSTRING, skipping
+// the test when it is not set. The schema must already be migrated.
+func setupTestPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	connString := os.Getenv("GOOSE_DBSTRING")
+	if connString == "" {
+		t.Skip("GOOSE_DBSTRING not set, skipping integration tests")
+	}
+
+	pool, err := pgxpool.New(context.Background(), connString)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	require.NoError(t, pool.Ping(context.Background()))
+	return pool
+}
+
+// createTestOrganization inserts an organization that is deleted, along with
+// its jobs, when the test finishes.
+func createTestOrganization(t *testing.T, pool *pgxpool.Pool) uuid.UUID {
+	t.Helper()
+
+	orgID := uuid.New()
+	_, err := pool.Exec(context.Background(),
+		`INSERT INTO organizations (id, name) VALUES ($1, 'Test Organization')`, orgID)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_, _ = pool.Exec(context.Background(), `DELETE FROM organizations WHERE id = $1`, orgID)
+	})
+	return orgID
+}
+
+func TestQueue_Conformance(t *testing.T) {
+	pool := setupTestPool(t)
+	logger := slog.New(slog.DiscardHandler)
+
+	queuetest.Run(t, func(t *testing.T) (aletheia.Queue, queuetest.OrgFactory) {
+		newOrg := func(t *testing.T) uuid.UUID { return createTestOrganization(t, pool) }
+		return NewQueue(pool, logger, aletheia.DefaultQueueConfig()), newOrg
+	})
+}
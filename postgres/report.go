@@ -0,0 +1,236 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Compile-time check that ReportService implements aletheia.ReportService.
+var _ aletheia.ReportService = (*ReportService)(nil)
+
+// ReportService implements aletheia.ReportService using PostgreSQL.
+type ReportService struct {
+	db *DB
+}
+
+func (s *ReportService) FindReportByID(ctx context.Context, id uuid.UUID) (*aletheia.Report, error) {
+	report, err := s.db.queries.GetReport(ctx, toPgUUID(id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, aletheia.NotFound("Report not found")
+		}
+		return nil, aletheia.Internal("Failed to fetch report", err)
+	}
+	return toDomainReport(report), nil
+}
+
+func (s *ReportService) FindReports(ctx context.Context, inspectionID uuid.UUID) ([]*aletheia.Report, error) {
+	reports, err := s.db.queries.ListReports(ctx, toPgUUID(inspectionID))
+	if err != nil {
+		return nil, aletheia.Internal("Failed to list reports", err)
+	}
+	return toDomainReports(reports), nil
+}
+
+func (s *ReportService) FindReportBySHA256(ctx context.Context, sha256 string) (*aletheia.Report, error) {
+	if sha256 == "" {
+		return nil, aletheia.NotFound("Report not found")
+	}
+	report, err := s.db.queries.GetReportBySHA256(ctx, sha256)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, aletheia.NotFound("Report not found")
+		}
+		return nil, aletheia.Internal("Failed to fetch report", err)
+	}
+	return toDomainReport(report), nil
+}
+
+func (s *ReportService) CreateReport(ctx context.Context, report *aletheia.Report) error {
+	snapshot, err := json.Marshal(report.Snapshot)
+	if err != nil {
+		return aletheia.Internal("Failed to encode report snapshot", err)
+	}
+
+	if report.Template == "" {
+		report.Template = aletheia.ReportTemplateFull
+	}
+
+	if report.Signoffs == nil {
+		report.Signoffs = []aletheia.Signoff{}
+	}
+	signoffs, err := json.Marshal(report.Signoffs)
+	if err != nil {
+		return aletheia.Internal("Failed to encode report sign-offs", err)
+	}
+
+	dbReport, err := s.db.queries.CreateReport(ctx, database.CreateReportParams{
+		InspectionID: toPgUUID(report.InspectionID),
+		StorageUrl:   report.StorageURL,
+		SizeBytes:    report.SizeBytes,
+		SnapshotHash: report.SnapshotHash,
+		Snapshot:     snapshot,
+		GeneratedBy:  toPgUUID(report.GeneratedBy),
+		Template:     database.ReportTemplate(report.Template),
+		Sha256:       report.SHA256,
+		Signature:    report.Signature,
+		SigningKeyID: report.SigningKeyID,
+		Signoffs:     signoffs,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if isForeignKeyViolation(err) && errors.As(err, &pgErr) && pgErr.ConstraintName == "reports_generated_by_fkey" {
+			return aletheia.NotFound("User not found")
+		}
+		if isForeignKeyViolation(err) {
+			return aletheia.NotFound("Inspection not found")
+		}
+		// Another version was created at the same time
+		if isUniqueViolation(err) {
+			return aletheia.Conflict("Report version already exists")
+		}
+		return aletheia.Internal("Failed to create report", err)
+	}
+
+	*report = *toDomainReport(dbReport)
+	return nil
+}
+
+func (s *ReportService) FindReportDeliveryByID(ctx context.Context, id uuid.UUID) (*aletheia.ReportDelivery, error) {
+	delivery, err := s.db.queries.GetReportDelivery(ctx, toPgUUID(id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, aletheia.NotFound("Report delivery not found")
+		}
+		return nil, aletheia.Internal("Failed to fetch report delivery", err)
+	}
+	return toDomainReportDelivery(delivery), nil
+}
+
+func (s *ReportService) FindReportDeliveries(ctx context.Context, reportID uuid.UUID) ([]*aletheia.ReportDelivery, error) {
+	deliveries, err := s.db.queries.ListReportDeliveries(ctx, toPgUUID(reportID))
+	if err != nil {
+		return nil, aletheia.Internal("Failed to list report deliveries", err)
+	}
+	return toDomainReportDeliveries(deliveries), nil
+}
+
+func (s *ReportService) CreateReportDelivery(ctx context.Context, delivery *aletheia.ReportDelivery) error {
+	dbDelivery, err := s.db.queries.CreateReportDelivery(ctx, database.CreateReportDeliveryParams{
+		ReportID:  toPgUUID(delivery.ReportID),
+		Recipient: delivery.Recipient,
+		ContactID: toPgUUID(delivery.ContactID),
+		Attached:  delivery.Attached,
+		Message:   toPgText(delivery.Message),
+		SentBy:    toPgUUID(delivery.SentBy),
+	})
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return aletheia.NotFound("Report not found")
+		}
+		return aletheia.Internal("Failed to create report delivery", err)
+	}
+
+	*delivery = *toDomainReportDelivery(dbDelivery)
+	return nil
+}
+
+func (s *ReportService) MarkReportDeliverySent(ctx context.Context, id uuid.UUID, messageID string) error {
+	_, err := s.db.queries.MarkReportDeliverySent(ctx, database.MarkReportDeliverySentParams{
+		ID:                toPgUUID(id),
+		ProviderMessageID: toPgText(messageID),
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return aletheia.NotFound("Report delivery not found")
+		}
+		return aletheia.Internal("Failed to update report delivery", err)
+	}
+	return nil
+}
+
+func (s *ReportService) MarkReportDeliveryFailed(ctx context.Context, id uuid.UUID, reason string) error {
+	_, err := s.db.queries.MarkReportDeliveryFailed(ctx, database.MarkReportDeliveryFailedParams{
+		ID:    toPgUUID(id),
+		Error: toPgText(reason),
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return aletheia.NotFound("Report delivery not found")
+		}
+		return aletheia.Internal("Failed to update report delivery", err)
+	}
+	return nil
+}
+
+func (s *ReportService) MarkReportDeliveryBounced(ctx context.Context, messageID, reason string, bouncedAt time.Time) error {
+	_, err := s.db.queries.MarkReportDeliveryBounced(ctx, database.MarkReportDeliveryBouncedParams{
+		ProviderMessageID: toPgText(messageID),
+		Error:             toPgText(reason),
+		BouncedAt:         toPgTimestamp(bouncedAt),
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return aletheia.NotFound("Report delivery not found")
+		}
+		return aletheia.Internal("Failed to update report delivery", err)
+	}
+	return nil
+}
+
+func (s *ReportService) FindSummaryReportByID(ctx context.Context, id uuid.UUID) (*aletheia.SummaryReport, error) {
+	report, err := s.db.queries.GetSummaryReport(ctx, toPgUUID(id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, aletheia.NotFound("Summary report not found")
+		}
+		return nil, aletheia.Internal("Failed to fetch summary report", err)
+	}
+	return toDomainSummaryReport(report), nil
+}
+
+func (s *ReportService) FindSummaryReports(ctx context.Context, projectID uuid.UUID) ([]*aletheia.SummaryReport, error) {
+	reports, err := s.db.queries.ListSummaryReports(ctx, toPgUUID(projectID))
+	if err != nil {
+		return nil, aletheia.Internal("Failed to list summary reports", err)
+	}
+	return toDomainSummaryReports(reports), nil
+}
+
+func (s *ReportService) CreateSummaryReport(ctx context.Context, report *aletheia.SummaryReport) error {
+	summary, err := json.Marshal(report.Summary)
+	if err != nil {
+		return aletheia.Internal("Failed to encode summary", err)
+	}
+
+	dbReport, err := s.db.queries.CreateSummaryReport(ctx, database.CreateSummaryReportParams{
+		ProjectID:   toPgUUID(report.ProjectID),
+		PeriodStart: toPgTimestamp(report.From),
+		PeriodEnd:   toPgTimestamp(report.To),
+		StorageUrl:  report.StorageURL,
+		SizeBytes:   report.SizeBytes,
+		Summary:     summary,
+		GeneratedBy: toPgUUID(report.GeneratedBy),
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if isForeignKeyViolation(err) && errors.As(err, &pgErr) && pgErr.ConstraintName == "summary_reports_generated_by_fkey" {
+			return aletheia.NotFound("User not found")
+		}
+		if isForeignKeyViolation(err) {
+			return aletheia.NotFound("Project not found")
+		}
+		return aletheia.Internal("Failed to create summary report", err)
+	}
+
+	*report = *toDomainSummaryReport(dbReport)
+	return nil
+}
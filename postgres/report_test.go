@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindReports(t *testing.T) {
+	pool := setupTestPool(t)
+	db := NewDB(pool)
+	s := db.ReportService
+	ctx := context.Background()
+
+	inspectionID := createTestInspection(t, pool)
+
+	reports, err := s.FindReports(ctx, inspectionID)
+	require.NoError(t, err)
+	assert.Empty(t, reports)
+
+	for _, template := range []aletheia.ReportTemplate{aletheia.ReportTemplateFull, aletheia.ReportTemplateViolationsOnly} {
+		require.NoError(t, s.CreateReport(ctx, &aletheia.Report{
+			InspectionID: inspectionID,
+			StorageURL:   "https://example.com/report.pdf",
+			SizeBytes:    1024,
+			SnapshotHash: "hash-" + string(template),
+			Template:     template,
+			SHA256:       uuid.NewString(),
+			Signature:    "sig-" + string(template),
+			SigningKeyID: "key",
+			Signoffs:     []aletheia.Signoff{{Role: aletheia.SignoffInspector, SignerName: "Ada Inspector"}},
+		}))
+	}
+
+	// Newest version first
+	reports, err = s.FindReports(ctx, inspectionID)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	assert.Equal(t, 2, reports[0].Version)
+	assert.Equal(t, aletheia.ReportTemplateViolationsOnly, reports[0].Template)
+	assert.Equal(t, "hash-violations_only", reports[0].SnapshotHash)
+	assert.Equal(t, "sig-violations_only", reports[0].Signature)
+	assert.Equal(t, "key", reports[0].SigningKeyID)
+	assert.NotEmpty(t, reports[0].SHA256)
+	require.Len(t, reports[0].Signoffs, 1)
+	assert.Equal(t, "Ada Inspector", reports[0].Signoffs[0].SignerName)
+	assert.Equal(t, 1, reports[1].Version)
+	assert.Equal(t, aletheia.ReportTemplateFull, reports[1].Template)
+	assert.Equal(t, int64(1024), reports[1].SizeBytes)
+}
@@ -0,0 +1,192 @@
+package postgres
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/auth"
+	"github.com/dukerupert/aletheia/internal/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// shareTokenLength is the number of random bytes in a share link token.
+const shareTokenLength = 32
+
+// Compile-time check that ShareLinkService implements aletheia.ShareLinkService.
+var _ aletheia.ShareLinkService = (*ShareLinkService)(nil)
+
+// ShareLinkService implements aletheia.ShareLinkService using PostgreSQL.
+type ShareLinkService struct {
+	db *DB
+}
+
+func (s *ShareLinkService) FindShareLinkByID(ctx context.Context, id uuid.UUID) (*aletheia.ShareLink, error) {
+	link, err := s.db.queries.GetShareLink(ctx, toPgUUID(id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, aletheia.NotFound("Share link not found")
+		}
+		return nil, aletheia.Internal("Failed to fetch share link", err)
+	}
+	return toDomainShareLink(link), nil
+}
+
+func (s *ShareLinkService) FindShareLinkByToken(ctx context.Context, token string) (*aletheia.ShareLink, error) {
+	link, err := s.db.queries.GetShareLinkByTokenHash(ctx, hashShareToken(token))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, aletheia.NotFound("Share link not found")
+		}
+		return nil, aletheia.Internal("Failed to fetch share link", err)
+	}
+	return toDomainShareLink(link), nil
+}
+
+func (s *ShareLinkService) FindShareLinks(ctx context.Context, inspectionID uuid.UUID) ([]*aletheia.ShareLink, error) {
+	links, err := s.db.queries.ListShareLinks(ctx, toPgUUID(inspectionID))
+	if err != nil {
+		return nil, aletheia.Internal("Failed to list share links", err)
+	}
+	return toDomainShareLinks(links), nil
+}
+
+func (s *ShareLinkService) CreateShareLink(ctx context.Context, link *aletheia.ShareLink, password string) error {
+	if !link.Kind.IsValid() {
+		return aletheia.Invalid("Invalid share link kind: %s", link.Kind)
+	}
+	if (link.Kind == aletheia.ShareLinkReport) != (link.ReportID != uuid.Nil) {
+		return aletheia.Invalid("Report links must name a report, and only report links can")
+	}
+
+	token, err := generateShareToken()
+	if err != nil {
+		return aletheia.Internal("Failed to generate share token", err)
+	}
+
+	var passwordHash pgtype.Text
+	if password != "" {
+		hash, err := auth.HashPassword(password)
+		if err != nil {
+			return aletheia.Internal("Failed to hash password", err)
+		}
+		passwordHash = toPgText(hash)
+	}
+
+	dbLink, err := s.db.queries.CreateShareLink(ctx, database.CreateShareLinkParams{
+		InspectionID: toPgUUID(link.InspectionID),
+		ReportID:     toPgUUID(link.ReportID),
+		Kind:         database.ShareLinkKind(link.Kind),
+		TokenHash:    hashShareToken(token),
+		PasswordHash: passwordHash,
+		ExpiresAt:    toPgTimestamp(link.ExpiresAt),
+		CreatedBy:    toPgUUID(link.CreatedBy),
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if isForeignKeyViolation(err) && errors.As(err, &pgErr) {
+			switch pgErr.ConstraintName {
+			case "share_links_report_id_fkey":
+				return aletheia.NotFound("Report not found")
+			case "share_links_created_by_fkey":
+				return aletheia.NotFound("User not found")
+			}
+			return aletheia.NotFound("Inspection not found")
+		}
+		return aletheia.Internal("Failed to create share link", err)
+	}
+
+	*link = *toDomainShareLink(dbLink)
+	link.Token = token
+	return nil
+}
+
+func (s *ShareLinkService) RevokeShareLink(ctx context.Context, id uuid.UUID) (*aletheia.ShareLink, error) {
+	link, err := s.db.queries.RevokeShareLink(ctx, toPgUUID(id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, aletheia.NotFound("Share link not found")
+		}
+		return nil, aletheia.Internal("Failed to revoke share link", err)
+	}
+	return toDomainShareLink(link), nil
+}
+
+func (s *ShareLinkService) VerifyShareLinkPassword(ctx context.Context, link *aletheia.ShareLink, password string) error {
+	if link.PasswordHash == "" {
+		return nil
+	}
+	if password == "" {
+		return aletheia.Unauthorized("This link requires a password")
+	}
+	if err := auth.VerifyPassword(password, link.PasswordHash); err != nil {
+		return aletheia.Unauthorized("Incorrect password")
+	}
+	return nil
+}
+
+func (s *ShareLinkService) RecordShareLinkView(ctx context.Context, view *aletheia.ShareLinkView) error {
+	tx, err := s.db.pool.Begin(ctx)
+	if err != nil {
+		return aletheia.Internal("Failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.db.queries.WithTx(tx)
+
+	dbView, err := qtx.CreateShareLinkView(ctx, database.CreateShareLinkViewParams{
+		ShareLinkID: toPgUUID(view.ShareLinkID),
+		IpAddress:   toPgText(view.IPAddress),
+		UserAgent:   toPgText(view.UserAgent),
+	})
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return aletheia.NotFound("Share link not found")
+		}
+		return aletheia.Internal("Failed to record share link view", err)
+	}
+	if err := qtx.IncrementShareLinkViews(ctx, dbView.ShareLinkID); err != nil {
+		return aletheia.Internal("Failed to count share link view", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return aletheia.Internal("Failed to commit transaction", err)
+	}
+
+	*view = *toDomainShareLinkView(dbView)
+	return nil
+}
+
+func (s *ShareLinkService) FindShareLinkViews(ctx context.Context, shareLinkID uuid.UUID, limit int) ([]*aletheia.ShareLinkView, error) {
+	views, err := s.db.queries.ListShareLinkViews(ctx, database.ListShareLinkViewsParams{
+		ShareLinkID: toPgUUID(shareLinkID),
+		Limit:       int32(limit),
+	})
+	if err != nil {
+		return nil, aletheia.Internal("Failed to list share link views", err)
+	}
+	return toDomainShareLinkViews(views), nil
+}
+
+// generateShareToken generates a random URL-safe share link token.
+func generateShareToken() (string, error) {
+	b := make([]byte, shareTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashShareToken returns the hash a share link token is stored as. Tokens
+// are random, so a fast hash is enough.
+func hashShareToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
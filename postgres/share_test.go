@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestInspection inserts an inspection in a new organization, deleted
+// when the test finishes.
+func createTestInspection(t *testing.T, pool *pgxpool.Pool) uuid.UUID {
+	t.Helper()
+	ctx := context.Background()
+
+	// The inspector is deleted after the organization, which cascades to
+	// the inspection that references it
+	userID := uuid.New()
+	_, err := pool.Exec(ctx,
+		`INSERT INTO users (id, email, username, password_hash) VALUES ($1, $2, $3, 'x')`,
+		userID, userID.String()+"@example.com", userID.String()[:30])
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = pool.Exec(context.Background(), `DELETE FROM users WHERE id = $1`, userID)
+	})
+
+	orgID := createTestOrganization(t, pool)
+
+	projectID, inspectionID := uuid.New(), uuid.New()
+	_, err = pool.Exec(ctx, `INSERT INTO projects (id, organization_id, name) VALUES ($1, $2, 'Test Project')`, projectID, orgID)
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx, `INSERT INTO inspections (id, project_id, inspector_id) VALUES ($1, $2, $3)`, inspectionID, projectID, userID)
+	require.NoError(t, err)
+	return inspectionID
+}
+
+func TestShareLinkService(t *testing.T) {
+	pool := setupTestPool(t)
+	db := NewDB(pool)
+	s := db.ShareLinkService
+	ctx := context.Background()
+
+	inspectionID := createTestInspection(t, pool)
+
+	link := &aletheia.ShareLink{
+		InspectionID: inspectionID,
+		Kind:         aletheia.ShareLinkInspection,
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}
+	require.NoError(t, s.CreateShareLink(ctx, link, "site-visit"))
+	require.NotEmpty(t, link.Token)
+	assert.True(t, link.HasPassword)
+
+	found, err := s.FindShareLinkByToken(ctx, link.Token)
+	require.NoError(t, err)
+	assert.Equal(t, link.ID, found.ID)
+	assert.Empty(t, found.Token)
+	assert.NoError(t, found.Active())
+
+	_, err = s.FindShareLinkByToken(ctx, link.Token+"x")
+	assert.True(t, aletheia.IsErrorCode(err, aletheia.ENOTFOUND))
+
+	assert.True(t, aletheia.IsErrorCode(s.VerifyShareLinkPassword(ctx, found, ""), aletheia.EUNAUTHORIZED))
+	assert.True(t, aletheia.IsErrorCode(s.VerifyShareLinkPassword(ctx, found, "wrong"), aletheia.EUNAUTHORIZED))
+	assert.NoError(t, s.VerifyShareLinkPassword(ctx, found, "site-visit"))
+
+	require.NoError(t, s.RecordShareLinkView(ctx, &aletheia.ShareLinkView{ShareLinkID: link.ID, IPAddress: "203.0.113.7"}))
+	require.NoError(t, s.RecordShareLinkView(ctx, &aletheia.ShareLinkView{ShareLinkID: link.ID}))
+	views, err := s.FindShareLinkViews(ctx, link.ID, 10)
+	require.NoError(t, err)
+	assert.Len(t, views, 2)
+
+	revoked, err := s.RevokeShareLink(ctx, link.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, revoked.ViewCount)
+	assert.NotNil(t, revoked.LastViewedAt)
+	assert.True(t, aletheia.IsErrorCode(revoked.Active(), aletheia.ENOTFOUND))
+
+	// Report links must name a report
+	err = s.CreateShareLink(ctx, &aletheia.ShareLink{InspectionID: inspectionID, Kind: aletheia.ShareLinkReport, ExpiresAt: time.Now().Add(time.Hour)}, "")
+	assert.True(t, aletheia.IsErrorCode(err, aletheia.EINVALID))
+}
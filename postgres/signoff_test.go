@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspectionSignoffs(t *testing.T) {
+	pool := setupTestPool(t)
+	db := NewDB(pool)
+	s := db.InspectionService
+	ctx := context.Background()
+
+	inspectionID := createTestInspection(t, pool)
+
+	signoff := &aletheia.Signoff{
+		InspectionID:  inspectionID,
+		Role:          aletheia.SignoffInspector,
+		SignerName:    "Ada Inspector",
+		SignatureKind: aletheia.SignatureTyped,
+		Signature:     "Ada Inspector",
+		ContentHash:   "abc123",
+	}
+	require.NoError(t, s.CreateSignoff(ctx, signoff))
+	assert.NotEqual(t, uuid.Nil, signoff.ID)
+	assert.False(t, signoff.SignedAt.IsZero())
+
+	// Each role signs once
+	again := *signoff
+	err := s.CreateSignoff(ctx, &again)
+	assert.True(t, aletheia.IsErrorCode(err, aletheia.ECONFLICT))
+
+	signoffs, err := s.FindSignoffs(ctx, inspectionID)
+	require.NoError(t, err)
+	require.Len(t, signoffs, 1)
+	assert.Equal(t, "abc123", signoffs[0].ContentHash)
+
+	require.NoError(t, s.DeleteSignoff(ctx, signoff.ID))
+	signoffs, err = s.FindSignoffs(ctx, inspectionID)
+	require.NoError(t, err)
+	assert.Empty(t, signoffs)
+}
+
+func TestFindReportBySHA256(t *testing.T) {
+	pool := setupTestPool(t)
+	db := NewDB(pool)
+	s := db.ReportService
+	ctx := context.Background()
+
+	inspectionID := createTestInspection(t, pool)
+	sha := uuid.NewString()
+
+	report := &aletheia.Report{
+		InspectionID: inspectionID,
+		StorageURL:   "https://example.com/report.pdf",
+		SHA256:       sha,
+		Signature:    "sig",
+		SigningKeyID: "key",
+		Signoffs:     []aletheia.Signoff{{Role: aletheia.SignoffInspector, SignerName: "Ada Inspector"}},
+	}
+	require.NoError(t, s.CreateReport(ctx, report))
+
+	found, err := s.FindReportBySHA256(ctx, sha)
+	require.NoError(t, err)
+	assert.Equal(t, report.ID, found.ID)
+	assert.Equal(t, "sig", found.Signature)
+	require.Len(t, found.Signoffs, 1)
+	assert.Equal(t, "Ada Inspector", found.Signoffs[0].SignerName)
+
+	_, err = s.FindReportBySHA256(ctx, "")
+	assert.True(t, aletheia.IsErrorCode(err, aletheia.ENOTFOUND))
+}
@@ -2,16 +2,26 @@ package postgres
 
 import (
 	"context"
+	"crypto/md5"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/dukerupert/aletheia"
 	"github.com/google/uuid"
 )
@@ -24,20 +34,16 @@ var _ aletheia.FileStorage = (*S3Storage)(nil)
 func NewFileStorage(ctx context.Context, logger *slog.Logger, cfg aletheia.StorageConfig) (aletheia.FileStorage, error) {
 	switch cfg.Provider {
 	case "s3":
-		awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(cfg.S3Region))
+		storage, err := newS3Storage(ctx, cfg)
 		if err != nil {
-			return nil, fmt.Errorf("loading AWS config: %w", err)
+			return nil, err
 		}
-		client := s3.NewFromConfig(awsCfg)
 		logger.Info("initialized S3 storage",
 			slog.String("bucket", cfg.S3Bucket),
-			slog.String("region", cfg.S3Region))
-		return &S3Storage{
-			client:  client,
-			bucket:  cfg.S3Bucket,
-			region:  cfg.S3Region,
-			baseURL: cfg.S3BaseURL,
-		}, nil
+			slog.String("region", cfg.S3Region),
+			slog.String("endpoint", cfg.S3Endpoint),
+			slog.Bool("path_style", cfg.S3UsePathStyle))
+		return storage, nil
 	default:
 		if err := os.MkdirAll(cfg.LocalPath, 0755); err != nil {
 			return nil, fmt.Errorf("creating storage directory: %w", err)
@@ -112,12 +118,175 @@ func (s *LocalStorage) Exists(ctx context.Context, key string) (bool, error) {
 	return false, fmt.Errorf("checking file: %w", err)
 }
 
+// Open opens a file on local disk.
+func (s *LocalStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	file, err := os.Open(filepath.Join(s.basePath, key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, aletheia.NotFound("File not found")
+		}
+		return nil, fmt.Errorf("opening file: %w", err)
+	}
+	return file, nil
+}
+
+// multipartDir returns the directory holding the parts of a local multipart upload.
+func (s *LocalStorage) multipartDir(uploadID string) string {
+	return filepath.Join(s.basePath, ".multipart", uploadID)
+}
+
+// CreateMultipartUpload creates a staging directory for the upload's parts.
+func (s *LocalStorage) CreateMultipartUpload(ctx context.Context, key string, contentType string) (string, error) {
+	uploadID := uuid.New().String()
+	if err := os.MkdirAll(s.multipartDir(uploadID), 0755); err != nil {
+		return "", fmt.Errorf("creating multipart directory: %w", err)
+	}
+	return uploadID, nil
+}
+
+// UploadPart writes a part to the upload's staging directory.
+func (s *LocalStorage) UploadPart(ctx context.Context, key string, uploadID string, partNumber int, reader io.Reader) (string, error) {
+	partPath := filepath.Join(s.multipartDir(uploadID), fmt.Sprintf("%05d", partNumber))
+
+	file, err := os.Create(partPath)
+	if err != nil {
+		return "", fmt.Errorf("creating part file: %w", err)
+	}
+	defer file.Close()
+
+	hash := md5.New()
+	if _, err := io.Copy(io.MultiWriter(file, hash), reader); err != nil {
+		return "", fmt.Errorf("writing part file: %w", err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// CompleteMultipartUpload concatenates the staged parts into the final file.
+func (s *LocalStorage) CompleteMultipartUpload(ctx context.Context, key string, uploadID string, parts []aletheia.StoragePart) (string, error) {
+	filePath := filepath.Join(s.basePath, key)
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return "", fmt.Errorf("creating directories: %w", err)
+	}
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return "", fmt.Errorf("creating file: %w", err)
+	}
+	defer file.Close()
+
+	for _, part := range parts {
+		partPath := filepath.Join(s.multipartDir(uploadID), fmt.Sprintf("%05d", part.PartNumber))
+		src, err := os.Open(partPath)
+		if err != nil {
+			return "", fmt.Errorf("opening part %d: %w", part.PartNumber, err)
+		}
+		_, err = io.Copy(file, src)
+		src.Close()
+		if err != nil {
+			return "", fmt.Errorf("writing part %d: %w", part.PartNumber, err)
+		}
+	}
+
+	if err := os.RemoveAll(s.multipartDir(uploadID)); err != nil {
+		return "", fmt.Errorf("removing multipart directory: %w", err)
+	}
+
+	return s.GetURL(key), nil
+}
+
+// AbortMultipartUpload removes the upload's staging directory.
+func (s *LocalStorage) AbortMultipartUpload(ctx context.Context, key string, uploadID string) error {
+	if err := os.RemoveAll(s.multipartDir(uploadID)); err != nil {
+		return fmt.Errorf("removing multipart directory: %w", err)
+	}
+	return nil
+}
+
 // S3Storage implements aletheia.FileStorage for AWS S3.
 type S3Storage struct {
-	client  *s3.Client
-	bucket  string
-	region  string
-	baseURL string
+	client       *s3.Client
+	bucket       string
+	region       string
+	baseURL      string
+	endpoint     string
+	usePathStyle bool
+}
+
+// newS3Storage creates an S3 client from the storage configuration.
+// A custom endpoint targets S3-compatible stores such as MinIO or Ceph.
+func newS3Storage(ctx context.Context, cfg aletheia.StorageConfig) (*S3Storage, error) {
+	opts := []func(*awsconfig.LoadOptions) error{
+		awsconfig.WithRegion(cfg.S3Region),
+	}
+
+	if cfg.S3AccessKeyID != "" || cfg.S3SecretAccessKey != "" {
+		opts = append(opts, awsconfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(cfg.S3AccessKeyID, cfg.S3SecretAccessKey, ""),
+		))
+	}
+
+	if cfg.S3CACertFile != "" || cfg.S3InsecureSkipVerify {
+		tlsCfg, err := s3TLSConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, awsconfig.WithHTTPClient(
+			awshttp.NewBuildableClient().WithTransportOptions(func(tr *http.Transport) {
+				tr.TLSClientConfig = tlsCfg
+			}),
+		))
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		o.UsePathStyle = cfg.S3UsePathStyle
+		if cfg.S3Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.S3Endpoint)
+			// Many S3-compatible stores reject the flexible checksums the SDK
+			// sends by default, so only send them when an operation requires it.
+			o.RequestChecksumCalculation = aws.RequestChecksumCalculationWhenRequired
+			o.ResponseChecksumValidation = aws.ResponseChecksumValidationWhenRequired
+		}
+	})
+
+	return &S3Storage{
+		client:       client,
+		bucket:       cfg.S3Bucket,
+		region:       cfg.S3Region,
+		baseURL:      cfg.S3BaseURL,
+		endpoint:     strings.TrimSuffix(cfg.S3Endpoint, "/"),
+		usePathStyle: cfg.S3UsePathStyle,
+	}, nil
+}
+
+// s3TLSConfig builds the TLS configuration for a custom S3 endpoint.
+func s3TLSConfig(cfg aletheia.StorageConfig) (*tls.Config, error) {
+	tlsCfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.S3InsecureSkipVerify,
+	}
+
+	if cfg.S3CACertFile != "" {
+		pem, err := os.ReadFile(cfg.S3CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading S3 CA certificate: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", cfg.S3CACertFile)
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	return tlsCfg, nil
 }
 
 // Upload uploads a file to S3.
@@ -157,6 +326,14 @@ func (s *S3Storage) GetURL(key string) string {
 	if s.baseURL != "" {
 		return fmt.Sprintf("%s/%s", s.baseURL, key)
 	}
+	if s.endpoint != "" {
+		if s.usePathStyle {
+			return fmt.Sprintf("%s/%s/%s", s.endpoint, s.bucket, key)
+		}
+		if scheme, host, ok := strings.Cut(s.endpoint, "://"); ok {
+			return fmt.Sprintf("%s://%s.%s/%s", scheme, s.bucket, host, key)
+		}
+	}
 	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.bucket, s.region, key)
 }
 
@@ -172,3 +349,83 @@ func (s *S3Storage) Exists(ctx context.Context, key string) (bool, error) {
 	}
 	return true, nil
 }
+
+// Open downloads a file from S3.
+func (s *S3Storage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	result, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, aletheia.NotFound("File not found")
+		}
+		return nil, fmt.Errorf("downloading from S3: %w", err)
+	}
+	return result.Body, nil
+}
+
+// CreateMultipartUpload starts an S3 multipart upload.
+func (s *S3Storage) CreateMultipartUpload(ctx context.Context, key string, contentType string) (string, error) {
+	out, err := s.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return "", fmt.Errorf("creating S3 multipart upload: %w", err)
+	}
+	return aws.ToString(out.UploadId), nil
+}
+
+// UploadPart uploads a single part of an S3 multipart upload.
+func (s *S3Storage) UploadPart(ctx context.Context, key string, uploadID string, partNumber int, reader io.Reader) (string, error) {
+	out, err := s.client.UploadPart(ctx, &s3.UploadPartInput{
+		Bucket:     aws.String(s.bucket),
+		Key:        aws.String(key),
+		UploadId:   aws.String(uploadID),
+		PartNumber: aws.Int32(int32(partNumber)),
+		Body:       reader,
+	})
+	if err != nil {
+		return "", fmt.Errorf("uploading S3 part %d: %w", partNumber, err)
+	}
+	return aws.ToString(out.ETag), nil
+}
+
+// CompleteMultipartUpload assembles the uploaded parts into the final S3 object.
+func (s *S3Storage) CompleteMultipartUpload(ctx context.Context, key string, uploadID string, parts []aletheia.StoragePart) (string, error) {
+	completed := make([]types.CompletedPart, len(parts))
+	for i, part := range parts {
+		completed[i] = types.CompletedPart{
+			ETag:       aws.String(part.ETag),
+			PartNumber: aws.Int32(int32(part.PartNumber)),
+		}
+	}
+
+	_, err := s.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(s.bucket),
+		Key:             aws.String(key),
+		UploadId:        aws.String(uploadID),
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: completed},
+	})
+	if err != nil {
+		return "", fmt.Errorf("completing S3 multipart upload: %w", err)
+	}
+
+	return s.GetURL(key), nil
+}
+
+// AbortMultipartUpload aborts an S3 multipart upload and frees its parts.
+func (s *S3Storage) AbortMultipartUpload(ctx context.Context, key string, uploadID string) error {
+	_, err := s.client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(s.bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	})
+	if err != nil {
+		return fmt.Errorf("aborting S3 multipart upload: %w", err)
+	}
+	return nil
+}
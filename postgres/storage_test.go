@@ -0,0 +1,360 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testS3Bucket    = "aletheia-test"
+	testS3AccessKey = "test-access-key"
+	testS3SecretKey = "test-secret-key"
+)
+
+// s3Stub is a minimal in-memory S3-compatible server. It speaks the
+// path-style object and multipart APIs used by S3Storage.
+type s3Stub struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	uploads  map[string]map[int][]byte
+	requests []*http.Request
+}
+
+func newS3Stub() *s3Stub {
+	return &s3Stub{
+		objects: make(map[string][]byte),
+		uploads: make(map[string]map[int][]byte),
+	}
+}
+
+func (s *s3Stub) object(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.objects[testS3Bucket+"/"+key]
+	return data, ok
+}
+
+func (s *s3Stub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r)
+
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	query := r.URL.Query()
+
+	switch {
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		uploadID := uuid.New().String()
+		s.uploads[uploadID] = make(map[int][]byte)
+		writeS3XML(w, struct {
+			XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+			UploadID string   `xml:"UploadId"`
+		}{UploadID: uploadID})
+
+	case r.Method == http.MethodPut && query.Has("uploadId"):
+		parts, ok := s.uploads[query.Get("uploadId")]
+		if !ok {
+			http.Error(w, "NoSuchUpload", http.StatusNotFound)
+			return
+		}
+		partNumber, _ := strconv.Atoi(query.Get("partNumber"))
+		body, _ := io.ReadAll(r.Body)
+		parts[partNumber] = body
+		w.Header().Set("ETag", fmt.Sprintf(`"etag-%d"`, partNumber))
+
+	case r.Method == http.MethodPost && query.Has("uploadId"):
+		parts, ok := s.uploads[query.Get("uploadId")]
+		if !ok {
+			http.Error(w, "NoSuchUpload", http.StatusNotFound)
+			return
+		}
+		numbers := make([]int, 0, len(parts))
+		for n := range parts {
+			numbers = append(numbers, n)
+		}
+		sort.Ints(numbers)
+		var buf bytes.Buffer
+		for _, n := range numbers {
+			buf.Write(parts[n])
+		}
+		s.objects[path] = buf.Bytes()
+		delete(s.uploads, query.Get("uploadId"))
+		writeS3XML(w, struct {
+			XMLName xml.Name `xml:"CompleteMultipartUploadResult"`
+			ETag    string   `xml:"ETag"`
+		}{ETag: `"complete"`})
+
+	case r.Method == http.MethodDelete && query.Has("uploadId"):
+		delete(s.uploads, query.Get("uploadId"))
+		w.WriteHeader(http.StatusNoContent)
+
+	case r.Method == http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		s.objects[path] = body
+		w.Header().Set("ETag", `"object"`)
+
+	case r.Method == http.MethodGet:
+		data, ok := s.objects[path]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+			return
+		}
+		w.Write(data)
+
+	case r.Method == http.MethodHead:
+		if _, ok := s.objects[path]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(s.objects[path])))
+
+	case r.Method == http.MethodDelete:
+		delete(s.objects, path)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "NotImplemented", http.StatusNotImplemented)
+	}
+}
+
+func writeS3XML(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(v)
+}
+
+// newTestS3Storage creates S3Storage for an S3-compatible store. When
+// S3_TEST_ENDPOINT is set the tests run against that store (e.g. MinIO)
+// instead of the in-memory stand-in.
+func newTestS3Storage(t *testing.T, stub *s3Stub) aletheia.FileStorage {
+	t.Helper()
+
+	cfg := aletheia.StorageConfig{
+		Provider:          "s3",
+		S3Bucket:          testS3Bucket,
+		S3Region:          "us-east-1",
+		S3UsePathStyle:    true,
+		S3AccessKeyID:     testS3AccessKey,
+		S3SecretAccessKey: testS3SecretKey,
+	}
+
+	if endpoint := os.Getenv("S3_TEST_ENDPOINT"); endpoint != "" {
+		cfg.S3Endpoint = endpoint
+		cfg.S3Bucket = os.Getenv("S3_TEST_BUCKET")
+		cfg.S3AccessKeyID = os.Getenv("S3_TEST_ACCESS_KEY_ID")
+		cfg.S3SecretAccessKey = os.Getenv("S3_TEST_SECRET_ACCESS_KEY")
+	} else {
+		server := httptest.NewServer(stub)
+		t.Cleanup(server.Close)
+		cfg.S3Endpoint = server.URL
+	}
+
+	storage, err := NewFileStorage(context.Background(), slog.New(slog.DiscardHandler), cfg)
+	require.NoError(t, err)
+	return storage
+}
+
+func TestS3Storage_CompatibleEndpoint(t *testing.T) {
+	ctx := context.Background()
+	stub := newS3Stub()
+	storage := newTestS3Storage(t, stub)
+
+	key := "photos/" + uuid.New().String()
+	content := []byte("jpeg bytes")
+
+	url, err := storage.Upload(ctx, key, bytes.NewReader(content), "image/jpeg")
+	require.NoError(t, err)
+	assert.Equal(t, storage.GetURL(key), url)
+
+	exists, err := storage.Exists(ctx, key)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	storedKey, ok := aletheia.StorageKey(storage, url)
+	require.True(t, ok)
+	assert.Equal(t, key, storedKey)
+
+	file, err := storage.Open(ctx, key)
+	require.NoError(t, err)
+	got, err := io.ReadAll(file)
+	file.Close()
+	require.NoError(t, err)
+	assert.Equal(t, content, got)
+
+	require.NoError(t, storage.Delete(ctx, key))
+
+	exists, err = storage.Exists(ctx, key)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = storage.Open(ctx, key)
+	assert.Equal(t, aletheia.ENOTFOUND, aletheia.ErrorCode(err))
+
+	if os.Getenv("S3_TEST_ENDPOINT") == "" {
+		require.NotEmpty(t, stub.requests)
+		for _, r := range stub.requests {
+			assert.True(t, strings.HasPrefix(r.URL.Path, "/"+testS3Bucket+"/"), "expected path-style request, got %s", r.URL.Path)
+			assert.Contains(t, r.Header.Get("Authorization"), "Credential="+testS3AccessKey+"/")
+		}
+	}
+}
+
+func TestS3Storage_MultipartUpload(t *testing.T) {
+	ctx := context.Background()
+	stub := newS3Stub()
+	storage := newTestS3Storage(t, stub)
+
+	key := "photos/" + uuid.New().String()
+	first := bytes.Repeat([]byte("a"), aletheia.UploadPartSize)
+	second := []byte("tail")
+
+	uploadID, err := storage.CreateMultipartUpload(ctx, key, "image/png")
+	require.NoError(t, err)
+	require.NotEmpty(t, uploadID)
+
+	var parts []aletheia.StoragePart
+	for i, data := range [][]byte{first, second} {
+		etag, err := storage.UploadPart(ctx, key, uploadID, i+1, bytes.NewReader(data))
+		require.NoError(t, err)
+		parts = append(parts, aletheia.StoragePart{PartNumber: i + 1, ETag: etag, Size: int64(len(data))})
+	}
+
+	url, err := storage.CompleteMultipartUpload(ctx, key, uploadID, parts)
+	require.NoError(t, err)
+	assert.Equal(t, storage.GetURL(key), url)
+
+	exists, err := storage.Exists(ctx, key)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	if os.Getenv("S3_TEST_ENDPOINT") == "" {
+		data, ok := stub.object(key)
+		require.True(t, ok)
+		assert.Equal(t, append(first, second...), data)
+	}
+
+	require.NoError(t, storage.Delete(ctx, key))
+
+	// An aborted upload leaves no object behind
+	uploadID, err = storage.CreateMultipartUpload(ctx, key, "image/png")
+	require.NoError(t, err)
+	_, err = storage.UploadPart(ctx, key, uploadID, 1, bytes.NewReader(second))
+	require.NoError(t, err)
+	require.NoError(t, storage.AbortMultipartUpload(ctx, key, uploadID))
+
+	exists, err = storage.Exists(ctx, key)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestS3Storage_TLS(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewTLSServer(newS3Stub())
+	t.Cleanup(server.Close)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, caPEM, 0600))
+
+	base := aletheia.StorageConfig{
+		Provider:          "s3",
+		S3Bucket:          testS3Bucket,
+		S3Region:          "us-east-1",
+		S3Endpoint:        server.URL,
+		S3UsePathStyle:    true,
+		S3AccessKeyID:     testS3AccessKey,
+		S3SecretAccessKey: testS3SecretKey,
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*aletheia.StorageConfig)
+		wantErr bool
+	}{
+		{
+			name:    "untrusted certificate",
+			modify:  func(cfg *aletheia.StorageConfig) {},
+			wantErr: true,
+		},
+		{
+			name:   "custom CA",
+			modify: func(cfg *aletheia.StorageConfig) { cfg.S3CACertFile = caFile },
+		},
+		{
+			name:   "insecure skip verify",
+			modify: func(cfg *aletheia.StorageConfig) { cfg.S3InsecureSkipVerify = true },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := base
+			tt.modify(&cfg)
+
+			storage, err := NewFileStorage(ctx, slog.New(slog.DiscardHandler), cfg)
+			require.NoError(t, err)
+
+			_, err = storage.Upload(ctx, "photos/tls", strings.NewReader("data"), "image/jpeg")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestS3Storage_GetURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		storage S3Storage
+		want    string
+	}{
+		{
+			name:    "AWS default",
+			storage: S3Storage{bucket: "photos", region: "us-west-2"},
+			want:    "https://photos.s3.us-west-2.amazonaws.com/a/b.jpg",
+		},
+		{
+			name:    "base URL takes precedence",
+			storage: S3Storage{bucket: "photos", baseURL: "https://cdn.example.com", endpoint: "http://minio:9000"},
+			want:    "https://cdn.example.com/a/b.jpg",
+		},
+		{
+			name:    "path-style endpoint",
+			storage: S3Storage{bucket: "photos", endpoint: "http://minio:9000", usePathStyle: true},
+			want:    "http://minio:9000/photos/a/b.jpg",
+		},
+		{
+			name:    "virtual-hosted endpoint",
+			storage: S3Storage{bucket: "photos", endpoint: "https://s3.example.com"},
+			want:    "https://photos.s3.example.com/a/b.jpg",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.storage.GetURL("a/b.jpg"))
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/internal/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// Compile-time check that UploadService implements aletheia.UploadService.
+var _ aletheia.UploadService = (*UploadService)(nil)
+
+// UploadService implements aletheia.UploadService using PostgreSQL for session
+// state and FileStorage multipart uploads for the file data.
+type UploadService struct {
+	db      *DB
+	storage aletheia.FileStorage
+}
+
+// NewUploadService creates a new upload service.
+func NewUploadService(db *DB, storage aletheia.FileStorage) aletheia.UploadService {
+	return &UploadService{db: db, storage: storage}
+}
+
+func (s *UploadService) FindUploadByID(ctx context.Context, id uuid.UUID) (*aletheia.Upload, error) {
+	upload, err := s.db.queries.GetUpload(ctx, toPgUUID(id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, aletheia.NotFound("Upload not found")
+		}
+		return nil, aletheia.Internal("Failed to fetch upload", err)
+	}
+	return toDomainUpload(upload), nil
+}
+
+func (s *UploadService) CreateUpload(ctx context.Context, upload *aletheia.Upload) error {
+	if upload.Length <= 0 {
+		return aletheia.Invalid("Upload length must be greater than zero")
+	}
+	if upload.Length > aletheia.MaxResumableUploadSize {
+		return aletheia.Invalid("Upload exceeds maximum size of 25MB")
+	}
+	if !aletheia.IsAcceptedImageType(upload.ContentType) {
+		return aletheia.Invalid("Invalid image type, must be JPEG, PNG, or WebP")
+	}
+
+	if upload.ExpiresAt.IsZero() {
+		upload.ExpiresAt = time.Now().Add(aletheia.DefaultUploadExpiry)
+	}
+
+	// The storage key doubles as the photo's storage path once assembled.
+	key := "photos/" + upload.InspectionID.String() + "/" + uuid.New().String()
+
+	multipartID, err := s.storage.CreateMultipartUpload(ctx, key, upload.ContentType)
+	if err != nil {
+		return aletheia.Internal("Failed to start upload", err)
+	}
+
+	dbUpload, err := s.db.queries.CreateUpload(ctx, database.CreateUploadParams{
+		InspectionID:      toPgUUID(upload.InspectionID),
+		UserID:            toPgUUID(upload.UserID),
+		Filename:          toPgText(upload.Filename),
+		ContentType:       upload.ContentType,
+		UploadLength:      upload.Length,
+		StorageKey:        key,
+		MultipartUploadID: multipartID,
+		ExpiresAt:         toPgTimestamp(upload.ExpiresAt),
+	})
+	if err != nil {
+		_ = s.storage.AbortMultipartUpload(ctx, key, multipartID)
+		if isForeignKeyViolation(err) {
+			return aletheia.NotFound("Inspection not found")
+		}
+		return aletheia.Internal("Failed to create upload", err)
+	}
+
+	// Update upload with generated values
+	*upload = *toDomainUpload(dbUpload)
+
+	return nil
+}
+
+func (s *UploadService) WriteUploadChunk(ctx context.Context, id uuid.UUID, offset int64, data []byte) (*aletheia.Upload, error) {
+	tx, err := s.db.pool.Begin(ctx)
+	if err != nil {
+		return nil, aletheia.Internal("Failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.db.queries.WithTx(tx)
+
+	// Lock the session so concurrent chunks for the same upload serialize.
+	upload, err := qtx.GetUploadForUpdate(ctx, toPgUUID(id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, aletheia.NotFound("Upload not found")
+		}
+		return nil, aletheia.Internal("Failed to fetch upload", err)
+	}
+
+	if offset != upload.UploadOffset {
+		return nil, aletheia.Conflict("Upload offset does not match")
+	}
+	if offset+int64(len(data)) > upload.UploadLength {
+		return nil, aletheia.Invalid("Chunk exceeds upload length")
+	}
+	if upload.Status == database.UploadStatusCompleted {
+		return toDomainUpload(upload), nil
+	}
+	if time.Now().After(upload.ExpiresAt.Time) {
+		return nil, aletheia.NotFound("Upload has expired")
+	}
+	if len(data) == 0 {
+		return toDomainUpload(upload), nil
+	}
+
+	newOffset := offset + int64(len(data))
+	complete := newOffset == upload.UploadLength
+	pending := append(upload.PendingData, data...)
+
+	parts, err := qtx.ListUploadParts(ctx, upload.ID)
+	if err != nil {
+		return nil, aletheia.Internal("Failed to list upload parts", err)
+	}
+
+	// Flush full parts to storage. Only the final part may be smaller than
+	// the part size, so remaining bytes stay buffered until more arrive.
+	for len(pending) >= aletheia.UploadPartSize || (complete && len(pending) > 0) {
+		size := min(len(pending), aletheia.UploadPartSize)
+		partNumber := len(parts) + 1
+
+		etag, err := s.storage.UploadPart(ctx, upload.StorageKey, upload.MultipartUploadID, partNumber, bytes.NewReader(pending[:size]))
+		if err != nil {
+			return nil, aletheia.Internal("Failed to upload part", err)
+		}
+
+		part := database.CreateUploadPartParams{
+			UploadID:   upload.ID,
+			PartNumber: int32(partNumber),
+			Etag:       etag,
+			Size:       int64(size),
+		}
+		if err := qtx.CreateUploadPart(ctx, part); err != nil {
+			return nil, aletheia.Internal("Failed to record upload part", err)
+		}
+		parts = append(parts, database.UploadPart{
+			UploadID:   part.UploadID,
+			PartNumber: part.PartNumber,
+			Etag:       part.Etag,
+			Size:       part.Size,
+		})
+		pending = pending[size:]
+	}
+
+	upload, err = qtx.UpdateUploadProgress(ctx, database.UpdateUploadProgressParams{
+		ID:           upload.ID,
+		UploadOffset: newOffset,
+		PendingData:  pending,
+	})
+	if err != nil {
+		return nil, aletheia.Internal("Failed to update upload", err)
+	}
+
+	if complete {
+		storageParts := make([]aletheia.StoragePart, len(parts))
+		for i, p := range parts {
+			storageParts[i] = aletheia.StoragePart{
+				PartNumber: int(p.PartNumber),
+				ETag:       p.Etag,
+				Size:       p.Size,
+			}
+		}
+
+		url, err := s.storage.CompleteMultipartUpload(ctx, upload.StorageKey, upload.MultipartUploadID, storageParts)
+		if err != nil {
+			return nil, aletheia.Internal("Failed to assemble upload", err)
+		}
+
+		upload, err = qtx.CompleteUpload(ctx, database.CompleteUploadParams{
+			ID:         upload.ID,
+			StorageUrl: toPgText(url),
+		})
+		if err != nil {
+			return nil, aletheia.Internal("Failed to complete upload", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, aletheia.Internal("Failed to commit upload chunk", err)
+	}
+
+	return toDomainUpload(upload), nil
+}
+
+func (s *UploadService) UpdateUpload(ctx context.Context, id uuid.UUID, upd aletheia.UploadUpdate) (*aletheia.Upload, error) {
+	if upd.PhotoID == nil {
+		return s.FindUploadByID(ctx, id)
+	}
+
+	upload, err := s.db.queries.SetUploadPhoto(ctx, database.SetUploadPhotoParams{
+		ID:      toPgUUID(id),
+		PhotoID: toPgUUID(*upd.PhotoID),
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, aletheia.NotFound("Upload not found")
+		}
+		if isForeignKeyViolation(err) {
+			return nil, aletheia.NotFound("Photo not found")
+		}
+		return nil, aletheia.Internal("Failed to update upload", err)
+	}
+	return toDomainUpload(upload), nil
+}
+
+func (s *UploadService) AbortUpload(ctx context.Context, id uuid.UUID) error {
+	upload, err := s.db.queries.GetUpload(ctx, toPgUUID(id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return aletheia.NotFound("Upload not found")
+		}
+		return aletheia.Internal("Failed to fetch upload", err)
+	}
+
+	if upload.Status == database.UploadStatusCompleted {
+		return aletheia.Invalid("Upload has already completed")
+	}
+
+	return s.removeUpload(ctx, upload)
+}
+
+func (s *UploadService) CleanupExpiredUploads(ctx context.Context) (int, error) {
+	uploads, err := s.db.queries.ListExpiredUploads(ctx)
+	if err != nil {
+		return 0, aletheia.Internal("Failed to list expired uploads", err)
+	}
+
+	removed := 0
+	for _, upload := range uploads {
+		if err := s.removeUpload(ctx, upload); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// removeUpload discards the storage multipart upload and deletes the session.
+func (s *UploadService) removeUpload(ctx context.Context, upload database.Upload) error {
+	if err := s.storage.AbortMultipartUpload(ctx, upload.StorageKey, upload.MultipartUploadID); err != nil {
+		return aletheia.Internal("Failed to abort storage upload", err)
+	}
+	if err := s.db.queries.DeleteUpload(ctx, upload.ID); err != nil {
+		return aletheia.Internal("Failed to delete upload", err)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/dukerupert/aletheia"
+	"github.com/dukerupert/aletheia/mock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// multipartStorage is a mock storage that records the multipart calls made
+// to it.
+type multipartStorage struct {
+	mock.FileStorage
+	parts     []int // Size of each part, in order
+	completed []aletheia.StoragePart
+	aborted   []string
+}
+
+func newMultipartStorage() *multipartStorage {
+	s := &multipartStorage{}
+	s.UploadPartFn = func(ctx context.Context, key, uploadID string, partNumber int, reader io.Reader) (string, error) {
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return "", err
+		}
+		s.parts = append(s.parts, len(data))
+		return "etag-" + uuid.NewString(), nil
+	}
+	s.CompleteMultipartUploadFn = func(ctx context.Context, key, uploadID string, parts []aletheia.StoragePart) (string, error) {
+		s.completed = parts
+		return "https://example.com/" + key, nil
+	}
+	s.AbortMultipartUploadFn = func(ctx context.Context, key, uploadID string) error {
+		s.aborted = append(s.aborted, key)
+		return nil
+	}
+	return s
+}
+
+// createTestUpload starts an upload of length bytes for a new inspection.
+func createTestUpload(t *testing.T, pool *pgxpool.Pool, s aletheia.UploadService, length int64, expiresAt time.Time) *aletheia.Upload {
+	t.Helper()
+	ctx := context.Background()
+
+	inspectionID := createTestInspection(t, pool)
+	var userID uuid.UUID
+	require.NoError(t, pool.QueryRow(ctx, `SELECT inspector_id FROM inspections WHERE id = $1`, inspectionID).Scan(&userID))
+
+	upload := &aletheia.Upload{
+		InspectionID: inspectionID,
+		UserID:       userID,
+		ContentType:  "image/jpeg",
+		Length:       length,
+		ExpiresAt:    expiresAt,
+	}
+	require.NoError(t, s.CreateUpload(ctx, upload))
+	return upload
+}
+
+func TestUploadService_OffsetMismatch(t *testing.T) {
+	pool := setupTestPool(t)
+	s := NewUploadService(NewDB(pool), newMultipartStorage())
+	ctx := context.Background()
+
+	upload := createTestUpload(t, pool, s, 10, time.Time{})
+
+	_, err := s.WriteUploadChunk(ctx, upload.ID, 0, []byte("hello"))
+	require.NoError(t, err)
+
+	// A chunk resent from the start no longer matches the offset
+	_, err = s.WriteUploadChunk(ctx, upload.ID, 0, []byte("hello"))
+	assert.True(t, aletheia.IsErrorCode(err, aletheia.ECONFLICT))
+
+	_, err = s.WriteUploadChunk(ctx, upload.ID, 5, []byte("too long"))
+	assert.True(t, aletheia.IsErrorCode(err, aletheia.EINVALID))
+
+	found, err := s.FindUploadByID(ctx, upload.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), found.Offset)
+}
+
+func TestUploadService_BuffersParts(t *testing.T) {
+	pool := setupTestPool(t)
+	storage := newMultipartStorage()
+	s := NewUploadService(NewDB(pool), storage)
+	ctx := context.Background()
+
+	const mb = 1024 * 1024
+	upload := createTestUpload(t, pool, s, 8*mb, time.Time{})
+
+	// Less than a part is buffered
+	upload, err := s.WriteUploadChunk(ctx, upload.ID, 0, bytes.Repeat([]byte{1}, 3*mb))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3*mb), upload.Offset)
+	assert.Empty(t, storage.parts)
+
+	// Crossing the part size flushes one full part and buffers the rest
+	upload, err = s.WriteUploadChunk(ctx, upload.ID, upload.Offset, bytes.Repeat([]byte{2}, 3*mb))
+	require.NoError(t, err)
+	assert.Equal(t, int64(6*mb), upload.Offset)
+	assert.Equal(t, []int{aletheia.UploadPartSize}, storage.parts)
+	assert.Equal(t, aletheia.UploadStatusInProgress, upload.Status)
+
+	// The final part may be smaller
+	upload, err = s.WriteUploadChunk(ctx, upload.ID, upload.Offset, bytes.Repeat([]byte{3}, 2*mb))
+	require.NoError(t, err)
+	assert.Equal(t, []int{aletheia.UploadPartSize, 3 * mb}, storage.parts)
+	require.Len(t, storage.completed, 2)
+	assert.Equal(t, 1, storage.completed[0].PartNumber)
+	assert.Equal(t, int64(3*mb), storage.completed[1].Size)
+
+	assert.Equal(t, aletheia.UploadStatusCompleted, upload.Status)
+	assert.True(t, upload.IsComplete())
+	assert.NotEmpty(t, upload.StorageURL)
+
+	// Repeating the final chunk is rejected by its offset
+	_, err = s.WriteUploadChunk(ctx, upload.ID, 6*mb, bytes.Repeat([]byte{3}, 2*mb))
+	assert.True(t, aletheia.IsErrorCode(err, aletheia.ECONFLICT))
+}
+
+func TestUploadService_AbortUpload(t *testing.T) {
+	pool := setupTestPool(t)
+	storage := newMultipartStorage()
+	s := NewUploadService(NewDB(pool), storage)
+	ctx := context.Background()
+
+	upload := createTestUpload(t, pool, s, 10, time.Time{})
+	require.NoError(t, s.AbortUpload(ctx, upload.ID))
+	assert.Equal(t, []string{upload.StorageKey}, storage.aborted)
+
+	_, err := s.FindUploadByID(ctx, upload.ID)
+	assert.True(t, aletheia.IsErrorCode(err, aletheia.ENOTFOUND))
+
+	// Completed uploads are kept
+	done := createTestUpload(t, pool, s, 5, time.Time{})
+	_, err = s.WriteUploadChunk(ctx, done.ID, 0, []byte("hello"))
+	require.NoError(t, err)
+	err = s.AbortUpload(ctx, done.ID)
+	assert.True(t, aletheia.IsErrorCode(err, aletheia.EINVALID))
+}
+
+func TestUploadService_CleanupExpiredUploads(t *testing.T) {
+	pool := setupTestPool(t)
+	storage := newMultipartStorage()
+	s := NewUploadService(NewDB(pool), storage)
+	ctx := context.Background()
+
+	expired := createTestUpload(t, pool, s, 10, time.Now().Add(-time.Minute))
+	active := createTestUpload(t, pool, s, 10, time.Time{})
+
+	removed, err := s.CleanupExpiredUploads(ctx)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, removed, 1)
+	assert.Contains(t, storage.aborted, expired.StorageKey)
+	assert.NotContains(t, storage.aborted, active.StorageKey)
+
+	_, err = s.FindUploadByID(ctx, expired.ID)
+	assert.True(t, aletheia.IsErrorCode(err, aletheia.ENOTFOUND))
+	_, err = s.FindUploadByID(ctx, active.ID)
+	assert.NoError(t, err)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/dukerupert/aletheia/internal/database"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // Compile-time check that ViolationService implements aletheia.ViolationService.
@@ -210,3 +211,42 @@ func (s *ViolationService) GetViolationsByInspection(ctx context.Context, inspec
 	}
 	return toDomainViolations(violations), nil
 }
+
+// exportBatchSize is how many violations ExportViolations reads at a time.
+const exportBatchSize = 1000
+
+func (s *ViolationService) ExportViolations(ctx context.Context, filter aletheia.ViolationExportFilter, fn func(*aletheia.ViolationExportRow) error) error {
+	params := database.ListViolationsForExportParams{
+		OrganizationID: toPgUUID(filter.OrganizationID),
+		CreatedFrom:    toPgTimestampPtr(filter.CreatedFrom),
+		CreatedTo:      toPgTimestampPtr(filter.CreatedTo),
+		AfterCreatedAt: pgtype.Timestamptz{InfinityModifier: pgtype.NegativeInfinity, Valid: true},
+		AfterID:        pgtype.UUID{Valid: true},
+		BatchSize:      exportBatchSize,
+	}
+	if filter.ProjectID != nil {
+		params.ProjectID = toPgUUID(*filter.ProjectID)
+	}
+	if filter.Status != nil {
+		params.Status = database.NullViolationStatus{ViolationStatus: database.ViolationStatus(*filter.Status), Valid: true}
+	}
+
+	// Page through the violations after the last one seen rather than
+	// holding one long query open while the export is written
+	for {
+		rows, err := s.db.queries.ListViolationsForExport(ctx, params)
+		if err != nil {
+			return aletheia.Internal("Failed to export violations", err)
+		}
+		for _, row := range rows {
+			if err := fn(toDomainViolationExportRow(row)); err != nil {
+				return err
+			}
+		}
+		if len(rows) < exportBatchSize {
+			return nil
+		}
+		last := rows[len(rows)-1]
+		params.AfterCreatedAt, params.AfterID = last.CreatedAt, last.ID
+	}
+}
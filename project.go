@@ -76,7 +76,31 @@ type ProjectService interface {
 	DeleteProject(ctx context.Context, id uuid.UUID) error
 
 	// GetProjectStats retrieves statistics for a project.
+	// Returns ENOTFOUND if the project does not exist.
 	GetProjectStats(ctx context.Context, id uuid.UUID) (*ProjectStats, error)
+
+	// FindProjectContacts retrieves the contacts of a project, by name.
+	FindProjectContacts(ctx context.Context, projectID uuid.UUID) ([]*ProjectContact, error)
+
+	// CreateProjectContact adds a contact to a project.
+	// Returns ENOTFOUND if the project does not exist.
+	// Returns ECONFLICT if the project has a contact with the same email.
+	CreateProjectContact(ctx context.Context, contact *ProjectContact) error
+
+	// DeleteProjectContact removes a contact from a project.
+	// Returns ENOTFOUND if the contact does not exist.
+	DeleteProjectContact(ctx context.Context, id uuid.UUID) error
+}
+
+// ProjectContact is a person outside the organization, such as an owner or
+// general contractor, who receives a project's reports.
+type ProjectContact struct {
+	ID        uuid.UUID `json:"id"`
+	ProjectID uuid.UUID `json:"projectId"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
 }
 
 // ProjectFilter defines criteria for filtering projects.
@@ -104,12 +128,15 @@ type ProjectUpdate struct {
 	Country     *string
 }
 
-// ProjectStats contains aggregated statistics for a project.
+// ProjectStats contains aggregated statistics for a project. Violations are
+// open while pending review, and closed once confirmed or dismissed.
 type ProjectStats struct {
-	ProjectID        uuid.UUID `json:"projectId"`
-	InspectionCount  int       `json:"inspectionCount"`
-	PhotoCount       int       `json:"photoCount"`
-	ViolationCount   int       `json:"violationCount"`
-	OpenViolations   int       `json:"openViolations"`
-	ClosedViolations int       `json:"closedViolations"`
+	ProjectID           uuid.UUID `json:"projectId"`
+	InspectionCount     int       `json:"inspectionCount"`
+	PhotoCount          int       `json:"photoCount"`
+	ViolationCount      int       `json:"violationCount"`
+	OpenViolations      int       `json:"openViolations"`
+	ClosedViolations    int       `json:"closedViolations"`
+	ConfirmedViolations int       `json:"confirmedViolations"` // Of those closed, confirmed
+	DismissedViolations int       `json:"dismissedViolations"` // Of those closed, dismissed
 }
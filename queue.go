@@ -2,6 +2,9 @@ package aletheia
 
 import (
 	"context"
+	"errors"
+	"math/rand/v2"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,38 +12,158 @@ import (
 
 // Queue defines operations for a job queue.
 type Queue interface {
-	// Enqueue adds a job to the queue.
+	// Enqueue adds a job to the queue. A job with prerequisites (see
+	// WithDependsOn) is not dequeued until they are satisfied, and is
+	// resolved by its DependencyPolicy if one of them does not complete.
+	// Returns EINVALID if a prerequisite does not exist.
+	//
+	// If the job has a UniqueKey (see WithUniqueKey) and a duplicate is
+	// still active, nothing is enqueued and job is overwritten with the
+	// existing job; callers can compare job.ID to tell.
 	Enqueue(ctx context.Context, job *Job, opts ...EnqueueOption) error
 
-	// Dequeue retrieves the next available job from a queue.
-	// Returns nil if no jobs are available.
-	Dequeue(ctx context.Context, queueName string) (*Job, error)
+	// EnqueueBatch enqueues jobs together with a callback job that depends
+	// on all of them, in one transaction. The callback runs once the batch
+	// completes; its DependencyPolicy decides what happens if a batch job
+	// does not. opts apply to the batch jobs, not the callback.
+	EnqueueBatch(ctx context.Context, jobs []*Job, callback *Job, opts ...EnqueueOption) error
 
-	// Complete marks a job as completed with optional result data.
-	Complete(ctx context.Context, jobID uuid.UUID, result []byte) error
+	// EnqueueScheduled enqueues job as the run of a recurring schedule due
+	// at runAt. Only the first call for a schedule and runAt enqueues the
+	// job, so every instance may run the same schedules; later calls report
+	// false.
+	EnqueueScheduled(ctx context.Context, schedule string, runAt time.Time, job *Job, opts ...EnqueueOption) (bool, error)
 
-	// Fail marks a job as failed with an error message.
-	// The job may be retried based on its retry configuration.
-	Fail(ctx context.Context, jobID uuid.UUID, errMsg string) error
+	// Dequeue retrieves the next available job from a queue and assigns it
+	// to workerID. Among jobs of equal priority, it prefers organizations
+	// with the fewest running jobs so one organization's backlog cannot
+	// starve others. When rate limiting is enabled, organizations at their
+	// concurrency or hourly limit are skipped. Returns nil if no jobs are
+	// available.
+	Dequeue(ctx context.Context, queueName, workerID string) (*Job, error)
+
+	// Heartbeat records that workerID is still processing a job.
+	// Returns ECONFLICT if the job is no longer running on workerID, for
+	// example because it was reclaimed by ReapStaleJobs.
+	Heartbeat(ctx context.Context, jobID uuid.UUID, workerID string) error
+
+	// UpdateProgress records how far workerID has got with a running job,
+	// as a percentage and a short description of the current stage. It also
+	// counts as a heartbeat. Returns EINVALID if percent is outside 0 to 100
+	// and ECONFLICT if the job is no longer running on workerID.
+	UpdateProgress(ctx context.Context, jobID uuid.UUID, workerID string, percent int, message string) error
+
+	// ReapStaleJobs reclaims running jobs whose last heartbeat is older than
+	// timeout, counting the lost attempt. Jobs with attempts left return to
+	// pending; the rest are moved to the dead-letter state. Returns the
+	// number reclaimed.
+	ReapStaleJobs(ctx context.Context, timeout time.Duration) (int, error)
+
+	// Complete marks a job running on workerID as completed with optional
+	// result data and full progress, making jobs that depend on it runnable
+	// once their other prerequisites are satisfied. Returns ECONFLICT if the
+	// job is no longer running on workerID, for example because it was
+	// reclaimed by ReapStaleJobs.
+	Complete(ctx context.Context, jobID uuid.UUID, workerID string, result []byte) error
+
+	// Fail records a failed attempt of a job running on workerID and appends
+	// jobErr to the job's error history. The job is rescheduled with
+	// exponential backoff until its attempts are exhausted, after which it is
+	// moved to the dead-letter state, or jobErr is permanent (see Permanent),
+	// after which it is marked failed. Returns ECONFLICT if the job is no
+	// longer running on workerID.
+	Fail(ctx context.Context, jobID uuid.UUID, workerID string, jobErr error) error
+
+	// CleanupJobs deletes jobs that finished more than retention ago, along
+	// with records of scheduled runs that old. Returns the number of jobs
+	// deleted.
+	CleanupJobs(ctx context.Context, retention time.Duration) (int, error)
 
 	// GetJob retrieves a job by its ID.
 	// Returns ENOTFOUND if the job does not exist.
 	GetJob(ctx context.Context, jobID uuid.UUID) (*Job, error)
 
-	// CancelJob cancels a pending job.
+	// CancelJob cancels a pending job. Jobs that depend on it are resolved
+	// by their DependencyPolicy.
 	// Returns EINVALID if the job is already running or completed.
 	CancelJob(ctx context.Context, jobID uuid.UUID) error
 
 	// GetPendingJobs retrieves pending jobs for an organization.
 	GetPendingJobs(ctx context.Context, orgID uuid.UUID, queueName string) ([]*Job, error)
+
+	// GetRunningJobs retrieves running jobs and the workers holding them.
+	// An empty queueName matches all queues.
+	GetRunningJobs(ctx context.Context, queueName string) ([]*Job, error)
+
+	// ListJobs retrieves jobs matching filter, newest first, along with the
+	// total number of matches before pagination.
+	ListJobs(ctx context.Context, filter JobFilter) ([]*Job, int, error)
+
+	// RetryJob returns a failed, dead, or cancelled job to pending with a
+	// fresh set of attempts. Its error history is kept. A retried job waits
+	// for its prerequisites again, so failed prerequisites must be retried
+	// too.
+	// Returns EINVALID if the job is pending, running, or completed.
+	RetryJob(ctx context.Context, jobID uuid.UUID) error
+
+	// RetryJobs retries every job matching filter that can be retried, as
+	// RetryJob does, ignoring pagination. Returns the number retried.
+	RetryJobs(ctx context.Context, filter JobFilter) (int, error)
+
+	// CancelJobs cancels every pending job matching filter, ignoring
+	// pagination. Returns the number cancelled.
+	CancelJobs(ctx context.Context, filter JobFilter) (int, error)
+
+	// ListRateLimits retrieves the rate limits set for an organization.
+	// Queues without one use the defaults in QueueConfig.
+	ListRateLimits(ctx context.Context, orgID uuid.UUID) ([]*RateLimit, error)
+
+	// SetRateLimit creates or replaces the rate limit for an organization's
+	// queue. Returns EINVALID if the limit is invalid and ENOTFOUND if the
+	// organization does not exist.
+	SetRateLimit(ctx context.Context, limit *RateLimit) error
+
+	// DeleteRateLimit removes an organization's rate limit for a queue,
+	// returning it to the defaults.
+	// Returns ENOTFOUND if no rate limit is set.
+	DeleteRateLimit(ctx context.Context, orgID uuid.UUID, queueName string) error
+}
+
+// QueueListener is implemented by queues that can push notifications when
+// jobs are enqueued or change, letting workers and job event streams react
+// immediately instead of polling.
+type QueueListener interface {
+	// Listen subscribes to new jobs on queueNames. The returned channel
+	// receives the name of a queue that may have a job available, and is
+	// closed when ctx is done. Notifications may be dropped or coalesced, so
+	// receivers should still poll occasionally.
+	Listen(ctx context.Context, queueNames []string) (<-chan string, error)
+
+	// ListenJobEvents subscribes to jobs being enqueued and to changes in
+	// their status and progress. The returned channel is closed when ctx is
+	// done. Notifications may be dropped, so receivers should still poll
+	// occasionally.
+	ListenJobEvents(ctx context.Context) (<-chan JobChange, error)
 }
 
-// Job represents a background job.
+// JobChange identifies a job that was enqueued or changed. InspectionID is
+// set when a job working on an inspection is enqueued, so streams following
+// the inspection can pick it up. A zero JobChange means any job may have
+// changed.
+type JobChange struct {
+	JobID        uuid.UUID
+	InspectionID uuid.UUID
+}
+
+// Job represents a background job. Maintenance jobs that belong to no
+// organization have a nil OrganizationID. Jobs working on an inspection set
+// InspectionID so its page can follow their progress.
 type Job struct {
 	ID             uuid.UUID  `json:"id"`
 	QueueName      string     `json:"queueName"`
 	JobType        string     `json:"jobType"`
 	OrganizationID uuid.UUID  `json:"organizationId"`
+	InspectionID   uuid.UUID  `json:"inspectionId"`
 	Payload        []byte     `json:"payload"`
 	Status         JobStatus  `json:"status"`
 	Priority       int        `json:"priority"`
@@ -52,7 +175,64 @@ type Job struct {
 	CompletedAt    *time.Time `json:"completedAt,omitempty"`
 	Result         []byte     `json:"result,omitempty"`
 	ErrorMessage   string     `json:"errorMessage,omitempty"`
+	Errors         []JobError `json:"errors,omitempty"`
 	WorkerID       string     `json:"workerId,omitempty"`
+	HeartbeatAt    *time.Time `json:"heartbeatAt,omitempty"`
+
+	// Progress is the percentage of the current attempt done, as reported
+	// by its handler, and ProgressMessage describes the current stage.
+	Progress        int    `json:"progress"`
+	ProgressMessage string `json:"progressMessage,omitempty"`
+
+	// DependsOn lists the jobs that must finish before this job runs.
+	DependsOn []uuid.UUID `json:"dependsOn,omitempty"`
+
+	// DependencyPolicy decides what happens when a prerequisite does not
+	// complete.
+	DependencyPolicy DependencyPolicy `json:"dependencyPolicy,omitempty"`
+
+	// UniqueKey identifies duplicate jobs within an organization, such as
+	// two analyses of the same photo. A job is not enqueued while another
+	// with the same key is pending or running, or completed less than
+	// UniqueFor ago. UniqueFor only applies to the enqueue it is set on and
+	// is not stored.
+	UniqueKey string        `json:"uniqueKey,omitempty"`
+	UniqueFor time.Duration `json:"-"`
+}
+
+// JobFilter defines criteria for filtering jobs.
+type JobFilter struct {
+	QueueName      *string
+	JobType        *string
+	Status         *JobStatus
+	OrganizationID *uuid.UUID
+	InspectionID   *uuid.UUID
+
+	// Pagination
+	Offset int
+	Limit  int
+}
+
+// JobError records why a single job attempt failed.
+type JobError struct {
+	Attempt  int       `json:"attempt"`
+	Message  string    `json:"message"`
+	FailedAt time.Time `json:"failedAt"`
+}
+
+// CanRetry reports whether a failed attempt should be retried.
+func (j *Job) CanRetry(jobErr error) bool {
+	return j.AttemptCount < j.MaxAttempts && !IsPermanent(jobErr)
+}
+
+// FailedStatus returns the status of a job whose attempt failed with jobErr
+// and will not be retried: dead if it ran out of attempts, failed if jobErr
+// is permanent.
+func (j *Job) FailedStatus(jobErr error) JobStatus {
+	if IsPermanent(jobErr) {
+		return JobStatusFailed
+	}
+	return JobStatusDead
 }
 
 // JobStatus represents the status of a job.
@@ -64,11 +244,151 @@ const (
 	JobStatusCompleted JobStatus = "completed"
 	JobStatusFailed    JobStatus = "failed"
 	JobStatusCancelled JobStatus = "cancelled"
+
+	// JobStatusDead marks a job that exhausted its attempts. Dead jobs are
+	// kept aside until an operator replays them with RetryJob or RetryJobs.
+	JobStatusDead JobStatus = "dead"
 )
 
+// IsValid returns true if s is a known job status.
+func (s JobStatus) IsValid() bool {
+	switch s {
+	case JobStatusPending, JobStatusRunning, JobStatusCompleted, JobStatusFailed, JobStatusCancelled, JobStatusDead:
+		return true
+	}
+	return false
+}
+
 // IsTerminal returns true if the job is in a terminal state.
 func (s JobStatus) IsTerminal() bool {
-	return s == JobStatusCompleted || s == JobStatusFailed || s == JobStatusCancelled
+	return s == JobStatusCompleted || s == JobStatusFailed || s == JobStatusCancelled || s == JobStatusDead
+}
+
+// IsRetryable returns true if a job in this state can be retried manually.
+func (s JobStatus) IsRetryable() bool {
+	return s == JobStatusFailed || s == JobStatusCancelled || s == JobStatusDead
+}
+
+// DependencyPolicy decides what happens to a job when one of its
+// prerequisites fails, is cancelled, or is dead-lettered.
+type DependencyPolicy string
+
+const (
+	// DependencyPolicyFail marks the job failed. It is the default.
+	DependencyPolicyFail DependencyPolicy = "fail"
+
+	// DependencyPolicySkip cancels the job.
+	DependencyPolicySkip DependencyPolicy = "skip"
+
+	// DependencyPolicyContinue runs the job once every prerequisite has
+	// finished, whatever the outcome. The handler can inspect them with
+	// GetJob, for example to report on a partly failed batch.
+	DependencyPolicyContinue DependencyPolicy = "continue"
+)
+
+// IsValid returns true if p is a known dependency policy.
+func (p DependencyPolicy) IsValid() bool {
+	switch p {
+	case DependencyPolicyFail, DependencyPolicySkip, DependencyPolicyContinue:
+		return true
+	}
+	return false
+}
+
+// ResolvedStatus returns the status of a job whose prerequisite did not
+// complete, or an empty status if the job should still run.
+func (p DependencyPolicy) ResolvedStatus() JobStatus {
+	switch p {
+	case DependencyPolicySkip:
+		return JobStatusCancelled
+	case DependencyPolicyContinue:
+		return ""
+	}
+	return JobStatusFailed
+}
+
+// DependencyFailedMessage is the error message recorded on jobs resolved
+// because a prerequisite did not complete.
+const DependencyFailedMessage = "prerequisite job did not complete"
+
+// RateLimit caps how many jobs an organization may run from a queue.
+type RateLimit struct {
+	OrganizationID    uuid.UUID `json:"organizationId"`
+	QueueName         string    `json:"queueName"`
+	Tier              string    `json:"tier"`
+	MaxJobsPerHour    int       `json:"maxJobsPerHour"`
+	MaxConcurrentJobs int       `json:"maxConcurrentJobs"`
+	CreatedAt         time.Time `json:"createdAt"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
+
+// Rate limit tiers.
+const (
+	RateLimitTierFree       = "free"
+	RateLimitTierPro        = "pro"
+	RateLimitTierEnterprise = "enterprise"
+)
+
+// rateLimitTiers holds the limits each tier starts with, as
+// {max jobs per hour, max concurrent jobs}.
+var rateLimitTiers = map[string][2]int{
+	RateLimitTierFree:       {10, 2},
+	RateLimitTierPro:        {100, 10},
+	RateLimitTierEnterprise: {1000, 50},
+}
+
+// NewRateLimit returns the rate limit for an organization's queue on tier.
+// Returns EINVALID if the tier is unknown.
+func NewRateLimit(orgID uuid.UUID, queueName, tier string) (*RateLimit, error) {
+	limits, ok := rateLimitTiers[tier]
+	if !ok {
+		return nil, Invalid("Unknown rate limit tier: %s", tier)
+	}
+	return &RateLimit{
+		OrganizationID:    orgID,
+		QueueName:         queueName,
+		Tier:              tier,
+		MaxJobsPerHour:    limits[0],
+		MaxConcurrentJobs: limits[1],
+	}, nil
+}
+
+// Validate returns EINVALID if the rate limit cannot be stored.
+func (r *RateLimit) Validate() error {
+	if r.QueueName == "" || len(r.QueueName) > 50 {
+		return Invalid("Queue name must be 1 to 50 characters")
+	}
+	if _, ok := rateLimitTiers[r.Tier]; !ok {
+		return Invalid("Unknown rate limit tier: %s", r.Tier)
+	}
+	if r.MaxJobsPerHour <= 0 || r.MaxConcurrentJobs <= 0 {
+		return Invalid("Rate limits must be positive")
+	}
+	return nil
+}
+
+// permanentError marks an error that must not be retried.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so the queue fails the job without retrying it.
+// Handlers return it for errors that will not resolve on their own, such as
+// a malformed payload or a deleted resource.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was wrapped with Permanent.
+func IsPermanent(err error) bool {
+	var e *permanentError
+	return errors.As(err, &e)
 }
 
 // Common job types.
@@ -76,6 +396,7 @@ const (
 	JobTypePhotoAnalysis     = "photo_analysis"
 	JobTypeReportGeneration  = "report_generation"
 	JobTypeNotificationEmail = "notification_email"
+	JobTypeProjectSummary    = "project_summary"
 )
 
 // Common queue names.
@@ -89,10 +410,14 @@ const (
 type EnqueueOption func(*enqueueOptions)
 
 type enqueueOptions struct {
-	Priority    int
-	MaxAttempts int
-	ScheduledAt time.Time
-	Delay       time.Duration
+	Priority         int
+	MaxAttempts      int
+	ScheduledAt      time.Time
+	Delay            time.Duration
+	DependsOn        []uuid.UUID
+	DependencyPolicy DependencyPolicy
+	UniqueKey        string
+	UniqueFor        time.Duration
 }
 
 // WithPriority sets the job priority (higher = more important).
@@ -123,6 +448,123 @@ func WithDelay(d time.Duration) EnqueueOption {
 	}
 }
 
+// WithDependsOn adds prerequisites that must finish before the job runs.
+func WithDependsOn(jobIDs ...uuid.UUID) EnqueueOption {
+	return func(o *enqueueOptions) {
+		o.DependsOn = append(o.DependsOn, jobIDs...)
+	}
+}
+
+// WithDependencyPolicy sets what happens when a prerequisite does not
+// complete.
+func WithDependencyPolicy(policy DependencyPolicy) EnqueueOption {
+	return func(o *enqueueOptions) {
+		o.DependencyPolicy = policy
+	}
+}
+
+// WithUniqueKey deduplicates the job against active jobs with the same key
+// in its organization. See Job.UniqueKey.
+func WithUniqueKey(key string) EnqueueOption {
+	return func(o *enqueueOptions) {
+		o.UniqueKey = key
+	}
+}
+
+// WithUniqueFor also deduplicates a unique job against jobs with its key
+// that completed less than d ago.
+func WithUniqueFor(d time.Duration) EnqueueOption {
+	return func(o *enqueueOptions) {
+		o.UniqueFor = d
+	}
+}
+
+// DefaultMaxAttempts is the number of attempts a job gets unless overridden.
+const DefaultMaxAttempts = 3
+
+// PrepareJob fills in defaults for a new job and applies enqueue options.
+// Queue implementations call it from Enqueue.
+func PrepareJob(job *Job, opts ...EnqueueOption) {
+	o := enqueueOptions{
+		Priority:         job.Priority,
+		MaxAttempts:      job.MaxAttempts,
+		ScheduledAt:      job.ScheduledAt,
+		DependsOn:        job.DependsOn,
+		DependencyPolicy: job.DependencyPolicy,
+		UniqueKey:        job.UniqueKey,
+		UniqueFor:        job.UniqueFor,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	now := time.Now()
+	if job.ID == uuid.Nil {
+		job.ID = uuid.New()
+	}
+	if job.Status == "" {
+		job.Status = JobStatusPending
+	}
+	if job.CreatedAt.IsZero() {
+		job.CreatedAt = now
+	}
+
+	job.Priority = o.Priority
+	job.MaxAttempts = o.MaxAttempts
+	if job.MaxAttempts <= 0 {
+		job.MaxAttempts = DefaultMaxAttempts
+	}
+
+	job.ScheduledAt = o.ScheduledAt
+	if o.Delay > 0 {
+		job.ScheduledAt = now.Add(o.Delay)
+	}
+	if job.ScheduledAt.IsZero() {
+		job.ScheduledAt = now
+	}
+
+	job.DependsOn = nil
+	for _, id := range o.DependsOn {
+		if !slices.Contains(job.DependsOn, id) {
+			job.DependsOn = append(job.DependsOn, id)
+		}
+	}
+	job.DependencyPolicy = o.DependencyPolicy
+	if job.DependencyPolicy == "" {
+		job.DependencyPolicy = DependencyPolicyFail
+	}
+
+	job.UniqueKey = o.UniqueKey
+	job.UniqueFor = o.UniqueFor
+}
+
+// DuplicateOf reports whether job, being enqueued, is a duplicate of
+// existing under the job's unique key.
+func (j *Job) DuplicateOf(existing *Job) bool {
+	if j.UniqueKey == "" || existing.UniqueKey != j.UniqueKey || existing.OrganizationID != j.OrganizationID {
+		return false
+	}
+	switch existing.Status {
+	case JobStatusPending, JobStatusRunning:
+		return true
+	case JobStatusCompleted:
+		return j.UniqueFor > 0 && existing.CompletedAt != nil && time.Since(*existing.CompletedAt) < j.UniqueFor
+	}
+	return false
+}
+
+// ValidateDependencies returns EINVALID if a prepared job's dependency
+// policy is unknown or it depends on itself.
+func (j *Job) ValidateDependencies() error {
+	if !j.DependencyPolicy.IsValid() {
+		return Invalid("Unknown dependency policy: %s", j.DependencyPolicy)
+	}
+	if slices.Contains(j.DependsOn, j.ID) {
+		return Invalid("Job cannot depend on itself")
+	}
+	return nil
+}
+
 // QueueConfig holds configuration for the job queue.
 type QueueConfig struct {
 	// Provider is the queue provider ("postgres" or "redis").
@@ -139,17 +581,81 @@ type QueueConfig struct {
 
 	// EnableRateLimiting enables per-organization rate limits.
 	EnableRateLimiting bool
+
+	// DefaultMaxJobsPerHour limits how many jobs an organization without a
+	// RateLimit may start per queue in any hour. Zero means unlimited.
+	DefaultMaxJobsPerHour int
+
+	// DefaultMaxConcurrentJobs limits how many jobs an organization without
+	// a RateLimit may run at once per queue. Zero means unlimited.
+	DefaultMaxConcurrentJobs int
+
+	// RetryBaseDelay is the backoff before the first retry. Each further
+	// retry doubles it.
+	RetryBaseDelay time.Duration
+
+	// RetryMaxDelay caps the backoff between retries.
+	RetryMaxDelay time.Duration
 }
 
 // DefaultQueueConfig returns the default queue configuration.
 func DefaultQueueConfig() QueueConfig {
 	return QueueConfig{
-		Provider:           "postgres",
-		WorkerCount:        3,
-		PollInterval:       time.Second,
-		JobTimeout:         60 * time.Second,
-		EnableRateLimiting: true,
+		Provider:                 "postgres",
+		WorkerCount:              3,
+		PollInterval:             time.Second,
+		JobTimeout:               60 * time.Second,
+		EnableRateLimiting:       true,
+		DefaultMaxJobsPerHour:    100,
+		DefaultMaxConcurrentJobs: 10,
+		RetryBaseDelay:           10 * time.Second,
+		RetryMaxDelay:            time.Hour,
+	}
+}
+
+// RetryDelay returns the backoff before retrying a job whose attempt-th
+// attempt failed. The delay grows exponentially from RetryBaseDelay, is
+// capped at RetryMaxDelay, and is jittered into [d/2, d) so retries of jobs
+// that failed together spread out.
+func (c QueueConfig) RetryDelay(attempt int) time.Duration {
+	base, maxDelay := c.RetryBaseDelay, c.RetryMaxDelay
+	if base <= 0 {
+		base = DefaultQueueConfig().RetryBaseDelay
+	}
+	if maxDelay <= 0 {
+		maxDelay = DefaultQueueConfig().RetryMaxDelay
+	}
+
+	d := base
+	for i := 1; i < attempt && d < maxDelay; i++ {
+		d *= 2
+	}
+	d = min(d, maxDelay)
+
+	half := d / 2
+	return half + rand.N(d-half)
+}
+
+// ProgressFunc records the progress of the job being handled.
+type ProgressFunc func(ctx context.Context, percent int, message string) error
+
+type progressKey struct{}
+
+// WithProgress returns a context whose job handler reports progress to fn.
+// Worker pools call it before running a handler.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// ReportProgress records the progress of the job handled under ctx, as a
+// percentage and a short description of the current stage. It does nothing
+// if ctx does not belong to a job handler.
+func ReportProgress(ctx context.Context, percent int, message string) error {
+	fn, ok := ctx.Value(progressKey{}).(ProgressFunc)
+	if !ok {
+		return nil
 	}
+	return fn(ctx, percent, message)
 }
 
 // JobHandler handles processing of a specific job type.
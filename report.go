@@ -0,0 +1,370 @@
+package aletheia
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Report is a generated document summarizing an inspection. Each report is
+// a new version; earlier versions are kept unchanged.
+type Report struct {
+	ID           uuid.UUID      `json:"id"`
+	InspectionID uuid.UUID      `json:"inspectionId"`
+	Version      int            `json:"version"`
+	StorageURL   string         `json:"storageUrl"`
+	SizeBytes    int64          `json:"sizeBytes"`
+	SnapshotHash string         `json:"snapshotHash"`
+	GeneratedBy  uuid.UUID      `json:"generatedBy,omitempty"` // Nil if generated by the system or the user is gone
+	Template     ReportTemplate `json:"template"`
+	CreatedAt    time.Time      `json:"createdAt"`
+
+	// SHA256 is the hex SHA-256 of the report's PDF. Signature is the
+	// server's signature of it, made with the key SigningKeyID; both are
+	// empty if reports are not signed. Signoffs are the sign-offs the
+	// report shows.
+	SHA256       string    `json:"sha256,omitempty"`
+	Signature    string    `json:"signature,omitempty"`
+	SigningKeyID string    `json:"signingKeyId,omitempty"`
+	Signoffs     []Signoff `json:"signoffs"`
+
+	// Snapshot is the content the report was built from.
+	Snapshot ReportSnapshot `json:"-"`
+}
+
+// ReportTemplate selects what a report includes.
+type ReportTemplate string
+
+const (
+	// ReportTemplateFull includes the summary, every confirmed violation,
+	// and the photos. It is the default.
+	ReportTemplateFull ReportTemplate = "full"
+
+	// ReportTemplateExecutiveSummary includes the summary and the critical
+	// and high severity violations, without photos.
+	ReportTemplateExecutiveSummary ReportTemplate = "executive_summary"
+
+	// ReportTemplateViolationsOnly lists every confirmed violation, without
+	// the summary or photos.
+	ReportTemplateViolationsOnly ReportTemplate = "violations_only"
+)
+
+// IsValid returns true if t is a known report template.
+func (t ReportTemplate) IsValid() bool {
+	switch t {
+	case ReportTemplateFull, ReportTemplateExecutiveSummary, ReportTemplateViolationsOnly:
+		return true
+	}
+	return false
+}
+
+// ReportSnapshot fingerprints the photos, confirmed violations, and
+// sign-offs a report shows, so that later changes to the inspection can be
+// detected.
+type ReportSnapshot struct {
+	Photos     map[uuid.UUID]string `json:"photos"`
+	Violations map[uuid.UUID]string `json:"violations"`
+	Signoffs   map[uuid.UUID]string `json:"signoffs,omitempty"`
+}
+
+// NewReportSnapshot fingerprints the photos and violations of a report, and
+// those of the sign-offs that are still current.
+func NewReportSnapshot(photos []*Photo, violations []*Violation, signoffs []*Signoff) ReportSnapshot {
+	s := ReportSnapshot{
+		Photos:     make(map[uuid.UUID]string, len(photos)),
+		Violations: make(map[uuid.UUID]string, len(violations)),
+	}
+	for _, p := range photos {
+		s.Photos[p.ID] = fingerprint(p.StorageURL, p.ThumbnailURL)
+	}
+	for _, v := range violations {
+		s.Violations[v.ID] = fingerprint(v.PhotoID.String(), v.SafetyCodeID.String(),
+			v.Description, string(v.Severity), string(v.Status), v.Location)
+	}
+	for _, signoff := range signoffs {
+		if !signoff.Current(s) {
+			continue
+		}
+		if s.Signoffs == nil {
+			s.Signoffs = map[uuid.UUID]string{}
+		}
+		s.Signoffs[signoff.ID] = signoff.fingerprint()
+	}
+	return s
+}
+
+// Hash returns a digest of the whole snapshot. It equals ContentHash if
+// there are no sign-offs.
+func (s ReportSnapshot) Hash() string {
+	lines := s.contentLines()
+	for id, fp := range s.Signoffs {
+		lines = append(lines, fmt.Sprintf("signoff %s %s", id, fp))
+	}
+	slices.Sort(lines)
+	return fingerprint(lines...)
+}
+
+// ContentHash returns a digest of the photos and violations only, which is
+// what sign-offs approve.
+func (s ReportSnapshot) ContentHash() string {
+	lines := s.contentLines()
+	slices.Sort(lines)
+	return fingerprint(lines...)
+}
+
+func (s ReportSnapshot) contentLines() []string {
+	lines := make([]string, 0, len(s.Photos)+len(s.Violations)+len(s.Signoffs))
+	for id, fp := range s.Photos {
+		lines = append(lines, fmt.Sprintf("photo %s %s", id, fp))
+	}
+	for id, fp := range s.Violations {
+		lines = append(lines, fmt.Sprintf("violation %s %s", id, fp))
+	}
+	return lines
+}
+
+// Changes counts the photos, violations, and sign-offs added, removed, or
+// edited in current since s was taken.
+func (s ReportSnapshot) Changes(current ReportSnapshot) ReportChanges {
+	return ReportChanges{
+		Photos:     countChanges(s.Photos, current.Photos),
+		Violations: countChanges(s.Violations, current.Violations),
+		Signoffs:   countChanges(s.Signoffs, current.Signoffs),
+	}
+}
+
+func countChanges(before, after map[uuid.UUID]string) int {
+	n := 0
+	for id, fp := range after {
+		if before[id] != fp {
+			n++
+		}
+	}
+	for id := range before {
+		if _, ok := after[id]; !ok {
+			n++
+		}
+	}
+	return n
+}
+
+func fingerprint(fields ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// ReportChanges counts what changed in an inspection since a report.
+type ReportChanges struct {
+	Photos     int `json:"photos"`
+	Violations int `json:"violations"`
+	Signoffs   int `json:"signoffs"`
+}
+
+// ReportStatus describes whether an inspection's latest report is current.
+type ReportStatus struct {
+	Latest  *Report       `json:"latest,omitempty"` // Nil if no report was generated
+	Stale   bool          `json:"stale"`
+	Changes ReportChanges `json:"changes"`
+	Summary string        `json:"summary"`
+}
+
+// NewReportStatus compares the latest report of an inspection, if any, with
+// a snapshot of the inspection as it is now.
+func NewReportStatus(latest *Report, current ReportSnapshot) *ReportStatus {
+	if latest == nil {
+		return &ReportStatus{Summary: "No report generated"}
+	}
+
+	status := &ReportStatus{
+		Latest:  latest,
+		Changes: latest.Snapshot.Changes(current),
+		Summary: "Report up to date",
+	}
+	if latest.SnapshotHash == current.Hash() {
+		return status
+	}
+
+	status.Stale = true
+	switch {
+	case status.Changes.Violations > 0:
+		status.Summary = fmt.Sprintf("Report out of date (%d %s changed)",
+			status.Changes.Violations, pluralize(status.Changes.Violations, "violation"))
+	case status.Changes.Photos > 0:
+		status.Summary = fmt.Sprintf("Report out of date (%d %s changed)",
+			status.Changes.Photos, pluralize(status.Changes.Photos, "photo"))
+	case status.Changes.Signoffs > 0:
+		status.Summary = "Report out of date (sign-off changed)"
+	default:
+		status.Summary = "Report out of date"
+	}
+	return status
+}
+
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return noun
+	}
+	return noun + "s"
+}
+
+// PreflightSeverity is how serious a pre-flight finding is.
+type PreflightSeverity string
+
+const (
+	// PreflightBlocking findings must be fixed before a report is generated.
+	PreflightBlocking PreflightSeverity = "blocking"
+	// PreflightWarning findings are shown, but a report can still be generated.
+	PreflightWarning PreflightSeverity = "warning"
+)
+
+// Pre-flight finding codes.
+const (
+	PreflightMissingSafetyCode     = "missing_safety_code"
+	PreflightPendingViolations     = "pending_violations"
+	PreflightNoPhotos              = "no_photos"
+	PreflightNoConfirmedViolations = "no_confirmed_violations"
+	PreflightInspectionIncomplete  = "inspection_incomplete"
+	PreflightSignoffOutdated       = "signoff_outdated"
+)
+
+// PreflightFinding is a problem found while checking an inspection before
+// generating its report.
+type PreflightFinding struct {
+	Code         string            `json:"code"`
+	Severity     PreflightSeverity `json:"severity"`
+	Message      string            `json:"message"`
+	ViolationIDs []uuid.UUID       `json:"violationIds,omitempty"`
+}
+
+// Preflight summarizes what a report of an inspection would include and
+// what should be fixed first.
+type Preflight struct {
+	InspectionID        uuid.UUID          `json:"inspectionId"`
+	ConfirmedViolations int                `json:"confirmedViolations"`
+	BySeverity          map[Severity]int   `json:"bySeverity"` // Confirmed violations only
+	DismissedViolations int                `json:"dismissedViolations"`
+	PendingViolations   int                `json:"pendingViolations"`
+	Photos              int                `json:"photos"`
+	Findings            []PreflightFinding `json:"findings"`
+	Ready               bool               `json:"ready"` // No blocking findings
+}
+
+// Err returns an EINVALID error listing the blocking findings, or nil if
+// there are none.
+func (p *Preflight) Err() error {
+	fields := map[string]string{}
+	for _, f := range p.Findings {
+		if f.Severity == PreflightBlocking {
+			fields[f.Code] = f.Message
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return &Error{
+		Code:    EINVALID,
+		Message: "Inspection is not ready for a report",
+		Fields:  fields,
+	}
+}
+
+// ReportService defines operations for managing generated reports.
+type ReportService interface {
+	// FindReportByID retrieves a report by its ID.
+	// Returns ENOTFOUND if the report does not exist.
+	FindReportByID(ctx context.Context, id uuid.UUID) (*Report, error)
+
+	// FindReports retrieves every version of an inspection's report,
+	// newest first.
+	FindReports(ctx context.Context, inspectionID uuid.UUID) ([]*Report, error)
+
+	// FindReportBySHA256 retrieves the report whose PDF has a hex SHA-256.
+	// Returns ENOTFOUND if no report has it.
+	FindReportBySHA256(ctx context.Context, sha256 string) (*Report, error)
+
+	// CreateReport records a report whose file has been stored as the next
+	// version of the inspection's report. Reports cannot be changed once
+	// created. Returns ENOTFOUND if the inspection does not exist.
+	CreateReport(ctx context.Context, report *Report) error
+
+	// FindReportDeliveryByID retrieves a delivery by its ID.
+	// Returns ENOTFOUND if the delivery does not exist.
+	FindReportDeliveryByID(ctx context.Context, id uuid.UUID) (*ReportDelivery, error)
+
+	// FindReportDeliveries retrieves the deliveries of a report, newest first.
+	FindReportDeliveries(ctx context.Context, reportID uuid.UUID) ([]*ReportDelivery, error)
+
+	// CreateReportDelivery records a pending delivery of a report.
+	// Returns ENOTFOUND if the report does not exist.
+	CreateReportDelivery(ctx context.Context, delivery *ReportDelivery) error
+
+	// MarkReportDeliverySent records that a delivery was accepted by the
+	// email provider. Returns ENOTFOUND if the delivery does not exist.
+	MarkReportDeliverySent(ctx context.Context, id uuid.UUID, messageID string) error
+
+	// MarkReportDeliveryFailed records why a delivery could not be sent.
+	// Returns ENOTFOUND if the delivery does not exist.
+	MarkReportDeliveryFailed(ctx context.Context, id uuid.UUID, reason string) error
+
+	// MarkReportDeliveryBounced records that the message sent for a delivery
+	// bounced. Returns ENOTFOUND if no delivery sent that message.
+	MarkReportDeliveryBounced(ctx context.Context, messageID, reason string, bouncedAt time.Time) error
+
+	// FindSummaryReportByID retrieves a project summary report by its ID.
+	// Returns ENOTFOUND if the report does not exist.
+	FindSummaryReportByID(ctx context.Context, id uuid.UUID) (*SummaryReport, error)
+
+	// FindSummaryReports retrieves the summary reports of a project, newest
+	// first.
+	FindSummaryReports(ctx context.Context, projectID uuid.UUID) ([]*SummaryReport, error)
+
+	// CreateSummaryReport records a project summary report whose file has
+	// been stored. Returns ENOTFOUND if the project does not exist.
+	CreateSummaryReport(ctx context.Context, report *SummaryReport) error
+}
+
+// DeliveryStatus is the state of a report delivery.
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending DeliveryStatus = "pending"
+	DeliveryStatusSent    DeliveryStatus = "sent"
+	DeliveryStatusFailed  DeliveryStatus = "failed"
+	DeliveryStatusBounced DeliveryStatus = "bounced"
+)
+
+// ReportDelivery is a report emailed to one recipient.
+type ReportDelivery struct {
+	ID                uuid.UUID      `json:"id"`
+	ReportID          uuid.UUID      `json:"reportId"`
+	Recipient         string         `json:"recipient"`
+	ContactID         uuid.UUID      `json:"contactId,omitempty"` // Nil for ad-hoc addresses
+	Attached          bool           `json:"attached"`            // Attached rather than linked
+	Message           string         `json:"message,omitempty"`
+	Status            DeliveryStatus `json:"status"`
+	ProviderMessageID string         `json:"providerMessageId,omitempty"`
+	Error             string         `json:"error,omitempty"` // Why sending failed or the message bounced
+	SentBy            uuid.UUID      `json:"sentBy,omitempty"`
+	SentAt            *time.Time     `json:"sentAt,omitempty"`
+	BouncedAt         *time.Time     `json:"bouncedAt,omitempty"`
+	CreatedAt         time.Time      `json:"createdAt"`
+}
+
+// ReportDeliveryPayload is the payload of a notification email job that
+// sends a report.
+type ReportDeliveryPayload struct {
+	DeliveryID uuid.UUID `json:"deliveryId"`
+}
+
+// ReportGenerationPayload is the payload of a report generation job.
+type ReportGenerationPayload struct {
+	InspectionID uuid.UUID      `json:"inspectionId"`
+	RequestedBy  uuid.UUID      `json:"requestedBy,omitempty"`
+	Template     ReportTemplate `json:"template,omitempty"` // Empty for the organization's default
+}
@@ -0,0 +1,106 @@
+package aletheia
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ShareLinkKind is what a share link shows.
+type ShareLinkKind string
+
+const (
+	// ShareLinkReport shares one version of an inspection's report.
+	ShareLinkReport ShareLinkKind = "report"
+
+	// ShareLinkInspection shares a summary of an inspection's confirmed
+	// violations.
+	ShareLinkInspection ShareLinkKind = "inspection"
+)
+
+// IsValid returns true if k is a known share link kind.
+func (k ShareLinkKind) IsValid() bool {
+	return k == ShareLinkReport || k == ShareLinkInspection
+}
+
+// Share link lifetimes.
+const (
+	DefaultShareLinkDuration = 14 * 24 * time.Hour
+	MaxShareLinkDuration     = 90 * 24 * time.Hour
+)
+
+// ShareLink lets people without an account view an inspection's report or
+// summary until it expires or is revoked. Only a hash of its token is
+// stored, so the token can only be read when the link is created.
+type ShareLink struct {
+	ID           uuid.UUID     `json:"id"`
+	InspectionID uuid.UUID     `json:"inspectionId"`
+	ReportID     uuid.UUID     `json:"reportId,omitempty"` // Nil unless Kind is ShareLinkReport
+	Kind         ShareLinkKind `json:"kind"`
+	Token        string        `json:"token,omitempty"` // Only set when the link is created
+	HasPassword  bool          `json:"hasPassword"`
+	ExpiresAt    time.Time     `json:"expiresAt"`
+	RevokedAt    *time.Time    `json:"revokedAt,omitempty"`
+	ViewCount    int           `json:"viewCount"`
+	LastViewedAt *time.Time    `json:"lastViewedAt,omitempty"`
+	CreatedBy    uuid.UUID     `json:"createdBy,omitempty"` // Nil if the user is gone
+	CreatedAt    time.Time     `json:"createdAt"`
+
+	PasswordHash string `json:"-"`
+}
+
+// Active returns nil if the link can be viewed, or an ENOTFOUND error
+// saying why it cannot.
+func (l *ShareLink) Active() error {
+	if l.RevokedAt != nil {
+		return NotFound("This link has been revoked")
+	}
+	if !time.Now().Before(l.ExpiresAt) {
+		return NotFound("This link has expired")
+	}
+	return nil
+}
+
+// ShareLinkView is one view of a share link.
+type ShareLinkView struct {
+	ID          uuid.UUID `json:"id"`
+	ShareLinkID uuid.UUID `json:"shareLinkId"`
+	IPAddress   string    `json:"ipAddress,omitempty"`
+	UserAgent   string    `json:"userAgent,omitempty"`
+	ViewedAt    time.Time `json:"viewedAt"`
+}
+
+// ShareLinkService defines operations for managing share links.
+type ShareLinkService interface {
+	// FindShareLinkByID retrieves a share link by its ID.
+	// Returns ENOTFOUND if the link does not exist.
+	FindShareLinkByID(ctx context.Context, id uuid.UUID) (*ShareLink, error)
+
+	// FindShareLinkByToken retrieves a share link by its token, whether or
+	// not it is active. Returns ENOTFOUND if no link has the token.
+	FindShareLinkByToken(ctx context.Context, token string) (*ShareLink, error)
+
+	// FindShareLinks retrieves the share links of an inspection, newest first.
+	FindShareLinks(ctx context.Context, inspectionID uuid.UUID) ([]*ShareLink, error)
+
+	// CreateShareLink creates a share link with a new random token, which is
+	// set on link. If password is not empty, viewers must give it.
+	// Returns ENOTFOUND if the inspection or report does not exist.
+	CreateShareLink(ctx context.Context, link *ShareLink, password string) error
+
+	// RevokeShareLink stops a share link from being viewed. Revoking a
+	// revoked link has no effect. Returns ENOTFOUND if the link does not exist.
+	RevokeShareLink(ctx context.Context, id uuid.UUID) (*ShareLink, error)
+
+	// VerifyShareLinkPassword checks the password of a share link.
+	// Returns EUNAUTHORIZED if the link has a password and it does not match.
+	VerifyShareLinkPassword(ctx context.Context, link *ShareLink, password string) error
+
+	// RecordShareLinkView logs a view of a share link and counts it.
+	RecordShareLinkView(ctx context.Context, view *ShareLinkView) error
+
+	// FindShareLinkViews retrieves the most recent views of a share link,
+	// newest first.
+	FindShareLinkViews(ctx context.Context, shareLinkID uuid.UUID, limit int) ([]*ShareLinkView, error)
+}
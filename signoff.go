@@ -0,0 +1,66 @@
+package aletheia
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SignoffRole is the capacity in which someone signs off on an inspection.
+type SignoffRole string
+
+const (
+	// SignoffInspector is the inspector attesting to their findings.
+	SignoffInspector SignoffRole = "inspector"
+
+	// SignoffReviewer is an organization owner or admin approving the
+	// inspector's findings. It is optional.
+	SignoffReviewer SignoffRole = "reviewer"
+)
+
+// IsValid returns true if r is a known sign-off role.
+func (r SignoffRole) IsValid() bool {
+	return r == SignoffInspector || r == SignoffReviewer
+}
+
+// SignatureKind is how a signature was given.
+type SignatureKind string
+
+const (
+	// SignatureDrawn is an image of a signature drawn by hand.
+	SignatureDrawn SignatureKind = "drawn"
+
+	// SignatureTyped is the signer's name typed as their signature.
+	SignatureTyped SignatureKind = "typed"
+)
+
+// IsValid returns true if k is a known signature kind.
+func (k SignatureKind) IsValid() bool {
+	return k == SignatureDrawn || k == SignatureTyped
+}
+
+// Signoff is someone's approval of an inspection's photos and confirmed
+// violations as they were when they signed. Each role signs at most once;
+// to sign again, the sign-off is deleted first.
+type Signoff struct {
+	ID            uuid.UUID     `json:"id"`
+	InspectionID  uuid.UUID     `json:"inspectionId"`
+	Role          SignoffRole   `json:"role"`
+	SignerID      uuid.UUID     `json:"signerId,omitempty"` // Nil if the user is gone
+	SignerName    string        `json:"signerName"`
+	SignatureKind SignatureKind `json:"signatureKind"`
+	Signature     string        `json:"signature"` // The typed name, or the URL of the drawn image
+	ContentHash   string        `json:"contentHash"`
+	SignedAt      time.Time     `json:"signedAt"`
+}
+
+// Current returns true if the inspection's content has not changed since
+// the sign-off, given a snapshot of it as it is now.
+func (s *Signoff) Current(snapshot ReportSnapshot) bool {
+	return s.ContentHash == snapshot.ContentHash()
+}
+
+func (s *Signoff) fingerprint() string {
+	return fingerprint(string(s.Role), s.SignerName, string(s.SignatureKind), s.Signature,
+		s.ContentHash, s.SignedAt.UTC().Format(time.RFC3339Nano))
+}
@@ -3,6 +3,9 @@ package aletheia
 import (
 	"context"
 	"io"
+	"strings"
+
+	"github.com/google/uuid"
 )
 
 // FileStorage defines operations for file storage.
@@ -21,6 +24,38 @@ type FileStorage interface {
 
 	// Exists checks if a file exists in storage.
 	Exists(ctx context.Context, key string) (bool, error)
+
+	// Open returns a reader for the contents of a file. The caller must
+	// close it. Returns ENOTFOUND if the file does not exist.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// CreateMultipartUpload starts a multipart upload for key and returns its upload ID.
+	CreateMultipartUpload(ctx context.Context, key string, contentType string) (uploadID string, err error)
+
+	// UploadPart uploads a single part of a multipart upload.
+	// Part numbers start at 1. Returns the part's ETag.
+	UploadPart(ctx context.Context, key string, uploadID string, partNumber int, reader io.Reader) (etag string, err error)
+
+	// CompleteMultipartUpload assembles the uploaded parts and returns the file URL.
+	// Parts must be given in ascending part number order.
+	CompleteMultipartUpload(ctx context.Context, key string, uploadID string, parts []StoragePart) (url string, err error)
+
+	// AbortMultipartUpload discards a multipart upload and any uploaded parts.
+	AbortMultipartUpload(ctx context.Context, key string, uploadID string) error
+}
+
+// StorageKey returns the key of a file in storage from its URL, as returned
+// by Upload or GetURL. It reports false if the URL is not in storage.
+func StorageKey(storage FileStorage, url string) (string, bool) {
+	key, ok := strings.CutPrefix(url, storage.GetURL(""))
+	return key, ok && key != ""
+}
+
+// StoragePart identifies an uploaded part of a multipart upload.
+type StoragePart struct {
+	PartNumber int    `json:"partNumber"`
+	ETag       string `json:"etag"`
+	Size       int64  `json:"size"`
 }
 
 // StorageConfig holds configuration for file storage.
@@ -36,6 +71,47 @@ type StorageConfig struct {
 	S3Bucket  string
 	S3Region  string
 	S3BaseURL string
+
+	// S3-compatible store options (MinIO, Ceph, etc.)
+	S3Endpoint           string // Custom endpoint URL, e.g. "http://localhost:9000"
+	S3UsePathStyle       bool   // Address objects as endpoint/bucket/key
+	S3AccessKeyID        string // Static credentials; the default AWS chain is used when empty
+	S3SecretAccessKey    string
+	S3CACertFile         string // PEM file of additional CAs to trust for the endpoint
+	S3InsecureSkipVerify bool   // Skip TLS verification (development only)
+}
+
+// StorageUsage reports the bytes stored by an organization. Uploads in
+// progress count toward the total with their declared length, so uploads
+// started together cannot overshoot the quota. The organization's logo is
+// not counted, as there is at most one and it is capped at 2MB.
+type StorageUsage struct {
+	OrganizationID     uuid.UUID              `json:"organizationId"`
+	PhotoBytes         int64                  `json:"photoBytes"`
+	ReportBytes        int64                  `json:"reportBytes"`
+	SummaryReportBytes int64                  `json:"summaryReportBytes"`
+	UploadBytes        int64                  `json:"uploadBytes"` // Reserved by uploads in progress
+	TotalBytes         int64                  `json:"totalBytes"`
+	QuotaBytes         int64                  `json:"quotaBytes"` // 0 means unlimited
+	Projects           []*ProjectStorageUsage `json:"projects"`
+}
+
+// ExceedsQuota returns true if storing size more bytes would exceed the quota.
+func (u *StorageUsage) ExceedsQuota(size int64) bool {
+	return u.QuotaBytes > 0 && u.TotalBytes+size > u.QuotaBytes
+}
+
+// ProjectStorageUsage reports the bytes stored by a single project.
+type ProjectStorageUsage struct {
+	ProjectID          uuid.UUID `json:"projectId"`
+	ProjectName        string    `json:"projectName"`
+	PhotoCount         int64     `json:"photoCount"`
+	PhotoBytes         int64     `json:"photoBytes"`
+	ReportCount        int64     `json:"reportCount"`
+	ReportBytes        int64     `json:"reportBytes"`
+	SummaryReportBytes int64     `json:"summaryReportBytes"`
+	UploadBytes        int64     `json:"uploadBytes"`
+	TotalBytes         int64     `json:"totalBytes"`
 }
 
 // Accepted content types for uploads.
@@ -0,0 +1,163 @@
+package aletheia
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxSummaryPeriod is the longest period a project summary can cover.
+const MaxSummaryPeriod = 366 * 24 * time.Hour
+
+// SummaryInterval is the length of the periods a summary's trend is
+// broken into.
+type SummaryInterval string
+
+const (
+	SummaryIntervalDay   SummaryInterval = "day"
+	SummaryIntervalWeek  SummaryInterval = "week"
+	SummaryIntervalMonth SummaryInterval = "month"
+)
+
+// SummaryIntervalFor returns the trend interval for a summary period: days
+// for up to a month, weeks for up to half a year, and months beyond.
+func SummaryIntervalFor(from, to time.Time) SummaryInterval {
+	switch d := to.Sub(from); {
+	case d <= 31*24*time.Hour:
+		return SummaryIntervalDay
+	case d <= 183*24*time.Hour:
+		return SummaryIntervalWeek
+	default:
+		return SummaryIntervalMonth
+	}
+}
+
+// Start returns the start of the interval containing t, in UTC. Weeks start
+// on Monday.
+func (i SummaryInterval) Start(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch i {
+	case SummaryIntervalWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case SummaryIntervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// Next returns the start of the interval after the one starting at start.
+func (i SummaryInterval) Next(start time.Time) time.Time {
+	switch i {
+	case SummaryIntervalWeek:
+		return start.AddDate(0, 0, 7)
+	case SummaryIntervalMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// ProjectSummary aggregates a project's inspections and violations over a
+// period. A violation is open while it is pending review, and closed once
+// it is confirmed or dismissed.
+type ProjectSummary struct {
+	ProjectID   uuid.UUID       `json:"projectId"`
+	ProjectName string          `json:"projectName"`
+	From        time.Time       `json:"from"` // Inclusive
+	To          time.Time       `json:"to"`   // Exclusive
+	Interval    SummaryInterval `json:"interval"`
+
+	Inspections SummaryInspections `json:"inspections"`
+	Violations  SummaryViolations  `json:"violations"`
+
+	// BySeverity counts the confirmed violations detected in the period.
+	BySeverity map[Severity]int `json:"bySeverity"`
+
+	// BySafetyCode tallies the confirmed violations detected in the period
+	// by safety code, most cited first. Violations without a code are
+	// tallied under an empty code.
+	BySafetyCode []SafetyCodeTally `json:"bySafetyCode"`
+
+	// RepeatOffenders are the safety codes cited in more than one
+	// inspection in the period, most inspections first.
+	RepeatOffenders []SafetyCodeTally `json:"repeatOffenders"`
+
+	// Trend counts the violations opened and closed in each interval of
+	// the period.
+	Trend []SummaryTrendPoint `json:"trend"`
+
+	Resolution SummaryResolution `json:"resolution"`
+}
+
+// IsEmpty returns true if nothing happened on the project in the period.
+func (s *ProjectSummary) IsEmpty() bool {
+	return s.Inspections.Total == 0 && s.Violations.Detected == 0 && s.Violations.Closed == 0
+}
+
+// SummaryInspections counts the inspections performed in a period.
+type SummaryInspections struct {
+	Total    int                      `json:"total"`
+	ByStatus map[InspectionStatus]int `json:"byStatus"`
+}
+
+// SummaryViolations counts the violations of a period.
+type SummaryViolations struct {
+	Detected    int `json:"detected"`    // Detected in the period
+	Confirmed   int `json:"confirmed"`   // Of those detected, confirmed
+	Dismissed   int `json:"dismissed"`   // Of those detected, dismissed
+	Pending     int `json:"pending"`     // Of those detected, still pending
+	Closed      int `json:"closed"`      // Confirmed or dismissed in the period
+	OpenAtStart int `json:"openAtStart"` // Pending when the period began
+	OpenAtEnd   int `json:"openAtEnd"`   // Pending when the period ended
+}
+
+// SafetyCodeTally counts the violations citing a safety code and the
+// inspections they were found in.
+type SafetyCodeTally struct {
+	Code        string `json:"code"`
+	Description string `json:"description,omitempty"`
+	Violations  int    `json:"violations"`
+	Inspections int    `json:"inspections"`
+}
+
+// SummaryTrendPoint counts the violations opened and closed in one interval
+// of a summary, and those open at its end.
+type SummaryTrendPoint struct {
+	Start  time.Time `json:"start"`
+	Opened int       `json:"opened"`
+	Closed int       `json:"closed"`
+	Open   int       `json:"open"`
+}
+
+// SummaryResolution describes how long violations closed in a period took
+// to resolve, in hours. Violations resolved before resolution times were
+// recorded are left out.
+type SummaryResolution struct {
+	Resolved     int     `json:"resolved"`
+	AverageHours float64 `json:"averageHours"`
+	MedianHours  float64 `json:"medianHours"`
+	LongestHours float64 `json:"longestHours"`
+}
+
+// SummaryReport is a stored project summary rendered to PDF.
+type SummaryReport struct {
+	ID          uuid.UUID       `json:"id"`
+	ProjectID   uuid.UUID       `json:"projectId"`
+	From        time.Time       `json:"from"`
+	To          time.Time       `json:"to"`
+	StorageURL  string          `json:"storageUrl"`
+	SizeBytes   int64           `json:"sizeBytes"`
+	GeneratedBy uuid.UUID       `json:"generatedBy,omitempty"` // Nil if generated on a schedule or the user is gone
+	CreatedAt   time.Time       `json:"createdAt"`
+	Summary     *ProjectSummary `json:"summary,omitempty"`
+}
+
+// ProjectSummaryPayload is the payload of a project summary job.
+type ProjectSummaryPayload struct {
+	ProjectID   uuid.UUID `json:"projectId"`
+	From        time.Time `json:"from"`
+	To          time.Time `json:"to"`
+	RequestedBy uuid.UUID `json:"requestedBy,omitempty"` // Nil for scheduled summaries
+}
@@ -0,0 +1,96 @@
+package aletheia
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Upload represents a resumable upload session for a photo.
+// Chunks are appended at Offset until Offset reaches Length, at which point
+// the assembled file is available at StorageURL.
+type Upload struct {
+	ID           uuid.UUID    `json:"id"`
+	InspectionID uuid.UUID    `json:"inspectionId"`
+	UserID       uuid.UUID    `json:"userId"`
+	Filename     string       `json:"filename,omitempty"`
+	ContentType  string       `json:"contentType"`
+	Length       int64        `json:"length"`
+	Offset       int64        `json:"offset"`
+	StorageKey   string       `json:"-"`
+	StorageURL   string       `json:"storageUrl,omitempty"`
+	Status       UploadStatus `json:"status"`
+	PhotoID      *uuid.UUID   `json:"photoId,omitempty"`
+	ExpiresAt    time.Time    `json:"expiresAt"`
+	CreatedAt    time.Time    `json:"createdAt"`
+	UpdatedAt    time.Time    `json:"updatedAt"`
+}
+
+// IsExpired returns true if the upload session has expired.
+func (u *Upload) IsExpired() bool {
+	return time.Now().After(u.ExpiresAt)
+}
+
+// IsComplete returns true if all bytes of the upload have been received.
+func (u *Upload) IsComplete() bool {
+	return u.Offset >= u.Length
+}
+
+// UploadStatus represents the status of an upload session.
+type UploadStatus string
+
+const (
+	UploadStatusInProgress UploadStatus = "in_progress"
+	UploadStatusCompleted  UploadStatus = "completed"
+)
+
+// UploadService defines operations for managing resumable uploads.
+type UploadService interface {
+	// FindUploadByID retrieves an upload session by its ID.
+	// Returns ENOTFOUND if the upload does not exist.
+	FindUploadByID(ctx context.Context, id uuid.UUID) (*Upload, error)
+
+	// CreateUpload starts a new upload session and its multipart upload in storage.
+	// Returns ENOTFOUND if the inspection does not exist.
+	CreateUpload(ctx context.Context, upload *Upload) error
+
+	// WriteUploadChunk appends data to an upload at the given offset.
+	// Once the final byte is received the file is assembled in storage.
+	// Returns ECONFLICT if offset does not match the current upload offset.
+	// Returns EINVALID if the data would exceed the declared upload length.
+	// Returns ENOTFOUND if the upload does not exist or has expired.
+	WriteUploadChunk(ctx context.Context, id uuid.UUID, offset int64, data []byte) (*Upload, error)
+
+	// UpdateUpload updates an existing upload session.
+	// Returns ENOTFOUND if the upload does not exist.
+	UpdateUpload(ctx context.Context, id uuid.UUID, upd UploadUpdate) (*Upload, error)
+
+	// AbortUpload terminates an in-progress upload and discards received data.
+	// Returns ENOTFOUND if the upload does not exist.
+	// Returns EINVALID if the upload has already completed.
+	AbortUpload(ctx context.Context, id uuid.UUID) error
+
+	// CleanupExpiredUploads aborts all in-progress uploads past their expiry.
+	// Returns the number of uploads removed.
+	CleanupExpiredUploads(ctx context.Context) (int, error)
+}
+
+// UploadUpdate defines fields that can be updated on an upload.
+type UploadUpdate struct {
+	PhotoID *uuid.UUID
+}
+
+// MaxResumableUploadSize is the maximum total size of a resumable upload (25MB).
+const MaxResumableUploadSize = 25 * 1024 * 1024
+
+// MaxUploadChunkSize is the maximum size of a single chunk request (8MB).
+const MaxUploadChunkSize = 8 * 1024 * 1024
+
+// UploadPartSize is the minimum size of a multipart part sent to storage (5MB).
+// Smaller chunks are buffered until a full part is available. This matches the
+// S3 minimum part size; only the final part may be smaller.
+const UploadPartSize = 5 * 1024 * 1024
+
+// DefaultUploadExpiry is how long an upload session stays resumable.
+const DefaultUploadExpiry = 24 * time.Hour
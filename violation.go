@@ -18,6 +18,7 @@ type Violation struct {
 	ConfidenceScore float64         `json:"confidenceScore,omitempty"`
 	Location        string          `json:"location,omitempty"`
 	CreatedAt       time.Time       `json:"createdAt"`
+	ResolvedAt      *time.Time      `json:"resolvedAt,omitempty"` // When first confirmed or dismissed
 
 	// Joined fields (populated by some queries)
 	Photo      *Photo      `json:"photo,omitempty"`
@@ -59,6 +60,15 @@ const (
 	ViolationStatusDismissed ViolationStatus = "dismissed"
 )
 
+// IsValid returns true if s is a known violation status.
+func (s ViolationStatus) IsValid() bool {
+	switch s {
+	case ViolationStatusPending, ViolationStatusConfirmed, ViolationStatusDismissed:
+		return true
+	}
+	return false
+}
+
 // IsResolved returns true if the violation has been reviewed.
 func (s ViolationStatus) IsResolved() bool {
 	return s == ViolationStatusConfirmed || s == ViolationStatusDismissed
@@ -102,6 +112,12 @@ type ViolationService interface {
 
 	// GetViolationsByInspection retrieves all violations for an inspection.
 	GetViolationsByInspection(ctx context.Context, inspectionID uuid.UUID) ([]*Violation, error)
+
+	// ExportViolations calls fn with each violation of an organization
+	// matching the filter, oldest first. Violations are read in batches, so
+	// exports of any size use little memory. It stops at the first error fn
+	// returns.
+	ExportViolations(ctx context.Context, filter ViolationExportFilter, fn func(*ViolationExportRow) error) error
 }
 
 // ViolationFilter defines criteria for filtering violations.
@@ -118,6 +134,36 @@ type ViolationFilter struct {
 	Limit  int
 }
 
+// ViolationExportFilter defines which violations are exported.
+type ViolationExportFilter struct {
+	OrganizationID uuid.UUID // Required
+	ProjectID      *uuid.UUID
+	Status         *ViolationStatus
+	CreatedFrom    *time.Time // Inclusive
+	CreatedTo      *time.Time // Exclusive
+}
+
+// ViolationExportRow is a violation with the project, inspection, photo and
+// safety code it is exported with.
+type ViolationExportRow struct {
+	ID                    uuid.UUID
+	ProjectID             uuid.UUID
+	ProjectName           string
+	InspectionID          uuid.UUID
+	InspectionStatus      InspectionStatus
+	InspectionDate        time.Time
+	PhotoID               uuid.UUID
+	PhotoURL              string
+	SafetyCode            string // Empty if no code is assigned
+	SafetyCodeDescription string
+	Description           string
+	Severity              Severity
+	Status                ViolationStatus
+	Location              string
+	CreatedAt             time.Time
+	ResolvedAt            *time.Time // nil if pending, or resolved before this was recorded
+}
+
 // ViolationUpdate defines fields that can be updated on a violation.
 type ViolationUpdate struct {
 	Description  *string